@@ -5,8 +5,10 @@ import (
 	"context"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"eth2-exporter/db"
 	"eth2-exporter/erc20"
+	"eth2-exporter/metrics"
 	"eth2-exporter/rpc"
 	"eth2-exporter/services"
 	"eth2-exporter/types"
@@ -24,6 +26,7 @@ import (
 	"time"
 
 	"github.com/coocood/freecache"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/shopspring/decimal"
@@ -70,6 +73,16 @@ func main() {
 
 	enableEnsUpdater := flag.Bool("ens.enabled", false, "Enable ens update process")
 
+	enableNftMetadataUpdater := flag.Bool("nft.enabled", false, "Enable nft metadata update process")
+	nftMetadataUpdaterBatchSize := flag.Int64("nft.batch", 100, "Maximum number of nfts to resolve the metadata for per index run")
+
+	enableStream := flag.Bool("stream.enabled", false, "Index new blocks as soon as they are announced via a newHeads subscription, falls back to batched indexing if the indexer is behind")
+	streamEndpoint := flag.String("stream.ws", "", "Websocket endpoint of the erigon node for the newHeads subscription, defaults to the erigon endpoint if not set")
+	streamDuration := flag.Duration("stream.duration", time.Minute, "Maximum time to stream new heads before the next batched index run")
+	streamUpdatesInterval := flag.Duration("stream.updates.interval", time.Minute, "Interval to run the enabled balance, ens and nft metadata update processes at while new heads are streamed")
+
+	recordFixturesDir := flag.String("fixtures.record", "", "If set, all json-rpc responses of the erigon node are recorded to this directory for use as test fixtures")
+
 	flag.Parse()
 
 	if *versionFlag {
@@ -94,6 +107,15 @@ func main() {
 		}()
 	}
 
+	if utils.Config.Metrics.Enabled {
+		go func(addr string) {
+			logrus.Infof("serving metrics on %v", addr)
+			if err := metrics.Serve(addr); err != nil {
+				logrus.WithError(err).Fatal("Error serving metrics")
+			}
+		}(utils.Config.Metrics.Address)
+	}
+
 	db.MustInitDB(&types.DatabaseConfig{
 		Username:     cfg.WriterDatabase.Username,
 		Password:     cfg.WriterDatabase.Password,
@@ -126,8 +148,19 @@ func main() {
 
 	}
 
+	if *enableStream && *streamEndpoint == "" && utils.Config.Eth1ErigonWsEndpoint != "" {
+		logrus.Info("applying erigon websocket endpoint from config")
+		*streamEndpoint = utils.Config.Eth1ErigonWsEndpoint
+	}
+
 	logrus.Infof("using erigon node at %v", *erigonEndpoint)
-	client, err := rpc.NewErigonClient(*erigonEndpoint)
+	var client *rpc.ErigonClient
+	if *recordFixturesDir != "" {
+		logrus.Infof("recording erigon node fixtures to %v", *recordFixturesDir)
+		client, err = rpc.NewErigonClientWithFixtures(*erigonEndpoint, *recordFixturesDir, rpc.FixtureModeRecord)
+	} else {
+		client, err = rpc.NewErigonClient(*erigonEndpoint)
+	}
 	if err != nil {
 		utils.LogFatal(err, "erigon client creation error", 0)
 	}
@@ -169,19 +202,10 @@ func main() {
 		return
 	}
 
-	transforms := make([]func(blk *types.Eth1Block, cache *freecache.Cache) (*types.BulkMutations, *types.BulkMutations, error), 0)
-	transforms = append(transforms,
-		bt.TransformBlock,
-		bt.TransformTx,
-		bt.TransformItx,
-		bt.TransformBlobTx,
-		bt.TransformERC20,
-		bt.TransformERC721,
-		bt.TransformERC1155,
-		bt.TransformUncle,
-		bt.TransformWithdrawals,
-		bt.TransformEnsNameRegistered,
-		bt.TransformContract)
+	transforms, err := bt.GetEth1Transforms(nil)
+	if err != nil {
+		logrus.Fatal(err)
+	}
 
 	cache := freecache.NewCache(100 * 1024 * 1024) // 100 MB limit
 
@@ -230,7 +254,44 @@ func main() {
 	var lastBlockFromNodeOld uint64
 	var lastBlockFromNodeSameCount uint64
 	lastSuccessulBlockIndexingTs := time.Now()
-	for ; ; time.Sleep(time.Second * 14) {
+
+	// runPeriodicUpdaters runs the enabled update processes, they are run after every index run and periodically while new heads are streamed
+	runPeriodicUpdaters := func() error {
+		if *enableBalanceUpdater {
+			ProcessMetadataUpdates(bt, client, balanceUpdaterPrefix, *balanceUpdaterBatchSize, 10)
+		}
+
+		if *enableEnsUpdater {
+			err := bt.ImportEnsUpdates(client.GetNativeClient(), 1000)
+			if err != nil {
+				utils.LogError(err, "error importing ens updates", 0, nil)
+				return err
+			}
+		}
+
+		if *enableNftMetadataUpdater {
+			err := bt.ImportNftMetadataUpdates(client.GetNativeClient(), *nftMetadataUpdaterBatchSize)
+			if err != nil {
+				utils.LogError(err, "error importing nft metadata updates", 0, nil)
+				return err
+			}
+		}
+		return nil
+	}
+
+	// in stream mode new heads are indexed in between the index runs, the batched indexing of the run catches up if the stream falls behind
+	waitForNextRun := func() {
+		if !*enableStream {
+			time.Sleep(time.Second * 14)
+			return
+		}
+		err := StreamNewHeads(bt, client, *streamEndpoint, transforms, *traceMode, *reorgDepth, *streamDuration, *streamUpdatesInterval, func() { _ = runPeriodicUpdaters() }, cache)
+		if err != nil {
+			logrus.Warnf("falling back to batched indexing: %v", err)
+			time.Sleep(time.Second * 14)
+		}
+	}
+	for ; ; waitForNextRun() {
 		err := HandleChainReorgs(bt, client, *reorgDepth)
 		if err != nil {
 			logrus.Errorf("error handling chain reorgs: %v", err)
@@ -360,16 +421,9 @@ func main() {
 			}
 		}
 
-		if *enableBalanceUpdater {
-			ProcessMetadataUpdates(bt, client, balanceUpdaterPrefix, *balanceUpdaterBatchSize, 10)
-		}
-
-		if *enableEnsUpdater {
-			err := bt.ImportEnsUpdates(client.GetNativeClient(), 1000)
-			if err != nil {
-				utils.LogError(err, "error importing ens updates", 0, nil)
-				continue
-			}
+		err = runPeriodicUpdaters()
+		if err != nil {
+			continue
 		}
 
 		logrus.Infof("index run completed")
@@ -474,70 +528,109 @@ func UpdateTokenPrices(bt *db.Bigtable, client *rpc.ErigonClient, tokenListPath
 	return bt.SaveERC20TokenPrices(tokenPrices)
 }
 
+// HandleChainReorgs compares the last block of the blocks table with the node, if the hashes differ it walks back to the common ancestor
+// and reverts all orphaned blocks by replaying their undo journals. The indexer will then re-index the blocks of the new canonical chain
 func HandleChainReorgs(bt *db.Bigtable, client *rpc.ErigonClient, depth int) error {
 	ctx := context.Background()
-	// get latest block from the node
-	latestNodeBlock, err := client.GetNativeClient().BlockByNumber(ctx, nil)
+
+	lastBlock, err := bt.GetLastBlockInBlocksTable()
 	if err != nil {
-		return err
+		return fmt.Errorf("error retrieving last block in blocks table: %w", err)
 	}
-	latestNodeBlockNumber := latestNodeBlock.NumberU64()
 
-	// for each block check if block node hash and block db hash match
-	if depth > int(latestNodeBlockNumber) {
-		depth = int(latestNodeBlockNumber)
-	}
-	for i := latestNodeBlockNumber - uint64(depth); i <= latestNodeBlockNumber; i++ {
-		nodeBlock, err := client.GetNativeClient().HeaderByNumber(ctx, big.NewInt(int64(i)))
+	// in the common case only the head of the blocks table has to be checked
+	orphaned := make([]*types.Eth1Block, 0)
+	for number := uint64(lastBlock); ; number-- {
+		dbBlock, err := bt.GetBlockFromBlocksTable(number)
 		if err != nil {
+			if err == db.ErrBlockNotFound { // stop if we hit a block that is not in the db
+				break
+			}
 			return err
 		}
 
-		dbBlock, err := bt.GetBlockFromBlocksTable(i)
+		nodeBlock, err := client.GetNativeClient().HeaderByNumber(ctx, new(big.Int).SetUint64(number))
 		if err != nil {
-			if err == db.ErrBlockNotFound { // exit if we hit a block that is not yet in the db
+			if errors.Is(err, ethereum.NotFound) { // the node is behind the db, there is nothing to compare yet
+				logrus.Warnf("block %v is not known to the node yet, skipping chain reorg check", number)
 				return nil
 			}
-			return err
+			return fmt.Errorf("error retrieving header of block %v from the node: %w", number, err)
 		}
 
-		if !bytes.Equal(nodeBlock.Hash().Bytes(), dbBlock.Hash) {
-			logrus.Warnf("found incosistency at height %v, node block hash: %x, db block hash: %x", i, nodeBlock.Hash().Bytes(), dbBlock.Hash)
+		if bytes.Equal(nodeBlock.Hash().Bytes(), dbBlock.Hash) {
+			break
+		}
+		logrus.Warnf("found inconsistency at height %v, node block hash: %x, db block hash: %x", number, nodeBlock.Hash().Bytes(), dbBlock.Hash)
+		orphaned = append(orphaned, dbBlock)
 
-			// first we set the cached marker of the last block in the blocks/data table to the block prior to the forked one
-			if i > 0 {
-				previousBlock := i - 1
-				err := bt.SetLastBlockInBlocksTable(int64(previousBlock))
-				if err != nil {
-					return fmt.Errorf("error setting last block [%v] in blocks table: %w", previousBlock, err)
-				}
-				err = bt.SetLastBlockInDataTable(int64(previousBlock))
-				if err != nil {
-					return fmt.Errorf("error setting last block [%v] in data table: %w", previousBlock, err)
-				}
-				// now we can proceed to delete all blocks including and after the forked block
-			}
-			// delete all blocks starting from the fork block up to the latest block in the db
-			for j := i; j <= latestNodeBlockNumber; j++ {
-				dbBlock, err := bt.GetBlockFromBlocksTable(j)
-				if err != nil {
-					if err == db.ErrBlockNotFound { // exit if we hit a block that is not yet in the db
-						return nil
-					}
-					return err
-				}
-				logrus.Infof("deleting block at height %v with hash %x", dbBlock.Number, dbBlock.Hash)
+		if len(orphaned) > depth {
+			return fmt.Errorf("chain reorg at block %v is deeper than the reorg depth of %v blocks", lastBlock, depth)
+		}
+		if number == 0 {
+			break
+		}
+	}
 
-				err = bt.DeleteBlock(dbBlock.Number, dbBlock.Hash)
-				if err != nil {
-					return err
-				}
+	if len(orphaned) == 0 {
+		return nil
+	}
+
+	start := time.Now()
+	defer func() {
+		metrics.TaskDuration.WithLabelValues("eth1indexer_handle_chain_reorg").Observe(time.Since(start).Seconds())
+	}()
+	metrics.Tasks.WithLabelValues("eth1indexer_chain_reorg").Inc()
+	metrics.Eth1ChainReorgDepth.Observe(float64(len(orphaned)))
+
+	lastBlockInDataTable, err := bt.GetLastBlockInDataTable()
+	if err != nil {
+		return fmt.Errorf("error retrieving last block in data table: %w", err)
+	}
+
+	// revert the orphaned blocks from the head down to the common ancestor, the last block markers are moved along
+	// so that an interrupted revert is continued from the right block in the next iteration
+	for _, block := range orphaned {
+		logrus.Infof("reverting block at height %v with hash %x", block.Number, block.Hash)
+
+		err = bt.RevertEth1Block(block.Number, block.Hash)
+		if err != nil {
+			return fmt.Errorf("error reverting block %v: %w", block.Number, err)
+		}
+
+		previousBlock := int64(block.Number) - 1
+		if previousBlock < 0 {
+			continue
+		}
+		err = bt.SetLastBlockInBlocksTable(previousBlock)
+		if err != nil {
+			return fmt.Errorf("error setting last block [%v] in blocks table: %w", previousBlock, err)
+		}
+		if previousBlock < int64(lastBlockInDataTable) {
+			err = bt.SetLastBlockInDataTable(previousBlock)
+			if err != nil {
+				return fmt.Errorf("error setting last block [%v] in data table: %w", previousBlock, err)
 			}
-		} else {
-			logrus.Infof("height %v, node block hash: %x, db block hash: %x", i, nodeBlock.Hash().Bytes(), dbBlock.Hash)
 		}
 	}
 
+	forkBlock := orphaned[len(orphaned)-1]
+	newHead, err := client.GetNativeClient().HeaderByNumber(ctx, new(big.Int).SetUint64(orphaned[0].Number))
+	if err != nil {
+		return fmt.Errorf("error retrieving header of block %v from the node: %w", orphaned[0].Number, err)
+	}
+	err = db.SaveEth1ChainReorg(&types.Eth1ChainReorg{
+		BlockNumber: forkBlock.Number,
+		Depth:       uint64(len(orphaned)),
+		OldHeadHash: orphaned[0].Hash,
+		NewHeadHash: newHead.Hash().Bytes(),
+		Ts:          time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	logrus.Infof("handled chain reorg at block %v with depth %v in %v", forkBlock.Number, len(orphaned), time.Since(start))
 	return nil
 }
 
@@ -557,6 +650,13 @@ func ProcessMetadataUpdates(bt *db.Bigtable, client *rpc.ErigonClient, prefix st
 			return
 		}
 
+		// the balances are retrieved at the last indexed block so the balance history can be derived from them and the indexed balance deltas
+		lastBlockFromDataTable, err := bt.GetLastBlockInDataTable()
+		if err != nil {
+			logrus.Errorf("error retrieving last block from data table: %v", err)
+			return
+		}
+
 		balances := make([]*types.Eth1AddressBalance, 0, len(pairs))
 		for b := 0; b < len(pairs); b += batchSize {
 			start := b
@@ -567,7 +667,7 @@ func ProcessMetadataUpdates(bt *db.Bigtable, client *rpc.ErigonClient, prefix st
 
 			logrus.Infof("processing batch %v with start %v and end %v", b, start, end)
 
-			b, err := client.GetBalances(pairs[start:end], 2, 4)
+			b, err := client.GetBalancesAtBlock(pairs[start:end], uint64(lastBlockFromDataTable))
 
 			if err != nil {
 				logrus.Errorf("error retrieving balances from node: %v", err)
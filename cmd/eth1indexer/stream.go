@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"eth2-exporter/db"
+	"eth2-exporter/metrics"
+	"eth2-exporter/rpc"
+	"eth2-exporter/types"
+	"fmt"
+	"time"
+
+	"github.com/coocood/freecache"
+	geth_types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/sirupsen/logrus"
+)
+
+// maximum time without a new head before the subscription is considered stale
+const streamHeadTimeout = time.Minute * 2
+
+// StreamNewHeads subscribes to the newHeads of the node and indexes every announced block into the blocks and data table as soon as it arrives.
+// The periodic updaters are run every updateInterval while the stream is active.
+// It returns nil once duration has passed, an error is returned if the subscription drops or the indexer is behind the node
+// so that the batched indexing can catch up
+func StreamNewHeads(bt *db.Bigtable, client *rpc.ErigonClient, wsEndpoint string, transforms []func(blk *types.Eth1Block, cache *freecache.Cache) (*types.BulkMutations, *types.BulkMutations, error), traceMode string, reorgDepth int, duration time.Duration, updateInterval time.Duration, runUpdaters func(), cache *freecache.Cache) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	heads := make(chan *geth_types.Header, 16)
+	sub, err := client.SubscribeNewHeads(ctx, wsEndpoint, heads)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	return streamHeads(heads, sub.Err(), duration, updateInterval, func(head *geth_types.Header) error {
+		return indexNewHead(bt, client, head, transforms, traceMode, reorgDepth, cache)
+	}, runUpdaters)
+}
+
+// streamHeads passes every received head to indexHead until duration has passed, the subscription fails or a head could not be indexed.
+// runUpdaters is called every updateInterval in its own goroutine so that long running updates do not delay the indexing of new heads,
+// a running update is awaited before streamHeads returns
+func streamHeads(heads <-chan *geth_types.Header, subErr <-chan error, duration time.Duration, updateInterval time.Duration, indexHead func(head *geth_types.Header) error, runUpdaters func()) error {
+	stopUpdaters := make(chan struct{})
+	updatersDone := make(chan struct{})
+	go func() {
+		defer close(updatersDone)
+		if runUpdaters == nil || updateInterval <= 0 {
+			return
+		}
+		ticker := time.NewTicker(updateInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopUpdaters:
+				return
+			case <-ticker.C:
+				// a tick may be pending after a long update, the stop takes precedence
+				select {
+				case <-stopUpdaters:
+					return
+				default:
+				}
+				runUpdaters()
+			}
+		}
+	}()
+	defer func() {
+		close(stopUpdaters)
+		<-updatersDone
+	}()
+
+	end := time.After(duration)
+	for {
+		select {
+		case <-end:
+			return nil
+		case err := <-subErr:
+			return fmt.Errorf("new heads subscription dropped: %w", err)
+		case <-time.After(streamHeadTimeout):
+			return fmt.Errorf("no new head received within %v", streamHeadTimeout)
+		case head := <-heads:
+			err := indexHead(head)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// indexNewHead indexes a single announced block if it is the successor of the last indexed block, reorgs are handled before the block is indexed
+func indexNewHead(bt *db.Bigtable, client *rpc.ErigonClient, head *geth_types.Header, transforms []func(blk *types.Eth1Block, cache *freecache.Cache) (*types.BulkMutations, *types.BulkMutations, error), traceMode string, reorgDepth int, cache *freecache.Cache) error {
+	start := time.Now()
+	number := head.Number.Int64()
+
+	lastBlockFromBlocksTable, lastBlockHash, err := getLastIndexedBlock(bt)
+	if err != nil {
+		return err
+	}
+
+	// the new head replaces a block that has already been indexed or builds on a block that has been reorged out
+	if number <= int64(lastBlockFromBlocksTable)+1 && !isSuccessor(head, lastBlockFromBlocksTable, lastBlockHash) {
+		err := HandleChainReorgs(bt, client, reorgDepth)
+		if err != nil {
+			return fmt.Errorf("error handling chain reorgs: %w", err)
+		}
+		lastBlockFromBlocksTable, lastBlockHash, err = getLastIndexedBlock(bt)
+		if err != nil {
+			return err
+		}
+	}
+
+	lastBlockFromDataTable, err := bt.GetLastBlockInDataTable()
+	if err != nil {
+		return fmt.Errorf("error retrieving last block from data table: %w", err)
+	}
+
+	if !isSuccessor(head, lastBlockFromBlocksTable, lastBlockHash) || lastBlockFromDataTable != lastBlockFromBlocksTable {
+		return fmt.Errorf("new head %v (parent 0x%x) is not the successor of the last indexed block (blocks: %v 0x%x, data: %v)", number, head.ParentHash.Bytes(), lastBlockFromBlocksTable, lastBlockHash, lastBlockFromDataTable)
+	}
+
+	err = IndexFromNode(bt, client, number, number, 1, traceMode)
+	if err != nil {
+		return fmt.Errorf("error indexing new head %v from node: %w", number, err)
+	}
+
+	err = bt.IndexEventsWithTransformers(number, number, transforms, 1, cache)
+	if err != nil {
+		return fmt.Errorf("error indexing new head %v from bigtable: %w", number, err)
+	}
+
+	metrics.TaskDuration.WithLabelValues("eth1indexer_stream_block").Observe(time.Since(start).Seconds())
+	logrus.Infof("indexed new head %v (0x%x) in %v", number, head.Hash().Bytes(), time.Since(start))
+	return nil
+}
+
+// getLastIndexedBlock returns the number and hash of the last block in the blocks table
+func getLastIndexedBlock(bt *db.Bigtable) (int, []byte, error) {
+	lastBlock, err := bt.GetLastBlockInBlocksTable()
+	if err != nil {
+		return 0, nil, fmt.Errorf("error retrieving last block from blocks table: %w", err)
+	}
+	block, err := bt.GetBlockFromBlocksTable(uint64(lastBlock))
+	if err != nil {
+		return 0, nil, fmt.Errorf("error retrieving block %v from blocks table: %w", lastBlock, err)
+	}
+	return lastBlock, block.GetHash(), nil
+}
+
+// isSuccessor reports whether the head directly builds on the last indexed block
+func isSuccessor(head *geth_types.Header, lastBlock int, lastBlockHash []byte) bool {
+	return head.Number.Int64() == int64(lastBlock)+1 && bytes.Equal(head.ParentHash.Bytes(), lastBlockHash)
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	geth_types "github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestStreamHeadsRunsUpdaters(t *testing.T) {
+	heads := make(chan *geth_types.Header)
+	subErr := make(chan error)
+	stopHeads := make(chan struct{})
+	go func() {
+		for number := int64(1); ; number++ {
+			select {
+			case heads <- &geth_types.Header{Number: big.NewInt(number)}:
+			case <-stopHeads:
+				return
+			}
+			time.Sleep(time.Millisecond * 5)
+		}
+	}()
+	defer close(stopHeads)
+
+	var indexed, updates, running int32
+	err := streamHeads(heads, subErr, time.Millisecond*200, time.Millisecond*20, func(head *geth_types.Header) error {
+		atomic.AddInt32(&indexed, 1)
+		return nil
+	}, func() {
+		atomic.StoreInt32(&running, 1)
+		time.Sleep(time.Millisecond * 10)
+		atomic.AddInt32(&updates, 1)
+		atomic.StoreInt32(&running, 0)
+	})
+	if err != nil {
+		t.Fatalf("expected the stream to end without an error, got %v", err)
+	}
+	if atomic.LoadInt32(&indexed) < 10 {
+		t.Errorf("expected the heads to be indexed while the updaters run, got %v indexed heads", indexed)
+	}
+	if atomic.LoadInt32(&updates) < 3 {
+		t.Errorf("expected the updaters to run periodically while streaming, got %v runs", updates)
+	}
+	if atomic.LoadInt32(&running) != 0 {
+		t.Errorf("expected the running update to be finished once the stream returns")
+	}
+
+	// no updates are started once the stream has returned
+	updatesAfterReturn := atomic.LoadInt32(&updates)
+	time.Sleep(time.Millisecond * 50)
+	if atomic.LoadInt32(&updates) != updatesAfterReturn {
+		t.Errorf("expected no updates after the stream returned")
+	}
+}
+
+func TestStreamHeadsErrors(t *testing.T) {
+	heads := make(chan *geth_types.Header, 1)
+	subErr := make(chan error, 1)
+
+	// a failing head stops the stream so the batched indexing can catch up
+	heads <- &geth_types.Header{Number: big.NewInt(1)}
+	err := streamHeads(heads, subErr, time.Minute, time.Minute, func(head *geth_types.Header) error {
+		return fmt.Errorf("head %v is not the successor", head.Number)
+	}, nil)
+	if err == nil || err.Error() != "head 1 is not the successor" {
+		t.Errorf("expected the indexing error, got %v", err)
+	}
+
+	// a dropped subscription waits for the running update before returning
+	var updates int32
+	updateStarted := make(chan struct{})
+	go func() {
+		<-updateStarted
+		subErr <- fmt.Errorf("connection closed")
+	}()
+	err = streamHeads(heads, subErr, time.Minute, time.Millisecond*10, func(head *geth_types.Header) error {
+		return nil
+	}, func() {
+		if atomic.AddInt32(&updates, 1) == 1 {
+			close(updateStarted)
+		}
+		time.Sleep(time.Millisecond * 50)
+	})
+	if err == nil {
+		t.Fatalf("expected an error for the dropped subscription")
+	}
+	if atomic.LoadInt32(&updates) != 1 {
+		t.Errorf("expected exactly 1 update, got %v", updates)
+	}
+}
+
+func TestIsSuccessor(t *testing.T) {
+	lastBlockHash := common.HexToHash("0x0a").Bytes()
+	tests := []struct {
+		Name       string
+		Number     int64
+		ParentHash common.Hash
+		Expected   bool
+	}{
+		{"successor", 11, common.HexToHash("0x0a"), true},
+		{"successor of an orphaned block", 11, common.HexToHash("0x0b"), false},
+		{"replaces the last block", 10, common.HexToHash("0x09"), false},
+		{"indexer behind", 12, common.HexToHash("0x0c"), false},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			head := &geth_types.Header{Number: big.NewInt(test.Number), ParentHash: test.ParentHash}
+			if isSuccessor(head, 10, lastBlockHash) != test.Expected {
+				t.Errorf("expected successor %v for head %v with parent %v", test.Expected, test.Number, test.ParentHash)
+			}
+		})
+	}
+}
@@ -225,11 +225,28 @@ func main() {
 		var rpcClient rpc.Client
 
 		chainID := new(big.Int).SetUint64(utils.Config.Chain.ClConfig.DepositChainID)
-		if utils.Config.Indexer.Node.Type == "lighthouse" {
-			rpcClient, err = rpc.NewLighthouseClient("http://"+cfg.Indexer.Node.Host+":"+cfg.Indexer.Node.Port, chainID)
+		if len(utils.Config.Indexer.Nodes) > 0 {
+			nodes := []rpc.BeaconNodeConfig{{Endpoint: "http://" + cfg.Indexer.Node.Host + ":" + cfg.Indexer.Node.Port, Type: cfg.Indexer.Node.Type}}
+			for _, node := range cfg.Indexer.Nodes {
+				nodes = append(nodes, rpc.BeaconNodeConfig{Endpoint: "http://" + node.Host + ":" + node.Port, Type: node.Type})
+			}
+			rpcClient, err = rpc.NewMultiNodeClient(nodes, chainID, cfg.Indexer.NodeCrossCheck)
+			if err != nil {
+				utils.LogFatal(err, "new explorer multi node client error", 0)
+			}
+		} else if utils.Config.Indexer.Node.Type == "lighthouse" {
+			lighthouseClient, err := rpc.NewLighthouseClient("http://"+cfg.Indexer.Node.Host+":"+cfg.Indexer.Node.Port, chainID)
 			if err != nil {
 				utils.LogFatal(err, "new explorer lighthouse client error", 0)
 			}
+			if cfg.Indexer.RecordFixturesDir != "" {
+				logrus.Infof("recording beacon node fixtures to %v", cfg.Indexer.RecordFixturesDir)
+				err = lighthouseClient.RecordFixtures(cfg.Indexer.RecordFixturesDir)
+				if err != nil {
+					utils.LogFatal(err, "error enabling fixture recording", 0)
+				}
+			}
+			rpcClient = lighthouseClient
 		} else {
 			logrus.Fatalf("invalid note type %v specified. supported node types are prysm and lighthouse", utils.Config.Indexer.Node.Type)
 		}
@@ -271,12 +288,16 @@ func main() {
 		apiV1Router.HandleFunc("/block/{slot}/voluntaryexits", handlers.ApiSlotVoluntaryExits).Methods("GET", "OPTIONS")
 
 		apiV1Router.HandleFunc("/sync_committee/{period}", handlers.ApiSyncCommittee).Methods("GET", "OPTIONS")
+		apiV1Router.HandleFunc("/sync_committee/{period}/participation", handlers.ApiSyncCommitteeParticipation).Methods("GET", "OPTIONS")
 		apiV1Router.HandleFunc("/eth1deposit/{txhash}", handlers.ApiEth1Deposit).Methods("GET", "OPTIONS")
 		apiV1Router.HandleFunc("/validator/leaderboard", handlers.ApiValidatorLeaderboard).Methods("GET", "OPTIONS")
 		apiV1Router.HandleFunc("/validator/{indexOrPubkey}", handlers.ApiValidatorGet).Methods("GET", "OPTIONS")
 		apiV1Router.HandleFunc("/validator", handlers.ApiValidatorPost).Methods("POST", "OPTIONS")
 		apiV1Router.HandleFunc("/validator/{indexOrPubkey}/withdrawals", handlers.ApiValidatorWithdrawals).Methods("GET", "OPTIONS")
 		apiV1Router.HandleFunc("/validator/{indexOrPubkey}/blsChange", handlers.ApiValidatorBlsChange).Methods("GET", "OPTIONS")
+		apiV1Router.HandleFunc("/validator/{indexOrPubkey}/blockvalues", handlers.ApiValidatorBlockValues).Methods("GET", "OPTIONS")
+		apiV1Router.HandleFunc("/validator/{indexOrPubkey}/withdrawal_requests", handlers.ApiValidatorWithdrawalRequests).Methods("GET", "OPTIONS")
+		apiV1Router.HandleFunc("/validator/{indexOrPubkey}/consolidations", handlers.ApiValidatorConsolidations).Methods("GET", "OPTIONS")
 		apiV1Router.HandleFunc("/validator/{indexOrPubkey}/balancehistory", handlers.ApiValidatorBalanceHistory).Methods("GET", "OPTIONS")
 		apiV1Router.HandleFunc("/validator/{indexOrPubkey}/incomedetailhistory", handlers.ApiValidatorIncomeDetailsHistory).Methods("GET", "OPTIONS")
 		apiV1Router.HandleFunc("/validator/{indexOrPubkey}/performance", handlers.ApiValidatorPerformance).Methods("GET", "OPTIONS")
@@ -313,6 +334,11 @@ func main() {
 
 		apiV1Router.HandleFunc("/execution/address/{address}", handlers.ApiEth1Address).Methods("GET", "OPTIONS")
 		apiV1Router.HandleFunc("/execution/address/{address}/erc20tokens", handlers.ApiEth1AddressERC20Tokens).Methods("GET", "OPTIONS")
+		apiV1Router.HandleFunc("/execution/address/{address}/balancehistory", handlers.ApiEth1AddressBalanceHistory).Methods("GET", "OPTIONS")
+		apiV1Router.HandleFunc("/execution/address/{address}/deployedcontracts", handlers.ApiEth1AddressDeployedContracts).Methods("GET", "OPTIONS")
+		apiV1Router.HandleFunc("/execution/contracts/codehash/{codeHash}", handlers.ApiEth1ContractsByCodeHash).Methods("GET", "OPTIONS")
+		apiV1Router.HandleFunc("/execution/token/{token}/holders", handlers.ApiEth1TokenHolders).Methods("GET", "OPTIONS")
+		apiV1Router.HandleFunc("/execution/logs", handlers.ApiEth1Logs).Methods("GET", "OPTIONS")
 
 		apiV1Router.HandleFunc("/validator/{indexOrPubkey}/widget", handlers.GetMobileWidgetStatsGet).Methods("GET")
 		apiV1Router.HandleFunc("/dashboard/widget", handlers.GetMobileWidgetStatsPost).Methods("POST")
@@ -335,6 +361,12 @@ func main() {
 		apiV1AuthRouter.HandleFunc("/notifications/subscribe", handlers.UserNotificationsSubscribe).Methods("POST", "OPTIONS")
 		apiV1AuthRouter.HandleFunc("/notifications/unsubscribe", handlers.UserNotificationsUnsubscribe).Methods("POST", "OPTIONS")
 		apiV1AuthRouter.HandleFunc("/notifications", handlers.UserNotificationsSubscribed).Methods("POST", "GET", "OPTIONS")
+		apiV1AuthRouter.HandleFunc("/notifications/delivery", handlers.UserNotificationDeliveryModes).Methods("GET", "OPTIONS")
+		apiV1AuthRouter.HandleFunc("/notifications/delivery", handlers.UserNotificationDeliveryModesPost).Methods("POST", "OPTIONS")
+		apiV1AuthRouter.HandleFunc("/alerts", handlers.UserAlertRules).Methods("GET", "OPTIONS")
+		apiV1AuthRouter.HandleFunc("/alerts", handlers.UserAlertRuleCreate).Methods("POST", "OPTIONS")
+		apiV1AuthRouter.HandleFunc("/alerts/{ruleID}", handlers.UserAlertRuleUpdate).Methods("POST", "OPTIONS")
+		apiV1AuthRouter.HandleFunc("/alerts/{ruleID}/delete", handlers.UserAlertRuleDelete).Methods("POST", "OPTIONS")
 		apiV1AuthRouter.HandleFunc("/stats", handlers.ClientStats).Methods("GET", "OPTIONS")
 		apiV1AuthRouter.HandleFunc("/stats/{offset}/{limit}", handlers.ClientStats).Methods("GET", "OPTIONS")
 		apiV1AuthRouter.HandleFunc("/ethpool", handlers.RegisterEthpoolSubscription).Methods("POST", "OPTIONS")
@@ -404,14 +436,20 @@ func main() {
 			router.HandleFunc("/address/{address}/erc20", handlers.Eth1AddressErc20Transactions).Methods("GET")
 			router.HandleFunc("/address/{address}/erc721", handlers.Eth1AddressErc721Transactions).Methods("GET")
 			router.HandleFunc("/address/{address}/erc1155", handlers.Eth1AddressErc1155Transactions).Methods("GET")
+			router.HandleFunc("/address/{address}/balanceHistory", handlers.Eth1AddressBalanceHistory).Methods("GET")
+			router.HandleFunc("/address/{address}/{role:userOps|sponsoredUserOps|bundledUserOps}", handlers.Eth1AddressUserOperations).Methods("GET")
 			router.HandleFunc("/token/{token}", handlers.Eth1Token).Methods("GET")
 			router.HandleFunc("/token/{token}/transfers", handlers.Eth1TokenTransfers).Methods("GET")
+			router.HandleFunc("/token/{token}/holders", handlers.Eth1TokenHolders).Methods("GET")
+			router.HandleFunc("/token/{token}/{id:[0-9]+}", handlers.Eth1TokenNft).Methods("GET")
+			router.HandleFunc("/token/{token}/{id:[0-9]+}/image", handlers.Eth1TokenNftImage).Methods("GET")
 			router.HandleFunc("/transactions", handlers.Eth1Transactions).Methods("GET")
 			router.HandleFunc("/transactions/data", handlers.Eth1TransactionsData).Methods("GET")
 			router.HandleFunc("/block/{block}", handlers.Eth1Block).Methods("GET")
 			router.HandleFunc("/block/{block}/transactions", handlers.BlockTransactionsData).Methods("GET")
 			router.HandleFunc("/tx/{hash}", handlers.Eth1TransactionTx).Methods("GET")
 			router.HandleFunc("/tx/{hash}/data", handlers.Eth1TransactionTxData).Methods("GET")
+			router.HandleFunc("/userop/{hash}", handlers.Eth1UserOperation).Methods("GET")
 			router.HandleFunc("/mempool", handlers.MempoolView).Methods("GET")
 			router.HandleFunc("/burn", handlers.Burn).Methods("GET")
 			router.HandleFunc("/burn/data", handlers.BurnPageData).Methods("GET")
@@ -546,6 +584,8 @@ func main() {
 			authRouter.HandleFunc("/settings/flags", handlers.UserUpdateFlagsPost).Methods("POST")
 			authRouter.HandleFunc("/settings/delete", handlers.UserDeletePost).Methods("POST")
 			authRouter.HandleFunc("/settings/email", handlers.UserUpdateEmailPost).Methods("POST")
+			authRouter.HandleFunc("/settings/telegram/link", handlers.UserTelegramLinkPost).Methods("POST")
+			authRouter.HandleFunc("/settings/telegram/unlink", handlers.UserTelegramUnlinkPost).Methods("POST")
 			authRouter.HandleFunc("/notifications", handlers.UserNotificationsCenter).Methods("GET")
 			authRouter.HandleFunc("/notifications/channels", handlers.UsersNotificationChannels).Methods("POST")
 			authRouter.HandleFunc("/notifications/data", handlers.UserNotificationsData).Methods("GET")
@@ -563,6 +603,9 @@ func main() {
 			authRouter.HandleFunc("/ad_configuration/delete", handlers.AdConfigurationDeletePost).Methods("POST")
 			authRouter.HandleFunc("/explorer_configuration", handlers.ExplorerConfiguration).Methods("GET")
 			authRouter.HandleFunc("/explorer_configuration", handlers.ExplorerConfigurationPost).Methods("POST")
+			authRouter.HandleFunc("/contract_abi", handlers.ContractAbi).Methods("GET")
+			authRouter.HandleFunc("/contract_abi", handlers.ContractAbiPost).Methods("POST")
+			authRouter.HandleFunc("/contract_abi/submissions/{submissionID}", handlers.ContractAbiReviewPost).Methods("POST")
 
 			authRouter.HandleFunc("/notifications-center", handlers.UserNotificationsCenter).Methods("GET")
 			authRouter.HandleFunc("/notifications-center/removeall", handlers.RemoveAllValidatorsAndUnsubscribe).Methods("POST")
@@ -578,6 +621,8 @@ func main() {
 			authRouter.HandleFunc("/webhooks/add", handlers.UsersAddWebhook).Methods("POST")
 			authRouter.HandleFunc("/webhooks/{webhookID}/update", handlers.UsersEditWebhook).Methods("POST")
 			authRouter.HandleFunc("/webhooks/{webhookID}/delete", handlers.UsersDeleteWebhook).Methods("POST")
+			authRouter.HandleFunc("/webhooks/{webhookID}/secret", handlers.UsersRotateWebhookSecret).Methods("POST")
+			authRouter.HandleFunc("/webhooks/{webhookID}/deliveries/{deliveryID}/redeliver", handlers.UsersRedeliverWebhook).Methods("POST")
 
 			err = initStripe(authRouter)
 			if err != nil {
@@ -0,0 +1,244 @@
+package commands
+
+import (
+	"context"
+	"eth2-exporter/db"
+	"eth2-exporter/exporter"
+	"eth2-exporter/rpc"
+	"eth2-exporter/types"
+	"eth2-exporter/utils"
+	"flag"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+)
+
+// BackfillCommand re-exports a range of epochs in parallel. Every epoch is exported within its own tx and marked as done
+// in the backfill_epochs table by the same tx, so an interrupted backfill resumes with the first epoch that was not completed.
+type BackfillCommand struct {
+	Config backfillConfig
+}
+
+type backfillConfig struct {
+	Node       string
+	Workers    int
+	MaxLatency time.Duration
+	MaxDelay   time.Duration
+	Force      bool
+}
+
+func (b *BackfillCommand) ParseCommandOptions() {
+	flag.StringVar(&b.Config.Node, "backfill.node", "", "Url of the (archive) beacon node to backfill from, defaults to the node of the indexer config")
+	flag.IntVar(&b.Config.Workers, "backfill.workers", 4, "Number of epochs that are exported in parallel")
+	flag.DurationVar(&b.Config.MaxLatency, "backfill.max-latency", 2*time.Second, "Slow down the backfill while the average block request latency of the beacon node is above this value, 0 disables throttling")
+	flag.DurationVar(&b.Config.MaxDelay, "backfill.max-delay", 30*time.Second, "Maximum delay between two slot exports of a worker when throttling")
+	flag.BoolVar(&b.Config.Force, "backfill.force", false, "Re-export epochs that have already been backfilled")
+}
+
+func (b *BackfillCommand) StartBackfillCommand(client rpc.Client, startEpoch, endEpoch uint64) error {
+	if endEpoch < startEpoch {
+		return errors.Errorf("invalid epoch range %v - %v", startEpoch, endEpoch)
+	}
+	if b.Config.Workers <= 0 {
+		return errors.New("Please specify a valid number of workers via --backfill.workers")
+	}
+
+	done := map[uint64]bool{}
+	if !b.Config.Force {
+		doneEpochs := []uint64{}
+		err := db.WriterDb.Select(&doneEpochs, `SELECT epoch FROM backfill_epochs WHERE epoch >= $1 AND epoch <= $2`, startEpoch, endEpoch)
+		if err != nil {
+			return errors.Wrap(err, "error retrieving backfilled epochs")
+		}
+		for _, epoch := range doneEpochs {
+			done[epoch] = true
+		}
+	}
+
+	pending := pendingEpochs(startEpoch, endEpoch, done)
+	logrus.Infof("backfilling epochs %v - %v with %v workers, %v epochs have already been backfilled", startEpoch, endEpoch, b.Config.Workers, len(done))
+	if len(pending) == 0 {
+		return nil
+	}
+
+	throttle := &latencyThrottle{maxLatency: b.Config.MaxLatency, maxDelay: b.Config.MaxDelay}
+	client = &latencyTrackingClient{Client: client, throttle: throttle}
+
+	return runBackfill(pending, b.Config.Workers, throttle, func(ctx context.Context, epoch uint64) error {
+		return backfillEpoch(ctx, client, throttle, epoch)
+	})
+}
+
+// pendingEpochs returns the epochs of the range that have not been backfilled yet
+func pendingEpochs(startEpoch, endEpoch uint64, done map[uint64]bool) []uint64 {
+	pending := make([]uint64, 0, endEpoch-startEpoch+1)
+	for epoch := startEpoch; epoch <= endEpoch; epoch++ {
+		if !done[epoch] {
+			pending = append(pending, epoch)
+		}
+	}
+	return pending
+}
+
+// runBackfill exports the pending epochs with the given number of workers, the first failing epoch stops the backfill
+func runBackfill(pending []uint64, workers int, throttle *latencyThrottle, exportEpoch func(ctx context.Context, epoch uint64) error) error {
+	epochs := make(chan uint64)
+	g, ctx := errgroup.WithContext(context.Background())
+	g.Go(func() error {
+		defer close(epochs)
+		for _, epoch := range pending {
+			select {
+			case epochs <- epoch:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+		return nil
+	})
+
+	start := time.Now()
+	completed := uint64(0)
+	for i := 0; i < workers; i++ {
+		g.Go(func() error {
+			for epoch := range epochs {
+				err := exportEpoch(ctx, epoch)
+				if err != nil {
+					return err
+				}
+
+				count := atomic.AddUint64(&completed, 1)
+				remaining := time.Duration(float64(time.Since(start)) / float64(count) * float64(uint64(len(pending))-count))
+				logrus.WithFields(logrus.Fields{
+					"epoch":     epoch,
+					"completed": count,
+					"pending":   uint64(len(pending)) - count,
+					"latency":   throttle.averageLatency(),
+					"delay":     throttle.currentDelay(),
+					"eta":       remaining.Round(time.Second),
+				}).Infof("backfilled epoch %v", epoch)
+			}
+			return nil
+		})
+	}
+
+	return g.Wait()
+}
+
+// backfillEpoch exports all slots of an epoch and marks the epoch as done within one tx
+func backfillEpoch(ctx context.Context, client rpc.Client, throttle *latencyThrottle, epoch uint64) error {
+	tx, err := db.WriterDb.Beginx()
+	if err != nil {
+		return errors.Wrap(err, "error starting tx")
+	}
+	defer tx.Rollback()
+
+	for slot := epoch * utils.Config.Chain.ClConfig.SlotsPerEpoch; slot < (epoch+1)*utils.Config.Chain.ClConfig.SlotsPerEpoch; slot++ {
+		err = throttle.wait(ctx)
+		if err != nil {
+			return err
+		}
+		err = exporter.ExportSlot(client, slot, false, tx)
+		if err != nil {
+			return errors.Wrapf(err, "error exporting slot %v", slot)
+		}
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO backfill_epochs (epoch, ts)
+		VALUES ($1, NOW())
+		ON CONFLICT (epoch) DO UPDATE SET ts = excluded.ts`, epoch)
+	if err != nil {
+		return errors.Wrapf(err, "error marking epoch %v as backfilled", epoch)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrapf(err, "error committing tx for epoch %v", epoch)
+	}
+	return nil
+}
+
+// latencyTrackingClient reports the latency of the block requests to the throttle, block requests are the bulk of the
+// load that an export puts on the beacon node
+type latencyTrackingClient struct {
+	rpc.Client
+	throttle *latencyThrottle
+}
+
+func (c *latencyTrackingClient) GetBlockBySlot(slot uint64) (*types.Block, error) {
+	start := time.Now()
+	block, err := c.Client.GetBlockBySlot(slot)
+	c.throttle.observe(time.Since(start))
+	return block, err
+}
+
+// latencyThrottle keeps a moving average of the beacon node latency. While the average is above the max latency
+// the delay between slot exports is doubled with every request, once the node recovers the delay is halved again.
+type latencyThrottle struct {
+	mu         sync.Mutex
+	maxLatency time.Duration
+	maxDelay   time.Duration
+	average    time.Duration
+	delay      time.Duration
+}
+
+func (t *latencyThrottle) observe(latency time.Duration) {
+	if t.maxLatency == 0 {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.average == 0 {
+		t.average = latency
+	} else {
+		t.average = (t.average*4 + latency) / 5
+	}
+
+	if t.average > t.maxLatency {
+		if t.delay == 0 {
+			t.delay = 100 * time.Millisecond
+		} else {
+			t.delay *= 2
+		}
+		if t.delay > t.maxDelay {
+			t.delay = t.maxDelay
+		}
+	} else {
+		t.delay /= 2
+		if t.delay < 10*time.Millisecond {
+			t.delay = 0
+		}
+	}
+}
+
+func (t *latencyThrottle) wait(ctx context.Context) error {
+	delay := t.currentDelay()
+	if delay == 0 {
+		return ctx.Err()
+	}
+
+	select {
+	case <-time.After(delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (t *latencyThrottle) averageLatency() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.average
+}
+
+func (t *latencyThrottle) currentDelay() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.delay
+}
@@ -0,0 +1,132 @@
+package commands
+
+import (
+	"context"
+	"eth2-exporter/rpc"
+	"eth2-exporter/types"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBackfillResume(t *testing.T) {
+	// the checkpoint mirrors the backfill_epochs table, an epoch is only marked as done by the tx that exported it
+	checkpoint := map[uint64]bool{}
+	exported := []uint64{}
+	mu := sync.Mutex{}
+	export := func(failAt uint64) func(ctx context.Context, epoch uint64) error {
+		return func(ctx context.Context, epoch uint64) error {
+			if epoch == failAt {
+				return fmt.Errorf("error exporting epoch %v", epoch)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			exported = append(exported, epoch)
+			checkpoint[epoch] = true
+			return nil
+		}
+	}
+	throttle := &latencyThrottle{}
+
+	// the first run crashes at epoch 13
+	err := runBackfill(pendingEpochs(10, 15, checkpoint), 1, throttle, export(13))
+	if err == nil {
+		t.Fatalf("expected the backfill to stop at the failing epoch")
+	}
+	if !reflect.DeepEqual(exported, []uint64{10, 11, 12}) {
+		t.Fatalf("expected epochs 10 - 12 to be exported before the crash, got %v", exported)
+	}
+
+	// the second run resumes at the first epoch that has not been completed
+	exported = []uint64{}
+	pending := pendingEpochs(10, 15, checkpoint)
+	if !reflect.DeepEqual(pending, []uint64{13, 14, 15}) {
+		t.Fatalf("expected epochs 13 - 15 to be pending, got %v", pending)
+	}
+	err = runBackfill(pending, 3, throttle, export(0))
+	if err != nil {
+		t.Fatalf("error resuming the backfill: %v", err)
+	}
+	if len(exported) != 3 {
+		t.Errorf("expected 3 epochs to be exported by the resumed run, got %v", exported)
+	}
+	for epoch := uint64(10); epoch <= 15; epoch++ {
+		if !checkpoint[epoch] {
+			t.Errorf("expected epoch %v to be backfilled", epoch)
+		}
+	}
+	if pending := pendingEpochs(10, 15, checkpoint); len(pending) != 0 {
+		t.Errorf("expected no pending epochs after the backfill, got %v", pending)
+	}
+}
+
+func TestLatencyThrottle(t *testing.T) {
+	throttle := &latencyThrottle{maxLatency: 100 * time.Millisecond, maxDelay: 500 * time.Millisecond}
+
+	// the delay doubles with every slow request up to the max delay
+	for _, expected := range []time.Duration{100, 200, 400, 500, 500} {
+		throttle.observe(200 * time.Millisecond)
+		if throttle.currentDelay() != expected*time.Millisecond {
+			t.Fatalf("expected a delay of %vms, got %v", expected, throttle.currentDelay())
+		}
+	}
+
+	// the delay is kept until the moving average recovers, then it is halved with every request
+	delays := []time.Duration{}
+	for i := 0; i < 10; i++ {
+		throttle.observe(0)
+		delays = append(delays, throttle.currentDelay())
+	}
+	// the average drops to 160ms, 128ms, 102.4ms and 81.92ms before the delay is halved
+	expected := []time.Duration{
+		500 * time.Millisecond, 500 * time.Millisecond, 500 * time.Millisecond, 250 * time.Millisecond, 125 * time.Millisecond,
+		62500 * time.Microsecond, 31250 * time.Microsecond, 15625 * time.Microsecond, 0, 0,
+	}
+	if !reflect.DeepEqual(delays, expected) {
+		t.Errorf("expected delays %v while the node recovers, got %v", expected, delays)
+	}
+
+	// throttling is disabled without a max latency
+	disabled := &latencyThrottle{}
+	disabled.observe(time.Minute)
+	if disabled.currentDelay() != 0 || disabled.wait(context.Background()) != nil {
+		t.Errorf("expected no delay without a max latency")
+	}
+
+	// waiting is canceled with the backfill
+	throttle.delay = time.Minute
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if throttle.wait(ctx) != context.Canceled {
+		t.Errorf("expected the wait to be canceled")
+	}
+}
+
+// slowBlockClient serves blocks with a fixed latency, all other requests of the client are not used by the test
+type slowBlockClient struct {
+	rpc.Client
+	latency time.Duration
+}
+
+func (c *slowBlockClient) GetBlockBySlot(slot uint64) (*types.Block, error) {
+	time.Sleep(c.latency)
+	return &types.Block{Slot: slot}, nil
+}
+
+func TestLatencyTrackingClient(t *testing.T) {
+	throttle := &latencyThrottle{maxLatency: 10 * time.Millisecond, maxDelay: time.Second}
+	client := &latencyTrackingClient{Client: &slowBlockClient{latency: 20 * time.Millisecond}, throttle: throttle}
+
+	block, err := client.GetBlockBySlot(42)
+	if err != nil || block.Slot != 42 {
+		t.Fatalf("expected block 42 of the wrapped client, got %v, %v", block, err)
+	}
+	if throttle.averageLatency() < 20*time.Millisecond {
+		t.Errorf("expected the latency of the block request to be observed, got %v", throttle.averageLatency())
+	}
+	if throttle.currentDelay() == 0 {
+		t.Errorf("expected the slow block request to throttle the backfill")
+	}
+}
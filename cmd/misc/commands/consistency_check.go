@@ -0,0 +1,387 @@
+package commands
+
+import (
+	"bytes"
+	"database/sql"
+	"eth2-exporter/db"
+	"eth2-exporter/metrics"
+	"eth2-exporter/rpc"
+	"eth2-exporter/types"
+	"flag"
+	"time"
+
+	"github.com/coocood/freecache"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+)
+
+// execPayloadCheckName is the name the progress of the check is stored under in the consistency_checks table
+const execPayloadCheckName = "exec_payloads"
+
+const (
+	mismatchMissing           = "missing"
+	mismatchHash              = "hash"
+	mismatchFeeRecipient      = "fee_recipient"
+	mismatchTransactionsCount = "transactions_count"
+	mismatchNotIndexed        = "not_indexed"
+)
+
+// ConsistencyCheckCommand compares the execution payload data of the finalized slots in the blocks table of postgres with the
+// execution blocks in bigtable and re-indexes the execution blocks that are missing or do not match. The last checked slot is
+// stored in the consistency_checks table, so every run continues where the previous one stopped.
+type ConsistencyCheckCommand struct {
+	Config consistencyCheckConfig
+}
+
+type consistencyCheckConfig struct {
+	BatchSize   uint64
+	Reindex     bool
+	Resume      bool
+	Follow      bool
+	Interval    time.Duration
+	MetricsAddr string
+}
+
+func (c *ConsistencyCheckCommand) ParseCommandOptions() {
+	flag.Uint64Var(&c.Config.BatchSize, "consistency.batch-size", 100, "Number of slots that are checked at once")
+	flag.BoolVar(&c.Config.Reindex, "consistency.reindex", true, "Re-index the execution blocks of mismatching slots, if false mismatches are only recorded")
+	flag.BoolVar(&c.Config.Resume, "consistency.resume", false, "Continue after the last slot checked by a previous run instead of starting at --start-epoch")
+	flag.BoolVar(&c.Config.Follow, "consistency.follow", false, "Keep checking newly finalized slots instead of exiting at the end of the range")
+	flag.DurationVar(&c.Config.Interval, "consistency.interval", time.Minute, "Time to wait for newly finalized slots in follow mode")
+	flag.StringVar(&c.Config.MetricsAddr, "consistency.metrics-addr", "", "Address to serve the metrics of the check on, metrics are not served if empty")
+}
+
+type execPayloadRow struct {
+	Slot                  uint64 `db:"slot"`
+	ExecBlockNumber       uint64 `db:"exec_block_number"`
+	ExecBlockHash         []byte `db:"exec_block_hash"`
+	ExecFeeRecipient      []byte `db:"exec_fee_recipient"`
+	ExecTransactionsCount uint64 `db:"exec_transactions_count"`
+}
+
+type execPayloadMismatch struct {
+	Row        *execPayloadRow
+	Kind       string
+	ResolvedTs *time.Time
+	// IndexedHash is the hash of the execution block stored in bigtable for the block number of the slot, it is nil if the block is missing
+	IndexedHash []byte
+}
+
+type execPayloadChecker struct {
+	config     consistencyCheckConfig
+	client     *rpc.ErigonClient
+	bt         *db.Bigtable
+	transforms []func(blk *types.Eth1Block, cache *freecache.Cache) (*types.BulkMutations, *types.BulkMutations, error)
+	cache      *freecache.Cache
+}
+
+// StartConsistencyCheckCommand checks the finalized slots from startSlot up to endSlot. If resume is set the check continues after the
+// last slot checked by a previous run instead, if endSlot is 0 it checks up to the last finalized slot.
+func (c *ConsistencyCheckCommand) StartConsistencyCheckCommand(client *rpc.ErigonClient, bt *db.Bigtable, startSlot, endSlot uint64) error {
+	if c.Config.BatchSize == 0 {
+		return errors.New("Please specify a valid batch size via --consistency.batch-size")
+	}
+	if endSlot > 0 && endSlot < startSlot {
+		return errors.Errorf("invalid slot range %v - %v", startSlot, endSlot)
+	}
+
+	if c.Config.MetricsAddr != "" {
+		go func() {
+			logrus.WithFields(logrus.Fields{"addr": c.Config.MetricsAddr}).Infof("Serving metrics")
+			if err := metrics.Serve(c.Config.MetricsAddr); err != nil {
+				logrus.WithError(err).Fatal("Error serving metrics")
+			}
+		}()
+	}
+
+	if c.Config.Resume {
+		err := db.WriterDb.Get(&startSlot, `SELECT last_slot + 1 FROM consistency_checks WHERE name = $1`, execPayloadCheckName)
+		if err != nil && err != sql.ErrNoRows {
+			return errors.Wrap(err, "error retrieving the last checked slot")
+		}
+	}
+
+	transforms, err := bt.GetEth1Transforms(nil)
+	if err != nil {
+		return err
+	}
+
+	checker := &execPayloadChecker{
+		config:     c.Config,
+		client:     client,
+		bt:         bt,
+		transforms: transforms,
+		cache:      freecache.NewCache(100 * 1024 * 1024), // 100 MB limit
+	}
+
+	for {
+		nextSlot, err := checker.checkRange(startSlot, endSlot)
+		if err != nil {
+			return err
+		}
+		if !c.Config.Follow || (endSlot > 0 && nextSlot > endSlot) {
+			return nil
+		}
+		startSlot = nextSlot
+		time.Sleep(c.Config.Interval)
+	}
+}
+
+// checkRange checks all finalized slots from startSlot up to endSlot in batches and returns the next slot that has to be checked
+func (c *execPayloadChecker) checkRange(startSlot, endSlot uint64) (uint64, error) {
+	lastFinalizedSlot := uint64(0)
+	err := db.WriterDb.Get(&lastFinalizedSlot, `SELECT COALESCE(MAX(slot), 0) FROM blocks WHERE finalized`)
+	if err != nil {
+		return 0, errors.Wrap(err, "error retrieving the last finalized slot")
+	}
+	lastIndexedBlock, err := c.bt.GetLastBlockInBlocksTable()
+	if err != nil {
+		return 0, errors.Wrap(err, "error retrieving the last block of the blocks table")
+	}
+
+	to := lastFinalizedSlot
+	if endSlot > 0 && endSlot < to {
+		to = endSlot
+	}
+	logrus.Infof("checking execution payloads of slots %v - %v", startSlot, to)
+
+	for from := startSlot; from <= to; from += c.config.BatchSize {
+		batchEnd := from + c.config.BatchSize - 1
+		if batchEnd > to {
+			batchEnd = to
+		}
+
+		nextSlot, err := c.checkBatch(from, batchEnd, uint64(lastIndexedBlock))
+		if err != nil {
+			return 0, err
+		}
+		if nextSlot <= batchEnd {
+			logrus.Infof("execution blocks after slot %v have not been indexed yet, stopping the check", nextSlot-1)
+			return nextSlot, nil
+		}
+	}
+
+	if startSlot > to {
+		return startSlot, nil
+	}
+	return to + 1, nil
+}
+
+// checkBatch compares the slots from the db with the execution blocks in bigtable, records and resolves the mismatches and
+// stores the progress. It returns the next slot to check, which is lower than to + 1 if the eth1 indexer has not caught up yet.
+func (c *execPayloadChecker) checkBatch(from, to, lastIndexedBlock uint64) (uint64, error) {
+	start := time.Now()
+	defer func() {
+		metrics.TaskDuration.WithLabelValues("misc_consistency_check_batch").Observe(time.Since(start).Seconds())
+	}()
+
+	rows := []*execPayloadRow{}
+	err := db.WriterDb.Select(&rows, `
+		SELECT slot, exec_block_number, exec_block_hash, COALESCE(exec_fee_recipient, '\x'::BYTEA) AS exec_fee_recipient, exec_transactions_count
+		FROM blocks
+		WHERE slot >= $1 AND slot <= $2 AND status = '1' AND exec_block_number > 0
+		ORDER BY slot`, from, to)
+	if err != nil {
+		return 0, errors.Wrapf(err, "error retrieving execution payloads of slots %v - %v", from, to)
+	}
+
+	rows, nextSlot := indexedExecPayloads(rows, to, lastIndexedBlock)
+
+	mismatches, err := c.findMismatches(rows)
+	if err != nil {
+		return 0, err
+	}
+	metrics.Tasks.WithLabelValues("misc_consistency_check_slots").Add(float64(len(rows)))
+
+	for _, m := range mismatches {
+		metrics.Errors.WithLabelValues("misc_consistency_check_" + m.Kind).Inc()
+		logger := logrus.WithFields(logrus.Fields{"slot": m.Row.Slot, "block": m.Row.ExecBlockNumber, "kind": m.Kind})
+		if !c.config.Reindex {
+			logger.Warnf("found execution payload mismatch")
+			continue
+		}
+
+		resolved, err := c.reindex(m)
+		if err != nil {
+			return 0, err
+		}
+		if resolved {
+			now := time.Now()
+			m.ResolvedTs = &now
+			metrics.Tasks.WithLabelValues("misc_consistency_check_reindexed").Inc()
+			logger.Infof("re-indexed execution block of mismatching slot")
+		} else {
+			metrics.Errors.WithLabelValues("misc_consistency_check_unresolved").Inc()
+			logger.Warnf("unable to resolve execution payload mismatch, the execution node does not know the block of the slot")
+		}
+	}
+
+	tx, err := db.WriterDb.Beginx()
+	if err != nil {
+		return 0, errors.Wrap(err, "error starting tx")
+	}
+	defer tx.Rollback()
+
+	for _, m := range mismatches {
+		_, err = tx.Exec(`
+			INSERT INTO consistency_check_mismatches (slot, exec_block_number, kind, detected_ts, resolved_ts)
+			VALUES ($1, $2, $3, NOW(), $4)
+			ON CONFLICT (slot, kind) DO UPDATE SET exec_block_number = excluded.exec_block_number, resolved_ts = excluded.resolved_ts`,
+			m.Row.Slot, m.Row.ExecBlockNumber, m.Kind, m.ResolvedTs)
+		if err != nil {
+			return 0, errors.Wrapf(err, "error saving mismatch of slot %v", m.Row.Slot)
+		}
+	}
+
+	if nextSlot > from {
+		_, err = tx.Exec(`
+			INSERT INTO consistency_checks (name, last_slot, ts)
+			VALUES ($1, $2, NOW())
+			ON CONFLICT (name) DO UPDATE SET last_slot = excluded.last_slot, ts = excluded.ts`,
+			execPayloadCheckName, nextSlot-1)
+		if err != nil {
+			return 0, errors.Wrap(err, "error saving the last checked slot")
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, errors.Wrap(err, "error committing tx")
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"from":       from,
+		"to":         nextSlot - 1,
+		"mismatches": len(mismatches),
+		"duration":   time.Since(start),
+	}).Infof("checked execution payloads")
+	return nextSlot, nil
+}
+
+// indexedExecPayloads returns the leading rows whose execution blocks have already been indexed and the slot the check has to continue at,
+// which is the slot of the first row that has not been indexed or to + 1 if all rows have been indexed
+func indexedExecPayloads(rows []*execPayloadRow, to, lastIndexedBlock uint64) ([]*execPayloadRow, uint64) {
+	for i, row := range rows {
+		if row.ExecBlockNumber > lastIndexedBlock {
+			return rows[:i], row.Slot
+		}
+	}
+	return rows, to + 1
+}
+
+// findMismatches loads the execution blocks of the rows from the blocks table and the data table of bigtable and compares them with the rows
+func (c *execPayloadChecker) findMismatches(rows []*execPayloadRow) ([]*execPayloadMismatch, error) {
+	mismatches := []*execPayloadMismatch{}
+	if len(rows) == 0 {
+		return mismatches, nil
+	}
+
+	// the slots are ordered, so are the execution block numbers of the canonical chain
+	low := rows[0].ExecBlockNumber
+	high := rows[len(rows)-1].ExecBlockNumber
+
+	blocks := make(map[uint64]*types.Eth1Block, len(rows))
+	stream := make(chan *types.Eth1Block, 100)
+	g := errgroup.Group{}
+	g.Go(func() error {
+		defer close(stream)
+		return c.bt.GetFullBlocksDescending(stream, high, low)
+	})
+	for block := range stream {
+		blocks[block.Number] = block
+	}
+	err := g.Wait()
+	if err != nil {
+		return nil, errors.Wrapf(err, "error retrieving blocks %v - %v from bigtable", low, high)
+	}
+
+	numbers := make([]uint64, 0, len(rows))
+	for _, row := range rows {
+		numbers = append(numbers, row.ExecBlockNumber)
+	}
+	indexedBlocks, err := c.bt.GetBlocksIndexedMultiple(numbers, uint64(len(numbers)))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error retrieving indexed blocks %v - %v from bigtable", low, high)
+	}
+	indexed := make(map[uint64]bool, len(indexedBlocks))
+	for _, block := range indexedBlocks {
+		indexed[block.GetNumber()] = true
+	}
+
+	return compareExecPayloads(rows, blocks, indexed), nil
+}
+
+// compareExecPayloads compares the rows with the execution blocks of the blocks table and the indexed block numbers of the data table
+func compareExecPayloads(rows []*execPayloadRow, blocks map[uint64]*types.Eth1Block, indexed map[uint64]bool) []*execPayloadMismatch {
+	mismatches := []*execPayloadMismatch{}
+	for _, row := range rows {
+		block := blocks[row.ExecBlockNumber]
+
+		kind := ""
+		if block == nil {
+			kind = mismatchMissing
+		} else if !bytes.Equal(block.Hash, row.ExecBlockHash) {
+			kind = mismatchHash
+		} else if !bytes.Equal(block.Coinbase, row.ExecFeeRecipient) {
+			kind = mismatchFeeRecipient
+		} else if uint64(len(block.Transactions)) != row.ExecTransactionsCount {
+			kind = mismatchTransactionsCount
+		} else if !indexed[row.ExecBlockNumber] {
+			kind = mismatchNotIndexed
+		}
+
+		if kind != "" {
+			m := &execPayloadMismatch{Row: row, Kind: kind}
+			if block != nil {
+				m.IndexedHash = block.Hash
+			}
+			mismatches = append(mismatches, m)
+		}
+	}
+
+	return mismatches
+}
+
+// reindex fetches the execution block of a slot from the node, saves it to the blocks table and runs the transformers for it.
+// An orphaned block indexed under the same number is reverted through its journal first.
+// It returns false if the node does not have the block referenced by the consensus layer.
+func (c *execPayloadChecker) reindex(m *execPayloadMismatch) (bool, error) {
+	row := m.Row
+	block, _, err := c.client.GetBlock(int64(row.ExecBlockNumber), "parity/geth")
+	if err != nil {
+		return false, errors.Wrapf(err, "error retrieving block %v from the node", row.ExecBlockNumber)
+	}
+	if !bytes.Equal(block.Hash, row.ExecBlockHash) {
+		return false, nil
+	}
+
+	if !bytes.Equal(block.Coinbase, row.ExecFeeRecipient) || uint64(len(block.Transactions)) != row.ExecTransactionsCount {
+		// the node agrees on the block hash, so the payload data stored for the slot is wrong
+		_, err = db.WriterDb.Exec(`UPDATE blocks SET exec_fee_recipient = $1, exec_transactions_count = $2 WHERE slot = $3 AND exec_block_hash = $4`,
+			block.Coinbase, len(block.Transactions), row.Slot, row.ExecBlockHash)
+		if err != nil {
+			return false, errors.Wrapf(err, "error updating execution payload of slot %v", row.Slot)
+		}
+	}
+
+	if m.IndexedHash != nil && !bytes.Equal(m.IndexedHash, block.Hash) {
+		err = c.bt.RevertEth1Block(row.ExecBlockNumber, m.IndexedHash)
+		if err != nil {
+			return false, errors.Wrapf(err, "error reverting orphaned block %v with hash %x", row.ExecBlockNumber, m.IndexedHash)
+		}
+	}
+
+	err = c.bt.SaveBlock(block)
+	if err != nil {
+		return false, errors.Wrapf(err, "error saving block %v", row.ExecBlockNumber)
+	}
+
+	err = c.bt.IndexEventsWithTransformers(int64(row.ExecBlockNumber), int64(row.ExecBlockNumber), c.transforms, 1, c.cache)
+	if err != nil {
+		return false, errors.Wrapf(err, "error indexing block %v", row.ExecBlockNumber)
+	}
+	c.cache.Clear()
+
+	return true, nil
+}
@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"eth2-exporter/types"
+	"testing"
+)
+
+func TestCompareExecPayloads(t *testing.T) {
+	blocks := map[uint64]*types.Eth1Block{
+		100: {Number: 100, Hash: []byte{0x01}, Coinbase: []byte{0xaa}, Transactions: []*types.Eth1Transaction{{}, {}}},
+		101: {Number: 101, Hash: []byte{0x02}, Coinbase: []byte{0xaa}},
+	}
+	indexed := map[uint64]bool{100: true, 101: true}
+
+	tests := []struct {
+		Name         string
+		Row          *execPayloadRow
+		Indexed      map[uint64]bool
+		Expected     string
+		ExpectedHash []byte
+	}{
+		{"matching", &execPayloadRow{Slot: 10, ExecBlockNumber: 100, ExecBlockHash: []byte{0x01}, ExecFeeRecipient: []byte{0xaa}, ExecTransactionsCount: 2}, indexed, "", nil},
+		{"missing block", &execPayloadRow{Slot: 12, ExecBlockNumber: 102, ExecBlockHash: []byte{0x03}, ExecFeeRecipient: []byte{0xaa}}, indexed, mismatchMissing, nil},
+		{"orphaned block indexed", &execPayloadRow{Slot: 11, ExecBlockNumber: 101, ExecBlockHash: []byte{0x04}, ExecFeeRecipient: []byte{0xaa}}, indexed, mismatchHash, []byte{0x02}},
+		{"fee recipient", &execPayloadRow{Slot: 11, ExecBlockNumber: 101, ExecBlockHash: []byte{0x02}, ExecFeeRecipient: []byte{0xbb}}, indexed, mismatchFeeRecipient, []byte{0x02}},
+		{"transactions count", &execPayloadRow{Slot: 10, ExecBlockNumber: 100, ExecBlockHash: []byte{0x01}, ExecFeeRecipient: []byte{0xaa}, ExecTransactionsCount: 3}, indexed, mismatchTransactionsCount, []byte{0x01}},
+		{"not indexed", &execPayloadRow{Slot: 11, ExecBlockNumber: 101, ExecBlockHash: []byte{0x02}, ExecFeeRecipient: []byte{0xaa}}, map[uint64]bool{100: true}, mismatchNotIndexed, []byte{0x02}},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			mismatches := compareExecPayloads([]*execPayloadRow{test.Row}, blocks, test.Indexed)
+			if test.Expected == "" {
+				if len(mismatches) != 0 {
+					t.Errorf("expected no mismatch, got %v", mismatches[0].Kind)
+				}
+				return
+			}
+			if len(mismatches) != 1 {
+				t.Fatalf("expected 1 mismatch, got %v", len(mismatches))
+			}
+			m := mismatches[0]
+			if m.Kind != test.Expected || m.Row != test.Row {
+				t.Errorf("expected a %v mismatch of slot %v, got a %v mismatch of slot %v", test.Expected, test.Row.Slot, m.Kind, m.Row.Slot)
+			}
+			if string(m.IndexedHash) != string(test.ExpectedHash) {
+				t.Errorf("expected indexed hash %x, got %x", test.ExpectedHash, m.IndexedHash)
+			}
+		})
+	}
+}
+
+func TestIndexedExecPayloads(t *testing.T) {
+	rows := []*execPayloadRow{
+		{Slot: 10, ExecBlockNumber: 100},
+		{Slot: 12, ExecBlockNumber: 101},
+		{Slot: 13, ExecBlockNumber: 102},
+	}
+
+	tests := []struct {
+		Name             string
+		LastIndexedBlock uint64
+		ExpectedRows     int
+		ExpectedNextSlot uint64
+	}{
+		// the check resumes after the batch, even if its last slots are missed slots
+		{"all indexed", 102, 3, 16},
+		// the check resumes at the first slot whose execution block has not been indexed yet
+		{"indexer behind", 100, 1, 12},
+		{"nothing indexed", 99, 0, 10},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			indexedRows, nextSlot := indexedExecPayloads(rows, 15, test.LastIndexedBlock)
+			if len(indexedRows) != test.ExpectedRows {
+				t.Errorf("expected %v rows to be checked, got %v", test.ExpectedRows, len(indexedRows))
+			}
+			if nextSlot != test.ExpectedNextSlot {
+				t.Errorf("expected the check to resume at slot %v, got %v", test.ExpectedNextSlot, nextSlot)
+			}
+		})
+	}
+}
@@ -17,9 +17,12 @@ import (
 	"eth2-exporter/utils"
 	"eth2-exporter/version"
 	"fmt"
+	"io/fs"
 	"math"
 	"math/big"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
@@ -61,15 +64,18 @@ var opts = struct {
 	Key                 string
 	ValidatorNameRanges string
 	Email               string
+	Directory           string
 	DryRun              bool
 	Yes                 bool
 }{}
 
 func main() {
 	statsPartitionCommand := commands.StatsMigratorCommand{}
+	backfillCommand := commands.BackfillCommand{}
+	consistencyCheckCommand := commands.ConsistencyCheckCommand{}
 
 	configPath := flag.String("config", "config/default.config.yml", "Path to the config file")
-	flag.StringVar(&opts.Command, "command", "", "command to run, available: updateAPIKey, applyDbSchema, initBigtableSchema, epoch-export, debug-rewards, debug-blocks, clear-bigtable, index-old-eth1-blocks, update-aggregation-bits, historic-prices-export, index-missing-blocks, export-epoch-missed-slots, migrate-last-attestation-slot-bigtable, export-genesis-validators, update-block-finalization-sequentially, nameValidatorsByRanges, export-stats-totals, export-sync-committee-periods, export-sync-committee-validator-stats, partition-validator-stats, migrate-app-purchases, update-ratelimits, disable-user-per-email")
+	flag.StringVar(&opts.Command, "command", "", "command to run, available: updateAPIKey, applyDbSchema, initBigtableSchema, epoch-export, debug-rewards, debug-blocks, clear-bigtable, index-old-eth1-blocks, update-aggregation-bits, historic-prices-export, index-missing-blocks, export-epoch-missed-slots, backfill, consistency-check, migrate-last-attestation-slot-bigtable, export-genesis-validators, update-block-finalization-sequentially, nameValidatorsByRanges, export-stats-totals, export-sync-committee-periods, export-sync-committee-validator-stats, partition-validator-stats, migrate-app-purchases, update-ratelimits, disable-user-per-email, import-contract-abis, rebuild-token-holders")
 	flag.Uint64Var(&opts.StartEpoch, "start-epoch", 0, "start epoch")
 	flag.Uint64Var(&opts.EndEpoch, "end-epoch", 0, "end epoch")
 	flag.Uint64Var(&opts.User, "user", 0, "user id")
@@ -89,11 +95,14 @@ func main() {
 	flag.StringVar(&opts.Addresses, "addresses", "", "Comma separated list of addresses that should be processed by the command")
 	flag.StringVar(&opts.Columns, "columns", "", "Comma separated list of columns that should be affected by the command")
 	flag.StringVar(&opts.Email, "email", "", "Email of the user")
+	flag.StringVar(&opts.Directory, "directory", "", "Directory containing the files that should be processed by the command")
 	flag.BoolVar(&opts.Yes, "yes", false, "Answer yes to all questions")
 	dryRun := flag.String("dry-run", "true", "if 'false' it deletes all rows starting with the key, per default it only logs the rows that would be deleted, but does not really delete them")
 	versionFlag := flag.Bool("version", false, "Show version and exit")
 
 	statsPartitionCommand.ParseCommandOptions()
+	backfillCommand.ParseCommandOptions()
+	consistencyCheckCommand.ParseCommandOptions()
 	flag.Parse()
 
 	if *versionFlag {
@@ -264,6 +273,23 @@ func main() {
 				logrus.Fatalf("error committing tx: %v", err)
 			}
 		}
+	case "backfill":
+		var backfillClient rpc.Client = rpcClient
+		if backfillCommand.Config.Node != "" {
+			backfillClient, err = rpc.NewLighthouseClient(backfillCommand.Config.Node, chainIDBig)
+			if err != nil {
+				utils.LogFatal(err, "lighthouse client error", 0)
+			}
+		}
+		err = backfillCommand.StartBackfillCommand(backfillClient, opts.StartEpoch, opts.EndEpoch)
+	case "consistency-check":
+		// --consistency.resume continues after the last checked slot, no end epoch checks up to the last finalized slot
+		startSlot := opts.StartEpoch * utils.Config.Chain.ClConfig.SlotsPerEpoch
+		endSlot := uint64(0)
+		if opts.EndEpoch > 0 {
+			endSlot = (opts.EndEpoch+1)*utils.Config.Chain.ClConfig.SlotsPerEpoch - 1
+		}
+		err = consistencyCheckCommand.StartConsistencyCheckCommand(erigonClient, bt, startSlot, endSlot)
 	case "debug-rewards":
 		compareRewards(opts.StartDay, opts.EndDay, opts.Validator, bt)
 	case "debug-blocks":
@@ -400,6 +426,10 @@ func main() {
 		ratelimit.DBUpdater()
 	case "disable-user-per-email":
 		err = disableUserPerEmail()
+	case "import-contract-abis":
+		err = importContractAbis(opts.Directory)
+	case "rebuild-token-holders":
+		err = rebuildTokenHolders(bt)
 	default:
 		utils.LogFatal(nil, fmt.Sprintf("unknown command %s", opts.Command), 0)
 	}
@@ -411,6 +441,67 @@ func main() {
 	}
 }
 
+// importContractAbis imports verified contract abis from a directory. Abi or solidity metadata json files are either named after the contract address
+// (<address>.json) or stored in a directory named after the contract address (<address>/metadata.json, as in the sourcify repository)
+// rebuildTokenHolders backfills the token holder index and recounts the token holders, the metadata updater has to be stopped while it runs
+func rebuildTokenHolders(bt *db.Bigtable) error {
+	logrus.WithFields(logrus.Fields{"dry": opts.DryRun}).Infof("command: rebuild-token-holders")
+	return bt.RebuildTokenHolders(int(opts.BatchSize), opts.DryRun)
+}
+
+func importContractAbis(dir string) error {
+	logrus.Infof("command: import-contract-abis")
+	if dir == "" {
+		return errors.New("no directory specified")
+	}
+
+	imported := 0
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+
+		address := strings.TrimSuffix(filepath.Base(path), ".json")
+		if !utils.IsEth1Address(address) {
+			address = filepath.Base(filepath.Dir(path))
+			if !utils.IsEth1Address(address) {
+				logrus.Warnf("skipping %v, could not determine the contract address", path)
+				return nil
+			}
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		metadata, err := utils.ParseContractMetadata(data)
+		if err != nil {
+			logrus.Warnf("skipping %v: %v", path, err)
+			return nil
+		}
+
+		logrus.WithFields(logrus.Fields{"address": address, "name": metadata.Name, "file": path}).Infof("importing contract abi")
+		if opts.DryRun {
+			return nil
+		}
+		err = db.BigtableClient.SaveVerifiedContractMetadata(common.FromHex(address), metadata)
+		if err != nil {
+			return fmt.Errorf("error saving contract abi for address %v: %w", address, err)
+		}
+		imported++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	logrus.Infof("imported %v contract abis", imported)
+	return nil
+}
+
 func disableUserPerEmail() error {
 	if opts.Email == "" {
 		return errors.New("no email specified")
@@ -1512,51 +1603,19 @@ func indexOldEth1Blocks(startBlock uint64, endBlock uint64, batchSize uint64, co
 		return
 	}
 
-	transforms := make([]func(blk *types.Eth1Block, cache *freecache.Cache) (*types.BulkMutations, *types.BulkMutations, error), 0)
-
 	logrus.Infof("transformerFlag: %v", transformerFlag)
-	transformerList := strings.Split(transformerFlag, ",")
-	if transformerFlag == "all" {
-		transformerList = []string{"TransformBlock", "TransformTx", "TransformBlobTx", "TransformItx", "TransformERC20", "TransformERC721", "TransformERC1155", "TransformWithdrawals", "TransformUncle", "TransformEnsNameRegistered", "TransformContract"}
-	} else if len(transformerList) == 0 {
+	if transformerFlag == "" {
 		utils.LogError(nil, "no transformer functions provided", 0)
 		return
 	}
-	logrus.Infof("transformers: %v", transformerList)
-	importENSChanges := false
-	/**
-	* Add additional transformers you want to sync to this switch case
-	**/
-	for _, t := range transformerList {
-		switch t {
-		case "TransformBlock":
-			transforms = append(transforms, bt.TransformBlock)
-		case "TransformTx":
-			transforms = append(transforms, bt.TransformTx)
-		case "TransformBlobTx":
-			transforms = append(transforms, bt.TransformBlobTx)
-		case "TransformItx":
-			transforms = append(transforms, bt.TransformItx)
-		case "TransformERC20":
-			transforms = append(transforms, bt.TransformERC20)
-		case "TransformERC721":
-			transforms = append(transforms, bt.TransformERC721)
-		case "TransformERC1155":
-			transforms = append(transforms, bt.TransformERC1155)
-		case "TransformWithdrawals":
-			transforms = append(transforms, bt.TransformWithdrawals)
-		case "TransformUncle":
-			transforms = append(transforms, bt.TransformUncle)
-		case "TransformEnsNameRegistered":
-			transforms = append(transforms, bt.TransformEnsNameRegistered)
-			importENSChanges = true
-		case "TransformContract":
-			transforms = append(transforms, bt.TransformContract)
-		default:
-			utils.LogError(nil, "Invalid transformer flag %v", 0)
-			return
-		}
+	transformerList := strings.Split(transformerFlag, ",")
+	transforms, err := bt.GetEth1Transforms(transformerList)
+	if err != nil {
+		utils.LogError(err, "invalid transformer flag", 0)
+		return
 	}
+	logrus.Infof("transformers: %v", transformerList)
+	importENSChanges := transformerFlag == "all" || utils.ElementExists(transformerList, "TransformEnsNameRegistered")
 
 	cache := freecache.NewCache(100 * 1024 * 1024) // 100 MB limit
 
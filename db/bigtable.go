@@ -19,7 +19,6 @@ import (
 	itypes "github.com/gobitfly/eth-rewards/types"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
-	"google.golang.org/api/option"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -30,6 +29,7 @@ const (
 	VALIDATOR_BALANCES_FAMILY             = "vb"
 	VALIDATOR_HIGHEST_ACTIVE_INDEX_FAMILY = "ha"
 	ATTESTATIONS_FAMILY                   = "at"
+	ATTESTATION_FLAGS_FAMILY              = "af"
 	PROPOSALS_FAMILY                      = "pr"
 	SYNC_COMMITTEES_FAMILY                = "sc"
 	INCOME_DETAILS_COLUMN_FAMILY          = "id"
@@ -53,18 +53,18 @@ const (
 )
 
 type Bigtable struct {
-	client *gcp_bigtable.Client
+	backend BigtableBackend
 
-	tableBeaconchain       *gcp_bigtable.Table
-	tableValidators        *gcp_bigtable.Table
-	tableValidatorsHistory *gcp_bigtable.Table
+	tableBeaconchain       BigtableTable
+	tableValidators        BigtableTable
+	tableValidatorsHistory BigtableTable
 
-	tableData            *gcp_bigtable.Table
-	tableBlocks          *gcp_bigtable.Table
-	tableMetadataUpdates *gcp_bigtable.Table
-	tableMetadata        *gcp_bigtable.Table
+	tableData            BigtableTable
+	tableBlocks          BigtableTable
+	tableMetadataUpdates BigtableTable
+	tableMetadata        BigtableTable
 
-	tableMachineMetrics *gcp_bigtable.Table
+	tableMachineMetrics BigtableTable
 
 	redisCache *redis.Client
 
@@ -95,12 +95,26 @@ func InitBigtable(project, instance, chainId, redisAddress string) (*Bigtable, e
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
 	defer cancel()
 
-	poolSize := 50
-	btClient, err := gcp_bigtable.NewClient(ctx, project, instance, option.WithGRPCConnectionPool(poolSize))
-	// btClient, err := gcp_bigtable.NewClient(context.Background(), project, instance)
-
-	if err != nil {
-		return nil, err
+	var backend BigtableBackend
+	if utils.Config.Bigtable.Embedded {
+		if utils.Config.Bigtable.EmbeddedPath == "" {
+			utils.Config.Bigtable.EmbeddedPath = "bigtable"
+		}
+		logger.Infof("using embedded bigtable stored at %v", utils.Config.Bigtable.EmbeddedPath)
+		store, err := openEmbeddedBigtable(utils.Config.Bigtable.EmbeddedPath)
+		if err != nil {
+			return nil, err
+		}
+		backend, err = store.newBackend(ctx, project, instance)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		backend, err = newCloudBigtableBackend(ctx, project, instance)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	rdc := redis.NewClient(&redis.Options{
@@ -113,15 +127,15 @@ func InitBigtable(project, instance, chainId, redisAddress string) (*Bigtable, e
 	}
 
 	bt := &Bigtable{
-		client:                         btClient,
-		tableData:                      btClient.Open("data"),
-		tableBlocks:                    btClient.Open("blocks"),
-		tableMetadataUpdates:           btClient.Open("metadata_updates"),
-		tableMetadata:                  btClient.Open("metadata"),
-		tableBeaconchain:               btClient.Open("beaconchain"),
-		tableMachineMetrics:            btClient.Open("machine_metrics"),
-		tableValidators:                btClient.Open("beaconchain_validators"),
-		tableValidatorsHistory:         btClient.Open("beaconchain_validators_history"),
+		backend:                        backend,
+		tableData:                      backend.Open("data"),
+		tableBlocks:                    backend.Open("blocks"),
+		tableMetadataUpdates:           backend.Open("metadata_updates"),
+		tableMetadata:                  backend.Open("metadata"),
+		tableBeaconchain:               backend.Open("beaconchain"),
+		tableMachineMetrics:            backend.Open("machine_metrics"),
+		tableValidators:                backend.Open("beaconchain_validators"),
+		tableValidatorsHistory:         backend.Open("beaconchain_validators_history"),
 		chainId:                        chainId,
 		redisCache:                     rdc,
 		LastAttestationCacheMux:        &sync.Mutex{},
@@ -191,11 +205,11 @@ func (bigtable *Bigtable) commitQueuedMachineMetricWrites() {
 func (bigtable *Bigtable) Close() {
 	close(bigtable.machineMetricsQueuedWritesChan)
 	time.Sleep(time.Second * 5)
-	bigtable.client.Close()
+	bigtable.backend.Close()
 }
 
-func (bigtable *Bigtable) GetClient() *gcp_bigtable.Client {
-	return bigtable.client
+func (bigtable *Bigtable) GetBackend() BigtableBackend {
+	return bigtable.backend
 }
 
 func (bigtable *Bigtable) SaveMachineMetric(process string, userID uint64, machine string, data []byte) error {
@@ -509,6 +523,73 @@ func (bigtable Bigtable) GetMachineMetricsForNotifications(rowKeys gcp_bigtable.
 	return res, nil
 }
 
+// GetMachineFallbackInUseForNotifications returns a map[userID]map[machineName]bool of the machines whose latest metrics are not older than maxAge.
+// The value is true if the beaconnode is connected to its eth1 fallback respectively the validator client to its eth2 fallback
+func (bigtable Bigtable) GetMachineFallbackInUseForNotifications(rowKeys gcp_bigtable.RowList, maxAge time.Duration) (map[uint64]map[string]bool, error) {
+
+	tmr := time.AfterFunc(REPORT_TIMEOUT, func() {
+		logger.WithFields(logrus.Fields{
+			"rowKeys": rowKeys,
+		}).Warnf("%s call took longer than %v", utils.GetCurrentFuncName(), REPORT_TIMEOUT)
+	})
+	defer tmr.Stop()
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second*200))
+	defer cancel()
+
+	res := make(map[uint64]map[string]bool) // userID -> machine -> fallback in use
+
+	filter := gcp_bigtable.ChainFilters(
+		gcp_bigtable.FamilyFilter(MACHINE_METRICS_COLUMN_FAMILY),
+		gcp_bigtable.LatestNFilter(1),
+	)
+
+	var unmarshalErr error
+	err := bigtable.tableMachineMetrics.ReadRows(ctx, rowKeys, func(r gcp_bigtable.Row) bool {
+		success, userID, machine, process := machineMetricRowParts(r.Key())
+		if !success {
+			return false
+		}
+
+		for _, ri := range r[MACHINE_METRICS_COLUMN_FAMILY] {
+			if ri.Timestamp.Time().Before(time.Now().Add(-maxAge)) {
+				continue
+			}
+
+			fallbackInUse := false
+			switch process {
+			case "beaconnode":
+				obj := &types.MachineMetricNode{}
+				unmarshalErr = proto.Unmarshal(ri.Value, obj)
+				fallbackInUse = obj.SyncEth1FallbackConnected
+			case "validator":
+				obj := &types.MachineMetricValidator{}
+				unmarshalErr = proto.Unmarshal(ri.Value, obj)
+				fallbackInUse = obj.SyncEth2FallbackConnected
+			default:
+				unmarshalErr = fmt.Errorf("unsupported process %v of machine metrics row %v", process, r.Key())
+			}
+			if unmarshalErr != nil {
+				return false
+			}
+
+			if _, found := res[userID]; !found {
+				res[userID] = make(map[string]bool)
+			}
+			res[userID][machine] = fallbackInUse
+		}
+		return true
+	}, gcp_bigtable.RowFilter(filter))
+	if err != nil {
+		return nil, err
+	}
+	if unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+
+	return res, nil
+}
+
 func machineMetricRowParts(r string) (bool, uint64, string, string) {
 	keySplit := strings.Split(r, ":")
 
@@ -691,6 +772,33 @@ func (bigtable *Bigtable) SaveAttestationDuties(duties map[types.Slot]map[types.
 	return nil
 }
 
+// SaveAttestationFlags stores the vote flags of the attestations included at the inclusion slot. The flags are written into the rows
+// of the attestation duties, the column is the attested slot and the cell timestamp encodes the inclusion slot like for the duties.
+func (bigtable *Bigtable) SaveAttestationFlags(inclusionSlot uint64, flags map[types.Slot]map[types.ValidatorIndex]types.AttestationFlags) error {
+	start := time.Now()
+
+	muts := types.NewBulkMutations(MAX_BATCH_MUTATIONS)
+	for attestedSlot, validators := range flags {
+		epoch := utils.EpochOfSlot(uint64(attestedSlot))
+		for validator, f := range validators {
+			key := fmt.Sprintf("%s:%s:%s:%s", bigtable.chainId, bigtable.validatorIndexToKey(uint64(validator)), ATTESTATIONS_FAMILY, bigtable.reversedPaddedEpoch(epoch))
+
+			mut := gcp_bigtable.NewMutation()
+			mut.Set(ATTESTATION_FLAGS_FAMILY, fmt.Sprintf("%d", attestedSlot), gcp_bigtable.Timestamp((MAX_CL_BLOCK_NUMBER-inclusionSlot)*1000), []byte{byte(f)})
+
+			muts.Add(key, mut)
+		}
+	}
+
+	err := bigtable.WriteBulk(muts, bigtable.tableValidatorsHistory, MAX_BATCH_MUTATIONS)
+	if err != nil {
+		return fmt.Errorf("error writing attestation flag mutations: %v", err)
+	}
+
+	logger.Infof("exported %v attestation flags to bigtable in %v", muts.Len(), time.Since(start))
+	return nil
+}
+
 // This method is only to be used for migrating the last attestation slot to bigtable and should not be used for any other purpose
 func (bigtable *Bigtable) SetLastAttestationSlot(validator uint64, lastAttestationSlot uint64) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
@@ -763,6 +871,53 @@ func (bigtable *Bigtable) SaveSyncComitteeDuties(duties map[types.Slot]map[types
 	return nil
 }
 
+// RevertBlock removes the proposal, attestation inclusions and sync committee participations written for a block that has been orphaned by a reorg
+func (bigtable *Bigtable) RevertBlock(block *types.Block) error {
+	start := time.Now()
+
+	if len(block.BlockRoot) != 32 { // skip dummy blocks
+		return nil
+	}
+
+	inclusionTs := gcp_bigtable.Timestamp((MAX_CL_BLOCK_NUMBER - block.Slot) * 1000)
+	epochKey := bigtable.reversedPaddedEpoch(utils.EpochOfSlot(block.Slot))
+
+	muts := types.NewBulkMutations(len(block.AttestationDuties) + len(block.SyncDuties) + 1)
+
+	// the proposal assignment is kept, removing the proposal marks the slot as missed for the proposer
+	mut := gcp_bigtable.NewMutation()
+	mut.DeleteCellsInColumn(PROPOSALS_FAMILY, "b")
+	muts.Add(fmt.Sprintf("%s:%s:%s:%s:%s", bigtable.chainId, bigtable.validatorIndexToKey(block.Proposer), PROPOSALS_FAMILY, epochKey, bigtable.reversedPaddedSlot(block.Slot)), mut)
+
+	// only the inclusion cells written by the orphaned block are removed, inclusions by other blocks and the missed marker are kept
+	for validator, attestedSlots := range block.AttestationDuties {
+		for _, attestedSlot := range attestedSlots {
+			mut := gcp_bigtable.NewMutation()
+			mut.DeleteTimestampRange(ATTESTATIONS_FAMILY, fmt.Sprintf("%d", attestedSlot), inclusionTs, inclusionTs+1000)
+			key := fmt.Sprintf("%s:%s:%s:%s", bigtable.chainId, bigtable.validatorIndexToKey(uint64(validator)), ATTESTATIONS_FAMILY, bigtable.reversedPaddedEpoch(utils.EpochOfSlot(uint64(attestedSlot))))
+			muts.Add(key, mut)
+		}
+	}
+
+	for validator, participated := range block.SyncDuties {
+		if !participated {
+			continue
+		}
+		mut := gcp_bigtable.NewMutation()
+		mut.DeleteTimestampRange(SYNC_COMMITTEES_FAMILY, "s", inclusionTs, inclusionTs+1000)
+		key := fmt.Sprintf("%s:%s:%s:%s:%s", bigtable.chainId, bigtable.validatorIndexToKey(uint64(validator)), SYNC_COMMITTEES_FAMILY, epochKey, bigtable.reversedPaddedSlot(block.Slot))
+		muts.Add(key, mut)
+	}
+
+	err := bigtable.WriteBulk(muts, bigtable.tableValidatorsHistory, MAX_BATCH_MUTATIONS)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("reverted block 0x%x at slot %v in bigtable in %v", block.BlockRoot, block.Slot, time.Since(start))
+	return nil
+}
+
 // GetMaxValidatorindexForEpoch returns the higest validatorindex with a balance at that epoch
 func (bigtable *Bigtable) GetMaxValidatorindexForEpoch(epoch uint64) (uint64, error) {
 	return bigtable.getMaxValidatorindexForEpochV2(epoch)
@@ -1032,6 +1187,7 @@ func (bigtable *Bigtable) getValidatorAttestationHistoryV2(validators []uint64,
 	g.SetLimit(concurrency)
 
 	attestationsMap := make(map[types.ValidatorIndex]map[types.Slot][]*types.ValidatorAttestation)
+	flagsMap := make(map[types.ValidatorIndex]map[types.Slot]map[uint64]types.AttestationFlags)
 
 	for i := 0; i < len(validators); i += batchSize {
 
@@ -1089,6 +1245,29 @@ func (bigtable *Bigtable) getValidatorAttestationHistoryV2(validators []uint64,
 					resMux.Unlock()
 
 				}
+
+				for _, ri := range r[ATTESTATION_FLAGS_FAMILY] {
+					if len(ri.Value) == 0 {
+						continue
+					}
+					attesterSlotString := strings.Replace(ri.Column, ATTESTATION_FLAGS_FAMILY+":", "", 1)
+					attesterSlot, err := strconv.ParseUint(attesterSlotString, 10, 64)
+					if err != nil {
+						logger.Errorf("error parsing slot from row key %v: %v", r.Key(), err)
+						return false
+					}
+					inclusionSlot := MAX_CL_BLOCK_NUMBER - uint64(ri.Timestamp)/1000
+
+					resMux.Lock()
+					if flagsMap[types.ValidatorIndex(validator)] == nil {
+						flagsMap[types.ValidatorIndex(validator)] = make(map[types.Slot]map[uint64]types.AttestationFlags)
+					}
+					if flagsMap[types.ValidatorIndex(validator)][types.Slot(attesterSlot)] == nil {
+						flagsMap[types.ValidatorIndex(validator)][types.Slot(attesterSlot)] = make(map[uint64]types.AttestationFlags)
+					}
+					flagsMap[types.ValidatorIndex(validator)][types.Slot(attesterSlot)][inclusionSlot] |= types.AttestationFlags(ri.Value[0])
+					resMux.Unlock()
+				}
 				return true
 			}, filter)
 
@@ -1135,6 +1314,15 @@ func (bigtable *Bigtable) getValidatorAttestationHistoryV2(validators []uint64,
 			res[uint64(validator)] = make([]*types.ValidatorAttestation, 0)
 		}
 		for attesterSlot, att := range attestations {
+			// a later inclusion of the same vote can not earn more rewards, so combining the flags of all canonical inclusions
+			// yields the flags of the earliest one
+			flags := types.AttestationFlags(0)
+			for _, attInfo := range att {
+				if attInfo.Status == 1 && !orphanedSlotsMap[attInfo.InclusionSlot] {
+					flags |= flagsMap[validator][attesterSlot][attInfo.InclusionSlot]
+				}
+			}
+
 			currentAttInfo := att[0]
 			for _, attInfo := range att {
 				if orphanedSlotsMap[attInfo.InclusionSlot] {
@@ -1158,6 +1346,9 @@ func (bigtable *Bigtable) getValidatorAttestationHistoryV2(validators []uint64,
 			currentAttInfo.CommitteeIndex = 0
 			currentAttInfo.AttesterSlot = uint64(attesterSlot)
 			currentAttInfo.Delay = int64(currentAttInfo.InclusionSlot - uint64(attesterSlot) - missedSlotsCount - 1)
+			if currentAttInfo.Status == 1 {
+				currentAttInfo.Flags = flags
+			}
 
 			res[uint64(validator)] = append(res[uint64(validator)], currentAttInfo)
 		}
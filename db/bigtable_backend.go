@@ -0,0 +1,51 @@
+package db
+
+import (
+	"context"
+
+	gcp_bigtable "cloud.google.com/go/bigtable"
+	"google.golang.org/api/option"
+)
+
+// BigtableTable is the row and mutation layer of a single table. All reads and writes of Bigtable, e.g. SaveBlock, TransformTx,
+// GetValidatorBalanceHistory or the machine metrics, go through it. The row keys, row sets, filters and mutations are the ones of cloud bigtable,
+// so a backend has to support the same prefix scans on the reversed padded row keys.
+type BigtableTable interface {
+	ReadRow(ctx context.Context, row string, opts ...gcp_bigtable.ReadOption) (gcp_bigtable.Row, error)
+	ReadRows(ctx context.Context, arg gcp_bigtable.RowSet, f func(gcp_bigtable.Row) bool, opts ...gcp_bigtable.ReadOption) error
+	Apply(ctx context.Context, row string, m *gcp_bigtable.Mutation, opts ...gcp_bigtable.ApplyOption) error
+	ApplyBulk(ctx context.Context, rowKeys []string, muts []*gcp_bigtable.Mutation, opts ...gcp_bigtable.ApplyOption) ([]error, error)
+	ApplyReadModifyWrite(ctx context.Context, row string, m *gcp_bigtable.ReadModifyWrite) (gcp_bigtable.Row, error)
+}
+
+// BigtableBackend provides the tables of a bigtable instance, the available backends are cloud bigtable (or its emulator)
+// and the embedded pebble store
+type BigtableBackend interface {
+	Open(table string) BigtableTable
+	Close() error
+}
+
+var _ BigtableTable = (*gcp_bigtable.Table)(nil)
+
+// clientBigtableBackend serves the tables of a bigtable client
+type clientBigtableBackend struct {
+	client *gcp_bigtable.Client
+}
+
+// newCloudBigtableBackend connects to the cloud bigtable instance, the emulator is used if BIGTABLE_EMULATOR_HOST is set
+func newCloudBigtableBackend(ctx context.Context, project, instance string) (BigtableBackend, error) {
+	poolSize := 50
+	client, err := gcp_bigtable.NewClient(ctx, project, instance, option.WithGRPCConnectionPool(poolSize))
+	if err != nil {
+		return nil, err
+	}
+	return &clientBigtableBackend{client: client}, nil
+}
+
+func (b *clientBigtableBackend) Open(table string) BigtableTable {
+	return b.client.Open(table)
+}
+
+func (b *clientBigtableBackend) Close() error {
+	return b.client.Close()
+}
@@ -13,7 +13,7 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-func (bigtable *Bigtable) WriteBulk(mutations *types.BulkMutations, table *gcp_bigtable.Table, batchSize int) error {
+func (bigtable *Bigtable) WriteBulk(mutations *types.BulkMutations, table BigtableTable, batchSize int) error {
 
 	callingFunctionName := utils.GetParentFuncName()
 
@@ -82,7 +82,7 @@ func (bigtable *Bigtable) ClearByPrefix(table string, family, columns, prefix st
 
 	rowRange := gcp_bigtable.PrefixRange(prefix)
 
-	var btTable *gcp_bigtable.Table
+	var btTable BigtableTable
 
 	switch table {
 	case "data":
@@ -0,0 +1,963 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"net"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	gcp_bigtable "cloud.google.com/go/bigtable"
+	"github.com/cockroachdb/pebble"
+	"google.golang.org/api/option"
+	btpb "google.golang.org/genproto/googleapis/bigtable/v2"
+	statpb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// embeddedBigtable is the BigtableBackend that stores the tables in a local pebble store. The row sets, filters and mutations of
+// BigtableTable are opaque types of the bigtable client that can only be converted to their protobuf form by the client itself,
+// so the store implements the bigtable data api and the tables are served by a client connected in-process. Self-hosted setups
+// therefore run the same queries as cloud bigtable. Unlike the emulator shipped with the bigtable client (bttest), which keeps
+// all data in memory, the pebble store survives restarts.
+//
+// Only ReadRows, MutateRow, MutateRows, ReadModifyWriteRow and PingAndWarm are implemented, unsupported filters fail
+// with codes.Unimplemented instead of being ignored. The mutations of a request are committed in a single pebble batch, code
+// must still not rely on more than the single row atomicity of cloud bigtable. Cells exceeding the max age gc policy of their
+// family in the schema are removed by a daily gc run.
+//
+// Every cell is stored as a single pebble entry, the key is made of the escaped table, row, family and qualifier
+// followed by the inverted timestamp, which keeps rows in the lexicographic order of their keys and the cells of a
+// column ordered from newest to oldest.
+type embeddedBigtable struct {
+	btpb.UnimplementedBigtableServer
+
+	db       *pebble.DB
+	writeMux sync.Mutex
+
+	server   *grpc.Server
+	listener *bufconn.Listener
+}
+
+const (
+	embeddedBigtableBufferSize     = 1024 * 1024 * 32
+	embeddedBigtableMaxMessageSize = 1 << 28
+	embeddedBigtableGCInterval     = time.Hour * 24
+)
+
+var embeddedBigtables = make(map[string]*embeddedBigtable)
+var embeddedBigtablesMux = &sync.Mutex{}
+
+// openEmbeddedBigtable opens the store located at path, stores are shared by all clients of the process
+func openEmbeddedBigtable(path string) (*embeddedBigtable, error) {
+	embeddedBigtablesMux.Lock()
+	defer embeddedBigtablesMux.Unlock()
+
+	if s, ok := embeddedBigtables[path]; ok {
+		return s, nil
+	}
+
+	pdb, err := pebble.Open(path, &pebble.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("error opening embedded bigtable store at %v: %w", path, err)
+	}
+
+	s := newEmbeddedBigtable(pdb)
+	go s.runGC()
+
+	embeddedBigtables[path] = s
+	return s, nil
+}
+
+func newEmbeddedBigtable(pdb *pebble.DB) *embeddedBigtable {
+	s := &embeddedBigtable{
+		db:       pdb,
+		server:   grpc.NewServer(grpc.MaxRecvMsgSize(embeddedBigtableMaxMessageSize), grpc.MaxSendMsgSize(embeddedBigtableMaxMessageSize)),
+		listener: bufconn.Listen(embeddedBigtableBufferSize),
+	}
+	btpb.RegisterBigtableServer(s.server, s)
+
+	go func() {
+		err := s.server.Serve(s.listener)
+		if err != nil {
+			logger.Errorf("embedded bigtable server stopped: %v", err)
+		}
+	}()
+	return s
+}
+
+// newBackend returns a backend serving the tables of the embedded store
+func (s *embeddedBigtable) newBackend(ctx context.Context, project, instance string) (BigtableBackend, error) {
+	conn, err := grpc.DialContext(ctx, "embedded",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return s.listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(embeddedBigtableMaxMessageSize), grpc.MaxCallSendMsgSize(embeddedBigtableMaxMessageSize)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to embedded bigtable: %w", err)
+	}
+
+	client, err := gcp_bigtable.NewClient(ctx, project, instance, option.WithGRPCConn(conn), option.WithoutAuthentication())
+	if err != nil {
+		return nil, err
+	}
+	return &clientBigtableBackend{client: client}, nil
+}
+
+// key layout
+
+// escapeKeyPart escapes all zero bytes of a key part and terminates it, the escaping preserves the sort order of the parts
+func escapeKeyPart(dst, part []byte) []byte {
+	for _, b := range part {
+		if b == 0x00 {
+			dst = append(dst, 0x00, 0xff)
+		} else {
+			dst = append(dst, b)
+		}
+	}
+	return append(dst, 0x00, 0x01)
+}
+
+// unescapeKeyPart returns the unescaped leading part of key and the remainder of the key
+func unescapeKeyPart(key []byte) ([]byte, []byte, error) {
+	part := make([]byte, 0, len(key))
+	for i := 0; i < len(key); i++ {
+		if key[i] != 0x00 {
+			part = append(part, key[i])
+			continue
+		}
+		if i+1 >= len(key) {
+			return nil, nil, fmt.Errorf("invalid key part encoding")
+		}
+		switch key[i+1] {
+		case 0xff:
+			part = append(part, 0x00)
+			i++
+		case 0x01:
+			return part, key[i+2:], nil
+		default:
+			return nil, nil, fmt.Errorf("invalid key part encoding")
+		}
+	}
+	return nil, nil, fmt.Errorf("unterminated key part")
+}
+
+func embeddedTableKey(table string) []byte {
+	return escapeKeyPart(nil, []byte(table))
+}
+
+func embeddedRowKey(table string, row []byte) []byte {
+	return escapeKeyPart(embeddedTableKey(table), row)
+}
+
+func embeddedFamilyKey(table string, row []byte, family string) []byte {
+	return escapeKeyPart(embeddedRowKey(table, row), []byte(family))
+}
+
+func embeddedColumnKey(table string, row []byte, family string, qualifier []byte) []byte {
+	return escapeKeyPart(embeddedFamilyKey(table, row, family), qualifier)
+}
+
+func embeddedCellKey(table string, row []byte, family string, qualifier []byte, ts int64) []byte {
+	key := embeddedColumnKey(table, row, family, qualifier)
+	return binary.BigEndian.AppendUint64(key, uint64(math.MaxInt64-ts))
+}
+
+// keyPartEnd returns the first key that sorts after all keys starting with the given escaped and terminated part
+func keyPartEnd(key []byte) []byte {
+	end := append([]byte{}, key...)
+	end[len(end)-1] = 0x02
+	return end
+}
+
+type embeddedCell struct {
+	ts     int64
+	value  []byte
+	labels []string
+}
+
+type embeddedColumn struct {
+	qualifier []byte
+	cells     []embeddedCell
+}
+
+type embeddedFamily struct {
+	name    string
+	columns []*embeddedColumn
+}
+
+type embeddedRow struct {
+	key      []byte
+	families []*embeddedFamily
+}
+
+func (r *embeddedRow) copy() *embeddedRow {
+	nr := &embeddedRow{key: r.key, families: make([]*embeddedFamily, 0, len(r.families))}
+	for _, fam := range r.families {
+		nf := &embeddedFamily{name: fam.name, columns: make([]*embeddedColumn, 0, len(fam.columns))}
+		for _, col := range fam.columns {
+			nf.columns = append(nf.columns, &embeddedColumn{qualifier: col.qualifier, cells: append([]embeddedCell{}, col.cells...)})
+		}
+		nr.families = append(nr.families, nf)
+	}
+	return nr
+}
+
+func (r *embeddedRow) cellCount() int {
+	count := 0
+	for _, fam := range r.families {
+		for _, col := range fam.columns {
+			count += len(col.cells)
+		}
+	}
+	return count
+}
+
+// add appends a cell, cells have to be added in key order
+func (r *embeddedRow) add(family string, qualifier []byte, cell embeddedCell) {
+	if len(r.families) == 0 || r.families[len(r.families)-1].name != family {
+		r.families = append(r.families, &embeddedFamily{name: family})
+	}
+	fam := r.families[len(r.families)-1]
+	if len(fam.columns) == 0 || !bytes.Equal(fam.columns[len(fam.columns)-1].qualifier, qualifier) {
+		fam.columns = append(fam.columns, &embeddedColumn{qualifier: qualifier})
+	}
+	col := fam.columns[len(fam.columns)-1]
+	col.cells = append(col.cells, cell)
+}
+
+// decodeCellKey splits a cell key of the given table into its row, family, qualifier and timestamp
+func decodeCellKey(tablePrefix, key []byte) ([]byte, string, []byte, int64, error) {
+	if !bytes.HasPrefix(key, tablePrefix) {
+		return nil, "", nil, 0, fmt.Errorf("key %x is not part of the table", key)
+	}
+	row, rest, err := unescapeKeyPart(key[len(tablePrefix):])
+	if err != nil {
+		return nil, "", nil, 0, err
+	}
+	family, rest, err := unescapeKeyPart(rest)
+	if err != nil {
+		return nil, "", nil, 0, err
+	}
+	qualifier, rest, err := unescapeKeyPart(rest)
+	if err != nil {
+		return nil, "", nil, 0, err
+	}
+	if len(rest) != 8 {
+		return nil, "", nil, 0, fmt.Errorf("invalid timestamp length %v", len(rest))
+	}
+	return row, string(family), qualifier, math.MaxInt64 - int64(binary.BigEndian.Uint64(rest)), nil
+}
+
+// embeddedTableName strips the project and instance from a fully qualified table name
+func embeddedTableName(name string) string {
+	for i := len(name) - 1; i >= 0; i-- {
+		if name[i] == '/' {
+			return name[i+1:]
+		}
+	}
+	return name
+}
+
+type embeddedKeyRange struct {
+	start []byte
+	end   []byte
+}
+
+// keyRanges converts the requested row set into sorted and disjoint key ranges
+func keyRanges(table string, rows *btpb.RowSet) []embeddedKeyRange {
+	tablePrefix := embeddedTableKey(table)
+	tableEnd := keyPartEnd(tablePrefix)
+
+	if rows == nil || len(rows.RowKeys)+len(rows.RowRanges) == 0 {
+		return []embeddedKeyRange{{start: tablePrefix, end: tableEnd}}
+	}
+
+	ranges := make([]embeddedKeyRange, 0, len(rows.RowKeys)+len(rows.RowRanges))
+	for _, key := range rows.RowKeys {
+		start := embeddedRowKey(table, key)
+		ranges = append(ranges, embeddedKeyRange{start: start, end: keyPartEnd(start)})
+	}
+	for _, rr := range rows.RowRanges {
+		kr := embeddedKeyRange{start: tablePrefix, end: tableEnd}
+		switch sk := rr.StartKey.(type) {
+		case *btpb.RowRange_StartKeyClosed:
+			if len(sk.StartKeyClosed) > 0 {
+				kr.start = embeddedRowKey(table, sk.StartKeyClosed)
+			}
+		case *btpb.RowRange_StartKeyOpen:
+			kr.start = keyPartEnd(embeddedRowKey(table, sk.StartKeyOpen))
+		}
+		switch ek := rr.EndKey.(type) {
+		case *btpb.RowRange_EndKeyClosed:
+			kr.end = keyPartEnd(embeddedRowKey(table, ek.EndKeyClosed))
+		case *btpb.RowRange_EndKeyOpen:
+			if len(ek.EndKeyOpen) > 0 {
+				kr.end = embeddedRowKey(table, ek.EndKeyOpen)
+			}
+		}
+		if bytes.Compare(kr.start, kr.end) < 0 {
+			ranges = append(ranges, kr)
+		}
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		return bytes.Compare(ranges[i].start, ranges[j].start) < 0
+	})
+
+	merged := make([]embeddedKeyRange, 0, len(ranges))
+	for _, kr := range ranges {
+		if len(merged) > 0 && bytes.Compare(kr.start, merged[len(merged)-1].end) <= 0 {
+			if bytes.Compare(kr.end, merged[len(merged)-1].end) > 0 {
+				merged[len(merged)-1].end = kr.end
+			}
+			continue
+		}
+		merged = append(merged, kr)
+	}
+	return merged
+}
+
+func (s *embeddedBigtable) ReadRows(req *btpb.ReadRowsRequest, stream btpb.Bigtable_ReadRowsServer) error {
+	table := embeddedTableName(req.TableName)
+	tablePrefix := embeddedTableKey(table)
+	expired := expiredCellFilter(table, time.Now())
+
+	limit := req.RowsLimit
+	count := int64(0)
+
+	// emit filters and sends a completely read row, it returns false once the row limit is reached
+	emit := func(r *embeddedRow) (bool, error) {
+		if r == nil || r.cellCount() == 0 {
+			return true, nil
+		}
+		match, err := filterEmbeddedRow(req.Filter, r)
+		if err != nil {
+			return false, err
+		}
+		if !match || r.cellCount() == 0 {
+			return true, nil
+		}
+		err = sendEmbeddedRow(stream, r)
+		if err != nil {
+			return false, err
+		}
+		count++
+		return limit <= 0 || count < limit, nil
+	}
+
+	for _, kr := range keyRanges(table, req.Rows) {
+		iter, err := s.db.NewIter(&pebble.IterOptions{LowerBound: kr.start, UpperBound: kr.end})
+		if err != nil {
+			return status.Errorf(codes.Internal, "error creating iterator: %v", err)
+		}
+
+		var current *embeddedRow
+		cont := true
+		for iter.First(); iter.Valid(); iter.Next() {
+			rowKey, family, qualifier, ts, err := decodeCellKey(tablePrefix, iter.Key())
+			if err != nil {
+				iter.Close()
+				return status.Errorf(codes.Internal, "error decoding key: %v", err)
+			}
+			if expired(family, ts) {
+				continue
+			}
+
+			if current == nil || !bytes.Equal(current.key, rowKey) {
+				if err := stream.Context().Err(); err != nil {
+					iter.Close()
+					return status.FromContextError(err).Err()
+				}
+				cont, err = emit(current)
+				if err != nil || !cont {
+					iter.Close()
+					return err
+				}
+				current = &embeddedRow{key: rowKey}
+			}
+			current.add(family, qualifier, embeddedCell{ts: ts, value: append([]byte{}, iter.Value()...)})
+		}
+		err = iter.Close()
+		if err != nil {
+			return status.Errorf(codes.Internal, "error reading rows: %v", err)
+		}
+
+		cont, err = emit(current)
+		if err != nil || !cont {
+			return err
+		}
+	}
+	return nil
+}
+
+func sendEmbeddedRow(stream btpb.Bigtable_ReadRowsServer, r *embeddedRow) error {
+	res := &btpb.ReadRowsResponse{}
+	for _, fam := range r.families {
+		for _, col := range fam.columns {
+			for _, cell := range col.cells {
+				res.Chunks = append(res.Chunks, &btpb.ReadRowsResponse_CellChunk{
+					RowKey:          r.key,
+					FamilyName:      &wrapperspb.StringValue{Value: fam.name},
+					Qualifier:       &wrapperspb.BytesValue{Value: col.qualifier},
+					TimestampMicros: cell.ts,
+					Value:           cell.value,
+					Labels:          cell.labels,
+				})
+			}
+		}
+	}
+	res.Chunks[len(res.Chunks)-1].RowStatus = &btpb.ReadRowsResponse_CellChunk_CommitRow{CommitRow: true}
+	return stream.Send(res)
+}
+
+// expiredCellFilter returns a func that reports whether a cell has exceeded the max age of its family
+func expiredCellFilter(table string, now time.Time) func(family string, ts int64) bool {
+	maxAges := make(map[string]int64)
+	for family, gc := range bigtableSchema[table] {
+		if gc.maxAge > 0 {
+			maxAges[family] = now.Add(-gc.maxAge).UnixMicro()
+		}
+	}
+	return func(family string, ts int64) bool {
+		cutoff, ok := maxAges[family]
+		return ok && ts < cutoff
+	}
+}
+
+// filterEmbeddedRow applies the filter to the row in place and reports whether any cell of the row matched
+func filterEmbeddedRow(f *btpb.RowFilter, r *embeddedRow) (bool, error) {
+	if f == nil {
+		return true, nil
+	}
+
+	switch f := f.Filter.(type) {
+	case *btpb.RowFilter_PassAllFilter:
+		return true, nil
+	case *btpb.RowFilter_BlockAllFilter:
+		r.families = nil
+		return false, nil
+	case *btpb.RowFilter_Chain_:
+		for _, sub := range f.Chain.Filters {
+			match, err := filterEmbeddedRow(sub, r)
+			if err != nil {
+				return false, err
+			}
+			if !match {
+				r.families = nil
+				return false, nil
+			}
+		}
+		return r.cellCount() > 0, nil
+	case *btpb.RowFilter_Interleave_:
+		results := make([]*embeddedRow, 0, len(f.Interleave.Filters))
+		for _, sub := range f.Interleave.Filters {
+			sr := r.copy()
+			match, err := filterEmbeddedRow(sub, sr)
+			if err != nil {
+				return false, err
+			}
+			if match {
+				results = append(results, sr)
+			}
+		}
+		r.families = mergeEmbeddedRows(results)
+		return r.cellCount() > 0, nil
+	case *btpb.RowFilter_Condition_:
+		match, err := filterEmbeddedRow(f.Condition.PredicateFilter, r.copy())
+		if err != nil {
+			return false, err
+		}
+		next := f.Condition.FalseFilter
+		if match {
+			next = f.Condition.TrueFilter
+		}
+		if next == nil {
+			r.families = nil
+			return false, nil
+		}
+		return filterEmbeddedRow(next, r)
+	case *btpb.RowFilter_RowKeyRegexFilter:
+		rx, err := embeddedRegexp(f.RowKeyRegexFilter)
+		if err != nil {
+			return false, status.Errorf(codes.InvalidArgument, "invalid row key regex: %v", err)
+		}
+		if !rx.Match(r.key) {
+			r.families = nil
+			return false, nil
+		}
+		return true, nil
+	case *btpb.RowFilter_CellsPerColumnLimitFilter:
+		limit := int(f.CellsPerColumnLimitFilter)
+		for _, fam := range r.families {
+			for _, col := range fam.columns {
+				if len(col.cells) > limit {
+					col.cells = col.cells[:limit]
+				}
+			}
+		}
+		return r.cellCount() > 0, nil
+	case *btpb.RowFilter_CellsPerRowLimitFilter:
+		limit := int(f.CellsPerRowLimitFilter)
+		for _, fam := range r.families {
+			for _, col := range fam.columns {
+				if len(col.cells) > limit {
+					col.cells = col.cells[:limit]
+				}
+				limit -= len(col.cells)
+			}
+		}
+		return r.cellCount() > 0, nil
+	case *btpb.RowFilter_CellsPerRowOffsetFilter:
+		offset := int(f.CellsPerRowOffsetFilter)
+		for _, fam := range r.families {
+			for _, col := range fam.columns {
+				if offset >= len(col.cells) {
+					offset -= len(col.cells)
+					col.cells = nil
+					continue
+				}
+				col.cells = col.cells[offset:]
+				offset = 0
+			}
+		}
+		return r.cellCount() > 0, nil
+	case *btpb.RowFilter_RowSampleFilter, *btpb.RowFilter_Sink:
+		return false, status.Errorf(codes.Unimplemented, "filter %T is not supported by the embedded bigtable", f)
+	}
+
+	// all remaining filters operate on single cells
+	for _, fam := range r.families {
+		for _, col := range fam.columns {
+			filtered := col.cells[:0]
+			for _, cell := range col.cells {
+				include, err := includeEmbeddedCell(f, fam.name, col.qualifier, cell)
+				if err != nil {
+					return false, err
+				}
+				if !include {
+					continue
+				}
+				switch t := f.Filter.(type) {
+				case *btpb.RowFilter_StripValueTransformer:
+					cell = embeddedCell{ts: cell.ts, labels: cell.labels}
+				case *btpb.RowFilter_ApplyLabelTransformer:
+					cell = embeddedCell{ts: cell.ts, value: cell.value, labels: []string{t.ApplyLabelTransformer}}
+				}
+				filtered = append(filtered, cell)
+			}
+			col.cells = filtered
+		}
+	}
+	return r.cellCount() > 0, nil
+}
+
+func includeEmbeddedCell(f *btpb.RowFilter, family string, qualifier []byte, cell embeddedCell) (bool, error) {
+	switch f := f.Filter.(type) {
+	case *btpb.RowFilter_FamilyNameRegexFilter:
+		rx, err := embeddedRegexp([]byte(f.FamilyNameRegexFilter))
+		if err != nil {
+			return false, status.Errorf(codes.InvalidArgument, "invalid family name regex: %v", err)
+		}
+		return rx.MatchString(family), nil
+	case *btpb.RowFilter_ColumnQualifierRegexFilter:
+		rx, err := embeddedRegexp(f.ColumnQualifierRegexFilter)
+		if err != nil {
+			return false, status.Errorf(codes.InvalidArgument, "invalid column qualifier regex: %v", err)
+		}
+		return rx.Match(qualifier), nil
+	case *btpb.RowFilter_ValueRegexFilter:
+		rx, err := embeddedRegexp(f.ValueRegexFilter)
+		if err != nil {
+			return false, status.Errorf(codes.InvalidArgument, "invalid value regex: %v", err)
+		}
+		return rx.Match(cell.value), nil
+	case *btpb.RowFilter_ColumnRangeFilter:
+		if family != f.ColumnRangeFilter.FamilyName {
+			return false, nil
+		}
+		switch sq := f.ColumnRangeFilter.StartQualifier.(type) {
+		case *btpb.ColumnRange_StartQualifierClosed:
+			if bytes.Compare(qualifier, sq.StartQualifierClosed) < 0 {
+				return false, nil
+			}
+		case *btpb.ColumnRange_StartQualifierOpen:
+			if bytes.Compare(qualifier, sq.StartQualifierOpen) <= 0 {
+				return false, nil
+			}
+		}
+		switch eq := f.ColumnRangeFilter.EndQualifier.(type) {
+		case *btpb.ColumnRange_EndQualifierClosed:
+			if bytes.Compare(qualifier, eq.EndQualifierClosed) > 0 {
+				return false, nil
+			}
+		case *btpb.ColumnRange_EndQualifierOpen:
+			if bytes.Compare(qualifier, eq.EndQualifierOpen) >= 0 {
+				return false, nil
+			}
+		}
+		return true, nil
+	case *btpb.RowFilter_TimestampRangeFilter:
+		return cell.ts >= f.TimestampRangeFilter.StartTimestampMicros &&
+			(f.TimestampRangeFilter.EndTimestampMicros == 0 || cell.ts < f.TimestampRangeFilter.EndTimestampMicros), nil
+	case *btpb.RowFilter_ValueRangeFilter:
+		switch sv := f.ValueRangeFilter.StartValue.(type) {
+		case *btpb.ValueRange_StartValueClosed:
+			if bytes.Compare(cell.value, sv.StartValueClosed) < 0 {
+				return false, nil
+			}
+		case *btpb.ValueRange_StartValueOpen:
+			if bytes.Compare(cell.value, sv.StartValueOpen) <= 0 {
+				return false, nil
+			}
+		}
+		switch ev := f.ValueRangeFilter.EndValue.(type) {
+		case *btpb.ValueRange_EndValueClosed:
+			if bytes.Compare(cell.value, ev.EndValueClosed) > 0 {
+				return false, nil
+			}
+		case *btpb.ValueRange_EndValueOpen:
+			if bytes.Compare(cell.value, ev.EndValueOpen) >= 0 {
+				return false, nil
+			}
+		}
+		return true, nil
+	case *btpb.RowFilter_StripValueTransformer, *btpb.RowFilter_ApplyLabelTransformer:
+		return true, nil
+	default:
+		return false, status.Errorf(codes.Unimplemented, "filter %T is not supported by the embedded bigtable", f)
+	}
+}
+
+// mergeEmbeddedRows merges the results of interleaved filters, keeping families, columns and cells in key order
+func mergeEmbeddedRows(rows []*embeddedRow) []*embeddedFamily {
+	type cellRef struct {
+		family    string
+		qualifier []byte
+		cell      embeddedCell
+	}
+	refs := []cellRef{}
+	for _, r := range rows {
+		for _, fam := range r.families {
+			for _, col := range fam.columns {
+				for _, cell := range col.cells {
+					refs = append(refs, cellRef{family: fam.name, qualifier: col.qualifier, cell: cell})
+				}
+			}
+		}
+	}
+	sort.SliceStable(refs, func(i, j int) bool {
+		if refs[i].family != refs[j].family {
+			return refs[i].family < refs[j].family
+		}
+		if c := bytes.Compare(refs[i].qualifier, refs[j].qualifier); c != 0 {
+			return c < 0
+		}
+		return refs[i].cell.ts > refs[j].cell.ts
+	})
+
+	merged := &embeddedRow{}
+	for _, ref := range refs {
+		merged.add(ref.family, ref.qualifier, ref.cell)
+	}
+	return merged.families
+}
+
+var embeddedRegexps = make(map[string]*regexp.Regexp)
+var embeddedRegexpsMux = &sync.Mutex{}
+
+// embeddedRegexp compiles a filter regex, like bigtable the expression has to match the whole input
+func embeddedRegexp(pattern []byte) (*regexp.Regexp, error) {
+	embeddedRegexpsMux.Lock()
+	defer embeddedRegexpsMux.Unlock()
+
+	if rx, ok := embeddedRegexps[string(pattern)]; ok {
+		return rx, nil
+	}
+	rx, err := regexp.Compile("^(?s:" + string(pattern) + ")$")
+	if err != nil {
+		return nil, err
+	}
+	embeddedRegexps[string(pattern)] = rx
+	return rx, nil
+}
+
+func (s *embeddedBigtable) MutateRow(ctx context.Context, req *btpb.MutateRowRequest) (*btpb.MutateRowResponse, error) {
+	s.writeMux.Lock()
+	defer s.writeMux.Unlock()
+
+	batch := s.db.NewIndexedBatch()
+	defer batch.Close()
+
+	err := applyEmbeddedMutations(batch, embeddedTableName(req.TableName), req.RowKey, req.Mutations, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	err = batch.Commit(pebble.Sync)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error committing mutations: %v", err)
+	}
+	return &btpb.MutateRowResponse{}, nil
+}
+
+func (s *embeddedBigtable) MutateRows(req *btpb.MutateRowsRequest, stream btpb.Bigtable_MutateRowsServer) error {
+	s.writeMux.Lock()
+	defer s.writeMux.Unlock()
+
+	batch := s.db.NewIndexedBatch()
+	defer batch.Close()
+
+	table := embeddedTableName(req.TableName)
+	now := time.Now()
+	res := &btpb.MutateRowsResponse{Entries: make([]*btpb.MutateRowsResponse_Entry, 0, len(req.Entries))}
+	for i, entry := range req.Entries {
+		code := codes.OK
+		message := ""
+		err := applyEmbeddedMutations(batch, table, entry.RowKey, entry.Mutations, now)
+		if err != nil {
+			code = status.Code(err)
+			message = err.Error()
+		}
+		res.Entries = append(res.Entries, &btpb.MutateRowsResponse_Entry{
+			Index:  int64(i),
+			Status: &statpb.Status{Code: int32(code), Message: message},
+		})
+	}
+
+	err := batch.Commit(pebble.Sync)
+	if err != nil {
+		return status.Errorf(codes.Internal, "error committing mutations: %v", err)
+	}
+	return stream.Send(res)
+}
+
+func (s *embeddedBigtable) PingAndWarm(ctx context.Context, req *btpb.PingAndWarmRequest) (*btpb.PingAndWarmResponse, error) {
+	return &btpb.PingAndWarmResponse{}, nil
+}
+
+// ReadModifyWriteRow applies the append and increment rules to the latest cells of their columns and returns the new cells
+func (s *embeddedBigtable) ReadModifyWriteRow(ctx context.Context, req *btpb.ReadModifyWriteRowRequest) (*btpb.ReadModifyWriteRowResponse, error) {
+	s.writeMux.Lock()
+	defer s.writeMux.Unlock()
+
+	batch := s.db.NewIndexedBatch()
+	defer batch.Close()
+
+	table := embeddedTableName(req.TableName)
+	ts := time.Now().Truncate(time.Millisecond).UnixMicro()
+	res := &btpb.Row{Key: req.RowKey, Families: make([]*btpb.Family, 0, len(req.Rules))}
+	for _, rule := range req.Rules {
+		column := embeddedColumnKey(table, req.RowKey, rule.FamilyName, rule.ColumnQualifier)
+		iter, err := batch.NewIter(&pebble.IterOptions{LowerBound: column, UpperBound: keyPartEnd(column)})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "error reading column: %v", err)
+		}
+		var value []byte
+		if iter.First() {
+			value = append(value, iter.Value()...)
+		}
+		err = iter.Close()
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "error reading column: %v", err)
+		}
+
+		switch r := rule.Rule.(type) {
+		case *btpb.ReadModifyWriteRule_AppendValue:
+			value = append(value, r.AppendValue...)
+		case *btpb.ReadModifyWriteRule_IncrementAmount:
+			// increments are applied to 64 bit big endian integers, a missing cell counts as zero
+			if len(value) != 0 && len(value) != 8 {
+				return nil, status.Errorf(codes.FailedPrecondition, "value of column %s:%s is not a 64 bit integer", rule.FamilyName, rule.ColumnQualifier)
+			}
+			current := int64(0)
+			if len(value) == 8 {
+				current = int64(binary.BigEndian.Uint64(value))
+			}
+			value = binary.BigEndian.AppendUint64(nil, uint64(current+r.IncrementAmount))
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "unsupported read modify write rule %T", r)
+		}
+
+		err = batch.Set(embeddedCellKey(table, req.RowKey, rule.FamilyName, rule.ColumnQualifier, ts), value, nil)
+		if err == nil {
+			err = trimEmbeddedColumn(batch, table, req.RowKey, rule.FamilyName, rule.ColumnQualifier)
+		}
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "error applying rule: %v", err)
+		}
+
+		res.Families = append(res.Families, &btpb.Family{
+			Name: rule.FamilyName,
+			Columns: []*btpb.Column{{
+				Qualifier: rule.ColumnQualifier,
+				Cells:     []*btpb.Cell{{TimestampMicros: ts, Value: value}},
+			}},
+		})
+	}
+
+	err := batch.Commit(pebble.Sync)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error committing rules: %v", err)
+	}
+	return &btpb.ReadModifyWriteRowResponse{Row: res}, nil
+}
+
+// applyEmbeddedMutations adds the mutations of a row to the batch, the mutations are validated before so that
+// the mutations of a row are either applied completely or not at all
+func applyEmbeddedMutations(batch *pebble.Batch, table string, row []byte, mutations []*btpb.Mutation, now time.Time) error {
+	for _, mut := range mutations {
+		switch m := mut.Mutation.(type) {
+		case *btpb.Mutation_SetCell_, *btpb.Mutation_DeleteFromColumn_, *btpb.Mutation_DeleteFromFamily_, *btpb.Mutation_DeleteFromRow_:
+		default:
+			return status.Errorf(codes.InvalidArgument, "unsupported mutation %T", m)
+		}
+	}
+
+	for _, mut := range mutations {
+		var err error
+		switch m := mut.Mutation.(type) {
+		case *btpb.Mutation_SetCell_:
+			ts := m.SetCell.TimestampMicros
+			if ts == -1 {
+				ts = now.Truncate(time.Millisecond).UnixMicro()
+			}
+			err = batch.Set(embeddedCellKey(table, row, m.SetCell.FamilyName, m.SetCell.ColumnQualifier, ts), m.SetCell.Value, nil)
+			if err == nil {
+				err = trimEmbeddedColumn(batch, table, row, m.SetCell.FamilyName, m.SetCell.ColumnQualifier)
+			}
+		case *btpb.Mutation_DeleteFromColumn_:
+			start := embeddedColumnKey(table, row, m.DeleteFromColumn.FamilyName, m.DeleteFromColumn.ColumnQualifier)
+			end := keyPartEnd(start)
+			if tr := m.DeleteFromColumn.TimeRange; tr != nil {
+				// cells are ordered by their inverted timestamp, so the end of the time range is the start of the key range
+				if tr.EndTimestampMicros > 0 {
+					start = embeddedCellKey(table, row, m.DeleteFromColumn.FamilyName, m.DeleteFromColumn.ColumnQualifier, tr.EndTimestampMicros-1)
+				}
+				if tr.StartTimestampMicros > 0 {
+					end = embeddedCellKey(table, row, m.DeleteFromColumn.FamilyName, m.DeleteFromColumn.ColumnQualifier, tr.StartTimestampMicros-1)
+				}
+			}
+			if bytes.Compare(start, end) < 0 {
+				err = batch.DeleteRange(start, end, nil)
+			}
+		case *btpb.Mutation_DeleteFromFamily_:
+			start := embeddedFamilyKey(table, row, m.DeleteFromFamily.FamilyName)
+			err = batch.DeleteRange(start, keyPartEnd(start), nil)
+		case *btpb.Mutation_DeleteFromRow_:
+			start := embeddedRowKey(table, row)
+			err = batch.DeleteRange(start, keyPartEnd(start), nil)
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "error applying mutation: %v", err)
+		}
+	}
+	return nil
+}
+
+// trimEmbeddedColumn removes all versions of a column exceeding the max versions gc policy of its family
+func trimEmbeddedColumn(batch *pebble.Batch, table string, row []byte, family string, qualifier []byte) error {
+	maxVersions := bigtableSchema[table][family].maxVersions
+	if maxVersions <= 0 {
+		return nil
+	}
+
+	start := embeddedColumnKey(table, row, family, qualifier)
+	iter, err := batch.NewIter(&pebble.IterOptions{LowerBound: start, UpperBound: keyPartEnd(start)})
+	if err != nil {
+		return err
+	}
+	versions := 0
+	var obsolete []byte
+	for iter.First(); iter.Valid(); iter.Next() {
+		versions++
+		if versions > maxVersions {
+			obsolete = append([]byte{}, iter.Key()...)
+			break
+		}
+	}
+	err = iter.Close()
+	if err != nil {
+		return err
+	}
+
+	if obsolete == nil {
+		return nil
+	}
+	return batch.DeleteRange(obsolete, keyPartEnd(start), nil)
+}
+
+// runGC periodically removes all cells exceeding the max age gc policy of their family
+func (s *embeddedBigtable) runGC() {
+	for {
+		for table, families := range bigtableSchema {
+			for family, gc := range families {
+				if gc.maxAge <= 0 {
+					continue
+				}
+				start := time.Now()
+				deleted, err := s.collectExpiredCells(table, family, start)
+				if err != nil {
+					logger.Errorf("error collecting expired cells of family %v in table %v: %v", family, table, err)
+					continue
+				}
+				logger.Infof("collected %v expired cells of family %v in table %v, took %v", deleted, family, table, time.Since(start))
+			}
+		}
+		time.Sleep(embeddedBigtableGCInterval)
+	}
+}
+
+func (s *embeddedBigtable) collectExpiredCells(table, family string, now time.Time) (int, error) {
+	tablePrefix := embeddedTableKey(table)
+	expired := expiredCellFilter(table, now)
+
+	iter, err := s.db.NewIter(&pebble.IterOptions{LowerBound: tablePrefix, UpperBound: keyPartEnd(tablePrefix)})
+	if err != nil {
+		return 0, err
+	}
+	defer iter.Close()
+
+	deleted := 0
+	batch := s.db.NewBatch()
+	for iter.First(); iter.Valid(); iter.Next() {
+		_, cellFamily, _, ts, err := decodeCellKey(tablePrefix, iter.Key())
+		if err != nil {
+			batch.Close()
+			return deleted, err
+		}
+		if cellFamily != family || !expired(cellFamily, ts) {
+			continue
+		}
+		err = batch.Delete(iter.Key(), nil)
+		if err != nil {
+			batch.Close()
+			return deleted, err
+		}
+		deleted++
+
+		if batch.Count() >= DEFAULT_BATCH_INSERTS {
+			err = batch.Commit(pebble.Sync)
+			batch.Close()
+			if err != nil {
+				return deleted, err
+			}
+			batch = s.db.NewBatch()
+		}
+	}
+	err = batch.Commit(pebble.Sync)
+	batch.Close()
+	return deleted, err
+}
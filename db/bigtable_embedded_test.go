@@ -0,0 +1,199 @@
+package db
+
+import (
+	"context"
+	"encoding/binary"
+	"eth2-exporter/types"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	gcp_bigtable "cloud.google.com/go/bigtable"
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/vfs"
+)
+
+func TestEmbeddedBigtable(t *testing.T) {
+	pdb, err := pebble.Open("", &pebble.Options{FS: vfs.NewMem()})
+	if err != nil {
+		t.Fatalf("error opening pebble: %v", err)
+	}
+	store := newEmbeddedBigtable(pdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	backend, err := store.newBackend(ctx, "test", "test")
+	if err != nil {
+		t.Fatalf("error creating backend: %v", err)
+	}
+	defer backend.Close()
+	tbl := backend.Open("blocks")
+
+	// reversed padded keys have to be returned in key order, the zero byte key checks the key escaping
+	keys := []string{"1:999999998", "1:999999999", "1:\x00", "2:999999999", "1:999999997"}
+	muts := make([]*gcp_bigtable.Mutation, 0, len(keys))
+	for i := range keys {
+		mut := gcp_bigtable.NewMutation()
+		mut.Set(DEFAULT_FAMILY_BLOCKS, "data", gcp_bigtable.Timestamp(1000), []byte(fmt.Sprintf("v%d", i)))
+		muts = append(muts, mut)
+	}
+	errs, err := tbl.ApplyBulk(ctx, keys, muts)
+	if err != nil || errs != nil {
+		t.Fatalf("error writing rows: %v %v", err, errs)
+	}
+
+	// a second version exceeds the max versions policy of the family and replaces the first
+	mut := gcp_bigtable.NewMutation()
+	mut.Set(DEFAULT_FAMILY_BLOCKS, "data", gcp_bigtable.Timestamp(2000), []byte("v0-2"))
+	err = tbl.Apply(ctx, keys[0], mut)
+	if err != nil {
+		t.Fatalf("error updating row: %v", err)
+	}
+
+	read := []string{}
+	values := map[string]string{}
+	err = tbl.ReadRows(ctx, gcp_bigtable.PrefixRange("1:"), func(r gcp_bigtable.Row) bool {
+		read = append(read, r.Key())
+		if len(r[DEFAULT_FAMILY_BLOCKS]) != 1 {
+			t.Errorf("expected 1 cell for row %q, got %v", r.Key(), len(r[DEFAULT_FAMILY_BLOCKS]))
+		}
+		values[r.Key()] = string(r[DEFAULT_FAMILY_BLOCKS][0].Value)
+		return true
+	})
+	if err != nil {
+		t.Fatalf("error reading rows: %v", err)
+	}
+
+	expected := []string{"1:\x00", "1:999999997", "1:999999998", "1:999999999"}
+	if fmt.Sprintf("%q", read) != fmt.Sprintf("%q", expected) {
+		t.Errorf("expected rows %q, got %q", expected, read)
+	}
+	if values[keys[0]] != "v0-2" {
+		t.Errorf("expected latest version of row %q, got %q", keys[0], values[keys[0]])
+	}
+
+	read = read[:0]
+	err = tbl.ReadRows(ctx, gcp_bigtable.NewRange("1:999999998", "2:"), func(r gcp_bigtable.Row) bool {
+		read = append(read, r.Key())
+		return true
+	}, gcp_bigtable.LimitRows(1), gcp_bigtable.RowFilter(gcp_bigtable.ChainFilters(gcp_bigtable.FamilyFilter(DEFAULT_FAMILY_BLOCKS), gcp_bigtable.LatestNFilter(1))))
+	if err != nil {
+		t.Fatalf("error reading rows: %v", err)
+	}
+	if len(read) != 1 || read[0] != "1:999999998" {
+		t.Errorf("expected row 1:999999998, got %q", read)
+	}
+
+	del := gcp_bigtable.NewMutation()
+	del.DeleteRow()
+	err = tbl.Apply(ctx, keys[1], del)
+	if err != nil {
+		t.Fatalf("error deleting row: %v", err)
+	}
+	row, err := tbl.ReadRow(ctx, keys[1])
+	if err != nil {
+		t.Fatalf("error reading row: %v", err)
+	}
+	if len(row) != 0 {
+		t.Errorf("expected row %q to be deleted, got %v", keys[1], row)
+	}
+
+	for _, inc := range []int64{3, -1} {
+		rmw := gcp_bigtable.NewReadModifyWrite()
+		rmw.Increment(DEFAULT_FAMILY_BLOCKS, "count", inc)
+		row, err = tbl.ApplyReadModifyWrite(ctx, "counter", rmw)
+		if err != nil {
+			t.Fatalf("error incrementing counter: %v", err)
+		}
+	}
+	if len(row[DEFAULT_FAMILY_BLOCKS]) != 1 || binary.BigEndian.Uint64(row[DEFAULT_FAMILY_BLOCKS][0].Value) != 2 {
+		t.Errorf("expected counter value 2, got %v", row)
+	}
+}
+
+func TestEmbeddedValidatorBalanceHistory(t *testing.T) {
+	bt := newEmbeddedTestBigtable(t)
+
+	// the reversed keys of validator 1 and 21 share the prefix 1
+	for epoch := uint64(10); epoch <= 12; epoch++ {
+		err := bt.SaveValidatorBalances(epoch, []*types.Validator{
+			{Index: 1, Balance: 32000000000 + epoch, EffectiveBalance: 32000000000},
+			{Index: 21, Balance: 31000000000 + epoch, EffectiveBalance: 31000000000},
+		})
+		if err != nil {
+			t.Fatalf("error saving balances of epoch %v: %v", epoch, err)
+		}
+	}
+
+	balances, err := bt.GetValidatorBalanceHistory([]uint64{1}, 11, 12)
+	if err != nil {
+		t.Fatalf("error retrieving balance history: %v", err)
+	}
+	if len(balances) != 1 || len(balances[1]) != 2 {
+		t.Fatalf("expected 2 balances of validator 1, got %v", balances)
+	}
+	for i, epoch := range []uint64{12, 11} {
+		b := balances[1][i]
+		if b.Epoch != epoch || b.Index != 1 || b.Balance != 32000000000+epoch || b.EffectiveBalance != 32000000000 {
+			t.Errorf("unexpected balance %v at position %v, expected epoch %v", b, i, epoch)
+		}
+	}
+
+	maxIndex, err := bt.GetMaxValidatorindexForEpoch(12)
+	if err != nil {
+		t.Fatalf("error retrieving max validator index: %v", err)
+	}
+	if maxIndex != 21 {
+		t.Errorf("expected max validator index 21, got %v", maxIndex)
+	}
+}
+
+// newEmbeddedTestBigtable returns a bigtable client for chain 1 that is backed by an in-memory embedded store
+func newEmbeddedTestBigtable(t *testing.T) *Bigtable {
+	pdb, err := pebble.Open("", &pebble.Options{FS: vfs.NewMem()})
+	if err != nil {
+		t.Fatalf("error opening pebble: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	backend, err := newEmbeddedBigtable(pdb).newBackend(ctx, "test", "test")
+	if err != nil {
+		t.Fatalf("error creating backend: %v", err)
+	}
+	t.Cleanup(func() {
+		backend.Close()
+	})
+
+	return &Bigtable{
+		backend:                 backend,
+		tableData:               backend.Open("data"),
+		tableBlocks:             backend.Open("blocks"),
+		tableMetadataUpdates:    backend.Open("metadata_updates"),
+		tableMetadata:           backend.Open("metadata"),
+		tableBeaconchain:        backend.Open("beaconchain"),
+		tableMachineMetrics:     backend.Open("machine_metrics"),
+		tableValidators:         backend.Open("beaconchain_validators"),
+		tableValidatorsHistory:  backend.Open("beaconchain_validators_history"),
+		chainId:                 "1",
+		LastAttestationCacheMux: &sync.Mutex{},
+	}
+}
+
+// readEmbeddedTestRows returns all rows of the table starting with prefix
+func readEmbeddedTestRows(t *testing.T, tbl *gcp_bigtable.Table, prefix string) map[string]gcp_bigtable.Row {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	rows := make(map[string]gcp_bigtable.Row)
+	err := tbl.ReadRows(ctx, gcp_bigtable.PrefixRange(prefix), func(r gcp_bigtable.Row) bool {
+		rows[r.Key()] = r
+		return true
+	})
+	if err != nil {
+		t.Fatalf("error reading rows with prefix %v: %v", prefix, err)
+	}
+	return rows
+}
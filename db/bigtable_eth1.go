@@ -3,6 +3,7 @@ package db
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -57,6 +58,7 @@ const (
 	FILTER_METHOD         IndexFilter = "METHOD"
 	FILTER_CONTRACT       IndexFilter = "CONTRACT"
 	FILTER_ERROR          IndexFilter = "ERROR"
+	FILTER_BLOCK          IndexFilter = "BLOCK"
 )
 
 const (
@@ -73,14 +75,21 @@ const (
 	ITX_PER_TX_LIMIT               = 100_000
 	MAX_INT                        = 9223372036854775807
 	MIN_INT                        = -9223372036854775808
+
+	// max number of index rows scanned per requested log when filtering logs by topics that are not part of the index
+	logScanLimitFactor = 10
 )
 
 const (
 	ACCOUNT_COLUMN_NAME = "NAME"
 	ACCOUNT_IS_CONTRACT = "ISCONTRACT"
 
-	CONTRACT_NAME = "CONTRACTNAME"
-	CONTRACT_ABI  = "ABI"
+	TOKEN_HOLDER_COLUMN_BALANCE = "HOLDERBALANCE"
+	TOKEN_HOLDER_COLUMN_COUNT   = "HOLDERCOUNT"
+
+	CONTRACT_NAME   = "CONTRACTNAME"
+	CONTRACT_ABI    = "ABI"
+	CONTRACT_SOURCE = "SOURCE"
 
 	ERC20_COLUMN_DECIMALS    = "DECIMALS"
 	ERC20_COLUMN_TOTALSUPPLY = "TOTALSUPPLY"
@@ -638,6 +647,60 @@ func TimestampToBigtableTimeDesc(ts time.Time) string {
 	return fmt.Sprintf("%04d%02d%02d%02d%02d%02d", 9999-ts.Year(), 12-ts.Month(), 31-ts.Day(), 23-ts.Hour(), 59-ts.Minute(), 59-ts.Second())
 }
 
+// Eth1Transformer is a named transformer that turns an execution block into the mutations of the data and metadata_updates tables
+type Eth1Transformer struct {
+	Name      string
+	Transform func(blk *types.Eth1Block, cache *freecache.Cache) (bulkData *types.BulkMutations, bulkMetadataUpdates *types.BulkMutations, err error)
+}
+
+// Eth1Transformers returns all transformers in the order in which the eth1 indexer runs them
+func (bigtable *Bigtable) Eth1Transformers() []Eth1Transformer {
+	return []Eth1Transformer{
+		{"TransformBlock", bigtable.TransformBlock},
+		{"TransformTx", bigtable.TransformTx},
+		{"TransformItx", bigtable.TransformItx},
+		{"TransformBlobTx", bigtable.TransformBlobTx},
+		{"TransformERC20", bigtable.TransformERC20},
+		{"TransformERC721", bigtable.TransformERC721},
+		{"TransformERC1155", bigtable.TransformERC1155},
+		{"TransformLogs", bigtable.TransformLogs},
+		{"TransformBalanceDeltas", bigtable.TransformBalanceDeltas},
+		{"TransformUncle", bigtable.TransformUncle},
+		{"TransformWithdrawals", bigtable.TransformWithdrawals},
+		{"TransformEnsNameRegistered", bigtable.TransformEnsNameRegistered},
+		{"TransformContract", bigtable.TransformContract},
+		{"TransformUserOperations", bigtable.TransformUserOperations},
+	}
+}
+
+// GetEth1Transforms returns the transform functions of the transformers with the given names in the order of the names,
+// all transformers are returned if names is empty or only contains "all"
+func (bigtable *Bigtable) GetEth1Transforms(names []string) ([]func(blk *types.Eth1Block, cache *freecache.Cache) (*types.BulkMutations, *types.BulkMutations, error), error) {
+	transformers := bigtable.Eth1Transformers()
+	if len(names) == 0 || (len(names) == 1 && names[0] == "all") {
+		names = make([]string, 0, len(transformers))
+		for _, transformer := range transformers {
+			names = append(names, transformer.Name)
+		}
+	}
+
+	transforms := make([]func(blk *types.Eth1Block, cache *freecache.Cache) (*types.BulkMutations, *types.BulkMutations, error), 0, len(names))
+	for _, name := range names {
+		found := false
+		for _, transformer := range transformers {
+			if transformer.Name == name {
+				transforms = append(transforms, transformer.Transform)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown transformer %v", name)
+		}
+	}
+	return transforms, nil
+}
+
 func (bigtable *Bigtable) IndexEventsWithTransformers(start, end int64, transforms []func(blk *types.Eth1Block, cache *freecache.Cache) (bulkData *types.BulkMutations, bulkMetadataUpdates *types.BulkMutations, err error), concurrency int64, cache *freecache.Cache) error {
 	g := new(errgroup.Group)
 	g.SetLimit(int(concurrency))
@@ -673,43 +736,7 @@ func (bigtable *Bigtable) IndexEventsWithTransformers(start, end int64, transfor
 			for b := range blocksChan {
 				block := b
 				subG.Go(func() error {
-					bulkMutsData := types.BulkMutations{}
-					bulkMutsMetadataUpdate := types.BulkMutations{}
-					for _, transform := range transforms {
-						mutsData, mutsMetadataUpdate, err := transform(block, cache)
-						if err != nil {
-							logrus.WithError(err).Errorf("error transforming block [%v]", block.Number)
-						}
-						bulkMutsData.Keys = append(bulkMutsData.Keys, mutsData.Keys...)
-						bulkMutsData.Muts = append(bulkMutsData.Muts, mutsData.Muts...)
-
-						if mutsMetadataUpdate != nil {
-							bulkMutsMetadataUpdate.Keys = append(bulkMutsMetadataUpdate.Keys, mutsMetadataUpdate.Keys...)
-							bulkMutsMetadataUpdate.Muts = append(bulkMutsMetadataUpdate.Muts, mutsMetadataUpdate.Muts...)
-						}
-					}
-
-					if len(bulkMutsData.Keys) > 0 {
-						metaKeys := strings.Join(bulkMutsData.Keys, ",") // save block keys in order to be able to handle chain reorgs
-						err := bigtable.SaveBlockKeys(block.Number, block.Hash, metaKeys)
-						if err != nil {
-							return fmt.Errorf("error saving block [%v] keys to bigtable metadata updates table: %w", block.Number, err)
-						}
-
-						err = bigtable.WriteBulk(&bulkMutsData, bigtable.tableData, DEFAULT_BATCH_INSERTS)
-						if err != nil {
-							return fmt.Errorf("error writing block [%v] to bigtable data table: %w", block.Number, err)
-						}
-					}
-
-					if len(bulkMutsMetadataUpdate.Keys) > 0 {
-						err := bigtable.WriteBulk(&bulkMutsMetadataUpdate, bigtable.tableMetadataUpdates, DEFAULT_BATCH_INSERTS)
-						if err != nil {
-							return fmt.Errorf("error writing block [%v] to bigtable metadata updates table: %w", block.Number, err)
-						}
-					}
-
-					return nil
+					return bigtable.indexBlock(block, transforms, cache)
 				})
 			}
 			return subG.Wait()
@@ -745,6 +772,62 @@ func (bigtable *Bigtable) IndexEventsWithTransformers(start, end int64, transfor
 	return nil
 }
 
+// indexBlock transforms a block with the given transformers and writes the resulting mutations to the data and metadata updates tables.
+// The undo journal of the block is saved before any mutation is written so that the block can always be reverted
+func (bigtable *Bigtable) indexBlock(block *types.Eth1Block, transforms []func(blk *types.Eth1Block, cache *freecache.Cache) (bulkData *types.BulkMutations, bulkMetadataUpdates *types.BulkMutations, err error), cache *freecache.Cache) error {
+	bulkMutsData := types.BulkMutations{}
+	bulkMutsMetadataUpdate := types.BulkMutations{}
+	for _, transform := range transforms {
+		mutsData, mutsMetadataUpdate, err := transform(block, cache)
+		if err != nil {
+			logrus.WithError(err).Errorf("error transforming block [%v]", block.Number)
+		}
+		bulkMutsData.Keys = append(bulkMutsData.Keys, mutsData.Keys...)
+		bulkMutsData.Muts = append(bulkMutsData.Muts, mutsData.Muts...)
+		bulkMutsData.Journal = append(bulkMutsData.Journal, mutsData.Journal...)
+
+		if mutsMetadataUpdate != nil {
+			bulkMutsMetadataUpdate.Keys = append(bulkMutsMetadataUpdate.Keys, mutsMetadataUpdate.Keys...)
+			bulkMutsMetadataUpdate.Muts = append(bulkMutsMetadataUpdate.Muts, mutsMetadataUpdate.Muts...)
+			bulkMutsMetadataUpdate.Journal = append(bulkMutsMetadataUpdate.Journal, mutsMetadataUpdate.Journal...)
+		}
+	}
+
+	// all rows written to the data table are created by the block, the markers journaled by the transformers are set again after the rows have been deleted
+	for _, key := range bulkMutsData.Keys {
+		journalDeleteRow(&bulkMutsData, JOURNAL_TABLE_DATA, key)
+	}
+
+	journal := &types.Eth1BlockJournal{
+		Number:  block.Number,
+		Hash:    block.Hash,
+		Entries: append(bulkMutsData.Journal, bulkMutsMetadataUpdate.Journal...),
+	}
+	if len(journal.Entries) > 0 {
+		// save the undo journal of the block in order to be able to handle chain reorgs
+		err := bigtable.SaveBlockJournal(journal)
+		if err != nil {
+			return fmt.Errorf("error saving block [%v] journal to bigtable metadata updates table: %w", block.Number, err)
+		}
+	}
+
+	if len(bulkMutsData.Keys) > 0 {
+		err := bigtable.WriteBulk(&bulkMutsData, bigtable.tableData, DEFAULT_BATCH_INSERTS)
+		if err != nil {
+			return fmt.Errorf("error writing block [%v] to bigtable data table: %w", block.Number, err)
+		}
+	}
+
+	if len(bulkMutsMetadataUpdate.Keys) > 0 {
+		err := bigtable.WriteBulk(&bulkMutsMetadataUpdate, bigtable.tableMetadataUpdates, DEFAULT_BATCH_INSERTS)
+		if err != nil {
+			return fmt.Errorf("error writing block [%v] to bigtable metadata updates table: %w", block.Number, err)
+		}
+	}
+
+	return nil
+}
+
 // TransformBlock extracts blocks from bigtable more specifically from the table blocks.
 // It transforms the block and strips any information that is not necessary for a blocks view
 // It writes blocks to table data:
@@ -1157,21 +1240,93 @@ func decodeIsContractUpdateTs(ts gcp_bigtable.Timestamp) (block_number, tx_idx,
 	return block_number, tx_idx, trace_idx
 }
 
+// TransformContract accepts an eth1 block and tracks the contract status of all created and self destructed accounts in the metadata table.
+// In addition it writes a deployment record for every successful contract creation to the table data:
+// Row:    <chainID>:CONTRACT:<CONTRACT_ADDRESS>
+// Family: f
+// Column: data
+// Cell:   Proto<Eth1ContractDeploymentIndexed>
+//
+// It indexes contract deployments by:
+// Row:    <chainID>:I:CONTRACT:<DEPLOYER_ADDRESS>:BLOCK:<reversePaddedBlockNumber>:<paddedTxIndex>:<paddedITXIndex>
+// Family: f
+// Column: <chainID>:CONTRACT:<CONTRACT_ADDRESS>
+// Cell:   nil
+//
+// Row:    <chainID>:I:CONTRACT:<FACTORY_ADDRESS>:BLOCK:<reversePaddedBlockNumber>:<paddedTxIndex>:<paddedITXIndex>
+// Family: f
+// Column: <chainID>:CONTRACT:<CONTRACT_ADDRESS>
+// Cell:   nil
+//
+// Row:    <chainID>:I:CODEHASH:<CODE_HASH>:BLOCK:<reversePaddedBlockNumber>:<paddedTxIndex>:<paddedITXIndex>
+// Family: f
+// Column: <chainID>:CONTRACT:<CONTRACT_ADDRESS>
+// Cell:   nil
+//
+// The deployer is the sender of the transaction, the factory is set if the contract was created by another contract
 func (bigtable *Bigtable) TransformContract(blk *types.Eth1Block, cache *freecache.Cache) (bulkData *types.BulkMutations, bulkMetadataUpdates *types.BulkMutations, err error) {
 	bulkData = &types.BulkMutations{}
 	bulkMetadataUpdates = &types.BulkMutations{}
 	contractUpdateWrites := &types.BulkMutations{}
 
+	blockReversed := reversedPaddedBlockNumber(blk.GetNumber())
 	for i, tx := range blk.GetTransactions() {
 		if i >= TX_PER_BLOCK_LIMIT {
 			return nil, nil, fmt.Errorf("unexpected number of transactions in block expected at most %d but got: %v, tx: %x", TX_PER_BLOCK_LIMIT-1, i, tx.GetHash())
 		}
+		iReversed := reversePaddedIndex(i, TX_PER_BLOCK_LIMIT-1)
 
 		for j, itx := range tx.GetItx() {
 			if j >= ITX_PER_TX_LIMIT {
 				return nil, nil, fmt.Errorf("unexpected number of internal transactions in block expected at most %d but got: %v, tx: %x", ITX_PER_TX_LIMIT, j, tx.GetHash())
 			}
 
+			if itx.GetType() == "create" && itx.GetErrorMsg() == "" && tx.GetErrorMsg() == "" {
+				key := fmt.Sprintf("%s:CONTRACT:%x", bigtable.chainId, itx.GetTo())
+				deployment := &types.Eth1ContractDeploymentIndexed{
+					Address:        itx.GetTo(),
+					Deployer:       tx.GetFrom(),
+					TxHash:         tx.GetHash(),
+					BlockNumber:    blk.GetNumber(),
+					Time:           blk.GetTime(),
+					CodeHash:       itx.GetCodeHash(),
+					CreationMethod: itx.GetCreationMethod(),
+				}
+				if !bytes.Equal(itx.GetFrom(), tx.GetFrom()) {
+					deployment.Factory = itx.GetFrom()
+				}
+
+				b, err := proto.Marshal(deployment)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mut := gcp_bigtable.NewMutation()
+				mut.Set(DEFAULT_FAMILY, DATA_COLUMN, gcp_bigtable.Timestamp(0), b)
+
+				bulkData.Keys = append(bulkData.Keys, key)
+				bulkData.Muts = append(bulkData.Muts, mut)
+
+				jReversed := reversePaddedIndex(j, ITX_PER_TX_LIMIT-1)
+				indexes := []string{
+					fmt.Sprintf("%s:I:CONTRACT:%x:%s:%s:%s:%s", bigtable.chainId, deployment.Deployer, FILTER_BLOCK, blockReversed, iReversed, jReversed),
+				}
+				if len(deployment.Factory) > 0 {
+					indexes = append(indexes, fmt.Sprintf("%s:I:CONTRACT:%x:%s:%s:%s:%s", bigtable.chainId, deployment.Factory, FILTER_BLOCK, blockReversed, iReversed, jReversed))
+				}
+				if len(deployment.CodeHash) > 0 {
+					indexes = append(indexes, fmt.Sprintf("%s:I:CODEHASH:%x:%s:%s:%s:%s", bigtable.chainId, deployment.CodeHash, FILTER_BLOCK, blockReversed, iReversed, jReversed))
+				}
+
+				for _, idx := range indexes {
+					mut := gcp_bigtable.NewMutation()
+					mut.Set(DEFAULT_FAMILY, key, gcp_bigtable.Timestamp(0), nil)
+
+					bulkData.Keys = append(bulkData.Keys, idx)
+					bulkData.Muts = append(bulkData.Muts, mut)
+				}
+			}
+
 			if itx.GetType() == "create" || itx.GetType() == "suicide" {
 				contractUpdate := &types.IsContractUpdate{
 					IsContract: itx.GetType() == "create",
@@ -1192,9 +1347,11 @@ func (bigtable *Bigtable) TransformContract(blk *types.Eth1Block, cache *freecac
 				if err != nil {
 					utils.LogError(err, "error generating bigtable isContract timestamp", 0)
 				} else {
+					key := fmt.Sprintf("%s:S:%x", bigtable.chainId, address)
 					mutWrite.Set(ACCOUNT_METADATA_FAMILY, ACCOUNT_IS_CONTRACT, ts, b)
-					contractUpdateWrites.Keys = append(contractUpdateWrites.Keys, fmt.Sprintf("%s:S:%x", bigtable.chainId, address))
+					contractUpdateWrites.Keys = append(contractUpdateWrites.Keys, key)
 					contractUpdateWrites.Muts = append(contractUpdateWrites.Muts, mutWrite)
+					journalDeleteCell(bulkMetadataUpdates, JOURNAL_TABLE_METADATA, key, ACCOUNT_METADATA_FAMILY, ACCOUNT_IS_CONTRACT, ts)
 				}
 			}
 		}
@@ -1508,6 +1665,12 @@ func (bigtable *Bigtable) TransformERC20(blk *types.Eth1Block, cache *freecache.
 // Family: f
 // Column: <chainID>:ERC721:<txHash>:<paddedLogIndex>
 // Cell:   nil
+//
+// It tracks the token ids for a later resolution of their metadata (see ImportNftMetadataUpdates):
+// Row:    <chainID>:NFT:V:<TOKEN_ADDRESS>:<tokenId>
+// Family: f
+// Column: erc721
+// Cell:   nil
 func (bigtable *Bigtable) TransformERC721(blk *types.Eth1Block, cache *freecache.Cache) (bulkData *types.BulkMutations, bulkMetadataUpdates *types.BulkMutations, err error) {
 	bulkData = &types.BulkMutations{}
 	bulkMetadataUpdates = &types.BulkMutations{}
@@ -1570,6 +1733,8 @@ func (bigtable *Bigtable) TransformERC721(blk *types.Eth1Block, cache *freecache
 				TokenId:      tokenId.Bytes(),
 			}
 
+			bigtable.markNftMetadataUpdate(ERC721_METADATA_FAMILY, indexedLog.TokenAddress, tokenId, bulkData, cache)
+
 			b, err := proto.Marshal(indexedLog)
 			if err != nil {
 				return nil, nil, err
@@ -1658,6 +1823,12 @@ func (bigtable *Bigtable) TransformERC721(blk *types.Eth1Block, cache *freecache
 // Family: f
 // Column: <chainID>:ERC1155:<txHash>:<paddedLogIndex>
 // Cell:   nil
+//
+// It tracks the token ids for a later resolution of their metadata (see ImportNftMetadataUpdates):
+// Row:    <chainID>:NFT:V:<TOKEN_ADDRESS>:<tokenId>
+// Family: f
+// Column: erc1155
+// Cell:   nil
 func (bigtable *Bigtable) TransformERC1155(blk *types.Eth1Block, cache *freecache.Cache) (bulkData *types.BulkMutations, bulkMetadataUpdates *types.BulkMutations, err error) {
 	bulkData = &types.BulkMutations{}
 	bulkMetadataUpdates = &types.BulkMutations{}
@@ -1736,6 +1907,8 @@ func (bigtable *Bigtable) TransformERC1155(blk *types.Eth1Block, cache *freecach
 					indexedLog.TokenId = ids[ti]
 					indexedLog.Value = values[ti]
 					indexedLog.TokenAddress = log.GetAddress()
+
+					bigtable.markNftMetadataUpdate(ERC1155_METADATA_FAMILY, indexedLog.TokenAddress, transferBatch.Ids[ti], bulkData, cache)
 				}
 			} else if transferSingle != nil {
 				indexedLog.BlockNumber = blk.GetNumber()
@@ -1747,6 +1920,8 @@ func (bigtable *Bigtable) TransformERC1155(blk *types.Eth1Block, cache *freecach
 				indexedLog.TokenId = transferSingle.Id.Bytes()
 				indexedLog.Value = transferSingle.Value.Bytes()
 				indexedLog.TokenAddress = log.GetAddress()
+
+				bigtable.markNftMetadataUpdate(ERC1155_METADATA_FAMILY, indexedLog.TokenAddress, transferSingle.Id, bulkData, cache)
 			}
 
 			b, err := proto.Marshal(indexedLog)
@@ -1792,6 +1967,103 @@ func (bigtable *Bigtable) TransformERC1155(blk *types.Eth1Block, cache *freecach
 	return bulkData, bulkMetadataUpdates, nil
 }
 
+// TransformLogs accepts an eth1 block and creates bigtable mutations for all logs of the block, regardless of the emitting contract.
+// It writes logs to the table data:
+// Row:    <chainID>:LOG:<txHash>:<paddedLogIndex>
+// Family: f
+// Column: data
+// Cell:   Proto<Eth1LogIndexed>
+// Example scan: "1:LOG:4d3a6c56cecb40637c070601c275df9cc7b599b5dc1d5ac2473c92c7a9e62c64" returns all mainnet logs of transaction 0x4d3a6c56cecb40637c070601c275df9cc7b599b5dc1d5ac2473c92c7a9e62c64
+//
+// The log index is the position of the log within the block as reported by the node.
+// Blocks that have been stored before the log index was exported are indexed by counting their logs, which yields the same index.
+//
+// It indexes logs by:
+// Row:    <chainID>:I:LOG:<EMITTING_ADDRESS>:BLOCK:<reversePaddedBlockNumber>:<paddedTxIndex>:<PaddedLogIndex>
+// Family: f
+// Column: <chainID>:LOG:<txHash>:<paddedLogIndex>
+// Cell:   nil
+//
+// Row:    <chainID>:I:LOG:<TOPIC0>:BLOCK:<reversePaddedBlockNumber>:<paddedTxIndex>:<PaddedLogIndex>
+// Family: f
+// Column: <chainID>:LOG:<txHash>:<paddedLogIndex>
+// Cell:   nil
+//
+// Row:    <chainID>:I:LOG:<EMITTING_ADDRESS>:<TOPIC0>:<reversePaddedBlockNumber>:<paddedTxIndex>:<PaddedLogIndex>
+// Family: f
+// Column: <chainID>:LOG:<txHash>:<paddedLogIndex>
+// Cell:   nil
+// Example lookup: "1:I:LOG:a0b86991c6218b36c1d19d4a2e9eb0ce3606eb48:ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef:" returns all mainnet USDC transfer logs in desc order
+//
+// Contrary to the other indexes, the padded tx and log indexes have a fixed width so that the index rows of a block are ordered without rearranging
+func (bigtable *Bigtable) TransformLogs(blk *types.Eth1Block, cache *freecache.Cache) (bulkData *types.BulkMutations, bulkMetadataUpdates *types.BulkMutations, err error) {
+	bulkData = &types.BulkMutations{}
+	bulkMetadataUpdates = &types.BulkMutations{}
+
+	blockReversed := reversedPaddedBlockNumber(blk.GetNumber())
+	blockLogIndex := uint64(0)
+	for i, tx := range blk.GetTransactions() {
+		if i >= TX_PER_BLOCK_LIMIT {
+			return nil, nil, fmt.Errorf("unexpected number of transactions in block expected at most %d but got: %v, tx: %x", TX_PER_BLOCK_LIMIT-1, i, tx.GetHash())
+		}
+		iReversed := reversePaddedIndex(i, TX_PER_BLOCK_LIMIT-1)
+		for _, log := range tx.GetLogs() {
+			logIndex := log.GetIndex()
+			if logIndex == 0 {
+				logIndex = blockLogIndex
+			}
+			blockLogIndex++
+			if logIndex >= ITX_PER_TX_LIMIT {
+				return nil, nil, fmt.Errorf("unexpected number of logs in block expected at most %d but got: %v tx: %x", ITX_PER_TX_LIMIT-1, logIndex, tx.GetHash())
+			}
+			jReversed := reversePaddedIndex(int(logIndex), ITX_PER_TX_LIMIT-1)
+
+			key := fmt.Sprintf("%s:LOG:%x:%s", bigtable.chainId, tx.GetHash(), jReversed)
+			indexedLog := &types.Eth1LogIndexed{
+				ParentHash:  tx.GetHash(),
+				BlockNumber: blk.GetNumber(),
+				Address:     log.GetAddress(),
+				Time:        blk.GetTime(),
+				Topics:      log.GetTopics(),
+				Data:        log.GetData(),
+				TxIndex:     uint64(i),
+				LogIndex:    logIndex,
+			}
+
+			b, err := proto.Marshal(indexedLog)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			mut := gcp_bigtable.NewMutation()
+			mut.Set(DEFAULT_FAMILY, DATA_COLUMN, gcp_bigtable.Timestamp(0), b)
+
+			bulkData.Keys = append(bulkData.Keys, key)
+			bulkData.Muts = append(bulkData.Muts, mut)
+
+			indexes := []string{
+				fmt.Sprintf("%s:I:LOG:%x:%s:%s:%s:%s", bigtable.chainId, indexedLog.Address, FILTER_BLOCK, blockReversed, iReversed, jReversed),
+			}
+			if len(indexedLog.Topics) > 0 {
+				indexes = append(indexes,
+					fmt.Sprintf("%s:I:LOG:%x:%s:%s:%s:%s", bigtable.chainId, indexedLog.Topics[0], FILTER_BLOCK, blockReversed, iReversed, jReversed),
+					fmt.Sprintf("%s:I:LOG:%x:%x:%s:%s:%s", bigtable.chainId, indexedLog.Address, indexedLog.Topics[0], blockReversed, iReversed, jReversed),
+				)
+			}
+
+			for _, idx := range indexes {
+				mut := gcp_bigtable.NewMutation()
+				mut.Set(DEFAULT_FAMILY, key, gcp_bigtable.Timestamp(0), nil)
+
+				bulkData.Keys = append(bulkData.Keys, idx)
+				bulkData.Muts = append(bulkData.Muts, mut)
+			}
+		}
+	}
+
+	return bulkData, bulkMetadataUpdates, nil
+}
+
 // TransformUncle accepts an eth1 block and creates bigtable mutations.
 // It transforms the uncles contained within a block, extracts the necessary information to create a view and writes that information to bigtable
 // It writes uncles to table data:
@@ -1941,6 +2213,173 @@ func (bigtable *Bigtable) TransformWithdrawals(block *types.Eth1Block, cache *fr
 	return bulkData, bulkMetadataUpdates, nil
 }
 
+type balanceDelta struct {
+	address []byte
+	token   []byte
+	value   *big.Int
+}
+
+// balanceDeltas aggregates the balance changes of a block per address and token in order of their first occurrence
+type balanceDeltas struct {
+	keys   []string
+	deltas map[string]*balanceDelta
+}
+
+func (d *balanceDeltas) add(address []byte, token []byte, value *big.Int) {
+	if len(address) == 0 || value.Sign() == 0 {
+		return
+	}
+	key := fmt.Sprintf("%x:%x", address, token)
+	delta, ok := d.deltas[key]
+	if !ok {
+		delta = &balanceDelta{address: address, token: token, value: new(big.Int)}
+		d.deltas[key] = delta
+		d.keys = append(d.keys, key)
+	}
+	delta.value.Add(delta.value, value)
+}
+
+func (d *balanceDeltas) sub(address []byte, token []byte, value *big.Int) {
+	d.add(address, token, new(big.Int).Neg(value))
+}
+
+// TransformBalanceDeltas accepts an eth1 block and creates bigtable mutations for the balance changes of all addresses touched by the block.
+// Native balance changes are derived from tx values & fees, internal transactions, block & uncle rewards and withdrawals and are stored with the token 00.
+// Token balance changes are derived from ERC20 transfer events.
+// The changes of a block are aggregated per address and token and written to the table data:
+// Row:    <chainID>:BH:<ADDRESS>:<TOKEN_ADDRESS>:<reversePaddedBlockNumber>
+// Family: f
+// Column: data
+// Cell:   Proto<Eth1BalanceDeltaIndexed>
+// Example scan: "1:BH:ea674fdde714fd979de3edf0f56aa9716b898ec8:00:" returns the native balance changes of ethermine in desc order
+func (bigtable *Bigtable) TransformBalanceDeltas(blk *types.Eth1Block, cache *freecache.Cache) (bulkData *types.BulkMutations, bulkMetadataUpdates *types.BulkMutations, err error) {
+	bulkData = &types.BulkMutations{}
+	bulkMetadataUpdates = &types.BulkMutations{}
+
+	filterer, err := erc20.NewErc20Filterer(common.Address{}, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating filterer: %w", err)
+	}
+
+	nativeToken := []byte{0x0}
+	deltas := &balanceDeltas{deltas: make(map[string]*balanceDelta)}
+
+	blockReward := utils.Eth1BlockReward(blk.GetNumber(), blk.GetDifficulty())
+	coinbaseReward := new(big.Int).Set(blockReward)
+	for _, uncle := range blk.GetUncles() {
+		// uncle miners receive (uncleNumber + 8 - blockNumber) / 8 of the block reward, the block miner 1/32 of the block reward per included uncle
+		uncleReward := new(big.Int).SetUint64(uncle.GetNumber() + 8 - blk.GetNumber())
+		uncleReward.Mul(uncleReward, blockReward)
+		uncleReward.Div(uncleReward, big.NewInt(8))
+		deltas.add(uncle.GetCoinbase(), nativeToken, uncleReward)
+
+		coinbaseReward.Add(coinbaseReward, new(big.Int).Div(blockReward, big.NewInt(32)))
+	}
+
+	baseFee := new(big.Int).SetBytes(blk.GetBaseFee())
+	for i, tx := range blk.GetTransactions() {
+		if i >= TX_PER_BLOCK_LIMIT {
+			return nil, nil, fmt.Errorf("unexpected number of transactions in block expected at most %d but got: %v, tx: %x", TX_PER_BLOCK_LIMIT-1, i, tx.GetHash())
+		}
+
+		// the base fee is burned, the proposer receives the remaining priority fee
+		fee := CalculateTxFeeFromTransaction(tx, baseFee)
+		blobFee := new(big.Int).Mul(new(big.Int).SetBytes(tx.GetBlobGasPrice()), new(big.Int).SetUint64(tx.GetBlobGasUsed()))
+		deltas.sub(tx.GetFrom(), nativeToken, new(big.Int).Add(fee, blobFee))
+		coinbaseReward.Add(coinbaseReward, fee.Sub(fee, new(big.Int).Mul(baseFee, new(big.Int).SetUint64(tx.GetGasUsed()))))
+
+		// failed transactions only pay fees
+		if tx.GetErrorMsg() != "" {
+			continue
+		}
+
+		to := tx.GetTo()
+		if !bytes.Equal(tx.GetContractAddress(), ZERO_ADDRESS) {
+			to = tx.GetContractAddress()
+		}
+		value := new(big.Int).SetBytes(tx.GetValue())
+		deltas.sub(tx.GetFrom(), nativeToken, value)
+		deltas.add(to, nativeToken, value)
+
+		for _, itx := range tx.GetItx() {
+			if itx.Path == "[]" || bytes.Equal(itx.Value, []byte{0x0}) || itx.GetType() == "delegatecall" || itx.GetErrorMsg() != "" { // skip top level, empty, delegate and failed calls
+				continue
+			}
+			value := new(big.Int).SetBytes(itx.GetValue())
+			deltas.sub(itx.GetFrom(), nativeToken, value)
+			deltas.add(itx.GetTo(), nativeToken, value)
+		}
+
+		for j, log := range tx.GetLogs() {
+			if len(log.GetTopics()) != 3 || !bytes.Equal(log.GetTopics()[0], erc20.TransferTopic) {
+				continue
+			}
+
+			topics := make([]common.Hash, 0, len(log.GetTopics()))
+			for _, lTopic := range log.GetTopics() {
+				topics = append(topics, common.BytesToHash(lTopic))
+			}
+
+			transfer, _ := filterer.ParseTransfer(eth_types.Log{
+				Address:     common.BytesToAddress(log.GetAddress()),
+				Data:        log.Data,
+				Topics:      topics,
+				BlockNumber: blk.GetNumber(),
+				TxHash:      common.BytesToHash(tx.GetHash()),
+				TxIndex:     uint(i),
+				BlockHash:   common.BytesToHash(blk.GetHash()),
+				Index:       uint(j),
+				Removed:     log.GetRemoved(),
+			})
+			if transfer == nil || transfer.Value == nil {
+				continue
+			}
+
+			// mints and burns only change the balance of the counterparty
+			if transfer.From != (common.Address{}) {
+				deltas.sub(transfer.From.Bytes(), log.GetAddress(), transfer.Value)
+			}
+			if transfer.To != (common.Address{}) {
+				deltas.add(transfer.To.Bytes(), log.GetAddress(), transfer.Value)
+			}
+		}
+	}
+	deltas.add(blk.GetCoinbase(), nativeToken, coinbaseReward)
+
+	for _, withdrawal := range blk.GetWithdrawals() {
+		// withdrawal amounts are denominated in gwei
+		deltas.add(withdrawal.GetAddress(), nativeToken, new(big.Int).Mul(new(big.Int).SetBytes(withdrawal.GetAmount()), big.NewInt(1e9)))
+	}
+
+	for _, key := range deltas.keys {
+		delta := deltas.deltas[key]
+		if delta.value.Sign() == 0 {
+			continue
+		}
+
+		indexedDelta := &types.Eth1BalanceDeltaIndexed{
+			BlockNumber: blk.GetNumber(),
+			Time:        blk.GetTime(),
+			Token:       delta.token,
+			Delta:       new(big.Int).Abs(delta.value).Bytes(),
+			Negative:    delta.value.Sign() < 0,
+		}
+
+		b, err := proto.Marshal(indexedDelta)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		mut := gcp_bigtable.NewMutation()
+		mut.Set(DEFAULT_FAMILY, DATA_COLUMN, gcp_bigtable.Timestamp(0), b)
+
+		bulkData.Keys = append(bulkData.Keys, fmt.Sprintf("%s:BH:%x:%x:%s", bigtable.chainId, delta.address, delta.token, reversedPaddedBlockNumber(blk.GetNumber())))
+		bulkData.Muts = append(bulkData.Muts, mut)
+	}
+
+	return bulkData, bulkMetadataUpdates, nil
+}
+
 type IndexKeys struct {
 	indexes []string
 	keys    []string
@@ -3160,6 +3599,17 @@ func (bigtable *Bigtable) GetAddressErc721TableData(address []byte, pageToken st
 		return nil, err
 	}
 
+	tokens := make([][]byte, 0, len(transactions))
+	tokenIds := make([]*big.Int, 0, len(transactions))
+	for _, t := range transactions {
+		tokens = append(tokens, t.TokenAddress)
+		tokenIds = append(tokenIds, new(big.Int).SetBytes(t.TokenId))
+	}
+	nftMetadata, err := bigtable.getNftMetadataForTransfers(tokens, tokenIds)
+	if err != nil {
+		return nil, err
+	}
+
 	tableData := make([][]interface{}, len(transactions))
 	for i, t := range transactions {
 		fromName := names[string(t.From)]
@@ -3172,7 +3622,7 @@ func (bigtable *Bigtable) GetAddressErc721TableData(address []byte, pageToken st
 			utils.FormatAddressWithLimitsInAddressPageTable(address, t.From, fromName, false, digitLimitInAddressPagesTable, nameLimitInAddressPagesTable, true),
 			utils.FormatAddressWithLimitsInAddressPageTable(address, t.To, toName, false, digitLimitInAddressPagesTable, nameLimitInAddressPagesTable, true),
 			utils.FormatAddressAsLink(t.TokenAddress, "", true),
-			new(big.Int).SetBytes(t.TokenId).String(),
+			utils.FormatNftToken(t.TokenAddress, tokenIds[i], nftMetadata[fmt.Sprintf("%x:%s", t.TokenAddress, tokenIds[i])]),
 		}
 	}
 
@@ -3274,6 +3724,17 @@ func (bigtable *Bigtable) GetAddressErc1155TableData(address []byte, pageToken s
 		return nil, err
 	}
 
+	tokens := make([][]byte, 0, len(transactions))
+	tokenIds := make([]*big.Int, 0, len(transactions))
+	for _, t := range transactions {
+		tokens = append(tokens, t.TokenAddress)
+		tokenIds = append(tokenIds, new(big.Int).SetBytes(t.TokenId))
+	}
+	nftMetadata, err := bigtable.getNftMetadataForTransfers(tokens, tokenIds)
+	if err != nil {
+		return nil, err
+	}
+
 	for i, t := range transactions {
 		fromName := names[string(t.From)]
 		toName := names[string(t.To)]
@@ -3285,7 +3746,7 @@ func (bigtable *Bigtable) GetAddressErc1155TableData(address []byte, pageToken s
 			utils.FormatAddressWithLimitsInAddressPageTable(address, t.From, fromName, false, digitLimitInAddressPagesTable, nameLimitInAddressPagesTable, true),
 			utils.FormatAddressWithLimitsInAddressPageTable(address, t.To, toName, false, digitLimitInAddressPagesTable, nameLimitInAddressPagesTable, true),
 			utils.FormatAddressAsLink(t.TokenAddress, "", true),
-			new(big.Int).SetBytes(t.TokenId).String(),
+			utils.FormatNftToken(t.TokenAddress, tokenIds[i], nftMetadata[fmt.Sprintf("%x:%s", t.TokenAddress, tokenIds[i])]),
 			new(big.Int).SetBytes(t.Value).String(),
 		}
 	}
@@ -3298,12 +3759,330 @@ func (bigtable *Bigtable) GetAddressErc1155TableData(address []byte, pageToken s
 	return data, nil
 }
 
-func (bigtable *Bigtable) GetMetadataUpdates(prefix string, startToken string, limit int) ([]string, []*types.Eth1AddressBalance, error) {
+// GetEth1Logs returns up to limit logs matching the filter, starting after the given page token, together with the page token of the next page.
+// The scanned index is chosen by the address and topic0 of the filter, the remaining topics are matched against the fetched logs.
+// To bound the duration of a request, at most logScanLimitFactor*limit index rows are scanned, so a page can contain less than limit logs
+// while there are more results available. An empty page token is returned once the end of the index has been reached.
+func (bigtable *Bigtable) GetEth1Logs(filter *types.Eth1LogFilter, pageToken string, limit int64) ([]*types.Eth1LogIndexed, string, error) {
 
 	tmr := time.AfterFunc(REPORT_TIMEOUT, func() {
 		logger.WithFields(logrus.Fields{
-			"prefix":     prefix,
-			"startToken": startToken,
+			"filter":    filter,
+			"pageToken": pageToken,
+			"limit":     limit,
+		}).Warnf("%s call took longer than %v", utils.GetCurrentFuncName(), REPORT_TIMEOUT)
+	})
+	defer tmr.Stop()
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second*30))
+	defer cancel()
+
+	var prefix string
+	switch {
+	case len(filter.Address) > 0 && len(filter.Topics[0]) > 0:
+		prefix = fmt.Sprintf("%s:I:LOG:%x:%x:", bigtable.chainId, filter.Address, filter.Topics[0])
+	case len(filter.Address) > 0:
+		prefix = fmt.Sprintf("%s:I:LOG:%x:%s:", bigtable.chainId, filter.Address, FILTER_BLOCK)
+	case len(filter.Topics[0]) > 0:
+		prefix = fmt.Sprintf("%s:I:LOG:%x:%s:", bigtable.chainId, filter.Topics[0], FILTER_BLOCK)
+	default:
+		return nil, "", fmt.Errorf("either an address or topic0 is required to query logs")
+	}
+
+	// block numbers are stored reversed, the upper block bound is the start of the scan
+	start := prefix
+	if filter.ToBlock > 0 {
+		start = prefix + reversedPaddedBlockNumber(filter.ToBlock)
+	}
+	end := prefixSuccessor(prefix, 6)
+	if filter.FromBlock > 0 {
+		end = prefixSuccessor(prefix+reversedPaddedBlockNumber(filter.FromBlock), 6)
+	}
+	if pageToken != "" {
+		if !strings.HasPrefix(pageToken, prefix) {
+			return nil, "", fmt.Errorf("page token %v does not match the filter", pageToken)
+		}
+		// add \x00 to the row range such that we skip the previous value
+		start = pageToken + "\x00"
+	}
+
+	data := make([]*types.Eth1LogIndexed, 0, limit)
+	scanned := int64(0)
+	for scanned < limit*logScanLimitFactor && int64(len(data)) < limit {
+		keys := make([]string, 0, limit)
+		indexes := make([]string, 0, limit)
+		err := bigtable.tableData.ReadRows(ctx, gcp_bigtable.NewRange(start, end), func(row gcp_bigtable.Row) bool {
+			keys = append(keys, strings.TrimPrefix(row[DEFAULT_FAMILY][0].Column, "f:"))
+			indexes = append(indexes, row.Key())
+			return true
+		}, gcp_bigtable.LimitRows(limit))
+		if err != nil {
+			return nil, "", err
+		}
+		if len(keys) == 0 {
+			return data, "", nil
+		}
+		scanned += int64(len(keys))
+
+		keysMap := make(map[string]*types.Eth1LogIndexed, len(keys))
+		err = bigtable.tableData.ReadRows(ctx, gcp_bigtable.RowList(keys), func(row gcp_bigtable.Row) bool {
+			l := &types.Eth1LogIndexed{}
+			err := proto.Unmarshal(row[DEFAULT_FAMILY][0].Value, l)
+			if err != nil {
+				logrus.Fatalf("error parsing Eth1LogIndexed data: %v", err)
+			}
+			keysMap[row.Key()] = l
+			return true
+		})
+		if err != nil {
+			logger.WithError(err).WithField("prefix", prefix).WithField("limit", limit).Errorf("error reading rows in bigtable_eth1 / GetEth1Logs")
+			return nil, "", err
+		}
+
+		for i, key := range keys {
+			l := keysMap[key]
+			if l == nil || !filter.MatchesTopics(l.Topics) {
+				continue
+			}
+			data = append(data, l)
+			if int64(len(data)) == limit {
+				return data, indexes[i], nil
+			}
+		}
+
+		if int64(len(keys)) < limit {
+			return data, "", nil
+		}
+		pageToken = indexes[len(indexes)-1]
+		start = pageToken + "\x00"
+	}
+
+	return data, pageToken, nil
+}
+
+// GetContractDeployment returns the deployment record of a contract, nil is returned if no deployment has been indexed for the address
+func (bigtable *Bigtable) GetContractDeployment(address []byte) (*types.Eth1ContractDeploymentIndexed, error) {
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second*30))
+	defer cancel()
+
+	row, err := bigtable.tableData.ReadRow(ctx, fmt.Sprintf("%s:CONTRACT:%x", bigtable.chainId, address), gcp_bigtable.RowFilter(gcp_bigtable.LatestNFilter(1)))
+	if err != nil {
+		return nil, err
+	}
+	if len(row[DEFAULT_FAMILY]) == 0 {
+		return nil, nil
+	}
+
+	deployment := &types.Eth1ContractDeploymentIndexed{}
+	err = proto.Unmarshal(row[DEFAULT_FAMILY][0].Value, deployment)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing Eth1ContractDeploymentIndexed data for address %x: %w", address, err)
+	}
+	return deployment, nil
+}
+
+// GetContractDeploymentsForAddress returns the contracts deployed by an address in desc order, either directly or as a factory
+func (bigtable *Bigtable) GetContractDeploymentsForAddress(address []byte, pageToken string, limit int64) ([]*types.Eth1ContractDeploymentIndexed, string, error) {
+	return bigtable.getContractDeployments(fmt.Sprintf("%s:I:CONTRACT:%x:%s:", bigtable.chainId, address, FILTER_BLOCK), pageToken, limit)
+}
+
+// GetContractDeploymentsForCodeHash returns the contracts with the given runtime code hash in desc order
+func (bigtable *Bigtable) GetContractDeploymentsForCodeHash(codeHash []byte, pageToken string, limit int64) ([]*types.Eth1ContractDeploymentIndexed, string, error) {
+	return bigtable.getContractDeployments(fmt.Sprintf("%s:I:CODEHASH:%x:%s:", bigtable.chainId, codeHash, FILTER_BLOCK), pageToken, limit)
+}
+
+func (bigtable *Bigtable) getContractDeployments(prefix string, pageToken string, limit int64) ([]*types.Eth1ContractDeploymentIndexed, string, error) {
+
+	tmr := time.AfterFunc(REPORT_TIMEOUT, func() {
+		logger.WithFields(logrus.Fields{
+			"prefix":    prefix,
+			"pageToken": pageToken,
+			"limit":     limit,
+		}).Warnf("%s call took longer than %v", utils.GetCurrentFuncName(), REPORT_TIMEOUT)
+	})
+	defer tmr.Stop()
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second*30))
+	defer cancel()
+
+	rowRange := gcp_bigtable.PrefixRange(prefix)
+	if pageToken != "" {
+		if !strings.HasPrefix(pageToken, prefix) {
+			return nil, "", fmt.Errorf("page token %v does not match the query", pageToken)
+		}
+		// add \x00 to the row range such that we skip the previous value
+		rowRange = gcp_bigtable.NewRange(pageToken+"\x00", prefixSuccessor(prefix, 5))
+	}
+
+	keys := make([]string, 0, limit)
+	indexes := make([]string, 0, limit)
+	err := bigtable.tableData.ReadRows(ctx, rowRange, func(row gcp_bigtable.Row) bool {
+		keys = append(keys, strings.TrimPrefix(row[DEFAULT_FAMILY][0].Column, "f:"))
+		indexes = append(indexes, row.Key())
+		return true
+	}, gcp_bigtable.LimitRows(limit))
+	if err != nil {
+		return nil, "", err
+	}
+	if len(keys) == 0 {
+		return []*types.Eth1ContractDeploymentIndexed{}, "", nil
+	}
+
+	keysMap := make(map[string]*types.Eth1ContractDeploymentIndexed, len(keys))
+	err = bigtable.tableData.ReadRows(ctx, gcp_bigtable.RowList(keys), func(row gcp_bigtable.Row) bool {
+		deployment := &types.Eth1ContractDeploymentIndexed{}
+		err := proto.Unmarshal(row[DEFAULT_FAMILY][0].Value, deployment)
+		if err != nil {
+			logrus.Fatalf("error parsing Eth1ContractDeploymentIndexed data: %v", err)
+		}
+		keysMap[row.Key()] = deployment
+		return true
+	})
+	if err != nil {
+		logger.WithError(err).WithField("prefix", prefix).WithField("limit", limit).Errorf("error reading rows in bigtable_eth1 / getContractDeployments")
+		return nil, "", err
+	}
+
+	data := make([]*types.Eth1ContractDeploymentIndexed, 0, len(keys))
+	for _, key := range keys {
+		if deployment := keysMap[key]; deployment != nil {
+			data = append(data, deployment)
+		}
+	}
+
+	lastKey := indexes[len(indexes)-1]
+	if int64(len(keys)) < limit {
+		lastKey = ""
+	}
+	return data, lastKey, nil
+}
+
+// GetBalanceHistoryForAddress returns the balance of an address for a token at each of the given ascending points in time, native balances are queried with the token 00
+func (bigtable *Bigtable) GetBalanceHistoryForAddress(address []byte, token []byte, times []time.Time) ([]*big.Int, error) {
+	return bigtable.getBalanceHistoryForAddress(address, token, len(times), func(delta *types.Eth1BalanceDeltaIndexed, i int) bool {
+		return delta.GetTime().AsTime().After(times[i])
+	})
+}
+
+// GetBalanceHistoryForAddressAtBlocks returns the balance of an address for a token after each of the given ascending blocks, native balances are queried with the token 00
+func (bigtable *Bigtable) GetBalanceHistoryForAddressAtBlocks(address []byte, token []byte, blocks []uint64) ([]*big.Int, error) {
+	return bigtable.getBalanceHistoryForAddress(address, token, len(blocks), func(delta *types.Eth1BalanceDeltaIndexed, i int) bool {
+		return delta.GetBlockNumber() > blocks[i]
+	})
+}
+
+// getBalanceHistoryForAddress derives past balances from the stored balance of the address by subtracting the indexed balance deltas
+// that happened after each point, isAfter reports whether a delta happened after the point with index i.
+// The stored balance is anchored on the block it has been retrieved at, it includes genesis allocations and balance changes that have not
+// been indexed as deltas. The deltas indexed after the anchor block are added to it first to get the balance at the newest indexed block.
+// Without a stored balance or anchor block the balance before genesis is assumed to be 0, so all deltas of the address are added up.
+// As the deltas are stored in desc order only the deltas from the newest block down to the oldest requested point are read afterwards.
+func (bigtable *Bigtable) getBalanceHistoryForAddress(address []byte, token []byte, points int, isAfter func(delta *types.Eth1BalanceDeltaIndexed, i int) bool) ([]*big.Int, error) {
+	tmr := time.AfterFunc(REPORT_TIMEOUT, func() {
+		logger.WithFields(logrus.Fields{
+			"address": address,
+			"token":   token,
+			"points":  points,
+		}).Warnf("%s call took longer than %v", utils.GetCurrentFuncName(), REPORT_TIMEOUT)
+	})
+	defer tmr.Stop()
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second*30))
+	defer cancel()
+
+	prefix := fmt.Sprintf("%s:BH:%x:%x:", bigtable.chainId, address, token)
+
+	filter := gcp_bigtable.ChainFilters(gcp_bigtable.FamilyFilter(ACCOUNT_METADATA_FAMILY), gcp_bigtable.ColumnFilter(fmt.Sprintf("(B|BB):%x", token)), gcp_bigtable.LatestNFilter(1))
+	row, err := bigtable.tableMetadata.ReadRow(ctx, fmt.Sprintf("%s:%x", bigtable.chainId, address), gcp_bigtable.RowFilter(filter))
+	if err != nil {
+		return nil, err
+	}
+	var storedBalance []byte
+	anchored := false
+	newerRange := gcp_bigtable.PrefixRange(prefix)
+	for _, item := range row[ACCOUNT_METADATA_FAMILY] {
+		switch item.Column {
+		case fmt.Sprintf("%s:B:%x", ACCOUNT_METADATA_FAMILY, token):
+			storedBalance = item.Value
+		case fmt.Sprintf("%s:BB:%x", ACCOUNT_METADATA_FAMILY, token):
+			if len(item.Value) == 8 {
+				anchored = true
+				// the deltas of the blocks after the anchor block are stored in front of the deltas of the anchor block
+				newerRange = gcp_bigtable.NewRange(prefix, prefix+reversedPaddedBlockNumber(binary.BigEndian.Uint64(item.Value)))
+			}
+		}
+	}
+
+	balance := new(big.Int)
+	if anchored {
+		balance.SetBytes(storedBalance)
+	}
+
+	var parseErr error
+	parseDelta := func(row gcp_bigtable.Row) (*types.Eth1BalanceDeltaIndexed, *big.Int) {
+		d := &types.Eth1BalanceDeltaIndexed{}
+		parseErr = proto.Unmarshal(row[DEFAULT_FAMILY][0].Value, d)
+		if parseErr != nil {
+			return nil, nil
+		}
+		delta := new(big.Int).SetBytes(d.GetDelta())
+		if d.GetNegative() {
+			delta.Neg(delta)
+		}
+		return d, delta
+	}
+
+	err = bigtable.tableData.ReadRows(ctx, newerRange, func(row gcp_bigtable.Row) bool {
+		d, delta := parseDelta(row)
+		if d == nil {
+			return false
+		}
+		balance.Add(balance, delta)
+		return true
+	}, gcp_bigtable.RowFilter(gcp_bigtable.ColumnFilter(DATA_COLUMN)))
+	if err != nil {
+		return nil, err
+	}
+	if parseErr != nil {
+		return nil, fmt.Errorf("error parsing Eth1BalanceDeltaIndexed data: %w", parseErr)
+	}
+
+	balances := make([]*big.Int, points)
+	i := points - 1
+	err = bigtable.tableData.ReadRows(ctx, gcp_bigtable.PrefixRange(prefix), func(row gcp_bigtable.Row) bool {
+		d, delta := parseDelta(row)
+		if d == nil {
+			return false
+		}
+		for ; i >= 0 && !isAfter(d, i); i-- {
+			balances[i] = new(big.Int).Set(balance)
+		}
+		if i < 0 { // all remaining deltas happened before the oldest point
+			return false
+		}
+
+		balance.Sub(balance, delta)
+		return true
+	}, gcp_bigtable.RowFilter(gcp_bigtable.ColumnFilter(DATA_COLUMN)))
+	if err != nil {
+		return nil, err
+	}
+	if parseErr != nil {
+		return nil, fmt.Errorf("error parsing Eth1BalanceDeltaIndexed data: %w", parseErr)
+	}
+	for ; i >= 0; i-- {
+		balances[i] = new(big.Int).Set(balance)
+	}
+
+	return balances, nil
+}
+
+func (bigtable *Bigtable) GetMetadataUpdates(prefix string, startToken string, limit int) ([]string, []*types.Eth1AddressBalance, error) {
+
+	tmr := time.AfterFunc(REPORT_TIMEOUT, func() {
+		logger.WithFields(logrus.Fields{
+			"prefix":     prefix,
+			"startToken": startToken,
 		}).Warnf("%s call took longer than %v", utils.GetCurrentFuncName(), REPORT_TIMEOUT)
 	})
 	defer tmr.Stop()
@@ -3997,7 +4776,7 @@ func (bigtable *Bigtable) GetContractMetadata(address []byte) (*types.ContractMe
 	defer cancel()
 
 	rowKey := fmt.Sprintf("%s:%x", bigtable.chainId, address)
-	cacheKey := bigtable.chainId + ":CONTRACT:" + rowKey
+	cacheKey := bigtable.contractMetadataCacheKey(address)
 	if cached, err := cache.TieredCache.GetWithLocalTimeout(cacheKey, utils.Day, new(types.ContractMetadata)); err == nil {
 		ret := cached.(*types.ContractMetadata)
 		val, err := abi.JSON(bytes.NewReader(ret.ABIJson))
@@ -4064,6 +4843,8 @@ func (bigtable *Bigtable) GetContractMetadata(address []byte) (*types.ContractMe
 					logrus.Fatalf("error decoding abi for address 0x%x: %v", address, err)
 				}
 				ret.ABI = &val
+			} else if item.Column == CONTRACT_METADATA_FAMILY+":"+CONTRACT_SOURCE {
+				ret.Source = string(item.Value)
 			}
 		}
 	}
@@ -4079,10 +4860,30 @@ func (bigtable *Bigtable) SaveContractMetadata(address []byte, metadata *types.C
 	mut := gcp_bigtable.NewMutation()
 	mut.Set(CONTRACT_METADATA_FAMILY, CONTRACT_NAME, gcp_bigtable.Timestamp(0), []byte(metadata.Name))
 	mut.Set(CONTRACT_METADATA_FAMILY, CONTRACT_ABI, gcp_bigtable.Timestamp(0), metadata.ABIJson)
+	mut.Set(CONTRACT_METADATA_FAMILY, CONTRACT_SOURCE, gcp_bigtable.Timestamp(0), []byte(metadata.Source))
 
 	return bigtable.tableMetadata.Apply(ctx, fmt.Sprintf("%s:%x", bigtable.chainId, address), mut)
 }
 
+// SaveVerifiedContractMetadata stores a verified abi for a contract, replacing any abi previously fetched from etherscan
+func (bigtable *Bigtable) SaveVerifiedContractMetadata(address []byte, metadata *types.ContractMetadata) error {
+	metadata.Source = types.ContractMetadataSourceVerified
+	err := bigtable.SaveContractMetadata(address, metadata)
+	if err != nil {
+		return err
+	}
+
+	err = cache.TieredCache.Set(bigtable.contractMetadataCacheKey(address), metadata, utils.Day)
+	if err != nil {
+		return fmt.Errorf("error updating cached contract metadata for address %x: %w", address, err)
+	}
+	return nil
+}
+
+func (bigtable *Bigtable) contractMetadataCacheKey(address []byte) string {
+	return fmt.Sprintf("%s:CONTRACT:%s:%x", bigtable.chainId, bigtable.chainId, address)
+}
+
 func (bigtable *Bigtable) SaveBalances(balances []*types.Eth1AddressBalance, deleteKeys []string) error {
 	if len(balances) == 0 {
 		return nil
@@ -4093,15 +4894,24 @@ func (bigtable *Bigtable) SaveBalances(balances []*types.Eth1AddressBalance, del
 		Muts: make([]*gcp_bigtable.Mutation, 0, len(balances)),
 	}
 
+	err := bigtable.updateTokenHolders(balances)
+	if err != nil {
+		return fmt.Errorf("error updating token holders: %w", err)
+	}
+
 	for _, balance := range balances {
 		mutWrite := gcp_bigtable.NewMutation()
 
 		mutWrite.Set(ACCOUNT_METADATA_FAMILY, fmt.Sprintf("B:%x", balance.Token), gcp_bigtable.Timestamp(0), balance.Balance)
+		// the block the balance has been retrieved at anchors the balance history of the address
+		blockNumber := make([]byte, 8)
+		binary.BigEndian.PutUint64(blockNumber, balance.BlockNumber)
+		mutWrite.Set(ACCOUNT_METADATA_FAMILY, fmt.Sprintf("BB:%x", balance.Token), gcp_bigtable.Timestamp(0), blockNumber)
 		mutsWrite.Keys = append(mutsWrite.Keys, fmt.Sprintf("%s:%x", bigtable.chainId, balance.Address))
 		mutsWrite.Muts = append(mutsWrite.Muts, mutWrite)
 	}
 
-	err := bigtable.WriteBulk(mutsWrite, bigtable.tableMetadata, DEFAULT_BATCH_INSERTS)
+	err = bigtable.WriteBulk(mutsWrite, bigtable.tableMetadata, DEFAULT_BATCH_INSERTS)
 
 	if err != nil {
 		return err
@@ -4130,6 +4940,378 @@ func (bigtable *Bigtable) SaveBalances(balances []*types.Eth1AddressBalance, del
 	return nil
 }
 
+// reversePaddedBalance returns the inverted 32 byte representation of a balance so that larger balances are sorted first
+func reversePaddedBalance(balance []byte) string {
+	if len(balance) > 32 {
+		balance = balance[len(balance)-32:]
+	}
+	padded := make([]byte, 32)
+	copy(padded[32-len(balance):], balance)
+	for i := range padded {
+		padded[i] = ^padded[i]
+	}
+	return fmt.Sprintf("%x", padded)
+}
+
+// updateTokenHolders keeps the token holder index in sync with the token balances that are about to be saved.
+// It has to be called before the balances are written as the previous balances are required to remove outdated index rows.
+// It indexes the holders of a token in the table metadata:
+// Row:    <chainID>:TH:<TOKEN_ADDRESS>:<reversePaddedBalance>:<HOLDER_ADDRESS>
+// Family: a
+// Column: HOLDERBALANCE
+// Cell:   balance
+// Example scan: "1:TH:dac17f958d2ee523a2206206994597c13d831ec7:" returns the mainnet USDT holders in desc order of their balance
+//
+// It counts the holders of a token by:
+// Row:    <chainID>:TH:<TOKEN_ADDRESS>
+// Family: a
+// Column: HOLDERCOUNT
+// Cell:   number of addresses with a non zero balance as big endian int64
+// The count is only changed when a holder row is created or removed. As the increments are not idempotent a failure between writing the
+// holder rows and incrementing the count makes it drift, RebuildTokenHolders recounts the holders from the holder rows.
+func (bigtable *Bigtable) updateTokenHolders(balances []*types.Eth1AddressBalance) error {
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Minute))
+	defer cancel()
+
+	// only the last balance of an address & token pair is relevant
+	keys := make([]string, 0, len(balances))
+	latest := make(map[string]*types.Eth1AddressBalance, len(balances))
+	rowKeys := make(map[string]bool)
+	tokens := make(map[string]bool)
+	for _, balance := range balances {
+		if bytes.Equal(balance.Token, []byte{0x0}) {
+			continue
+		}
+		key := fmt.Sprintf("%x:%x", balance.Address, balance.Token)
+		if _, ok := latest[key]; !ok {
+			keys = append(keys, key)
+		}
+		latest[key] = balance
+		rowKeys[fmt.Sprintf("%s:%x", bigtable.chainId, balance.Address)] = true
+		tokens[fmt.Sprintf("%x", balance.Token)] = true
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	rowList := make(gcp_bigtable.RowList, 0, len(rowKeys))
+	for rowKey := range rowKeys {
+		rowList = append(rowList, rowKey)
+	}
+	tokenList := make([]string, 0, len(tokens))
+	for token := range tokens {
+		tokenList = append(tokenList, token)
+	}
+
+	previous := make(map[string]*big.Int, len(keys))
+	filter := gcp_bigtable.ChainFilters(gcp_bigtable.FamilyFilter(ACCOUNT_METADATA_FAMILY), gcp_bigtable.ColumnFilter(fmt.Sprintf("B:(%s)", strings.Join(tokenList, "|"))), gcp_bigtable.LatestNFilter(1))
+	err := bigtable.tableMetadata.ReadRows(ctx, rowList, func(row gcp_bigtable.Row) bool {
+		address := strings.TrimPrefix(row.Key(), bigtable.chainId+":")
+		for _, item := range row[ACCOUNT_METADATA_FAMILY] {
+			token := strings.TrimPrefix(item.Column, ACCOUNT_METADATA_FAMILY+":B:")
+			previous[fmt.Sprintf("%s:%s", address, token)] = new(big.Int).SetBytes(item.Value)
+		}
+		return true
+	}, gcp_bigtable.RowFilter(filter))
+	if err != nil {
+		return err
+	}
+
+	type holderTransition struct {
+		token      string
+		prevKey    string
+		currentKey string
+	}
+	transitions := make([]holderTransition, 0, len(keys))
+	holderKeys := make(gcp_bigtable.RowList, 0, len(keys)*2)
+	muts := &types.BulkMutations{}
+	for _, key := range keys {
+		balance := latest[key]
+		current := new(big.Int).SetBytes(balance.Balance)
+		prev, ok := previous[key]
+		if !ok {
+			prev = new(big.Int)
+		}
+		if prev.Cmp(current) == 0 {
+			continue
+		}
+
+		transition := holderTransition{token: fmt.Sprintf("%x", balance.Token)}
+		if prev.Sign() > 0 {
+			transition.prevKey = fmt.Sprintf("%s:TH:%s:%s:%x", bigtable.chainId, transition.token, reversePaddedBalance(prev.Bytes()), balance.Address)
+			holderKeys = append(holderKeys, transition.prevKey)
+
+			mut := gcp_bigtable.NewMutation()
+			mut.DeleteRow()
+			muts.Add(transition.prevKey, mut)
+		}
+		if current.Sign() > 0 {
+			transition.currentKey = fmt.Sprintf("%s:TH:%s:%s:%x", bigtable.chainId, transition.token, reversePaddedBalance(current.Bytes()), balance.Address)
+			holderKeys = append(holderKeys, transition.currentKey)
+
+			mut := gcp_bigtable.NewMutation()
+			mut.Set(ACCOUNT_METADATA_FAMILY, TOKEN_HOLDER_COLUMN_BALANCE, gcp_bigtable.Timestamp(0), current.Bytes())
+			muts.Add(transition.currentKey, mut)
+		}
+		transitions = append(transitions, transition)
+	}
+	if len(transitions) == 0 {
+		return nil
+	}
+
+	// the holder count is derived from the holder rows that exist before and after the update instead of the previous balances,
+	// if an earlier attempt already wrote the holder rows of the same balances the transition is not counted again
+	existing := make(map[string]bool, len(holderKeys))
+	err = bigtable.tableMetadata.ReadRows(ctx, holderKeys, func(row gcp_bigtable.Row) bool {
+		existing[row.Key()] = true
+		return true
+	}, gcp_bigtable.RowFilter(gcp_bigtable.ChainFilters(gcp_bigtable.ColumnFilter(TOKEN_HOLDER_COLUMN_BALANCE), gcp_bigtable.LatestNFilter(1), gcp_bigtable.StripValueFilter())))
+	if err != nil {
+		return err
+	}
+
+	holderCountChanges := make(map[string]int64)
+	for _, transition := range transitions {
+		wasHolder := existing[transition.prevKey] || existing[transition.currentKey]
+		isHolder := transition.currentKey != ""
+		if !wasHolder && isHolder {
+			holderCountChanges[transition.token]++
+		} else if wasHolder && !isHolder {
+			holderCountChanges[transition.token]--
+		}
+	}
+
+	err = bigtable.WriteBulk(muts, bigtable.tableMetadata, DEFAULT_BATCH_INSERTS)
+	if err != nil {
+		return err
+	}
+
+	for token, change := range holderCountChanges {
+		if change == 0 {
+			continue
+		}
+		rmw := gcp_bigtable.NewReadModifyWrite()
+		rmw.Increment(ACCOUNT_METADATA_FAMILY, TOKEN_HOLDER_COLUMN_COUNT, change)
+		_, err := bigtable.tableMetadata.ApplyReadModifyWrite(ctx, fmt.Sprintf("%s:TH:%s", bigtable.chainId, token), rmw)
+		if err != nil {
+			return fmt.Errorf("error updating holder count of token %v: %w", token, err)
+		}
+	}
+
+	return nil
+}
+
+// RebuildTokenHolders backfills the token holder index from the stored token balances and recounts the holders of every token.
+// Holder rows that do not match the current balance of their holder are removed, the holder counts are overwritten with the number of remaining rows.
+// It scans the whole metadata table and has to be run while the metadata updater is stopped.
+func (bigtable *Bigtable) RebuildTokenHolders(batchSize int, dryRun bool) error {
+	ctx := context.Background()
+
+	// write the holder rows of all token balances
+	muts := &types.BulkMutations{}
+	indexed := 0
+	var writeErr error
+	err := bigtable.tableMetadata.ReadRows(ctx, gcp_bigtable.PrefixRange(bigtable.chainId+":"), func(row gcp_bigtable.Row) bool {
+		address := strings.TrimPrefix(row.Key(), bigtable.chainId+":")
+		if !utils.IsEth1Address(address) {
+			return true
+		}
+		for _, item := range row[ACCOUNT_METADATA_FAMILY] {
+			token := strings.TrimPrefix(item.Column, ACCOUNT_METADATA_FAMILY+":B:")
+			balance := new(big.Int).SetBytes(item.Value)
+			if token == "00" || balance.Sign() == 0 {
+				continue
+			}
+			mut := gcp_bigtable.NewMutation()
+			mut.Set(ACCOUNT_METADATA_FAMILY, TOKEN_HOLDER_COLUMN_BALANCE, gcp_bigtable.Timestamp(0), balance.Bytes())
+			muts.Add(fmt.Sprintf("%s:TH:%s:%s:%s", bigtable.chainId, token, reversePaddedBalance(balance.Bytes()), address), mut)
+		}
+		if len(muts.Keys) >= batchSize {
+			indexed += len(muts.Keys)
+			if !dryRun {
+				writeErr = bigtable.WriteBulk(muts, bigtable.tableMetadata, DEFAULT_BATCH_INSERTS)
+			}
+			muts = &types.BulkMutations{}
+			logger.Infof("indexed %v token holders, last address %v", indexed, address)
+		}
+		return writeErr == nil
+	}, gcp_bigtable.RowFilter(gcp_bigtable.ChainFilters(gcp_bigtable.FamilyFilter(ACCOUNT_METADATA_FAMILY), gcp_bigtable.ColumnFilter("B:.*"), gcp_bigtable.LatestNFilter(1))))
+	if err != nil {
+		return fmt.Errorf("error reading token balances: %w", err)
+	}
+	if writeErr != nil {
+		return fmt.Errorf("error writing token holders: %w", writeErr)
+	}
+	indexed += len(muts.Keys)
+	if !dryRun {
+		err = bigtable.WriteBulk(muts, bigtable.tableMetadata, DEFAULT_BATCH_INSERTS)
+		if err != nil {
+			return fmt.Errorf("error writing token holders: %w", err)
+		}
+	}
+	logger.Infof("indexed %v token holders", indexed)
+
+	// remove outdated holder rows and count the remaining ones
+	counts := make(map[string]int64)
+	holderKeys := make([]string, 0, batchSize)
+	checkHolders := func() error {
+		if len(holderKeys) == 0 {
+			return nil
+		}
+		rowList := make(gcp_bigtable.RowList, 0, len(holderKeys))
+		for _, key := range holderKeys {
+			keySplit := strings.Split(key, ":")
+			rowList = append(rowList, fmt.Sprintf("%s:%s", bigtable.chainId, keySplit[4]))
+		}
+		balances := make(map[string]string)
+		err := bigtable.tableMetadata.ReadRows(ctx, rowList, func(row gcp_bigtable.Row) bool {
+			address := strings.TrimPrefix(row.Key(), bigtable.chainId+":")
+			for _, item := range row[ACCOUNT_METADATA_FAMILY] {
+				token := strings.TrimPrefix(item.Column, ACCOUNT_METADATA_FAMILY+":B:")
+				balances[fmt.Sprintf("%s:%s", token, address)] = reversePaddedBalance(new(big.Int).SetBytes(item.Value).Bytes())
+			}
+			return true
+		}, gcp_bigtable.RowFilter(gcp_bigtable.ChainFilters(gcp_bigtable.FamilyFilter(ACCOUNT_METADATA_FAMILY), gcp_bigtable.ColumnFilter("B:.*"), gcp_bigtable.LatestNFilter(1))))
+		if err != nil {
+			return fmt.Errorf("error reading token balances: %w", err)
+		}
+
+		deletes := &types.BulkMutations{}
+		for _, key := range holderKeys {
+			keySplit := strings.Split(key, ":")
+			if balances[fmt.Sprintf("%s:%s", keySplit[2], keySplit[4])] == keySplit[3] {
+				counts[keySplit[2]]++
+				continue
+			}
+			logger.Infof("removing outdated token holder row %v", key)
+			mut := gcp_bigtable.NewMutation()
+			mut.DeleteRow()
+			deletes.Add(key, mut)
+		}
+		holderKeys = holderKeys[:0]
+		if dryRun {
+			return nil
+		}
+		return bigtable.WriteBulk(deletes, bigtable.tableMetadata, DEFAULT_BATCH_INSERTS)
+	}
+
+	err = bigtable.tableMetadata.ReadRows(ctx, gcp_bigtable.PrefixRange(bigtable.chainId+":TH:"), func(row gcp_bigtable.Row) bool {
+		keySplit := strings.Split(row.Key(), ":")
+		if len(keySplit) == 3 {
+			// holder count row, tokens without any holder left are reset to 0
+			if _, ok := counts[keySplit[2]]; !ok {
+				counts[keySplit[2]] = 0
+			}
+			return true
+		}
+		if len(keySplit) != 5 {
+			return true
+		}
+		holderKeys = append(holderKeys, row.Key())
+		if len(holderKeys) >= batchSize {
+			writeErr = checkHolders()
+		}
+		return writeErr == nil
+	}, gcp_bigtable.RowFilter(gcp_bigtable.ChainFilters(gcp_bigtable.FamilyFilter(ACCOUNT_METADATA_FAMILY), gcp_bigtable.ColumnFilter(fmt.Sprintf("%s|%s", TOKEN_HOLDER_COLUMN_BALANCE, TOKEN_HOLDER_COLUMN_COUNT)), gcp_bigtable.LatestNFilter(1), gcp_bigtable.StripValueFilter())))
+	if err != nil {
+		return fmt.Errorf("error reading token holders: %w", err)
+	}
+	if writeErr != nil {
+		return writeErr
+	}
+	err = checkHolders()
+	if err != nil {
+		return err
+	}
+
+	countMuts := &types.BulkMutations{}
+	for token, count := range counts {
+		value := make([]byte, 8)
+		binary.BigEndian.PutUint64(value, uint64(count))
+		mut := gcp_bigtable.NewMutation()
+		mut.Set(ACCOUNT_METADATA_FAMILY, TOKEN_HOLDER_COLUMN_COUNT, gcp_bigtable.Timestamp(0), value)
+		countMuts.Add(fmt.Sprintf("%s:TH:%s", bigtable.chainId, token), mut)
+	}
+	logger.Infof("counted the holders of %v tokens", len(counts))
+	if dryRun {
+		return nil
+	}
+	return bigtable.WriteBulk(countMuts, bigtable.tableMetadata, DEFAULT_BATCH_INSERTS)
+}
+
+// GetTokenHolders returns the holders of a token in desc order of their balance
+func (bigtable *Bigtable) GetTokenHolders(token []byte, pageToken string, limit int64) ([]*types.Eth1AddressBalance, string, error) {
+
+	tmr := time.AfterFunc(REPORT_TIMEOUT, func() {
+		logger.WithFields(logrus.Fields{
+			"token":     token,
+			"pageToken": pageToken,
+			"limit":     limit,
+		}).Warnf("%s call took longer than %v", utils.GetCurrentFuncName(), REPORT_TIMEOUT)
+	})
+	defer tmr.Stop()
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second*30))
+	defer cancel()
+
+	prefix := fmt.Sprintf("%s:TH:%x:", bigtable.chainId, token)
+	rowRange := gcp_bigtable.PrefixRange(prefix)
+	if pageToken != "" {
+		if !strings.HasPrefix(pageToken, prefix) {
+			return nil, "", fmt.Errorf("page token %v does not match the token", pageToken)
+		}
+		// add \x00 to the row range such that we skip the previous value
+		rowRange = gcp_bigtable.NewRange(pageToken+"\x00", prefixSuccessor(prefix, 3))
+	}
+
+	holders := make([]*types.Eth1AddressBalance, 0, limit)
+	lastKey := ""
+	err := bigtable.tableMetadata.ReadRows(ctx, rowRange, func(row gcp_bigtable.Row) bool {
+		keySplit := strings.Split(row.Key(), ":")
+		holders = append(holders, &types.Eth1AddressBalance{
+			Address: common.FromHex(keySplit[len(keySplit)-1]),
+			Token:   token,
+			Balance: row[ACCOUNT_METADATA_FAMILY][0].Value,
+		})
+		lastKey = row.Key()
+		return true
+	}, gcp_bigtable.LimitRows(limit), gcp_bigtable.RowFilter(gcp_bigtable.ChainFilters(gcp_bigtable.ColumnFilter(TOKEN_HOLDER_COLUMN_BALANCE), gcp_bigtable.LatestNFilter(1))))
+	if err != nil {
+		return nil, "", err
+	}
+
+	if int64(len(holders)) < limit {
+		lastKey = ""
+	}
+	return holders, lastKey, nil
+}
+
+// GetTokenHolderCount returns the number of addresses holding a token
+func (bigtable *Bigtable) GetTokenHolderCount(token []byte) (uint64, error) {
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second*30))
+	defer cancel()
+
+	filter := gcp_bigtable.ChainFilters(gcp_bigtable.FamilyFilter(ACCOUNT_METADATA_FAMILY), gcp_bigtable.ColumnFilter(TOKEN_HOLDER_COLUMN_COUNT), gcp_bigtable.LatestNFilter(1))
+	row, err := bigtable.tableMetadata.ReadRow(ctx, fmt.Sprintf("%s:TH:%x", bigtable.chainId, token), gcp_bigtable.RowFilter(filter))
+	if err != nil {
+		return 0, err
+	}
+	if len(row[ACCOUNT_METADATA_FAMILY]) == 0 {
+		return 0, nil
+	}
+
+	value := row[ACCOUNT_METADATA_FAMILY][0].Value
+	if len(value) != 8 {
+		return 0, fmt.Errorf("invalid holder count %x for token %x", value, token)
+	}
+	count := int64(binary.BigEndian.Uint64(value))
+	if count < 0 {
+		logger.Warnf("holder count %v of token %x is negative, it has to be rebuilt with the rebuild-token-holders command", count, token)
+		return 0, nil
+	}
+	return uint64(count), nil
+}
+
 func (bigtable *Bigtable) SaveERC20TokenPrices(prices []*types.ERC20TokenPrice) error {
 	if len(prices) == 0 {
 		return nil
@@ -4158,19 +5340,6 @@ func (bigtable *Bigtable) SaveERC20TokenPrices(prices []*types.ERC20TokenPrice)
 	return nil
 }
 
-func (bigtable *Bigtable) SaveBlockKeys(blockNumber uint64, blockHash []byte, keys string) error {
-	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second*30))
-	defer cancel()
-
-	mut := gcp_bigtable.NewMutation()
-	mut.Set(METADATA_UPDATES_FAMILY_BLOCKS, "keys", gcp_bigtable.Now(), []byte(keys))
-
-	key := fmt.Sprintf("%s:BLOCK:%s:%x", bigtable.chainId, reversedPaddedBlockNumber(blockNumber), blockHash)
-	err := bigtable.tableMetadataUpdates.Apply(ctx, key, mut)
-
-	return err
-}
-
 func (bigtable *Bigtable) GetBlockKeys(blockNumber uint64, blockHash []byte) ([]string, error) {
 
 	tmr := time.AfterFunc(REPORT_TIMEOUT, func() {
@@ -4410,6 +5579,55 @@ func (bigtable *Bigtable) GetTokenTransactionsTableData(token []byte, address []
 	return data, nil
 }
 
+func (bigtable *Bigtable) GetTokenHoldersTableData(token []byte, pageToken string) (*types.DataTableResponse, error) {
+	holders, lastKey, err := bigtable.GetTokenHolders(token, pageToken, DefaultInfScrollRows)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := bigtable.GetERC20MetadataForAddress(token)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]string)
+	for _, h := range holders {
+		names[string(h.Address)] = ""
+	}
+	err = bigtable.GetAddressNames(names)
+	if err != nil {
+		return nil, err
+	}
+
+	tableData := make([][]interface{}, len(holders))
+	for i, h := range holders {
+		h.Metadata = metadata
+
+		tableData[i] = []interface{}{
+			utils.FormatAddress(h.Address, token, names[string(h.Address)], false, false, true),
+			utils.FormatTokenValue(h, false),
+			fmt.Sprintf("%s%%", utils.FormatPercentageWithGPrecision(TokenSupplyShare(h.Balance, metadata.TotalSupply), 4)),
+		}
+	}
+
+	data := &types.DataTableResponse{
+		Data:        tableData,
+		PagingToken: lastKey,
+	}
+
+	return data, nil
+}
+
+// TokenSupplyShare returns the share of the total supply of a token held by a balance
+func TokenSupplyShare(balance []byte, totalSupply []byte) float64 {
+	supply := new(big.Int).SetBytes(totalSupply)
+	if supply.Sign() == 0 {
+		return 0
+	}
+	share, _ := new(big.Float).Quo(new(big.Float).SetInt(new(big.Int).SetBytes(balance)), new(big.Float).SetInt(supply)).Float64()
+	return share
+}
+
 func (bigtable *Bigtable) SearchForAddress(addressPrefix []byte, limit int) ([]*types.Eth1AddressSearchItem, error) {
 
 	tmr := time.AfterFunc(REPORT_TIMEOUT, func() {
@@ -4664,6 +5882,7 @@ func prefixSuccessor(prefix string, pos int) string {
 func (bigtable *Bigtable) markBalanceUpdate(address []byte, token []byte, mutations *types.BulkMutations, cache *freecache.Cache) {
 	balanceUpdateKey := fmt.Sprintf("%s:B:%x", bigtable.chainId, address)                        // format is B: for balance update as chainid:prefix:address (token id will be encoded as column name)
 	balanceUpdateCacheKey := []byte(fmt.Sprintf("%s:B:%x:%x", bigtable.chainId, address, token)) // format is B: for balance update as chainid:prefix:address (token id will be encoded as column name)
+	journalMark(mutations, JOURNAL_TABLE_METADATA_UPDATES, balanceUpdateKey, DEFAULT_FAMILY, fmt.Sprintf("%x", token))
 	if _, err := cache.Get(balanceUpdateCacheKey); err != nil {
 		mut := gcp_bigtable.NewMutation()
 		mut.Set(DEFAULT_FAMILY, fmt.Sprintf("%x", token), gcp_bigtable.Timestamp(0), []byte{})
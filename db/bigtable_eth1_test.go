@@ -0,0 +1,404 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"eth2-exporter/types"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	gcp_bigtable "cloud.google.com/go/bigtable"
+	"github.com/coocood/freecache"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestGetBalanceHistoryForAddress(t *testing.T) {
+	bt := newEmbeddedTestBigtable(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	address := bytes.Repeat([]byte{0x01}, 20)
+	legacyAddress := bytes.Repeat([]byte{0x03}, 20)
+	token := []byte{0x0}
+	start := time.Unix(1700000000, 0)
+
+	writeDeltas := func(address []byte, deltas []int64) {
+		t.Helper()
+		for i, delta := range deltas {
+			block := uint64(10 * (i + 1))
+			b, err := proto.Marshal(&types.Eth1BalanceDeltaIndexed{
+				BlockNumber: block,
+				Time:        timestamppb.New(start.Add(time.Hour * time.Duration(i+1))),
+				Token:       token,
+				Delta:       new(big.Int).Abs(big.NewInt(delta)).Bytes(),
+				Negative:    delta < 0,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			mut := gcp_bigtable.NewMutation()
+			mut.Set(DEFAULT_FAMILY, DATA_COLUMN, gcp_bigtable.Timestamp(0), b)
+			err = bt.tableData.Apply(ctx, fmt.Sprintf("1:BH:%x:%x:%s", address, token, reversedPaddedBlockNumber(block)), mut)
+			if err != nil {
+				t.Fatalf("error writing delta: %v", err)
+			}
+		}
+	}
+
+	// the balance of 100 has been retrieved at block 30, it includes 40 that have never been indexed as a delta, e.g. a genesis allocation.
+	// The delta of block 40 has been indexed after the balance was retrieved.
+	writeDeltas(address, []int64{50, -20, 30, 5})
+	err := bt.SaveBalances([]*types.Eth1AddressBalance{{Address: address, Token: token, Balance: big.NewInt(100).Bytes(), BlockNumber: 30}}, nil)
+	if err != nil {
+		t.Fatalf("error saving balance: %v", err)
+	}
+
+	balances, err := bt.GetBalanceHistoryForAddressAtBlocks(address, token, []uint64{5, 10, 25, 30, 40, 50})
+	if err != nil {
+		t.Fatalf("error getting balance history: %v", err)
+	}
+	if fmt.Sprint(balances) != "[40 90 70 100 105 105]" {
+		t.Errorf("expected balances [40 90 70 100 105 105] at blocks, got %v", balances)
+	}
+
+	balances, err = bt.GetBalanceHistoryForAddress(address, token, []time.Time{start, start.Add(time.Hour * 90 / 60), start.Add(time.Hour * 5)})
+	if err != nil {
+		t.Fatalf("error getting balance history: %v", err)
+	}
+	if fmt.Sprint(balances) != "[40 90 105]" {
+		t.Errorf("expected balances [40 90 105] at times, got %v", balances)
+	}
+
+	// a balance without the block it has been retrieved at is not used, the history is the sum of the indexed deltas
+	writeDeltas(legacyAddress, []int64{50, -20})
+	mut := gcp_bigtable.NewMutation()
+	mut.Set(ACCOUNT_METADATA_FAMILY, fmt.Sprintf("B:%x", token), gcp_bigtable.Timestamp(0), big.NewInt(999).Bytes())
+	err = bt.tableMetadata.Apply(ctx, fmt.Sprintf("1:%x", legacyAddress), mut)
+	if err != nil {
+		t.Fatalf("error writing balance: %v", err)
+	}
+	balances, err = bt.GetBalanceHistoryForAddressAtBlocks(legacyAddress, token, []uint64{5, 10, 20})
+	if err != nil || fmt.Sprint(balances) != "[0 50 30]" {
+		t.Errorf("expected balances [0 50 30] without an anchor block, got %v %v", balances, err)
+	}
+
+	balances, err = bt.GetBalanceHistoryForAddressAtBlocks(bytes.Repeat([]byte{0x02}, 20), token, []uint64{10})
+	if err != nil || fmt.Sprint(balances) != "[0]" {
+		t.Errorf("expected a zero balance for an unknown address, got %v %v", balances, err)
+	}
+}
+
+func TestTokenHolders(t *testing.T) {
+	bt := newEmbeddedTestBigtable(t)
+
+	token := bytes.Repeat([]byte{0xee}, 20)
+	holder1 := bytes.Repeat([]byte{0x01}, 20)
+	holder2 := bytes.Repeat([]byte{0x02}, 20)
+	expectHolders := func(expected string, count uint64) {
+		t.Helper()
+		holders, _, err := bt.GetTokenHolders(token, "", 10)
+		if err != nil {
+			t.Fatalf("error getting token holders: %v", err)
+		}
+		actual := ""
+		for _, holder := range holders {
+			actual += fmt.Sprintf("%x:%v ", holder.Address[:1], new(big.Int).SetBytes(holder.Balance))
+		}
+		if actual != expected {
+			t.Errorf("expected holders %q, got %q", expected, actual)
+		}
+		holderCount, err := bt.GetTokenHolderCount(token)
+		if err != nil || holderCount != count {
+			t.Errorf("expected %v holders, got %v %v", count, holderCount, err)
+		}
+	}
+
+	balances := []*types.Eth1AddressBalance{
+		{Address: holder1, Token: token, Balance: big.NewInt(10).Bytes()},
+		{Address: holder2, Token: token, Balance: big.NewInt(20).Bytes()},
+	}
+	// a failed attempt that wrote the holder rows but not the balances is retried
+	err := bt.updateTokenHolders(balances)
+	if err != nil {
+		t.Fatalf("error updating token holders: %v", err)
+	}
+	err = bt.SaveBalances(balances, nil)
+	if err != nil {
+		t.Fatalf("error saving balances: %v", err)
+	}
+	expectHolders("02:20 01:10 ", 2)
+
+	err = bt.SaveBalances([]*types.Eth1AddressBalance{
+		{Address: holder1, Token: token, Balance: big.NewInt(30).Bytes()},
+		{Address: holder2, Token: token, Balance: []byte{}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("error saving balances: %v", err)
+	}
+	expectHolders("01:30 ", 1)
+
+	// the rebuild removes outdated holder rows and recounts the holders
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	mut := gcp_bigtable.NewMutation()
+	mut.Set(ACCOUNT_METADATA_FAMILY, TOKEN_HOLDER_COLUMN_BALANCE, gcp_bigtable.Timestamp(0), big.NewInt(5).Bytes())
+	err = bt.tableMetadata.Apply(ctx, fmt.Sprintf("1:TH:%x:%s:%x", token, reversePaddedBalance(big.NewInt(5).Bytes()), holder2), mut)
+	if err != nil {
+		t.Fatalf("error writing outdated holder row: %v", err)
+	}
+	rmw := gcp_bigtable.NewReadModifyWrite()
+	rmw.Increment(ACCOUNT_METADATA_FAMILY, TOKEN_HOLDER_COLUMN_COUNT, 3)
+	_, err = bt.tableMetadata.ApplyReadModifyWrite(ctx, fmt.Sprintf("1:TH:%x", token), rmw)
+	if err != nil {
+		t.Fatalf("error changing holder count: %v", err)
+	}
+
+	err = bt.RebuildTokenHolders(1, false)
+	if err != nil {
+		t.Fatalf("error rebuilding token holders: %v", err)
+	}
+	expectHolders("01:30 ", 1)
+}
+
+func TestGetEth1Logs(t *testing.T) {
+	bt := newEmbeddedTestBigtable(t)
+
+	addressA := bytes.Repeat([]byte{0x0a}, 20)
+	addressB := bytes.Repeat([]byte{0x0b}, 20)
+	topic1 := bytes.Repeat([]byte{0x01}, 32)
+	topic2 := bytes.Repeat([]byte{0x02}, 32)
+	recipient := bytes.Repeat([]byte{0x03}, 32)
+
+	blocks := []*types.Eth1Block{
+		{
+			Number: 10,
+			Hash:   bytes.Repeat([]byte{0x10}, 32),
+			Transactions: []*types.Eth1Transaction{
+				{Hash: bytes.Repeat([]byte{0x11}, 32), Logs: []*types.Eth1Log{
+					{Address: addressA, Topics: [][]byte{topic1}, Index: 0},
+					{Address: addressA, Topics: [][]byte{topic2}, Index: 1},
+				}},
+				{Hash: bytes.Repeat([]byte{0x12}, 32), Logs: []*types.Eth1Log{
+					{Address: addressB, Topics: [][]byte{topic1}, Index: 2},
+				}},
+			},
+		},
+		{
+			Number: 20,
+			Hash:   bytes.Repeat([]byte{0x20}, 32),
+			Transactions: []*types.Eth1Transaction{
+				{Hash: bytes.Repeat([]byte{0x21}, 32), Logs: []*types.Eth1Log{
+					{Address: addressA, Topics: [][]byte{topic1, recipient}, Index: 0},
+				}},
+			},
+		},
+		// a block that has been stored before the log index was exported
+		{
+			Number: 30,
+			Hash:   bytes.Repeat([]byte{0x30}, 32),
+			Transactions: []*types.Eth1Transaction{
+				{Hash: bytes.Repeat([]byte{0x31}, 32), Logs: []*types.Eth1Log{
+					{Address: addressA, Topics: [][]byte{topic1}},
+				}},
+				{Hash: bytes.Repeat([]byte{0x32}, 32), Logs: []*types.Eth1Log{
+					{Address: addressA, Topics: [][]byte{topic1}},
+				}},
+			},
+		},
+	}
+	for _, block := range blocks {
+		block.Time = timestamppb.New(time.Unix(1700000000+int64(block.Number)*12, 0))
+		err := bt.indexBlock(block, []func(blk *types.Eth1Block, cache *freecache.Cache) (*types.BulkMutations, *types.BulkMutations, error){bt.TransformLogs}, freecache.NewCache(1024*1024))
+		if err != nil {
+			t.Fatalf("error indexing block %v: %v", block.Number, err)
+		}
+	}
+
+	formatLogs := func(logs []*types.Eth1LogIndexed) string {
+		s := ""
+		for _, l := range logs {
+			s += fmt.Sprintf("%v:%v ", l.BlockNumber, l.LogIndex)
+		}
+		return s
+	}
+
+	tests := []struct {
+		Name     string
+		Filter   types.Eth1LogFilter
+		Expected string
+	}{
+		{"address", types.Eth1LogFilter{Address: addressA}, "30:1 30:0 20:0 10:1 10:0 "},
+		{"topic0", types.Eth1LogFilter{Topics: [4][]byte{topic1}}, "30:1 30:0 20:0 10:2 10:0 "},
+		{"address and topic0", types.Eth1LogFilter{Address: addressA, Topics: [4][]byte{topic1}}, "30:1 30:0 20:0 10:0 "},
+		{"topic1", types.Eth1LogFilter{Topics: [4][]byte{topic1, recipient}}, "20:0 "},
+		{"block range", types.Eth1LogFilter{Address: addressA, FromBlock: 15, ToBlock: 25}, "20:0 "},
+		{"from block", types.Eth1LogFilter{Address: addressA, FromBlock: 20}, "30:1 30:0 20:0 "},
+		{"to block", types.Eth1LogFilter{Address: addressA, ToBlock: 10}, "10:1 10:0 "},
+		{"unknown address", types.Eth1LogFilter{Address: bytes.Repeat([]byte{0x0c}, 20)}, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			logs, pageToken, err := bt.GetEth1Logs(&test.Filter, "", 10)
+			if err != nil {
+				t.Fatalf("error getting logs: %v", err)
+			}
+			if formatLogs(logs) != test.Expected || pageToken != "" {
+				t.Errorf("expected logs %q, got %q with page token %q", test.Expected, formatLogs(logs), pageToken)
+			}
+		})
+	}
+
+	t.Run("pagination", func(t *testing.T) {
+		filter := &types.Eth1LogFilter{Address: addressA}
+		pages := []string{}
+		pageToken := ""
+		for i := 0; i < 5; i++ {
+			logs, nextPageToken, err := bt.GetEth1Logs(filter, pageToken, 2)
+			if err != nil {
+				t.Fatalf("error getting logs: %v", err)
+			}
+			pages = append(pages, formatLogs(logs))
+			if nextPageToken == "" {
+				break
+			}
+			pageToken = nextPageToken
+		}
+		if fmt.Sprint(pages) != "[30:1 30:0  20:0 10:1  10:0 ]" {
+			t.Errorf("unexpected pages %q", pages)
+		}
+
+		_, _, err := bt.GetEth1Logs(&types.Eth1LogFilter{Address: addressB}, pageToken, 2)
+		if err == nil {
+			t.Errorf("expected an error for a page token of a different filter")
+		}
+	})
+
+	_, _, err := bt.GetEth1Logs(&types.Eth1LogFilter{FromBlock: 10}, "", 10)
+	if err == nil {
+		t.Errorf("expected an error for a filter without address and topic0")
+	}
+}
+
+func TestContractDeployments(t *testing.T) {
+	bt := newEmbeddedTestBigtable(t)
+
+	deployer := bytes.Repeat([]byte{0x01}, 20)
+	factory := bytes.Repeat([]byte{0x0f}, 20)
+	contract := func(b byte) []byte {
+		return bytes.Repeat([]byte{0xc0 + b}, 20)
+	}
+	codeHash := bytes.Repeat([]byte{0xaa}, 32)
+
+	blocks := []*types.Eth1Block{
+		{
+			Number: 10,
+			Hash:   bytes.Repeat([]byte{0x10}, 32),
+			Transactions: []*types.Eth1Transaction{
+				{Hash: bytes.Repeat([]byte{0x11}, 32), From: deployer, Itx: []*types.Eth1InternalTransaction{
+					{Type: "create", From: deployer, To: contract(1), CodeHash: codeHash, CreationMethod: "create"},
+				}},
+				// the contract is created by a factory contract called by the deployer
+				{Hash: bytes.Repeat([]byte{0x12}, 32), From: deployer, To: factory, Itx: []*types.Eth1InternalTransaction{
+					{Type: "call", From: deployer, To: factory},
+					{Type: "create", From: factory, To: contract(2), CodeHash: codeHash, CreationMethod: "create2"},
+				}},
+				// creations of reverted transactions and failed creations are not deployments
+				{Hash: bytes.Repeat([]byte{0x13}, 32), From: deployer, ErrorMsg: "execution reverted", Itx: []*types.Eth1InternalTransaction{
+					{Type: "create", From: deployer, To: contract(3), CodeHash: codeHash},
+				}},
+				{Hash: bytes.Repeat([]byte{0x14}, 32), From: deployer, Itx: []*types.Eth1InternalTransaction{
+					{Type: "create", From: deployer, To: contract(4), ErrorMsg: "out of gas"},
+				}},
+			},
+		},
+		{
+			Number: 20,
+			Hash:   bytes.Repeat([]byte{0x20}, 32),
+			Transactions: []*types.Eth1Transaction{
+				{Hash: bytes.Repeat([]byte{0x21}, 32), From: deployer, Itx: []*types.Eth1InternalTransaction{
+					{Type: "create", From: deployer, To: contract(5), CodeHash: bytes.Repeat([]byte{0xbb}, 32), CreationMethod: "create"},
+				}},
+			},
+		},
+	}
+	for _, block := range blocks {
+		block.Time = timestamppb.New(time.Unix(1700000000+int64(block.Number)*12, 0))
+		err := bt.indexBlock(block, []func(blk *types.Eth1Block, cache *freecache.Cache) (*types.BulkMutations, *types.BulkMutations, error){bt.TransformContract}, freecache.NewCache(1024*1024))
+		if err != nil {
+			t.Fatalf("error indexing block %v: %v", block.Number, err)
+		}
+	}
+
+	deployment, err := bt.GetContractDeployment(contract(1))
+	if err != nil {
+		t.Fatalf("error getting deployment: %v", err)
+	}
+	if deployment == nil || !bytes.Equal(deployment.Deployer, deployer) || len(deployment.Factory) != 0 || !bytes.Equal(deployment.CodeHash, codeHash) || deployment.CreationMethod != "create" || deployment.BlockNumber != 10 {
+		t.Errorf("unexpected deployment of a directly created contract: %v", deployment)
+	}
+
+	deployment, err = bt.GetContractDeployment(contract(2))
+	if err != nil {
+		t.Fatalf("error getting deployment: %v", err)
+	}
+	if deployment == nil || !bytes.Equal(deployment.Deployer, deployer) || !bytes.Equal(deployment.Factory, factory) || deployment.CreationMethod != "create2" || !bytes.Equal(deployment.TxHash, blocks[0].Transactions[1].Hash) {
+		t.Errorf("unexpected deployment of a factory created contract: %v", deployment)
+	}
+
+	for _, address := range [][]byte{contract(3), contract(4)} {
+		deployment, err = bt.GetContractDeployment(address)
+		if err != nil || deployment != nil {
+			t.Errorf("expected no deployment of failed creation %x, got %v %v", address, deployment, err)
+		}
+	}
+
+	formatDeployments := func(deployments []*types.Eth1ContractDeploymentIndexed) string {
+		s := ""
+		for _, d := range deployments {
+			s += fmt.Sprintf("%x ", d.Address[:1])
+		}
+		return s
+	}
+
+	tests := []struct {
+		Name     string
+		Query    func(pageToken string, limit int64) ([]*types.Eth1ContractDeploymentIndexed, string, error)
+		Expected string
+	}{
+		{"deployer", func(pageToken string, limit int64) ([]*types.Eth1ContractDeploymentIndexed, string, error) {
+			return bt.GetContractDeploymentsForAddress(deployer, pageToken, limit)
+		}, "c5 c2 c1 "},
+		{"factory", func(pageToken string, limit int64) ([]*types.Eth1ContractDeploymentIndexed, string, error) {
+			return bt.GetContractDeploymentsForAddress(factory, pageToken, limit)
+		}, "c2 "},
+		{"code hash", func(pageToken string, limit int64) ([]*types.Eth1ContractDeploymentIndexed, string, error) {
+			return bt.GetContractDeploymentsForCodeHash(codeHash, pageToken, limit)
+		}, "c2 c1 "},
+		{"unknown address", func(pageToken string, limit int64) ([]*types.Eth1ContractDeploymentIndexed, string, error) {
+			return bt.GetContractDeploymentsForAddress(contract(1), pageToken, limit)
+		}, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			deployments, pageToken, err := test.Query("", 10)
+			if err != nil {
+				t.Fatalf("error getting deployments: %v", err)
+			}
+			if formatDeployments(deployments) != test.Expected || pageToken != "" {
+				t.Errorf("expected deployments %q, got %q with page token %q", test.Expected, formatDeployments(deployments), pageToken)
+			}
+		})
+	}
+
+	deployments, pageToken, err := bt.GetContractDeploymentsForAddress(deployer, "", 2)
+	if err != nil || formatDeployments(deployments) != "c5 c2 " || pageToken == "" {
+		t.Fatalf("unexpected first page %q with page token %q: %v", formatDeployments(deployments), pageToken, err)
+	}
+	deployments, pageToken, err = bt.GetContractDeploymentsForAddress(deployer, pageToken, 2)
+	if err != nil || formatDeployments(deployments) != "c1 " || pageToken != "" {
+		t.Errorf("unexpected second page %q with page token %q: %v", formatDeployments(deployments), pageToken, err)
+	}
+}
@@ -9,44 +9,69 @@ import (
 	gcp_bigtable "cloud.google.com/go/bigtable"
 )
 
-func InitBigtableSchema() error {
-
-	tables := make(map[string]map[string]gcp_bigtable.GCPolicy)
+// bigtableFamilyGC is the garbage collection policy of a column family, zero values mean no limit
+type bigtableFamilyGC struct {
+	maxVersions int
+	maxAge      time.Duration
+}
 
-	tables["beaconchain_validators"] = map[string]gcp_bigtable.GCPolicy{
-		ATTESTATIONS_FAMILY: gcp_bigtable.MaxVersionsGCPolicy(1),
-	}
-	tables["beaconchain_validators_history"] = map[string]gcp_bigtable.GCPolicy{
-		VALIDATOR_BALANCES_FAMILY:             nil,
-		VALIDATOR_HIGHEST_ACTIVE_INDEX_FAMILY: nil,
-		ATTESTATIONS_FAMILY:                   nil,
-		PROPOSALS_FAMILY:                      nil,
-		SYNC_COMMITTEES_FAMILY:                nil,
-		INCOME_DETAILS_COLUMN_FAMILY:          nil,
-		STATS_COLUMN_FAMILY:                   nil,
+func (gc bigtableFamilyGC) policy() gcp_bigtable.GCPolicy {
+	if gc.maxVersions > 0 {
+		return gcp_bigtable.MaxVersionsGCPolicy(gc.maxVersions)
 	}
-	tables["blocks"] = map[string]gcp_bigtable.GCPolicy{
-		DEFAULT_FAMILY_BLOCKS: gcp_bigtable.MaxVersionsGCPolicy(1),
+	if gc.maxAge > 0 {
+		return gcp_bigtable.MaxAgeGCPolicy(gc.maxAge)
 	}
-	tables["data"] = map[string]gcp_bigtable.GCPolicy{
-		CONTRACT_METADATA_FAMILY: gcp_bigtable.MaxAgeGCPolicy(utils.Day),
-		DEFAULT_FAMILY:           nil,
-	}
-	tables["machine_metrics"] = map[string]gcp_bigtable.GCPolicy{
-		MACHINE_METRICS_COLUMN_FAMILY: gcp_bigtable.MaxAgeGCPolicy(utils.Day * 31),
-	}
-	tables["metadata"] = map[string]gcp_bigtable.GCPolicy{
-		ACCOUNT_METADATA_FAMILY:  nil,
-		CONTRACT_METADATA_FAMILY: nil,
-		ERC20_METADATA_FAMILY:    nil,
-		ERC721_METADATA_FAMILY:   nil,
-		ERC1155_METADATA_FAMILY:  nil,
-		SERIES_FAMILY:            gcp_bigtable.MaxVersionsGCPolicy(1),
-	}
-	tables["metadata_updates"] = map[string]gcp_bigtable.GCPolicy{
-		METADATA_UPDATES_FAMILY_BLOCKS: gcp_bigtable.MaxAgeGCPolicy(utils.Day),
-		DEFAULT_FAMILY:                 nil,
+	return nil
+}
+
+// bigtableSchema contains the column families and their gc policies of all tables
+var bigtableSchema = map[string]map[string]bigtableFamilyGC{
+	"beaconchain_validators": {
+		ATTESTATIONS_FAMILY: {maxVersions: 1},
+	},
+	"beaconchain_validators_history": {
+		VALIDATOR_BALANCES_FAMILY:             {},
+		VALIDATOR_HIGHEST_ACTIVE_INDEX_FAMILY: {},
+		ATTESTATIONS_FAMILY:                   {},
+		ATTESTATION_FLAGS_FAMILY:              {},
+		PROPOSALS_FAMILY:                      {},
+		SYNC_COMMITTEES_FAMILY:                {},
+		INCOME_DETAILS_COLUMN_FAMILY:          {},
+		STATS_COLUMN_FAMILY:                   {},
+	},
+	"blocks": {
+		DEFAULT_FAMILY_BLOCKS: {maxVersions: 1},
+	},
+	"data": {
+		CONTRACT_METADATA_FAMILY: {maxAge: utils.Day},
+		DEFAULT_FAMILY:           {},
+	},
+	"machine_metrics": {
+		MACHINE_METRICS_COLUMN_FAMILY: {maxAge: utils.Day * 31},
+	},
+	"metadata": {
+		ACCOUNT_METADATA_FAMILY:  {},
+		CONTRACT_METADATA_FAMILY: {},
+		ERC20_METADATA_FAMILY:    {},
+		ERC721_METADATA_FAMILY:   {},
+		ERC1155_METADATA_FAMILY:  {},
+		SERIES_FAMILY:            {maxVersions: 1},
+	},
+	"metadata_updates": {
+		METADATA_UPDATES_FAMILY_BLOCKS: {maxAge: utils.Day},
+		DEFAULT_FAMILY:                 {},
+	},
+}
+
+func InitBigtableSchema() error {
+
+	if utils.Config.Bigtable.Embedded {
+		// the embedded store does not need to create tables and column families, gc policies are taken from the schema directly
+		logger.Infof("skipping bigtable schema init for embedded bigtable at %v", utils.Config.Bigtable.EmbeddedPath)
+		return nil
 	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
 	defer cancel()
 
@@ -64,19 +89,19 @@ func InitBigtableSchema() error {
 		return fmt.Errorf("aborting bigtable schema init as tables are already present")
 	}
 
-	for name, definition := range tables {
+	for name, definition := range bigtableSchema {
 		err := admin.CreateTable(ctx, name)
 		if err != nil {
 			return err
 		}
 
-		for columnFamily, gcPolicy := range definition {
+		for columnFamily, gc := range definition {
 			err := admin.CreateColumnFamily(ctx, name, columnFamily)
 			if err != nil {
 				return err
 			}
 
-			if gcPolicy != nil {
+			if gcPolicy := gc.policy(); gcPolicy != nil {
 				err := admin.SetGCPolicy(ctx, name, columnFamily, gcPolicy)
 				if err != nil {
 					return err
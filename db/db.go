@@ -495,15 +495,16 @@ func SetSlotFinalizationAndStatus(slot uint64, finalized bool, status string, tx
 }
 
 type GetAllNonFinalizedSlotsRow struct {
-	Slot      uint64 `db:"slot"`
-	BlockRoot []byte `db:"blockroot"`
-	Finalized bool   `db:"finalized"`
-	Status    string `db:"status"`
+	Slot         uint64         `db:"slot"`
+	BlockRoot    []byte         `db:"blockroot"`
+	Finalized    bool           `db:"finalized"`
+	Status       string         `db:"status"`
+	MissedReason sql.NullString `db:"missed_reason"`
 }
 
 func GetAllNonFinalizedSlots() ([]*GetAllNonFinalizedSlotsRow, error) {
 	var slots []*GetAllNonFinalizedSlotsRow
-	err := WriterDb.Select(&slots, "SELECT slot, blockroot, finalized, status FROM blocks WHERE NOT finalized ORDER BY slot")
+	err := WriterDb.Select(&slots, "SELECT slot, blockroot, finalized, status, missed_reason FROM blocks WHERE NOT finalized ORDER BY slot")
 
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving all non finalized slots from the DB: %w", err)
@@ -512,6 +513,125 @@ func GetAllNonFinalizedSlots() ([]*GetAllNonFinalizedSlotsRow, error) {
 	return slots, nil
 }
 
+// GetCanonicalSlotsInRange returns all slots between startSlot and endSlot (inclusive) that are currently marked as proposed
+func GetCanonicalSlotsInRange(startSlot, endSlot uint64, tx *sqlx.Tx) ([]*GetAllNonFinalizedSlotsRow, error) {
+	var slots []*GetAllNonFinalizedSlotsRow
+	err := tx.Select(&slots, "SELECT slot, blockroot, finalized, status FROM blocks WHERE slot >= $1 AND slot <= $2 AND status = '1' ORDER BY slot", startSlot, endSlot)
+
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving canonical slots between %v and %v from the DB: %w", startSlot, endSlot, err)
+	}
+
+	return slots, nil
+}
+
+// SetBlockOrphaned marks a single block of a slot as orphaned, other blocks of the same slot are left untouched
+func SetBlockOrphaned(slot uint64, blockRoot []byte, tx *sqlx.Tx) error {
+	_, err := tx.Exec("UPDATE blocks SET status = '3' WHERE slot = $1 AND blockroot = $2", slot, blockRoot)
+
+	if err != nil {
+		return fmt.Errorf("error setting block 0x%x at slot %v as orphaned: %w", blockRoot, slot, err)
+	}
+
+	return nil
+}
+
+// SetBlockMissedReason stores the root cause of a missed or orphaned block
+func SetBlockMissedReason(slot uint64, blockRoot []byte, reason types.MissedSlotReason, tx *sqlx.Tx) error {
+	_, err := tx.Exec("UPDATE blocks SET missed_reason = $1 WHERE slot = $2 AND blockroot = $3", reason, slot, blockRoot)
+
+	if err != nil {
+		return fmt.Errorf("error setting missed reason of block 0x%x at slot %v: %w", blockRoot, slot, err)
+	}
+
+	return nil
+}
+
+// SaveChainReorg stores a chain reorg announced by the beacon node
+func SaveChainReorg(reorg *types.ChainReorg, tx *sqlx.Tx) error {
+	_, err := tx.Exec(`
+		INSERT INTO chain_reorgs (slot, depth, old_head_block, new_head_block, old_head_state, new_head_state, epoch, ts)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (slot, old_head_block, new_head_block) DO NOTHING`,
+		reorg.Slot, reorg.Depth, reorg.OldHeadBlock, reorg.NewHeadBlock, reorg.OldHeadState, reorg.NewHeadState, reorg.Epoch, reorg.Ts)
+
+	if err != nil {
+		return fmt.Errorf("error saving chain reorg at slot %v: %w", reorg.Slot, err)
+	}
+
+	return nil
+}
+
+// SaveEth1ChainReorg records a reorg of the execution chain, BlockNumber is the first block that has been reverted
+func SaveEth1ChainReorg(reorg *types.Eth1ChainReorg) error {
+	_, err := WriterDb.Exec(`
+		INSERT INTO eth1_chain_reorgs (block_number, depth, old_head_hash, new_head_hash, ts)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (block_number, old_head_hash, new_head_hash) DO NOTHING`,
+		reorg.BlockNumber, reorg.Depth, reorg.OldHeadHash, reorg.NewHeadHash, reorg.Ts)
+
+	if err != nil {
+		return fmt.Errorf("error saving eth1 chain reorg at block %v: %w", reorg.BlockNumber, err)
+	}
+
+	return nil
+}
+
+// GetChainReorgsForSlot returns all chain reorgs that affected the given slot
+func GetChainReorgsForSlot(slot uint64) ([]*types.ChainReorg, error) {
+	var reorgs []*types.ChainReorg
+	// the reorg of a slot can only be announced after the slot itself, limit the lookup to the maximum reorg depth of two epochs
+	err := ReaderDb.Select(&reorgs, `
+		SELECT slot, depth, old_head_block, new_head_block, old_head_state, new_head_state, epoch, ts
+		FROM chain_reorgs
+		WHERE slot >= $1 AND slot <= $2 AND slot - depth < $1
+		ORDER BY slot DESC, ts DESC`, slot, slot+utils.Config.Chain.ClConfig.SlotsPerEpoch*2)
+
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving chain reorgs for slot %v: %w", slot, err)
+	}
+
+	return reorgs, nil
+}
+
+// SaveLightClientSyncAggregate stores the sync aggregate of a light client update, finality and optimistic updates of the same signature slot are merged
+func SaveLightClientSyncAggregate(agg *types.LightClientSyncAggregate) error {
+	_, err := WriterDb.Exec(`
+		INSERT INTO sync_aggregates (slot, period, attested_slot, attested_block_root, finalized_slot, sync_committee_bits, sync_committee_signature, participation, ts)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (slot) DO UPDATE SET
+			period = excluded.period,
+			attested_slot = excluded.attested_slot,
+			attested_block_root = excluded.attested_block_root,
+			finalized_slot = COALESCE(excluded.finalized_slot, sync_aggregates.finalized_slot),
+			sync_committee_bits = excluded.sync_committee_bits,
+			sync_committee_signature = excluded.sync_committee_signature,
+			participation = excluded.participation`,
+		agg.Slot, agg.Period, agg.AttestedSlot, agg.AttestedBlockRoot, agg.FinalizedSlot, agg.SyncCommitteeBits, agg.SyncCommitteeSignature, agg.Participation, agg.Ts)
+
+	if err != nil {
+		return fmt.Errorf("error saving sync aggregate of slot %v: %w", agg.Slot, err)
+	}
+
+	return nil
+}
+
+// GetLightClientSyncAggregatesForPeriod returns all sync aggregates of a sync committee period ordered by slot
+func GetLightClientSyncAggregatesForPeriod(period uint64) ([]*types.LightClientSyncAggregate, error) {
+	var aggs []*types.LightClientSyncAggregate
+	err := ReaderDb.Select(&aggs, `
+		SELECT slot, period, attested_slot, attested_block_root, finalized_slot, sync_committee_bits, sync_committee_signature, participation, ts
+		FROM sync_aggregates
+		WHERE period = $1
+		ORDER BY slot`, period)
+
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving sync aggregates for period %v: %w", period, err)
+	}
+
+	return aggs, nil
+}
+
 // Get latest finalized epoch
 func GetLatestFinalizedEpoch() (uint64, error) {
 	var latestFinalized uint64
@@ -678,6 +798,26 @@ func SaveValidatorQueue(validators *types.ValidatorQueue, tx *sqlx.Tx) error {
 	return err
 }
 
+// GetLatestValidatorQueue returns the most recent hourly snapshot of the validator queue, nil is returned if no snapshot exists yet
+func GetLatestValidatorQueue() (*types.ValidatorQueue, error) {
+	var queues []struct {
+		Activating uint64 `db:"entering_validators_count"`
+		Exiting    uint64 `db:"exiting_validators_count"`
+	}
+	err := ReaderDb.Select(&queues, `
+		SELECT entering_validators_count, exiting_validators_count
+		FROM queue
+		ORDER BY ts DESC
+		LIMIT 1`)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving validator queue: %w", err)
+	}
+	if len(queues) == 0 {
+		return nil, nil
+	}
+	return &types.ValidatorQueue{Activating: queues[0].Activating, Exiting: queues[0].Exiting}, nil
+}
+
 func SaveBlock(block *types.Block, forceSlotUpdate bool, tx *sqlx.Tx) error {
 
 	blocksMap := make(map[uint64]map[string]*types.Block)
@@ -1240,6 +1380,33 @@ func saveBlocks(blocks map[uint64]map[string]*types.Block, tx *sqlx.Tx, forceSlo
 	}
 	defer stmtBLSChange.Close()
 
+	stmtDepositRequests, err := tx.Prepare(`
+		INSERT INTO blocks_deposit_requests (block_slot, block_root, request_index, pubkey, withdrawal_credentials, amount, signature, deposit_index)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (block_slot, block_root, request_index) DO NOTHING`)
+	if err != nil {
+		return err
+	}
+	defer stmtDepositRequests.Close()
+
+	stmtWithdrawalRequests, err := tx.Prepare(`
+		INSERT INTO blocks_withdrawal_requests (block_slot, block_root, request_index, source_address, validator_pubkey, amount)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (block_slot, block_root, request_index) DO NOTHING`)
+	if err != nil {
+		return err
+	}
+	defer stmtWithdrawalRequests.Close()
+
+	stmtConsolidationRequests, err := tx.Prepare(`
+		INSERT INTO blocks_consolidation_requests (block_slot, block_root, request_index, source_address, source_pubkey, target_pubkey)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (block_slot, block_root, request_index) DO NOTHING`)
+	if err != nil {
+		return err
+	}
+	defer stmtConsolidationRequests.Close()
+
 	stmtProposerSlashing, err := tx.Prepare(`
 		INSERT INTO blocks_proposerslashings (block_slot, block_index, block_root, proposerindex, header1_slot, header1_parentroot, header1_stateroot, header1_bodyroot, header1_signature, header2_slot, header2_parentroot, header2_stateroot, header2_bodyroot, header2_signature)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
@@ -1481,6 +1648,28 @@ func saveBlocks(blocks map[uint64]map[string]*types.Block, tx *sqlx.Tx, forceSlo
 			blockLog.WithField("duration", time.Since(t)).Tracef("stmtBLSChange")
 			t = time.Now()
 
+			logger.Tracef("writing execution layer requests data")
+			for i, d := range b.DepositRequests {
+				_, err := stmtDepositRequests.Exec(b.Slot, b.BlockRoot, i, d.Pubkey, d.WithdrawalCredentials, d.Amount, d.Signature, d.Index)
+				if err != nil {
+					return fmt.Errorf("error executing stmtDepositRequests for block %v index %v: %w", b.Slot, i, err)
+				}
+			}
+			for i, w := range b.WithdrawalRequests {
+				_, err := stmtWithdrawalRequests.Exec(b.Slot, b.BlockRoot, i, w.SourceAddress, w.ValidatorPubkey, w.Amount)
+				if err != nil {
+					return fmt.Errorf("error executing stmtWithdrawalRequests for block %v index %v: %w", b.Slot, i, err)
+				}
+			}
+			for i, c := range b.ConsolidationRequests {
+				_, err := stmtConsolidationRequests.Exec(b.Slot, b.BlockRoot, i, c.SourceAddress, c.SourcePubkey, c.TargetPubkey)
+				if err != nil {
+					return fmt.Errorf("error executing stmtConsolidationRequests for block %v index %v: %w", b.Slot, i, err)
+				}
+			}
+			blockLog.WithField("duration", time.Since(t)).Tracef("execution layer requests")
+			t = time.Now()
+
 			for i, as := range b.AttesterSlashings {
 				_, err := stmtAttesterSlashing.Exec(b.Slot, i, b.BlockRoot, pq.Array(as.Attestation1.AttestingIndices), as.Attestation1.Signature, as.Attestation1.Data.Slot, as.Attestation1.Data.CommitteeIndex, as.Attestation1.Data.BeaconBlockRoot, as.Attestation1.Data.Source.Epoch, as.Attestation1.Data.Source.Root, as.Attestation1.Data.Target.Epoch, as.Attestation1.Data.Target.Root, pq.Array(as.Attestation2.AttestingIndices), as.Attestation2.Signature, as.Attestation2.Data.Slot, as.Attestation2.Data.CommitteeIndex, as.Attestation2.Data.BeaconBlockRoot, as.Attestation2.Data.Source.Epoch, as.Attestation2.Data.Source.Root, as.Attestation2.Data.Target.Epoch, as.Attestation2.Data.Target.Root)
 				if err != nil {
@@ -2310,6 +2499,27 @@ func GetEpochWithdrawals(epoch uint64) ([]*types.WithdrawalsNotification, error)
 	return withdrawals, nil
 }
 
+// GetEpochDeposits returns the valid deposits that have been included by canonical blocks of the epoch
+func GetEpochDeposits(epoch uint64) ([]*types.DepositsNotification, error) {
+	var deposits []*types.DepositsNotification
+
+	err := ReaderDb.Select(&deposits, `
+	SELECT 
+		d.block_slot as slot, 
+		v.validatorindex, 
+		d.amount,
+		d.publickey as pubkey
+	FROM blocks_deposits d
+	INNER JOIN blocks b ON b.blockroot = d.block_root AND b.status = '1'
+	INNER JOIN validators v on v.pubkey = d.publickey
+	WHERE d.block_slot >= $1 AND d.block_slot < $2 AND d.valid_signature ORDER BY d.block_slot, d.block_index`, epoch*utils.Config.Chain.ClConfig.SlotsPerEpoch, (epoch+1)*utils.Config.Chain.ClConfig.SlotsPerEpoch)
+	if err != nil {
+		return nil, fmt.Errorf("error getting blocks_deposits for epoch: %d: %w", epoch, err)
+	}
+
+	return deposits, nil
+}
+
 func GetValidatorWithdrawals(validator uint64, limit uint64, offset uint64, orderBy string, orderDir string) ([]*types.Withdrawals, error) {
 	var withdrawals []*types.Withdrawals
 	if limit == 0 {
@@ -2931,6 +3141,103 @@ func GetValidatorsBLSChange(validators []uint64) ([]*types.ValidatorsBLSChange,
 	return change, nil
 }
 
+// GetSlotDepositRequests returns the execution layer deposit requests of the canonical block of a slot
+func GetSlotDepositRequests(slot uint64) ([]*types.BlockPageDepositRequest, error) {
+	requests := []*types.BlockPageDepositRequest{}
+
+	err := ReaderDb.Select(&requests, `
+	SELECT
+		r.block_slot,
+		r.block_root,
+		r.request_index,
+		r.pubkey,
+		v.validatorindex,
+		r.withdrawal_credentials,
+		r.amount,
+		r.signature,
+		r.deposit_index
+	FROM blocks_deposit_requests r
+	INNER JOIN blocks b ON b.blockroot = r.block_root AND b.status = '1'
+	LEFT JOIN validators v ON v.pubkey = r.pubkey
+	WHERE r.block_slot = $1
+	ORDER BY r.request_index`, slot)
+	if err != nil {
+		return nil, fmt.Errorf("error getting slot blocks_deposit_requests: %w", err)
+	}
+
+	return requests, nil
+}
+
+// GetSlotWithdrawalRequests returns the execution layer withdrawal requests of the canonical block of a slot
+func GetSlotWithdrawalRequests(slot uint64) ([]*types.BlockPageWithdrawalRequest, error) {
+	return getWithdrawalRequests("r.block_slot = $1", slot)
+}
+
+// GetValidatorsWithdrawalRequests returns the execution layer withdrawal requests of a list of validators
+func GetValidatorsWithdrawalRequests(pubkeys [][]byte) ([]*types.BlockPageWithdrawalRequest, error) {
+	return getWithdrawalRequests("r.validator_pubkey = ANY($1)", pq.ByteaArray(pubkeys))
+}
+
+func getWithdrawalRequests(condition string, arg interface{}) ([]*types.BlockPageWithdrawalRequest, error) {
+	requests := []*types.BlockPageWithdrawalRequest{}
+
+	err := ReaderDb.Select(&requests, fmt.Sprintf(`
+	SELECT
+		r.block_slot,
+		r.block_root,
+		r.request_index,
+		r.source_address,
+		r.validator_pubkey,
+		v.validatorindex,
+		r.amount
+	FROM blocks_withdrawal_requests r
+	INNER JOIN blocks b ON b.blockroot = r.block_root AND b.status = '1'
+	LEFT JOIN validators v ON v.pubkey = r.validator_pubkey
+	WHERE %s
+	ORDER BY r.block_slot DESC, r.request_index`, condition), arg)
+	if err != nil {
+		return nil, fmt.Errorf("error getting blocks_withdrawal_requests: %w", err)
+	}
+
+	return requests, nil
+}
+
+// GetSlotConsolidationRequests returns the execution layer consolidation requests of the canonical block of a slot
+func GetSlotConsolidationRequests(slot uint64) ([]*types.BlockPageConsolidationRequest, error) {
+	return getConsolidationRequests("r.block_slot = $1", slot)
+}
+
+// GetValidatorsConsolidationRequests returns the execution layer consolidation requests in which one of the validators is either source or target
+func GetValidatorsConsolidationRequests(pubkeys [][]byte) ([]*types.BlockPageConsolidationRequest, error) {
+	return getConsolidationRequests("(r.source_pubkey = ANY($1) OR r.target_pubkey = ANY($1))", pq.ByteaArray(pubkeys))
+}
+
+func getConsolidationRequests(condition string, arg interface{}) ([]*types.BlockPageConsolidationRequest, error) {
+	requests := []*types.BlockPageConsolidationRequest{}
+
+	err := ReaderDb.Select(&requests, fmt.Sprintf(`
+	SELECT
+		r.block_slot,
+		r.block_root,
+		r.request_index,
+		r.source_address,
+		r.source_pubkey,
+		vs.validatorindex AS source_index,
+		r.target_pubkey,
+		vt.validatorindex AS target_index
+	FROM blocks_consolidation_requests r
+	INNER JOIN blocks b ON b.blockroot = r.block_root AND b.status = '1'
+	LEFT JOIN validators vs ON vs.pubkey = r.source_pubkey
+	LEFT JOIN validators vt ON vt.pubkey = r.target_pubkey
+	WHERE %s
+	ORDER BY r.block_slot DESC, r.request_index`, condition), arg)
+	if err != nil {
+		return nil, fmt.Errorf("error getting blocks_consolidation_requests: %w", err)
+	}
+
+	return requests, nil
+}
+
 func GetWithdrawableValidatorCount(epoch uint64) (uint64, error) {
 	var count uint64
 	err := ReaderDb.Get(&count, `
@@ -3056,6 +3363,32 @@ func GetValidatorWithdrawalsForSlots(validators []uint64, fromSlot uint64, toSlo
 	`, validatorsPQArray, fromSlot, toSlot)
 }
 
+// GetWithdrawalsByValidatorForSlots returns the sum of the withdrawals of each of the validators in the canonical blocks of the slot range
+func GetWithdrawalsByValidatorForSlots(validators []uint64, fromSlot uint64, toSlot uint64) (map[uint64]uint64, error) {
+	rows := []struct {
+		ValidatorIndex uint64 `db:"validatorindex"`
+		Amount         uint64 `db:"amount"`
+	}{}
+	err := ReaderDb.Select(&rows, `
+		SELECT 
+			d.validatorindex,
+			COALESCE(SUM(d.amount), 0) AS amount
+		FROM blocks_withdrawals d
+		INNER JOIN blocks b ON b.blockroot = d.block_root AND b.status = '1' and b.slot >= $2 and b.slot <= $3
+		WHERE validatorindex = ANY($1)
+		GROUP BY d.validatorindex
+	`, pq.Array(validators), fromSlot, toSlot)
+	if err != nil {
+		return nil, fmt.Errorf("error getting blocks_withdrawals for validators from slot %v to %v: %w", fromSlot, toSlot, err)
+	}
+
+	withdrawals := make(map[uint64]uint64, len(rows))
+	for _, row := range rows {
+		withdrawals[row.ValidatorIndex] = row.Amount
+	}
+	return withdrawals, nil
+}
+
 func GetValidatorBalanceForDay(validators []uint64, day uint64, balance *uint64) error {
 	validatorsPQArray := pq.Array(validators)
 	return ReaderDb.Get(balance, `
@@ -3259,3 +3592,64 @@ func GetValidatorAttestationHistoryForNotifications(startEpoch uint64, endEpoch
 
 	return epochParticipation, nil
 }
+
+// GetSlotRelayBids returns the highest bid every relay received for the slot, ordered by value
+func GetSlotRelayBids(slot uint64) ([]*types.RelayBid, error) {
+	bids := []*types.RelayBid{}
+	err := ReaderDb.Select(&bids, `
+		SELECT block_slot, tag_id, exec_block_hash, builder_pubkey, value, bids_count
+		FROM relays_bids
+		WHERE block_slot = $1
+		ORDER BY value DESC`, slot)
+	if err != nil {
+		return nil, fmt.Errorf("error getting relays_bids of slot %v: %w", slot, err)
+	}
+	return bids, nil
+}
+
+// GetSlotProposerBlockValue returns the delivered payload value and the best relay bid of the canonical block of a slot
+func GetSlotProposerBlockValue(slot uint64) (*types.ProposerBlockValue, error) {
+	values, err := getProposerBlockValues("b.slot = $1", 1, slot)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+	return values[0], nil
+}
+
+// GetValidatorsProposerBlockValues returns the delivered payload values and the best relay bids of the latest canonical blocks proposed by the validators
+func GetValidatorsProposerBlockValues(validators []uint64, limit uint64) ([]*types.ProposerBlockValue, error) {
+	return getProposerBlockValues("b.proposer = ANY($1)", limit, pq.Array(validators))
+}
+
+func getProposerBlockValues(condition string, limit uint64, arg interface{}) ([]*types.ProposerBlockValue, error) {
+	values := []*types.ProposerBlockValue{}
+
+	err := ReaderDb.Select(&values, fmt.Sprintf(`
+	SELECT
+		b.slot,
+		b.proposer,
+		COALESCE(b.exec_block_number, 0) AS exec_block_number,
+		b.exec_block_hash,
+		rb.tag_id AS relay_tag,
+		rb.value AS delivered_value,
+		bid.tag_id AS best_bid_relay_tag,
+		bid.value AS best_bid_value
+	FROM blocks b
+	LEFT JOIN LATERAL (
+		SELECT tag_id, value FROM relays_blocks WHERE relays_blocks.exec_block_hash = b.exec_block_hash ORDER BY value DESC LIMIT 1
+	) rb ON TRUE
+	LEFT JOIN LATERAL (
+		SELECT tag_id, value FROM relays_bids WHERE relays_bids.block_slot = b.slot ORDER BY value DESC LIMIT 1
+	) bid ON TRUE
+	WHERE %s AND b.status = '1' AND b.exec_block_hash IS NOT NULL
+	ORDER BY b.slot DESC
+	LIMIT %d`, condition, limit), arg)
+	if err != nil {
+		return nil, fmt.Errorf("error getting proposer block values: %w", err)
+	}
+
+	return values, nil
+}
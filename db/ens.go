@@ -303,6 +303,10 @@ func (bigtable *Bigtable) TransformEnsNameRegistered(blk *types.Eth1Block, cache
 
 		bulkData.Keys = append(bulkData.Keys, key)
 		bulkData.Muts = append(bulkData.Muts, mut)
+
+		if strings.Contains(key, ":ENS:V:") {
+			journalMark(bulkData, JOURNAL_TABLE_DATA, key, DEFAULT_FAMILY, key)
+		}
 	}
 
 	return bulkData, bulkMetadataUpdates, nil
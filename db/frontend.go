@@ -9,6 +9,7 @@ import (
 	"eth2-exporter/types"
 	"eth2-exporter/utils"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -236,6 +237,370 @@ func SetUserMonitorSharingSetting(userID uint64, share bool) error {
 	return err
 }
 
+// GetUserTelegram returns the linked telegram chat of a user, nil is returned if the user has never started linking a chat
+func GetUserTelegram(userID uint64) (*types.UserTelegram, error) {
+	telegram := &types.UserTelegram{}
+	err := FrontendWriterDB.Get(telegram, "SELECT user_id, chat_id, username, linked_ts FROM users_telegram WHERE user_id = $1", userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return telegram, nil
+}
+
+// CreateUserTelegramLinkToken creates the token that is sent to the telegram bot to link a chat to the account of the user
+func CreateUserTelegramLinkToken(userID uint64) (string, error) {
+	token := utils.RandomString(32)
+	_, err := FrontendWriterDB.Exec(`
+		INSERT INTO users_telegram (user_id, link_token, link_token_ts) VALUES ($1, $2, NOW())
+		ON CONFLICT (user_id) DO UPDATE SET link_token = EXCLUDED.link_token, link_token_ts = EXCLUDED.link_token_ts`,
+		userID, token)
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// LinkUserTelegram links the chat to the user that created the token, link tokens are valid for one hour and can only be used once.
+// sql.ErrNoRows is returned if the token is unknown or expired
+func LinkUserTelegram(token string, chatID int64, username string) (uint64, error) {
+	var userID uint64
+	err := FrontendWriterDB.Get(&userID, `
+		UPDATE users_telegram SET chat_id = $2, username = NULLIF($3, ''), linked_ts = NOW(), link_token = NULL, link_token_ts = NULL
+		WHERE link_token = $1 AND link_token_ts > NOW() - INTERVAL '1 hour'
+		RETURNING user_id`,
+		token, chatID, username)
+	return userID, err
+}
+
+// UnlinkUserTelegram removes the linked telegram chat of a user
+func UnlinkUserTelegram(userID uint64) error {
+	_, err := FrontendWriterDB.Exec("DELETE FROM users_telegram WHERE user_id = $1", userID)
+	return err
+}
+
+// UnlinkTelegramChat removes the telegram chat from all users it is linked to
+func UnlinkTelegramChat(chatID int64) error {
+	_, err := FrontendWriterDB.Exec("DELETE FROM users_telegram WHERE chat_id = $1", chatID)
+	return err
+}
+
+// GetTelegramUpdateOffset returns the id of the next update that has to be requested for the bot, 0 is returned if no update has been processed yet
+func GetTelegramUpdateOffset(botID int64) (int64, error) {
+	var offset int64
+	err := FrontendWriterDB.Get(&offset, "SELECT update_offset FROM telegram_bot_updates WHERE bot_id = $1", botID)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return offset, err
+}
+
+func SetTelegramUpdateOffset(botID int64, offset int64) error {
+	_, err := FrontendWriterDB.Exec(`
+		INSERT INTO telegram_bot_updates (bot_id, update_offset, updated_ts) VALUES ($1, $2, NOW())
+		ON CONFLICT (bot_id) DO UPDATE SET update_offset = EXCLUDED.update_offset, updated_ts = EXCLUDED.updated_ts`,
+		botID, offset)
+	return err
+}
+
+// GetWebhookSecret returns the secret the payloads of the webhook are signed with
+func GetWebhookSecret(webhookID uint64) (string, error) {
+	var secret string
+	err := FrontendWriterDB.Get(&secret, "SELECT secret FROM users_webhooks WHERE id = $1", webhookID)
+	return secret, err
+}
+
+// RotateWebhookSecret replaces the secret of a webhook, payloads that are already queued are signed with the new secret
+func RotateWebhookSecret(userID, webhookID uint64) error {
+	secret, err := GenerateWebhookSecret()
+	if err != nil {
+		return err
+	}
+	_, err = FrontendWriterDB.Exec("UPDATE users_webhooks SET secret = $3 WHERE user_id = $1 AND id = $2", userID, webhookID, secret)
+	return err
+}
+
+// GenerateWebhookSecret returns a new random secret to sign the payloads of a webhook with
+func GenerateWebhookSecret() (string, error) {
+	b, err := utils.GenerateRandomBytesSecure(32)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// SaveWebhookDelivery records a delivery attempt of a webhook, attempts of deleted webhooks are not recorded
+func SaveWebhookDelivery(delivery *types.UserWebhookDelivery) error {
+	_, err := FrontendWriterDB.Exec(`
+		INSERT INTO users_webhooks_deliveries (webhook_id, user_id, event_name, payload, attempt, redelivery, status_code, latency_ms, response, error, ts)
+		SELECT id, user_id, $2, $3, $4, $5, $6, $7, $8, $9, NOW() FROM users_webhooks WHERE id = $1`,
+		delivery.WebhookID, delivery.EventName, delivery.Payload, delivery.Attempt, delivery.Redelivery, delivery.StatusCode, delivery.LatencyMs, delivery.Response, delivery.Error)
+	return err
+}
+
+// GetWebhookDeliveries returns the latest delivery attempts of a webhook of the user
+func GetWebhookDeliveries(userID, webhookID uint64, limit uint64) ([]types.UserWebhookDelivery, error) {
+	deliveries := []types.UserWebhookDelivery{}
+	err := FrontendWriterDB.Select(&deliveries, `
+		SELECT id, webhook_id, user_id, event_name, payload, attempt, redelivery, status_code, latency_ms, response, error, ts
+		FROM users_webhooks_deliveries
+		WHERE user_id = $1 AND webhook_id = $2
+		ORDER BY ts DESC
+		LIMIT $3`,
+		userID, webhookID, limit)
+	return deliveries, err
+}
+
+// QueueWebhookRedelivery queues the content of a recorded delivery again, it is sent to the current url of the webhook of the user.
+// sql.ErrNoRows is returned if the webhook does not exist anymore.
+func QueueWebhookRedelivery(userID, webhookID uint64, content *types.TransitWebhookContent) error {
+	err := FrontendWriterDB.Get(&content.Webhook, `SELECT id, user_id, url, retries, event_names, destination FROM users_webhooks WHERE user_id = $1 AND id = $2`, userID, webhookID)
+	if err != nil {
+		return err
+	}
+	content.Redelivery = true
+
+	_, err = FrontendWriterDB.Exec(`INSERT INTO notification_queue (created, channel, content) VALUES (now(), $1, $2);`, types.WebhookNotificationChannel, content)
+	return err
+}
+
+// GetWebhookDelivery returns a delivery attempt of a webhook of the user
+func GetWebhookDelivery(userID, webhookID, deliveryID uint64) (*types.UserWebhookDelivery, error) {
+	delivery := &types.UserWebhookDelivery{}
+	err := FrontendWriterDB.Get(delivery, `
+		SELECT id, webhook_id, user_id, event_name, payload, attempt, redelivery, status_code, latency_ms, response, error, ts
+		FROM users_webhooks_deliveries
+		WHERE user_id = $1 AND webhook_id = $2 AND id = $3`,
+		userID, webhookID, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// SaveContractAbiSubmission stores an abi uploaded by a user that has to be reviewed by an admin before it is used
+func SaveContractAbiSubmission(userID uint64, address []byte, name, abi string) error {
+	_, err := FrontendWriterDB.Exec(`INSERT INTO contract_abi_submissions (user_id, address, name, abi) VALUES ($1, $2, $3, $4)`, userID, address, name, abi)
+	return err
+}
+
+// GetPendingContractAbiSubmissions returns the abi submissions that have not been reviewed yet, oldest first
+func GetPendingContractAbiSubmissions() ([]types.ContractAbiSubmission, error) {
+	submissions := []types.ContractAbiSubmission{}
+	err := FrontendWriterDB.Select(&submissions, `
+		SELECT id, user_id, address, name, abi, status, created_ts, reviewed_ts
+		FROM contract_abi_submissions
+		WHERE status = $1
+		ORDER BY created_ts`,
+		types.ContractAbiSubmissionPending)
+	return submissions, err
+}
+
+// GetContractAbiSubmission returns an abi submission
+func GetContractAbiSubmission(id uint64) (*types.ContractAbiSubmission, error) {
+	submission := &types.ContractAbiSubmission{}
+	err := FrontendWriterDB.Get(submission, `
+		SELECT id, user_id, address, name, abi, status, created_ts, reviewed_ts
+		FROM contract_abi_submissions
+		WHERE id = $1`,
+		id)
+	if err != nil {
+		return nil, err
+	}
+	return submission, nil
+}
+
+// SetContractAbiSubmissionStatus marks an abi submission as reviewed
+func SetContractAbiSubmissionStatus(id uint64, status string) error {
+	_, err := FrontendWriterDB.Exec(`UPDATE contract_abi_submissions SET status = $2, reviewed_ts = NOW() WHERE id = $1`, id, status)
+	return err
+}
+
+const alertRuleColumns = `
+	r.id, r.user_id, r.name, r.validators, r.metric, r.operator, r.threshold, r.window_epochs, r.created_ts, r.updated_ts,
+	EXISTS (SELECT 1 FROM users_subscriptions us WHERE us.user_id = r.user_id AND us.event_name = r.network || ':' || $1 AND us.event_filter = r.id::TEXT) AS active`
+
+// GetAlertRules returns the alert rules of a user on the network
+func GetAlertRules(userID uint64, network string) ([]types.AlertRule, error) {
+	rules := []types.AlertRule{}
+	err := FrontendWriterDB.Select(&rules, `
+		SELECT `+alertRuleColumns+`
+		FROM users_alert_rules r
+		WHERE r.user_id = $2 AND r.network = $3
+		ORDER BY r.id`,
+		types.ValidatorAlertRuleEventName, userID, strings.ToLower(network))
+	return rules, err
+}
+
+// GetAlertRule returns an alert rule of a user on the network
+func GetAlertRule(userID, ruleID uint64, network string) (*types.AlertRule, error) {
+	rule := &types.AlertRule{}
+	err := FrontendWriterDB.Get(rule, `
+		SELECT `+alertRuleColumns+`
+		FROM users_alert_rules r
+		WHERE r.user_id = $2 AND r.network = $3 AND r.id = $4`,
+		types.ValidatorAlertRuleEventName, userID, strings.ToLower(network), ruleID)
+	if err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// GetAlertRulesByIDs returns the alert rules with the given ids regardless of the user they belong to
+func GetAlertRulesByIDs(ruleIDs []uint64) ([]types.AlertRule, error) {
+	rules := []types.AlertRule{}
+	err := FrontendWriterDB.Select(&rules, `
+		SELECT `+alertRuleColumns+`
+		FROM users_alert_rules r
+		WHERE r.id = ANY($2)`,
+		types.ValidatorAlertRuleEventName, pq.Array(ruleIDs))
+	return rules, err
+}
+
+// AddAlertRule saves a new alert rule of a user and subscribes the user to it if the rule is active
+func AddAlertRule(rule *types.AlertRule, network string) (uint64, error) {
+	tx, err := FrontendWriterDB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var ruleID uint64
+	err = tx.QueryRow(`
+		INSERT INTO users_alert_rules (user_id, network, name, validators, metric, operator, threshold, window_epochs)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id`,
+		rule.UserID, strings.ToLower(network), rule.Name, rule.Validators, rule.Metric, rule.Operator, rule.Threshold, rule.WindowEpochs).Scan(&ruleID)
+	if err != nil {
+		return 0, err
+	}
+	rule.ID = ruleID
+
+	err = setAlertRuleSubscription(tx, rule, network)
+	if err != nil {
+		return 0, err
+	}
+
+	return ruleID, tx.Commit()
+}
+
+// UpdateAlertRule updates an alert rule of a user, the rule will trigger again even if it has already been triggered with the previous settings
+func UpdateAlertRule(rule *types.AlertRule, network string) error {
+	tx, err := FrontendWriterDB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`
+		UPDATE users_alert_rules SET name = $4, validators = $5, metric = $6, operator = $7, threshold = $8, window_epochs = $9, updated_ts = NOW()
+		WHERE user_id = $1 AND network = $2 AND id = $3`,
+		rule.UserID, strings.ToLower(network), rule.ID, rule.Name, rule.Validators, rule.Metric, rule.Operator, rule.Threshold, rule.WindowEpochs)
+	if err != nil {
+		return err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	err = setAlertRuleSubscription(tx, rule, network)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DeleteAlertRule deletes an alert rule of a user and its subscription
+func DeleteAlertRule(userID, ruleID uint64, network string) error {
+	tx, err := FrontendWriterDB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("DELETE FROM users_subscriptions WHERE user_id = $1 AND event_name = $2 AND event_filter = $3", userID, strings.ToLower(network)+":"+string(types.ValidatorAlertRuleEventName), strconv.FormatUint(ruleID, 10))
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec("DELETE FROM users_alert_rules WHERE user_id = $1 AND network = $2 AND id = $3", userID, strings.ToLower(network), ruleID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// setAlertRuleSubscription subscribes the user to an active rule and unsubscribes the user from an inactive rule,
+// the internal state of an existing subscription is reset so that the rule is evaluated from scratch
+func setAlertRuleSubscription(tx *sql.Tx, rule *types.AlertRule, network string) error {
+	name := strings.ToLower(network) + ":" + string(types.ValidatorAlertRuleEventName)
+	filter := strconv.FormatUint(rule.ID, 10)
+
+	if !rule.Active {
+		_, err := tx.Exec("DELETE FROM users_subscriptions WHERE user_id = $1 AND event_name = $2 AND event_filter = $3", rule.UserID, name, filter)
+		return err
+	}
+
+	now := time.Now()
+	_, err := tx.Exec(`
+		INSERT INTO users_subscriptions (user_id, event_name, event_filter, created_ts, created_epoch, event_threshold)
+		VALUES ($1, $2, $3, TO_TIMESTAMP($4), $5, $6)
+		ON CONFLICT (user_id, event_name, event_filter) DO UPDATE SET event_threshold = $6, internal_state = NULL`,
+		rule.UserID, name, filter, now.Unix(), utils.TimeToEpoch(now), rule.Threshold)
+	return err
+}
+
+// GetNotificationDeliveryModes returns the delivery modes of the users
+func GetNotificationDeliveryModes(userIDs []uint64) (map[uint64][]types.UserNotificationDeliveryMode, error) {
+	modesByUserID := make(map[uint64][]types.UserNotificationDeliveryMode)
+	if len(userIDs) == 0 {
+		return modesByUserID, nil
+	}
+
+	var modes []types.UserNotificationDeliveryMode
+	err := FrontendWriterDB.Select(&modes, "SELECT user_id, channel, event_name, mode FROM users_notification_delivery_modes WHERE user_id = ANY($1)", pq.Array(userIDs))
+	if err != nil {
+		return nil, err
+	}
+	for _, mode := range modes {
+		modesByUserID[mode.UserID] = append(modesByUserID[mode.UserID], mode)
+	}
+	return modesByUserID, nil
+}
+
+// SetNotificationDeliveryModes saves the delivery modes of a user, an empty mode removes the delivery mode of the event so that
+// the default of the channel applies again. Removing the default of a channel delivers its notifications immediately
+func SetNotificationDeliveryModes(userID uint64, modes []types.UserNotificationDeliveryMode) error {
+	tx, err := FrontendWriterDB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, mode := range modes {
+		if mode.Mode == "" || (mode.EventName == "" && mode.Mode == types.NotificationDeliveryImmediate) {
+			_, err = tx.Exec("DELETE FROM users_notification_delivery_modes WHERE user_id = $1 AND channel = $2 AND event_name = $3", userID, mode.Channel, mode.EventName)
+		} else {
+			_, err = tx.Exec(`
+				INSERT INTO users_notification_delivery_modes (user_id, channel, event_name, mode) VALUES ($1, $2, $3, $4)
+				ON CONFLICT (user_id, channel, event_name) DO UPDATE SET mode = $4`,
+				userID, mode.Channel, mode.EventName, mode.Mode)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
 func GetUserDevicesByUserID(userID uint64) ([]types.PairedDevice, error) {
 	data := []types.PairedDevice{}
 
@@ -732,13 +1097,14 @@ func GetSubsForEventFilter(eventName types.EventName) ([][]byte, map[string][]ty
 			subMap[sub.EventFilter] = make([]types.Subscription, 0)
 		}
 		subMap[sub.EventFilter] = append(subMap[sub.EventFilter], types.Subscription{
-			UserID:         sub.UserID,
-			ID:             sub.ID,
-			LastEpoch:      sub.LastEpoch,
-			EventFilter:    sub.EventFilter,
-			CreatedEpoch:   sub.CreatedEpoch,
-			EventThreshold: sub.EventThreshold,
-			State:          sub.State,
+			UserID:          sub.UserID,
+			ID:              sub.ID,
+			LastEpoch:       sub.LastEpoch,
+			EventFilter:     sub.EventFilter,
+			CreatedEpoch:    sub.CreatedEpoch,
+			EventThreshold:  sub.EventThreshold,
+			State:           sub.State,
+			UnsubscribeHash: sub.UnsubscribeHash,
 		})
 
 		b, _ := hex.DecodeString(sub.EventFilter)
@@ -0,0 +1,209 @@
+package db
+
+import (
+	"context"
+	"eth2-exporter/types"
+	"eth2-exporter/utils"
+	"fmt"
+	"time"
+
+	gcp_bigtable "cloud.google.com/go/bigtable"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+)
+
+// The eth1 indexer keeps an undo journal for every block it indexes so that the block can be reverted exactly in case of a chain reorg.
+// The journal is stored next to the legacy block keys in the table metadata_updates:
+// Row:    <chainID>:BLOCK:<reversePaddedBlockNumber>:<blockHash>
+// Family: blocks
+// Column: journal
+// Cell:   Proto<Eth1BlockJournal>
+//
+// Every entry names the table and row written by a transformer and how the write is undone.
+const (
+	METADATA_UPDATES_COLUMN_JOURNAL = "journal"
+
+	JOURNAL_TABLE_DATA             = "data"
+	JOURNAL_TABLE_METADATA         = "metadata"
+	JOURNAL_TABLE_METADATA_UPDATES = "metadata_updates"
+
+	// JOURNAL_DELETE_ROW deletes a row that has been created by the block
+	JOURNAL_DELETE_ROW = "DELETE_ROW"
+	// JOURNAL_DELETE_CELL deletes the cell version that has been written by the block at the journaled timestamp
+	JOURNAL_DELETE_CELL = "DELETE_CELL"
+	// JOURNAL_MARK sets a marker cell again so that the state derived from the block (balances, ens names, nft metadata) is validated against the new canonical chain
+	JOURNAL_MARK = "MARK"
+)
+
+// journalDeleteRow records that the row has to be deleted when the block is reverted
+func journalDeleteRow(mutations *types.BulkMutations, table, key string) {
+	mutations.Journal = append(mutations.Journal, &types.Eth1BlockJournalEntry{
+		Table:  table,
+		Key:    key,
+		Action: JOURNAL_DELETE_ROW,
+	})
+}
+
+// journalDeleteCell records that the cell version written at ts has to be deleted when the block is reverted
+func journalDeleteCell(mutations *types.BulkMutations, table, key, family, column string, ts gcp_bigtable.Timestamp) {
+	mutations.Journal = append(mutations.Journal, &types.Eth1BlockJournalEntry{
+		Table:     table,
+		Key:       key,
+		Family:    family,
+		Column:    column,
+		Timestamp: int64(ts),
+		Action:    JOURNAL_DELETE_CELL,
+	})
+}
+
+// journalMark records that the marker cell has to be set again when the block is reverted.
+// Markers are journaled even if the write itself has been skipped by the cache as the block still changed the marked state.
+func journalMark(mutations *types.BulkMutations, table, key, family, column string) {
+	mutations.Journal = append(mutations.Journal, &types.Eth1BlockJournalEntry{
+		Table:  table,
+		Key:    key,
+		Family: family,
+		Column: column,
+		Action: JOURNAL_MARK,
+	})
+}
+
+func (bigtable *Bigtable) journalTable(name string) (BigtableTable, error) {
+	switch name {
+	case JOURNAL_TABLE_DATA:
+		return bigtable.tableData, nil
+	case JOURNAL_TABLE_METADATA:
+		return bigtable.tableMetadata, nil
+	case JOURNAL_TABLE_METADATA_UPDATES:
+		return bigtable.tableMetadataUpdates, nil
+	default:
+		return nil, fmt.Errorf("unknown journal table %v", name)
+	}
+}
+
+func (bigtable *Bigtable) blockJournalKey(blockNumber uint64, blockHash []byte) string {
+	return fmt.Sprintf("%s:BLOCK:%s:%x", bigtable.chainId, reversedPaddedBlockNumber(blockNumber), blockHash)
+}
+
+// SaveBlockJournal stores the undo journal of an indexed block, it has to be saved before the mutations of the block are written
+func (bigtable *Bigtable) SaveBlockJournal(journal *types.Eth1BlockJournal) error {
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second*30))
+	defer cancel()
+
+	b, err := proto.Marshal(journal)
+	if err != nil {
+		return fmt.Errorf("error marshalling journal of block %v: %w", journal.Number, err)
+	}
+
+	mut := gcp_bigtable.NewMutation()
+	mut.Set(METADATA_UPDATES_FAMILY_BLOCKS, METADATA_UPDATES_COLUMN_JOURNAL, gcp_bigtable.Now(), b)
+
+	return bigtable.tableMetadataUpdates.Apply(ctx, bigtable.blockJournalKey(journal.Number, journal.Hash), mut)
+}
+
+// GetBlockJournal returns the undo journal of an indexed block, nil is returned if the block has been indexed without a journal
+func (bigtable *Bigtable) GetBlockJournal(blockNumber uint64, blockHash []byte) (*types.Eth1BlockJournal, error) {
+	tmr := time.AfterFunc(REPORT_TIMEOUT, func() {
+		logger.WithFields(logrus.Fields{
+			"blockNumber": blockNumber,
+			"blockHash":   blockHash,
+		}).Warnf("%s call took longer than %v", utils.GetCurrentFuncName(), REPORT_TIMEOUT)
+	})
+	defer tmr.Stop()
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second*30))
+	defer cancel()
+
+	filter := gcp_bigtable.ChainFilters(
+		gcp_bigtable.FamilyFilter(METADATA_UPDATES_FAMILY_BLOCKS),
+		gcp_bigtable.ColumnFilter(METADATA_UPDATES_COLUMN_JOURNAL),
+		gcp_bigtable.LatestNFilter(1),
+	)
+	row, err := bigtable.tableMetadataUpdates.ReadRow(ctx, bigtable.blockJournalKey(blockNumber, blockHash), gcp_bigtable.RowFilter(filter))
+	if err != nil {
+		return nil, err
+	}
+	if len(row[METADATA_UPDATES_FAMILY_BLOCKS]) == 0 {
+		return nil, nil
+	}
+
+	journal := &types.Eth1BlockJournal{}
+	err = proto.Unmarshal(row[METADATA_UPDATES_FAMILY_BLOCKS][0].Value, journal)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling journal of block %v: %w", blockNumber, err)
+	}
+	return journal, nil
+}
+
+// RevertEth1Block undoes everything the eth1 indexer wrote for an orphaned block by replaying its journal.
+// Rows and cells created by the block are deleted before the markers are set again, afterwards the block itself and its journal are removed.
+// Blocks that have been indexed before the journal was introduced are reverted by deleting their data table keys,
+// blocks without any journal or keys have not been indexed into the data table yet.
+func (bigtable *Bigtable) RevertEth1Block(blockNumber uint64, blockHash []byte) error {
+	journal, err := bigtable.GetBlockJournal(blockNumber, blockHash)
+	if err != nil {
+		return err
+	}
+	if journal == nil {
+		if keys, err := bigtable.GetBlockKeys(blockNumber, blockHash); err == nil && len(keys) > 0 {
+			logger.Warnf("no journal found for block %v with hash %x, deleting its data table keys", blockNumber, blockHash)
+			return bigtable.DeleteBlock(blockNumber, blockHash)
+		}
+		// the data of the block has not been indexed yet, only the block itself has to be removed
+		journal = &types.Eth1BlockJournal{Number: blockNumber, Hash: blockHash}
+	}
+
+	deletes := make(map[string]*types.BulkMutations)
+	marks := make(map[string]*types.BulkMutations)
+	for _, entry := range journal.Entries {
+		mut := gcp_bigtable.NewMutation()
+		target := deletes
+		switch entry.Action {
+		case JOURNAL_DELETE_ROW:
+			mut.DeleteRow()
+		case JOURNAL_DELETE_CELL:
+			mut.DeleteTimestampRange(entry.Family, entry.Column, gcp_bigtable.Timestamp(entry.Timestamp), gcp_bigtable.Timestamp(entry.Timestamp+TIMESTAMP_GBT_SCALE))
+		case JOURNAL_MARK:
+			mut.Set(entry.Family, entry.Column, gcp_bigtable.Timestamp(0), nil)
+			target = marks
+		default:
+			return fmt.Errorf("unknown journal action %v for key %v of block %v", entry.Action, entry.Key, blockNumber)
+		}
+
+		if target[entry.Table] == nil {
+			target[entry.Table] = &types.BulkMutations{}
+		}
+		target[entry.Table].Add(entry.Key, mut)
+	}
+
+	for _, muts := range []map[string]*types.BulkMutations{deletes, marks} {
+		for table, mutations := range muts {
+			btTable, err := bigtable.journalTable(table)
+			if err != nil {
+				return err
+			}
+			err = bigtable.WriteBulk(mutations, btTable, DEFAULT_BATCH_INSERTS)
+			if err != nil {
+				return fmt.Errorf("error reverting block %v in table %v: %w", blockNumber, table, err)
+			}
+		}
+	}
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second*30))
+	defer cancel()
+
+	mutDelete := gcp_bigtable.NewMutation()
+	mutDelete.DeleteRow()
+	err = bigtable.tableBlocks.Apply(ctx, fmt.Sprintf("%s:%s", bigtable.chainId, reversedPaddedBlockNumber(blockNumber)), mutDelete)
+	if err != nil {
+		return fmt.Errorf("error deleting block %v from blocks table: %w", blockNumber, err)
+	}
+
+	err = bigtable.tableMetadataUpdates.Apply(ctx, bigtable.blockJournalKey(blockNumber, blockHash), mutDelete)
+	if err != nil {
+		return fmt.Errorf("error deleting journal of block %v: %w", blockNumber, err)
+	}
+
+	logger.Infof("reverted block %v with hash %x, replayed %v journal entries", blockNumber, blockHash, len(journal.Entries))
+	return nil
+}
@@ -0,0 +1,107 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"eth2-exporter/types"
+	"fmt"
+	"testing"
+	"time"
+
+	gcp_bigtable "cloud.google.com/go/bigtable"
+	"github.com/coocood/freecache"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestRevertEth1Block(t *testing.T) {
+	bt := newEmbeddedTestBigtable(t)
+
+	miner := bytes.Repeat([]byte{0x01}, 20)
+	deployer := bytes.Repeat([]byte{0x02}, 20)
+	contract := bytes.Repeat([]byte{0x03}, 20)
+	block := &types.Eth1Block{
+		Hash:     bytes.Repeat([]byte{0xaa}, 32),
+		Number:   10,
+		Coinbase: miner,
+		Time:     timestamppb.New(time.Unix(1700000000, 0)),
+		Transactions: []*types.Eth1Transaction{
+			{
+				Hash: bytes.Repeat([]byte{0xbb}, 32),
+				From: deployer,
+				Itx: []*types.Eth1InternalTransaction{
+					{Type: "create", From: deployer, To: contract, Path: "[]"},
+				},
+			},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	// the contract state written by an earlier block has to survive the revert
+	contractKey := fmt.Sprintf("1:S:%x", contract)
+	earlierTs, err := encodeIsContractUpdateTs(5, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mut := gcp_bigtable.NewMutation()
+	mut.Set(ACCOUNT_METADATA_FAMILY, ACCOUNT_IS_CONTRACT, earlierTs, []byte{0x1})
+	err = bt.tableMetadata.Apply(ctx, contractKey, mut)
+	if err != nil {
+		t.Fatalf("error writing earlier contract state: %v", err)
+	}
+
+	err = bt.SaveBlock(block)
+	if err != nil {
+		t.Fatalf("error saving block: %v", err)
+	}
+	err = bt.indexBlock(block, []func(blk *types.Eth1Block, cache *freecache.Cache) (*types.BulkMutations, *types.BulkMutations, error){bt.TransformBlock, bt.TransformContract}, freecache.NewCache(1024*1024))
+	if err != nil {
+		t.Fatalf("error indexing block: %v", err)
+	}
+
+	if rows := readEmbeddedTestRows(t, bt.tableData, "1:"); len(rows) == 0 {
+		t.Fatalf("expected indexed rows in the data table")
+	}
+	if row := readEmbeddedTestRows(t, bt.tableMetadata, contractKey)[contractKey]; len(row[ACCOUNT_METADATA_FAMILY]) != 2 {
+		t.Fatalf("expected 2 contract state cells, got %v", row)
+	}
+
+	// the balance of the miner has been updated in the meantime, which removes the marker
+	markerKey := fmt.Sprintf("1:B:%x", miner)
+	if _, exists := readEmbeddedTestRows(t, bt.tableMetadataUpdates, markerKey)[markerKey]; !exists {
+		t.Fatalf("expected balance update marker for the miner")
+	}
+	del := gcp_bigtable.NewMutation()
+	del.DeleteRow()
+	err = bt.tableMetadataUpdates.Apply(ctx, markerKey, del)
+	if err != nil {
+		t.Fatalf("error deleting marker: %v", err)
+	}
+
+	err = bt.RevertEth1Block(block.Number, block.Hash)
+	if err != nil {
+		t.Fatalf("error reverting block: %v", err)
+	}
+
+	if rows := readEmbeddedTestRows(t, bt.tableData, "1:"); len(rows) != 0 {
+		t.Errorf("expected all data rows of the block to be deleted, got %v rows", len(rows))
+	}
+	row := readEmbeddedTestRows(t, bt.tableMetadata, contractKey)[contractKey]
+	if len(row[ACCOUNT_METADATA_FAMILY]) != 1 || row[ACCOUNT_METADATA_FAMILY][0].Timestamp != earlierTs {
+		t.Errorf("expected only the earlier contract state to be left, got %v", row)
+	}
+	marker := readEmbeddedTestRows(t, bt.tableMetadataUpdates, markerKey)[markerKey]
+	if len(marker[DEFAULT_FAMILY]) != 1 || marker[DEFAULT_FAMILY][0].Column != DEFAULT_FAMILY+":00" {
+		t.Errorf("expected the balance update marker of the miner to be set again, got %v", marker)
+	}
+
+	journal, err := bt.GetBlockJournal(block.Number, block.Hash)
+	if err != nil || journal != nil {
+		t.Errorf("expected the journal to be deleted, got %v %v", journal, err)
+	}
+	_, err = bt.GetBlockFromBlocksTable(block.Number)
+	if err != ErrBlockNotFound {
+		t.Errorf("expected the block to be deleted from the blocks table, got %v", err)
+	}
+}
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - add table chain_reorgs';
+CREATE TABLE IF NOT EXISTS
+    chain_reorgs (
+        slot INT NOT NULL,
+        depth INT NOT NULL,
+        old_head_block BYTEA NOT NULL,
+        new_head_block BYTEA NOT NULL,
+        old_head_state BYTEA NOT NULL,
+        new_head_state BYTEA NOT NULL,
+        epoch INT NOT NULL,
+        ts TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+        PRIMARY KEY (slot, old_head_block, new_head_block)
+    );
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - drop table chain_reorgs';
+DROP TABLE IF EXISTS chain_reorgs;
+-- +goose StatementEnd
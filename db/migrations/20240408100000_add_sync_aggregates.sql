@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - add table sync_aggregates';
+CREATE TABLE IF NOT EXISTS
+    sync_aggregates (
+        slot INT NOT NULL,
+        period INT NOT NULL,
+        attested_slot INT NOT NULL,
+        attested_block_root BYTEA NOT NULL,
+        finalized_slot INT,
+        sync_committee_bits BYTEA NOT NULL,
+        sync_committee_signature BYTEA NOT NULL,
+        participation FLOAT NOT NULL,
+        ts TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+        PRIMARY KEY (slot)
+    );
+CREATE INDEX IF NOT EXISTS idx_sync_aggregates_period ON sync_aggregates (period);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - drop table sync_aggregates';
+DROP TABLE IF EXISTS sync_aggregates;
+-- +goose StatementEnd
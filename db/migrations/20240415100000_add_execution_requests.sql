@@ -0,0 +1,51 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - add tables for electra execution layer requests';
+CREATE TABLE IF NOT EXISTS
+    blocks_deposit_requests (
+        block_slot INT NOT NULL,
+        block_root BYTEA NOT NULL,
+        request_index INT NOT NULL,
+        pubkey BYTEA NOT NULL,
+        withdrawal_credentials BYTEA NOT NULL,
+        amount BIGINT NOT NULL,
+        signature BYTEA NOT NULL,
+        deposit_index BIGINT NOT NULL,
+        PRIMARY KEY (block_slot, block_root, request_index)
+    );
+CREATE INDEX IF NOT EXISTS idx_blocks_deposit_requests_pubkey ON blocks_deposit_requests (pubkey);
+
+CREATE TABLE IF NOT EXISTS
+    blocks_withdrawal_requests (
+        block_slot INT NOT NULL,
+        block_root BYTEA NOT NULL,
+        request_index INT NOT NULL,
+        source_address BYTEA NOT NULL,
+        validator_pubkey BYTEA NOT NULL,
+        amount BIGINT NOT NULL,
+        PRIMARY KEY (block_slot, block_root, request_index)
+    );
+CREATE INDEX IF NOT EXISTS idx_blocks_withdrawal_requests_validator_pubkey ON blocks_withdrawal_requests (validator_pubkey);
+CREATE INDEX IF NOT EXISTS idx_blocks_withdrawal_requests_source_address ON blocks_withdrawal_requests (source_address);
+
+CREATE TABLE IF NOT EXISTS
+    blocks_consolidation_requests (
+        block_slot INT NOT NULL,
+        block_root BYTEA NOT NULL,
+        request_index INT NOT NULL,
+        source_address BYTEA NOT NULL,
+        source_pubkey BYTEA NOT NULL,
+        target_pubkey BYTEA NOT NULL,
+        PRIMARY KEY (block_slot, block_root, request_index)
+    );
+CREATE INDEX IF NOT EXISTS idx_blocks_consolidation_requests_source_pubkey ON blocks_consolidation_requests (source_pubkey);
+CREATE INDEX IF NOT EXISTS idx_blocks_consolidation_requests_target_pubkey ON blocks_consolidation_requests (target_pubkey);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - drop tables for electra execution layer requests';
+DROP TABLE IF EXISTS blocks_consolidation_requests;
+DROP TABLE IF EXISTS blocks_withdrawal_requests;
+DROP TABLE IF EXISTS blocks_deposit_requests;
+-- +goose StatementEnd
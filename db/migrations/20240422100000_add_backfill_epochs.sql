@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - add table backfill_epochs';
+CREATE TABLE IF NOT EXISTS
+    backfill_epochs (
+        epoch INT NOT NULL,
+        ts TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+        PRIMARY KEY (epoch)
+    );
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - drop table backfill_epochs';
+DROP TABLE IF EXISTS backfill_epochs;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - add table relays_bids';
+CREATE TABLE IF NOT EXISTS
+    relays_bids (
+        block_slot INT NOT NULL,
+        tag_id VARCHAR NOT NULL,
+        exec_block_hash BYTEA NOT NULL,
+        builder_pubkey BYTEA NOT NULL,
+        value NUMERIC NOT NULL,
+        bids_count INT NOT NULL,
+        PRIMARY KEY (block_slot, tag_id)
+    );
+-- +goose StatementEnd
+-- +goose StatementBegin
+SELECT 'up SQL query - add column last_bids_export_slot to relays';
+ALTER TABLE relays ADD COLUMN IF NOT EXISTS last_bids_export_slot INT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - drop column last_bids_export_slot from relays';
+ALTER TABLE relays DROP COLUMN IF EXISTS last_bids_export_slot;
+-- +goose StatementEnd
+-- +goose StatementBegin
+SELECT 'down SQL query - drop table relays_bids';
+DROP TABLE IF EXISTS relays_bids;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - add column missed_reason to blocks';
+ALTER TABLE blocks ADD COLUMN IF NOT EXISTS missed_reason VARCHAR(20) CONSTRAINT blocks_missed_reason_check CHECK (missed_reason IN ('not_seen', 'late', 'orphaned', 'invalid_execution'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - drop column missed_reason from blocks';
+ALTER TABLE blocks DROP COLUMN IF EXISTS missed_reason;
+-- +goose StatementEnd
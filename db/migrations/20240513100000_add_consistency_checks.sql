@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - add table consistency_checks';
+CREATE TABLE IF NOT EXISTS
+    consistency_checks (
+        name VARCHAR(50) NOT NULL,
+        last_slot INT NOT NULL,
+        ts TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+        PRIMARY KEY (name)
+    );
+-- +goose StatementEnd
+-- +goose StatementBegin
+SELECT 'up SQL query - add table consistency_check_mismatches';
+CREATE TABLE IF NOT EXISTS
+    consistency_check_mismatches (
+        slot INT NOT NULL,
+        exec_block_number INT NOT NULL,
+        kind VARCHAR(30) NOT NULL,
+        detected_ts TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+        resolved_ts TIMESTAMP WITHOUT TIME ZONE,
+        PRIMARY KEY (slot, kind)
+    );
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - drop table consistency_check_mismatches';
+DROP TABLE IF EXISTS consistency_check_mismatches;
+-- +goose StatementEnd
+-- +goose StatementBegin
+SELECT 'down SQL query - drop table consistency_checks';
+DROP TABLE IF EXISTS consistency_checks;
+-- +goose StatementEnd
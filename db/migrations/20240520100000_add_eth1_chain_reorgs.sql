@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - add table eth1_chain_reorgs';
+CREATE TABLE IF NOT EXISTS
+    eth1_chain_reorgs (
+        block_number INT NOT NULL,
+        depth INT NOT NULL,
+        old_head_hash BYTEA NOT NULL,
+        new_head_hash BYTEA NOT NULL,
+        ts TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+        PRIMARY KEY (block_number, old_head_hash, new_head_hash)
+    );
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - drop table eth1_chain_reorgs';
+DROP TABLE IF EXISTS eth1_chain_reorgs;
+-- +goose StatementEnd
@@ -0,0 +1,37 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - add chat notification channels';
+ALTER TYPE notification_channels ADD VALUE IF NOT EXISTS 'telegram';
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TYPE notification_channels ADD VALUE IF NOT EXISTS 'webhook_slack';
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TYPE notification_channels ADD VALUE IF NOT EXISTS 'webhook_matrix';
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TYPE notification_channels ADD VALUE IF NOT EXISTS 'webhook_ntfy';
+-- +goose StatementEnd
+-- +goose StatementBegin
+SELECT 'up SQL query - add table users_telegram';
+CREATE TABLE IF NOT EXISTS
+    users_telegram (
+        user_id INT NOT NULL,
+        chat_id BIGINT,
+        username CHARACTER VARYING(256),
+        link_token CHARACTER VARYING(64),
+        link_token_ts TIMESTAMP WITHOUT TIME ZONE,
+        linked_ts TIMESTAMP WITHOUT TIME ZONE,
+        PRIMARY KEY (user_id)
+    );
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_telegram_link_token ON users_telegram (link_token);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - drop table users_telegram, the values of the notification_channels type can not be removed';
+DROP TABLE IF EXISTS users_telegram;
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - add webhook secrets, delivery attempts and table users_webhooks_deliveries';
+ALTER TABLE users_webhooks ADD COLUMN IF NOT EXISTS secret CHARACTER VARYING(64);
+ALTER TABLE notification_queue ADD COLUMN IF NOT EXISTS attempts INT NOT NULL DEFAULT 0;
+ALTER TABLE notification_queue ADD COLUMN IF NOT EXISTS next_attempt TIMESTAMP WITHOUT TIME ZONE;
+CREATE TABLE IF NOT EXISTS
+    users_webhooks_deliveries (
+        id BIGSERIAL NOT NULL,
+        webhook_id INT NOT NULL,
+        user_id INT NOT NULL,
+        event_name CHARACTER VARYING(100) NOT NULL,
+        payload JSONB NOT NULL,
+        attempt INT NOT NULL,
+        redelivery BOOLEAN NOT NULL DEFAULT FALSE,
+        status_code INT,
+        latency_ms INT NOT NULL,
+        response CHARACTER VARYING(1024),
+        error CHARACTER VARYING(1024),
+        ts TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+        PRIMARY KEY (id)
+    );
+CREATE INDEX IF NOT EXISTS idx_users_webhooks_deliveries_webhook_id_ts ON users_webhooks_deliveries (webhook_id, ts DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - drop table users_webhooks_deliveries and the webhook secrets and delivery attempts';
+DROP TABLE IF EXISTS users_webhooks_deliveries;
+ALTER TABLE notification_queue DROP COLUMN IF EXISTS next_attempt;
+ALTER TABLE notification_queue DROP COLUMN IF EXISTS attempts;
+ALTER TABLE users_webhooks DROP COLUMN IF EXISTS secret;
+-- +goose StatementEnd
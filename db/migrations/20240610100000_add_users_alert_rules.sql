@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - add table users_alert_rules';
+CREATE TABLE IF NOT EXISTS
+    users_alert_rules (
+        id SERIAL NOT NULL,
+        user_id INT NOT NULL,
+        network CHARACTER VARYING(20) NOT NULL,
+        name CHARACTER VARYING(100) NOT NULL,
+        validators INT[] NOT NULL,
+        metric CHARACTER VARYING(30) NOT NULL,
+        operator CHARACTER VARYING(10) NOT NULL,
+        threshold DOUBLE PRECISION NOT NULL,
+        window_epochs INT NOT NULL DEFAULT 1,
+        created_ts TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+        updated_ts TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+        PRIMARY KEY (id)
+    );
+CREATE INDEX IF NOT EXISTS idx_users_alert_rules_user_id_network ON users_alert_rules (user_id, network);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - drop table users_alert_rules';
+DROP TABLE IF EXISTS users_alert_rules;
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - add tables users_notification_delivery_modes and notification_digest_entries';
+CREATE TABLE IF NOT EXISTS
+    users_notification_delivery_modes (
+        user_id INT NOT NULL,
+        channel notification_channels NOT NULL,
+        event_name CHARACTER VARYING(100) NOT NULL DEFAULT '',
+        mode CHARACTER VARYING(10) NOT NULL,
+        PRIMARY KEY (user_id, channel, event_name)
+    );
+CREATE TABLE IF NOT EXISTS
+    notification_digest_entries (
+        id BIGSERIAL NOT NULL,
+        user_id INT NOT NULL,
+        network CHARACTER VARYING(20) NOT NULL,
+        channel notification_channels NOT NULL,
+        mode CHARACTER VARYING(10) NOT NULL,
+        event_name CHARACTER VARYING(100) NOT NULL,
+        epoch INT NOT NULL,
+        event_filter TEXT NOT NULL DEFAULT '',
+        validator_index INT,
+        title TEXT NOT NULL,
+        info TEXT NOT NULL,
+        created TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+        PRIMARY KEY (id)
+    );
+CREATE INDEX IF NOT EXISTS idx_notification_digest_entries_network_mode_created ON notification_digest_entries (network, mode, created);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - drop tables notification_digest_entries and users_notification_delivery_modes';
+DROP TABLE IF EXISTS notification_digest_entries;
+DROP TABLE IF EXISTS users_notification_delivery_modes;
+-- +goose StatementEnd
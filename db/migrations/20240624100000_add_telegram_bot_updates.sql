@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - add table telegram_bot_updates';
+CREATE TABLE IF NOT EXISTS
+    telegram_bot_updates (
+        bot_id BIGINT NOT NULL,
+        update_offset BIGINT NOT NULL,
+        updated_ts TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+        PRIMARY KEY (bot_id)
+    );
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - drop table telegram_bot_updates';
+DROP TABLE IF EXISTS telegram_bot_updates;
+-- +goose StatementEnd
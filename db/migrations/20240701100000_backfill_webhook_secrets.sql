@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - backfill the secrets of existing webhooks';
+UPDATE users_webhooks SET secret = REPLACE(gen_random_uuid()::TEXT, '-', '') || REPLACE(gen_random_uuid()::TEXT, '-', '') WHERE secret IS NULL;
+ALTER TABLE users_webhooks ALTER COLUMN secret SET NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - allow webhooks without a secret';
+ALTER TABLE users_webhooks ALTER COLUMN secret DROP NOT NULL;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - add table contract_abi_submissions';
+CREATE TABLE IF NOT EXISTS
+    contract_abi_submissions (
+        id BIGSERIAL NOT NULL,
+        user_id INT NOT NULL,
+        address BYTEA NOT NULL,
+        name CHARACTER VARYING(255) NOT NULL DEFAULT '',
+        abi TEXT NOT NULL,
+        status CHARACTER VARYING(20) NOT NULL DEFAULT 'pending',
+        created_ts TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+        reviewed_ts TIMESTAMP WITHOUT TIME ZONE,
+        PRIMARY KEY (id)
+    );
+CREATE INDEX IF NOT EXISTS idx_contract_abi_submissions_status ON contract_abi_submissions (status, created_ts);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - drop table contract_abi_submissions';
+DROP TABLE IF EXISTS contract_abi_submissions;
+-- +goose StatementEnd
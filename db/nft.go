@@ -0,0 +1,521 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"eth2-exporter/erc1155"
+	"eth2-exporter/erc721"
+	"eth2-exporter/types"
+	"eth2-exporter/utils"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	gcp_bigtable "cloud.google.com/go/bigtable"
+	"github.com/coocood/freecache"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	NFT_COLUMN_URI          = "URI"
+	NFT_COLUMN_METADATA     = "METADATA"
+	NFT_COLUMN_IMAGE        = "IMAGE"
+	NFT_COLUMN_IMAGE_FORMAT = "IMAGEFORMAT"
+
+	nftMetadataMaxSize     = 1024 * 1024 * 2
+	nftImageMaxSize        = 1024 * 1024 * 10
+	nftImageMaxPixels      = 4096 * 4096
+	nftSvgThumbnailMaxSize = 1024 * 256
+	nftThumbnailSize       = 256
+
+	nftMetadataMaxAttempts = 5
+	nftMetadataRetryDelay  = time.Hour
+)
+
+// nftHttpClient fetches the metadata of arbitrary token uris, which are controlled by the contract deployer.
+// In order to prevent requests to internal services it refuses to connect to loopback, private and link-local addresses.
+// The check is done on the resolved address of every connection, so it also covers redirects and dns rebinding.
+var nftHttpClient = &http.Client{
+	Timeout: time.Second * 10,
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: time.Second * 5,
+			Control: func(network, address string, c syscall.RawConn) error {
+				host, _, err := net.SplitHostPort(address)
+				if err != nil {
+					return err
+				}
+				ip := net.ParseIP(host)
+				if ip == nil || !isPublicNftIP(ip) {
+					return fmt.Errorf("connecting to non-public address %v is not allowed", host)
+				}
+				return nil
+			},
+		}).DialContext,
+		TLSHandshakeTimeout: time.Second * 5,
+		MaxIdleConns:        100,
+		IdleConnTimeout:     time.Second * 90,
+	},
+}
+
+// nftGatewayHttpClient fetches ipfs and arweave content from the configured gateways, which may be run on the local network
+var nftGatewayHttpClient = &http.Client{Timeout: time.Second * 10}
+
+func isPublicNftIP(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() && !ip.IsMulticast() && !ip.IsUnspecified()
+}
+
+// The ERC721 and ERC1155 transformers track every token id they see for a later metadata resolution via the node ("set dirty"):
+// Row:    <chainID>:NFT:V:<TOKEN_ADDRESS>:<tokenId>
+// Family: f
+// Column: erc721 or erc1155
+// Cell:   nil
+// Example scan: "1:NFT:V:bc4ca0eda7647a8ab7c2061c2e118a18a936f13d:1" returns the pending metadata update of the first mainnet BAYC token
+//
+// ImportNftMetadataUpdates resolves the token uri of those ids and stores the result in the table metadata:
+// Row:    <chainID>:NFT:<TOKEN_ADDRESS>:<tokenId>
+// Family: erc721 or erc1155
+// Column: URI, METADATA (json as returned by the token uri), IMAGE (thumbnail), IMAGEFORMAT (content type of the thumbnail)
+// Example lookup: "1:NFT:bc4ca0eda7647a8ab7c2061c2e118a18a936f13d:1" returns the metadata of the first mainnet BAYC token
+//
+// Failed resolutions are retried with an exponential backoff, the retries are queued in the table data:
+// Row:    <chainID>:NFT:R:<paddedRetryAfterUnixTs>:<TOKEN_ADDRESS>:<tokenId>
+// Family: f
+// Column: erc721 or erc1155
+// Cell:   number of failed attempts
+func (bigtable *Bigtable) markNftMetadataUpdate(family string, token []byte, tokenId *big.Int, mutations *types.BulkMutations, cache *freecache.Cache) {
+	key := fmt.Sprintf("%s:NFT:V:%x:%s", bigtable.chainId, token, tokenId.String())
+	journalMark(mutations, JOURNAL_TABLE_DATA, key, DEFAULT_FAMILY, family)
+	if _, err := cache.Get([]byte(key)); err != nil {
+		mut := gcp_bigtable.NewMutation()
+		mut.Set(DEFAULT_FAMILY, family, gcp_bigtable.Timestamp(0), nil)
+
+		mutations.Keys = append(mutations.Keys, key)
+		mutations.Muts = append(mutations.Muts, mut)
+
+		cache.Set([]byte(key), []byte{0x1}, int((utils.Day * 2).Seconds()))
+	}
+}
+
+// nftMetadataUpdate is a pending metadata resolution of an nft and the number of its failed attempts
+type nftMetadataUpdate struct {
+	metadata *types.NftMetadata
+	attempts uint64
+}
+
+func (bigtable *Bigtable) ImportNftMetadataUpdates(client *ethclient.Client, readBatchSize int64) error {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+
+	pending := []*nftMetadataUpdate{}
+	keys := []string{}
+	readUpdates := func(rowSet gcp_bigtable.RowSet, tokenPos int) error {
+		return bigtable.tableData.ReadRows(ctx, rowSet, func(row gcp_bigtable.Row) bool {
+			split := strings.Split(row.Key(), ":")
+			tokenId, ok := new(big.Int).SetString(split[tokenPos+1], 10)
+			if !ok {
+				logger.Warnf("invalid token id in nft metadata update %v", row.Key())
+			} else {
+				update := &nftMetadataUpdate{
+					metadata: &types.NftMetadata{
+						Token:    common.FromHex(split[tokenPos]),
+						TokenId:  tokenId,
+						Standard: strings.TrimPrefix(row[DEFAULT_FAMILY][0].Column, DEFAULT_FAMILY+":"),
+					},
+				}
+				if len(row[DEFAULT_FAMILY][0].Value) == 8 {
+					update.attempts = binary.BigEndian.Uint64(row[DEFAULT_FAMILY][0].Value)
+				}
+				pending = append(pending, update)
+			}
+			keys = append(keys, row.Key())
+			return true
+		}, gcp_bigtable.LimitRows(readBatchSize)) // limit the number of entries per run to avoid blocking the import of new blocks
+	}
+
+	err := readUpdates(gcp_bigtable.PrefixRange(fmt.Sprintf("%s:NFT:V:", bigtable.chainId)), 3)
+	if err != nil {
+		return err
+	}
+	retryPrefix := fmt.Sprintf("%s:NFT:R:", bigtable.chainId)
+	err = readUpdates(gcp_bigtable.NewRange(retryPrefix, retryPrefix+fmt.Sprintf("%012d", time.Now().Unix())), 4)
+	if err != nil {
+		return err
+	}
+
+	if len(keys) == 0 {
+		logger.Info("No NFT metadata updates to process")
+		return nil
+	}
+	logger.Infof("Resolving metadata of %v NFTs", len(pending))
+
+	retries := &types.BulkMutations{}
+	retriesMux := &sync.Mutex{}
+	g := new(errgroup.Group)
+	g.SetLimit(10) // limit load on the node and the metadata hosts
+	for _, u := range pending {
+		update := u
+		g.Go(func() error {
+			metadata := update.metadata
+			err := resolveNftMetadata(client, metadata)
+			if err != nil {
+				// failures are mostly caused by broken contracts or temporarily unavailable hosts, retry them a few times with an increasing delay
+				logger.Warnf("error resolving metadata of nft %x id %v (attempt %v): %v", metadata.Token, metadata.TokenId, update.attempts+1, err)
+				if update.attempts+1 < nftMetadataMaxAttempts {
+					retryAfter := time.Now().Add(nftMetadataRetryDelay * time.Duration(1<<update.attempts))
+					attempts := make([]byte, 8)
+					binary.BigEndian.PutUint64(attempts, update.attempts+1)
+
+					mut := gcp_bigtable.NewMutation()
+					mut.Set(DEFAULT_FAMILY, metadata.Standard, gcp_bigtable.Timestamp(0), attempts)
+
+					retriesMux.Lock()
+					retries.Add(fmt.Sprintf("%s:NFT:R:%012d:%x:%s", bigtable.chainId, retryAfter.Unix(), metadata.Token, metadata.TokenId.String()), mut)
+					retriesMux.Unlock()
+				}
+				if metadata.Uri == "" {
+					return nil
+				}
+			}
+			err = bigtable.SaveNftMetadata(metadata)
+			if err != nil {
+				return fmt.Errorf("error saving metadata of nft %x id %v: %w", metadata.Token, metadata.TokenId, err)
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	// the retries have to be queued before the processed updates are deleted
+	if retries.Len() > 0 {
+		err = bigtable.WriteBulk(retries, bigtable.tableData, DEFAULT_BATCH_INSERTS)
+		if err != nil {
+			return err
+		}
+	}
+
+	mutsDelete := &types.BulkMutations{
+		Keys: make([]string, 0, len(keys)),
+		Muts: make([]*gcp_bigtable.Mutation, 0, len(keys)),
+	}
+	for _, key := range keys {
+		mut := gcp_bigtable.NewMutation()
+		mut.DeleteRow()
+
+		mutsDelete.Keys = append(mutsDelete.Keys, key)
+		mutsDelete.Muts = append(mutsDelete.Muts, mut)
+	}
+	err = bigtable.WriteBulk(mutsDelete, bigtable.tableData, DEFAULT_BATCH_INSERTS)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Import of NFT metadata updates completed, %v failed resolutions will be retried", retries.Len())
+	return nil
+}
+
+// resolveNftMetadata retrieves the token uri of the nft from the node and fetches the metadata and the image it points to
+func resolveNftMetadata(client *ethclient.Client, metadata *types.NftMetadata) error {
+	switch metadata.Standard {
+	case ERC721_METADATA_FAMILY:
+		contract, err := erc721.NewErc721Caller(common.BytesToAddress(metadata.Token), client)
+		if err != nil {
+			return err
+		}
+		metadata.Uri, err = contract.TokenURI(nil, metadata.TokenId)
+		if err != nil {
+			return fmt.Errorf("error retrieving token uri: %w", err)
+		}
+	case ERC1155_METADATA_FAMILY:
+		contract, err := erc1155.NewErc1155Caller(common.BytesToAddress(metadata.Token), client)
+		if err != nil {
+			return err
+		}
+		uri, err := contract.Uri(nil, metadata.TokenId)
+		if err != nil {
+			return fmt.Errorf("error retrieving uri: %w", err)
+		}
+		// clients have to replace the {id} placeholder with the lowercase hex encoded id padded to 64 characters, see https://eips.ethereum.org/EIPS/eip-1155#metadata
+		metadata.Uri = strings.ReplaceAll(uri, "{id}", fmt.Sprintf("%064x", metadata.TokenId))
+	default:
+		return fmt.Errorf("unknown nft standard %v", metadata.Standard)
+	}
+
+	if metadata.Uri == "" {
+		return nil
+	}
+
+	raw, _, err := fetchNftResource(metadata.Uri, nftMetadataMaxSize)
+	if err != nil {
+		return fmt.Errorf("error fetching metadata from %v: %w", metadata.Uri, err)
+	}
+	metadata.Raw = raw
+	parseNftMetadataJson(metadata)
+
+	if metadata.ImageUri == "" {
+		return nil
+	}
+
+	image, format, err := fetchNftResource(metadata.ImageUri, nftImageMaxSize)
+	if err != nil {
+		return fmt.Errorf("error fetching image from %v: %w", metadata.ImageUri, err)
+	}
+	metadata.Image, metadata.ImageFormat, err = createNftThumbnail(image, format)
+	if err != nil {
+		return fmt.Errorf("error creating thumbnail of image %v: %w", metadata.ImageUri, err)
+	}
+	return nil
+}
+
+// parseNftMetadataJson extracts the displayed fields from the raw metadata json, fields with an unexpected type are skipped
+func parseNftMetadataJson(metadata *types.NftMetadata) {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(metadata.Raw, &fields); err != nil {
+		return
+	}
+
+	json.Unmarshal(fields["name"], &metadata.Name)
+	json.Unmarshal(fields["description"], &metadata.Description)
+	json.Unmarshal(fields["image"], &metadata.ImageUri)
+	if metadata.ImageUri == "" {
+		json.Unmarshal(fields["image_url"], &metadata.ImageUri)
+	}
+	json.Unmarshal(fields["attributes"], &metadata.Attributes)
+}
+
+// resolveNftUri rewrites ipfs and arweave uris to their configured http gateways
+func resolveNftUri(uri string) string {
+	uri = strings.TrimSpace(uri)
+	if strings.HasPrefix(uri, "ipfs://") {
+		path := strings.TrimPrefix(strings.TrimPrefix(uri, "ipfs://"), "ipfs/")
+		return strings.TrimSuffix(utils.Config.Indexer.NftMetadata.IpfsGateway, "/") + "/" + path
+	}
+	if strings.HasPrefix(uri, "ar://") {
+		return strings.TrimSuffix(utils.Config.Indexer.NftMetadata.ArweaveGateway, "/") + "/" + strings.TrimPrefix(uri, "ar://")
+	}
+	return uri
+}
+
+// isNftGatewayUri reports whether the uri points to one of the configured ipfs or arweave gateways
+func isNftGatewayUri(uri string) bool {
+	for _, gateway := range []string{utils.Config.Indexer.NftMetadata.IpfsGateway, utils.Config.Indexer.NftMetadata.ArweaveGateway} {
+		if gateway != "" && strings.HasPrefix(uri, strings.TrimSuffix(gateway, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchNftResource returns the content and the content type of an http, ipfs, arweave or data uri
+func fetchNftResource(uri string, maxSize int64) ([]byte, string, error) {
+	uri = resolveNftUri(uri)
+
+	if strings.HasPrefix(uri, "data:") {
+		header, data, found := strings.Cut(strings.TrimPrefix(uri, "data:"), ",")
+		if !found {
+			return nil, "", fmt.Errorf("invalid data uri")
+		}
+		if int64(len(data)) > maxSize {
+			return nil, "", fmt.Errorf("data uri exceeds the maximum size of %v bytes", maxSize)
+		}
+		contentType, isBase64 := strings.CutSuffix(header, ";base64")
+		if isBase64 {
+			content, err := base64.StdEncoding.DecodeString(data)
+			return content, contentType, err
+		}
+		content, err := url.PathUnescape(data)
+		return []byte(content), contentType, err
+	}
+
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return nil, "", err
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return nil, "", fmt.Errorf("unsupported uri scheme %v", parsed.Scheme)
+	}
+
+	client := nftHttpClient
+	if isNftGatewayUri(uri) {
+		client = nftGatewayHttpClient
+	}
+	resp, err := client.Get(parsed.String())
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("unexpected status code %v", resp.StatusCode)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if int64(len(content)) > maxSize {
+		return nil, "", fmt.Errorf("response exceeds the maximum size of %v bytes", maxSize)
+	}
+	return content, resp.Header.Get("Content-Type"), nil
+}
+
+// createNftThumbnail scales an image down to fit into nftThumbnailSize x nftThumbnailSize pixels and encodes it as png.
+// Small svg images are kept as they are.
+func createNftThumbnail(content []byte, contentType string) ([]byte, string, error) {
+	if strings.Contains(contentType, "svg") || bytes.HasPrefix(bytes.TrimSpace(content), []byte("<svg")) {
+		if len(content) > nftSvgThumbnailMaxSize {
+			return nil, "", fmt.Errorf("svg exceeds the maximum size of %v bytes", nftSvgThumbnailMaxSize)
+		}
+		return content, "image/svg+xml", nil
+	}
+
+	// the dimensions are checked before decoding as small images can expand to gigabytes of pixels
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return nil, "", err
+	}
+	if config.Width <= 0 || config.Height <= 0 || int64(config.Width)*int64(config.Height) > nftImageMaxPixels {
+		return nil, "", fmt.Errorf("image dimensions of %vx%v exceed the maximum of %v pixels", config.Width, config.Height, nftImageMaxPixels)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, "", err
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width == 0 || height == 0 {
+		return nil, "", fmt.Errorf("empty image")
+	}
+	scale := float64(nftThumbnailSize) / float64(width)
+	if height > width {
+		scale = float64(nftThumbnailSize) / float64(height)
+	}
+	if scale < 1 {
+		width = int(float64(width) * scale)
+		height = int(float64(height) * scale)
+		if width == 0 {
+			width = 1
+		}
+		if height == 0 {
+			height = 1
+		}
+	}
+
+	// nearest neighbor scaling is good enough for thumbnails
+	thumbnail := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			thumbnail.Set(x, y, img.At(bounds.Min.X+x*bounds.Dx()/width, bounds.Min.Y+y*bounds.Dy()/height))
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	err = png.Encode(buf, thumbnail)
+	if err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), "image/png", nil
+}
+
+func (bigtable *Bigtable) SaveNftMetadata(metadata *types.NftMetadata) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	mut := gcp_bigtable.NewMutation()
+	mut.Set(metadata.Standard, NFT_COLUMN_URI, gcp_bigtable.Timestamp(0), []byte(metadata.Uri))
+	mut.Set(metadata.Standard, NFT_COLUMN_METADATA, gcp_bigtable.Timestamp(0), metadata.Raw)
+	mut.Set(metadata.Standard, NFT_COLUMN_IMAGE, gcp_bigtable.Timestamp(0), metadata.Image)
+	mut.Set(metadata.Standard, NFT_COLUMN_IMAGE_FORMAT, gcp_bigtable.Timestamp(0), []byte(metadata.ImageFormat))
+
+	return bigtable.tableMetadata.Apply(ctx, fmt.Sprintf("%s:NFT:%x:%s", bigtable.chainId, metadata.Token, metadata.TokenId.String()), mut)
+}
+
+// GetNftMetadata returns the metadata of a single nft including its thumbnail, nil is returned if the metadata has not been resolved yet
+func (bigtable *Bigtable) GetNftMetadata(token []byte, tokenId *big.Int) (*types.NftMetadata, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	row, err := bigtable.tableMetadata.ReadRow(ctx, fmt.Sprintf("%s:NFT:%x:%s", bigtable.chainId, token, tokenId.String()), gcp_bigtable.RowFilter(gcp_bigtable.LatestNFilter(1)))
+	if err != nil {
+		return nil, err
+	}
+	if len(row) == 0 {
+		return nil, nil
+	}
+	return parseNftMetadataRow(row, token, tokenId), nil
+}
+
+// getNftMetadataForTransfers returns the metadata without the thumbnails of the given nfts, the keys of the returned map are formatted as <TOKEN_ADDRESS>:<tokenId>
+func (bigtable *Bigtable) getNftMetadataForTransfers(tokens [][]byte, tokenIds []*big.Int) (map[string]*types.NftMetadata, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	ret := make(map[string]*types.NftMetadata, len(tokens))
+	keys := make(gcp_bigtable.RowList, 0, len(tokens))
+	for i := range tokens {
+		keys = append(keys, fmt.Sprintf("%s:NFT:%x:%s", bigtable.chainId, tokens[i], tokenIds[i].String()))
+	}
+	if len(keys) == 0 {
+		return ret, nil
+	}
+
+	filter := gcp_bigtable.ChainFilters(gcp_bigtable.ColumnFilter(fmt.Sprintf("%s|%s|%s", NFT_COLUMN_URI, NFT_COLUMN_METADATA, NFT_COLUMN_IMAGE_FORMAT)), gcp_bigtable.LatestNFilter(1))
+	err := bigtable.tableMetadata.ReadRows(ctx, keys, func(row gcp_bigtable.Row) bool {
+		split := strings.Split(row.Key(), ":")
+		tokenId, ok := new(big.Int).SetString(split[3], 10)
+		if !ok {
+			return true
+		}
+		ret[fmt.Sprintf("%s:%s", split[2], split[3])] = parseNftMetadataRow(row, common.FromHex(split[2]), tokenId)
+		return true
+	}, gcp_bigtable.RowFilter(filter))
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func parseNftMetadataRow(row gcp_bigtable.Row, token []byte, tokenId *big.Int) *types.NftMetadata {
+	metadata := &types.NftMetadata{
+		Token:   token,
+		TokenId: tokenId,
+	}
+	for family, items := range row {
+		metadata.Standard = family
+		for _, item := range items {
+			switch strings.TrimPrefix(item.Column, family+":") {
+			case NFT_COLUMN_URI:
+				metadata.Uri = string(item.Value)
+			case NFT_COLUMN_METADATA:
+				metadata.Raw = item.Value
+			case NFT_COLUMN_IMAGE:
+				metadata.Image = item.Value
+			case NFT_COLUMN_IMAGE_FORMAT:
+				metadata.ImageFormat = string(item.Value)
+			}
+		}
+	}
+	parseNftMetadataJson(metadata)
+	return metadata
+}
@@ -0,0 +1,102 @@
+package db
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/png"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFetchNftResourceDataUri(t *testing.T) {
+	json := `{"name":"Test #1","image":"ipfs://bafy/1.png","attributes":[{"trait_type":"Eyes","value":"Blue"}]}`
+
+	for _, uri := range []string{
+		"data:application/json;base64," + base64.StdEncoding.EncodeToString([]byte(json)),
+		"data:application/json;utf8," + json,
+	} {
+		content, contentType, err := fetchNftResource(uri, nftMetadataMaxSize)
+		if err != nil {
+			t.Fatalf("error fetching %v: %v", uri, err)
+		}
+		if string(content) != json {
+			t.Errorf("expected content %v, got %v", json, string(content))
+		}
+		if contentType != "application/json" && contentType != "application/json;utf8" {
+			t.Errorf("unexpected content type %v", contentType)
+		}
+	}
+
+	_, _, err := fetchNftResource("file:///etc/passwd", nftMetadataMaxSize)
+	if err == nil {
+		t.Errorf("expected an error for an unsupported uri scheme")
+	}
+}
+
+func TestCreateNftThumbnail(t *testing.T) {
+	buf := new(bytes.Buffer)
+	err := png.Encode(buf, image.NewRGBA(image.Rect(0, 0, 1024, 512)))
+	if err != nil {
+		t.Fatalf("error encoding image: %v", err)
+	}
+
+	thumbnail, format, err := createNftThumbnail(buf.Bytes(), "image/png")
+	if err != nil {
+		t.Fatalf("error creating thumbnail: %v", err)
+	}
+	if format != "image/png" {
+		t.Errorf("expected format image/png, got %v", format)
+	}
+	img, err := png.Decode(bytes.NewReader(thumbnail))
+	if err != nil {
+		t.Fatalf("error decoding thumbnail: %v", err)
+	}
+	if img.Bounds().Dx() != nftThumbnailSize || img.Bounds().Dy() != nftThumbnailSize/2 {
+		t.Errorf("expected thumbnail of %vx%v, got %v", nftThumbnailSize, nftThumbnailSize/2, img.Bounds())
+	}
+}
+
+func TestFetchNftResourceRejectsInternalAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name":"internal"}`))
+	}))
+	defer server.Close()
+
+	_, _, err := fetchNftResource(server.URL, nftMetadataMaxSize)
+	if err == nil || !strings.Contains(err.Error(), "non-public address") {
+		t.Errorf("expected the loopback address to be rejected, got %v", err)
+	}
+
+	for ip, public := range map[string]bool{"8.8.8.8": true, "10.0.0.1": false, "169.254.169.254": false, "::1": false, "::ffff:127.0.0.1": false, "fe80::1": false, "2606:4700::1111": true} {
+		if isPublicNftIP(net.ParseIP(ip)) != public {
+			t.Errorf("expected public=%v for %v", public, ip)
+		}
+	}
+}
+
+func TestCreateNftThumbnailRejectsOversizedImages(t *testing.T) {
+	// a png header announcing a 100000x100000 image, decoding it would allocate 40GB
+	ihdr := make([]byte, 13)
+	binary.BigEndian.PutUint32(ihdr[0:4], 100000)
+	binary.BigEndian.PutUint32(ihdr[4:8], 100000)
+	ihdr[8] = 8 // bit depth
+	ihdr[9] = 6 // rgba
+
+	buf := new(bytes.Buffer)
+	buf.WriteString("\x89PNG\r\n\x1a\n")
+	binary.Write(buf, binary.BigEndian, uint32(len(ihdr)))
+	buf.WriteString("IHDR")
+	buf.Write(ihdr)
+	binary.Write(buf, binary.BigEndian, crc32.ChecksumIEEE(append([]byte("IHDR"), ihdr...)))
+
+	_, _, err := createNftThumbnail(buf.Bytes(), "image/png")
+	if err == nil || !strings.Contains(err.Error(), "exceed the maximum") {
+		t.Errorf("expected the image to be rejected before decoding, got %v", err)
+	}
+}
@@ -0,0 +1,167 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"eth2-exporter/golden"
+	"eth2-exporter/rpc"
+	"eth2-exporter/types"
+	"eth2-exporter/utils"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	gcp_bigtable "cloud.google.com/go/bigtable"
+	"google.golang.org/protobuf/proto"
+)
+
+// the fixtures in testdata cover the validators 0-3 of mainnet day 1000, the postgres rows of the day are seeded from statistics_day_1000.sql,
+// the beacon node fixtures contain the validator states of the first and the last epoch of the day
+const (
+	defaultFixturesDir   = "testdata/fixtures"
+	defaultFixturesChain = "mainnet"
+	defaultTestStatsDay  = 1000
+)
+
+// TestWriteValidatorStatisticsForDay exports the statistics of a day against the test postgres instance of the config referenced by EXPLORER_TEST_CONFIG.
+// By default the day is seeded from the fixtures in testdata and bigtable is embedded. If EXPLORER_TEST_FIXTURES is set, the day in EXPLORER_TEST_STATS_DAY
+// is exported against the test bigtable instance of the config instead, the day and the previous day have to be exported to the test instances before.
+func TestWriteValidatorStatisticsForDay(t *testing.T) {
+	configPath := os.Getenv("EXPLORER_TEST_CONFIG")
+	if configPath == "" {
+		t.Skip("EXPLORER_TEST_CONFIG is required for fixture tests")
+	}
+
+	cfg := &types.Config{}
+	err := utils.ReadConfig(cfg, configPath)
+	if err != nil {
+		t.Fatalf("error reading config file: %v", err)
+	}
+	utils.Config = cfg
+
+	dbConfig := &types.DatabaseConfig{
+		Username:     cfg.WriterDatabase.Username,
+		Password:     cfg.WriterDatabase.Password,
+		Name:         cfg.WriterDatabase.Name,
+		Host:         cfg.WriterDatabase.Host,
+		Port:         cfg.WriterDatabase.Port,
+		MaxOpenConns: cfg.WriterDatabase.MaxOpenConns,
+		MaxIdleConns: cfg.WriterDatabase.MaxIdleConns,
+	}
+	MustInitDB(dbConfig, dbConfig)
+	err = ApplyEmbeddedDbSchema(-2)
+	if err != nil {
+		t.Fatalf("error applying db schema: %v", err)
+	}
+
+	day := uint64(defaultTestStatsDay)
+	fixturesDir := os.Getenv("EXPLORER_TEST_FIXTURES")
+	if fixturesDir == "" {
+		fixturesDir = defaultFixturesDir
+		if cfg.Chain.ClConfig.ConfigName != defaultFixturesChain {
+			t.Skipf("the fixtures in %v require the %v chain config, got %v", defaultFixturesDir, defaultFixturesChain, cfg.Chain.ClConfig.ConfigName)
+		}
+		seedStatisticsDay(t, day)
+	} else {
+		dayStr := os.Getenv("EXPLORER_TEST_STATS_DAY")
+		if dayStr == "" {
+			t.Skip("EXPLORER_TEST_STATS_DAY is required for this fixture test")
+		}
+		day, err = strconv.ParseUint(dayStr, 10, 64)
+		if err != nil {
+			t.Fatalf("invalid EXPLORER_TEST_STATS_DAY %v: %v", dayStr, err)
+		}
+		BigtableClient, err = InitBigtable(cfg.Bigtable.Project, cfg.Bigtable.Instance, fmt.Sprintf("%d", cfg.Chain.ClConfig.DepositChainID), cfg.RedisCacheEndpoint)
+		if err != nil {
+			t.Fatalf("error connecting to bigtable: %v", err)
+		}
+	}
+
+	client, err := rpc.NewFixtureClient(fixturesDir, new(big.Int).SetUint64(cfg.Chain.ClConfig.DepositChainID))
+	if err != nil {
+		t.Fatalf("error creating fixture client: %v", err)
+	}
+
+	_, err = WriterDb.Exec("DELETE FROM validator_stats_status WHERE day = $1", day)
+	if err != nil {
+		t.Fatalf("error resetting export status of day %v: %v", day, err)
+	}
+
+	err = WriteValidatorStatisticsForDay(day, client)
+	if err != nil {
+		t.Fatalf("error writing validator statistics for day %v: %v", day, err)
+	}
+
+	golden.CompareRows(t, WriterDb, fmt.Sprintf("validator_stats_%d", day), `
+		SELECT validatorindex, day, start_balance, end_balance, start_effective_balance, end_effective_balance,
+			missed_attestations, missed_attestations_total, participated_sync, participated_sync_total, missed_sync, missed_sync_total,
+			proposed_blocks, missed_blocks, orphaned_blocks, attester_slashings, proposer_slashings,
+			deposits, deposits_total, deposits_amount, deposits_amount_total, withdrawals, withdrawals_total, withdrawals_amount, withdrawals_amount_total,
+			cl_rewards_gwei, cl_rewards_gwei_total, el_rewards_wei, el_rewards_wei_total, mev_rewards_wei, mev_rewards_wei_total
+		FROM validator_stats WHERE day = $1 ORDER BY validatorindex`, day)
+}
+
+// seedStatisticsDay writes the postgres rows of testdata/statistics_day_<day>.sql and replaces BigtableClient by an embedded bigtable
+// holding the highest validator index of the last epoch and the execution blocks of the day
+func seedStatisticsDay(t *testing.T, day uint64) {
+	seed, err := os.ReadFile(fmt.Sprintf("testdata/statistics_day_%d.sql", day))
+	if err != nil {
+		t.Fatalf("error reading seed of day %v: %v", day, err)
+	}
+	_, err = WriterDb.Exec(string(seed))
+	if err != nil {
+		t.Fatalf("error seeding day %v: %v", day, err)
+	}
+	_, lastEpoch := utils.GetFirstAndLastEpochForDay(day)
+	t.Cleanup(func() {
+		// the finalized epoch of the seed must not leak into other tests using the same database
+		WriterDb.Exec("DELETE FROM epochs WHERE epoch = $1", lastEpoch)
+	})
+
+	bt := newEmbeddedTestBigtable(t)
+	previousBigtableClient := BigtableClient
+	BigtableClient = bt
+	t.Cleanup(func() {
+		BigtableClient = previousBigtableClient
+	})
+
+	validators := []*types.Validator{}
+	for i := uint64(0); i <= 3; i++ {
+		validators = append(validators, &types.Validator{Index: i, Balance: 32000000000, EffectiveBalance: 32000000000})
+	}
+	err = bt.SaveValidatorBalances(lastEpoch, validators)
+	if err != nil {
+		t.Fatalf("error saving validator balances: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	// the payload of block 19000800 has been relayed, its mev reward is the value of the relay
+	for _, block := range []struct {
+		Number   uint64
+		Hash     byte
+		TxReward int64
+	}{
+		{19000000, 0xb0, 1000000000000000},
+		{19000800, 0xb2, 2000000000000000},
+	} {
+		b, err := proto.Marshal(&types.Eth1BlockIndexed{
+			Hash:     bytes.Repeat([]byte{block.Hash}, 32),
+			Number:   block.Number,
+			TxReward: big.NewInt(block.TxReward).Bytes(),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		mut := gcp_bigtable.NewMutation()
+		mut.Set(DEFAULT_FAMILY, DATA_COLUMN, gcp_bigtable.Timestamp(0), b)
+		err = bt.tableData.Apply(ctx, fmt.Sprintf("1:B:%s", reversedPaddedBlockNumber(block.Number)), mut)
+		if err != nil {
+			t.Fatalf("error writing block %v: %v", block.Number, err)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+-- mainnet day 1000 (epochs 225000 - 225224) of the validators 0 - 3, used by TestWriteValidatorStatisticsForDay:
+--   validator 0 proposes slot 7200800 (with an attester slashing and a relayed payload) and withdraws, it is member of both sync committees
+--   validator 1 proposes slot 7200000, misses the attestation of epoch 225024 and withdraws, it is member of the sync committee of period 878
+--   validator 2 misses slot 7200001, its withdrawal in the orphaned slot 7200002 is ignored, it is member of the sync committee of period 879
+--   validator 3 proposes the orphaned slot 7200002 and is deposited in slot 7200000, it is activated in epoch 225100
+DELETE FROM validators WHERE validatorindex <= 3;
+DELETE FROM blocks_attestations WHERE block_slot BETWEEN 7199968 AND 7207231;
+DELETE FROM blocks_deposits WHERE block_slot BETWEEN 7199968 AND 7207231;
+DELETE FROM blocks_withdrawals WHERE block_slot BETWEEN 7199968 AND 7207231;
+DELETE FROM relays_blocks WHERE block_slot BETWEEN 7199968 AND 7207231;
+DELETE FROM blocks WHERE slot BETWEEN 7199968 AND 7207231;
+DELETE FROM sync_committees WHERE period IN (878, 879);
+DELETE FROM validator_stats WHERE day IN (999, 1000);
+DELETE FROM validator_stats_status WHERE day IN (999, 1000);
+DELETE FROM epochs WHERE epoch = 225224;
+
+INSERT INTO validators (validatorindex, pubkey, withdrawableepoch, withdrawalcredentials, balance, effectivebalance, slashed, activationeligibilityepoch, activationepoch, exitepoch) VALUES
+    (0, decode(repeat('a0', 48), 'hex'), 9223372036854775807, '\x01', 32008000000, 32000000000, false, 0, 0, 9223372036854775807),
+    (1, decode(repeat('a1', 48), 'hex'), 9223372036854775807, '\x01', 32003000000, 32000000000, false, 0, 0, 9223372036854775807),
+    (2, decode(repeat('a2', 48), 'hex'), 9223372036854775807, '\x01', 32006000000, 32000000000, false, 0, 0, 9223372036854775807),
+    (3, decode(repeat('a3', 48), 'hex'), 9223372036854775807, '\x01', 32001000000, 32000000000, false, 225001, 225100, 9223372036854775807);
+
+INSERT INTO blocks (epoch, slot, blockroot, parentroot, stateroot, signature, eth1data_depositcount, syncaggregate_bits, proposerslashingscount, attesterslashingscount, attestationscount, depositscount, withdrawalcount, voluntaryexitscount, proposer, status, exec_block_number, exec_block_hash) VALUES
+    (225000, 7200000, decode(repeat('10', 32), 'hex'), decode(repeat('0f', 32), 'hex'), '\x00', '\x00', 0, '\x01', 0, 0, 1, 2, 0, 0, 1, '1', 19000000, decode(repeat('b0', 32), 'hex')),
+    (225000, 7200001, '\x01', '\x01', '\x01', '\x01', 0, NULL, 0, 0, 0, 0, 0, 0, 2, '2', NULL, NULL),
+    (225000, 7200002, decode(repeat('12', 32), 'hex'), decode(repeat('10', 32), 'hex'), '\x00', '\x00', 0, '\x00', 0, 0, 0, 0, 1, 0, 3, '3', 19000001, decode(repeat('b1', 32), 'hex')),
+    (225025, 7200800, decode(repeat('13', 32), 'hex'), decode(repeat('10', 32), 'hex'), '\x00', '\x00', 0, '\x03', 0, 1, 1, 0, 2, 0, 0, '1', 19000800, decode(repeat('b2', 32), 'hex'));
+
+-- the attestation of slot 7199999 belongs to the previous day
+INSERT INTO blocks_attestations (block_slot, block_index, block_root, aggregationbits, validators, signature, slot, committeeindex, beaconblockroot, source_epoch, source_root, target_epoch, target_root) VALUES
+    (7200000, 0, decode(repeat('10', 32), 'hex'), '\x0f', '{0,1,2}', '\x00', 7199999, 0, '\x00', 224998, '\x00', 224999, '\x00'),
+    (7200800, 0, decode(repeat('13', 32), 'hex'), '\x0b', '{0,2}', '\x00', 7200799, 0, '\x00', 225023, '\x00', 225024, '\x00');
+
+-- the second deposit of validator 3 has an invalid signature
+INSERT INTO blocks_deposits (block_slot, block_index, block_root, publickey, withdrawalcredentials, amount, signature, valid_signature) VALUES
+    (7200000, 0, decode(repeat('10', 32), 'hex'), decode(repeat('a3', 48), 'hex'), '\x01', 32000000000, '\x00', true),
+    (7200000, 1, decode(repeat('10', 32), 'hex'), decode(repeat('a3', 48), 'hex'), '\x01', 1000000000, '\x00', false);
+
+INSERT INTO blocks_withdrawals (block_slot, block_root, withdrawalindex, validatorindex, address, amount) VALUES
+    (7200002, decode(repeat('12', 32), 'hex'), 100, 2, '\x02', 7000000),
+    (7200800, decode(repeat('13', 32), 'hex'), 100, 0, '\x00', 15000000),
+    (7200800, decode(repeat('13', 32), 'hex'), 101, 1, '\x01', 20000000);
+
+INSERT INTO relays_blocks (tag_id, block_slot, block_root, exec_block_hash, builder_pubkey, proposer_pubkey, proposer_fee_recipient, value) VALUES
+    ('test', 7200800, decode(repeat('13', 32), 'hex'), decode(repeat('b2', 32), 'hex'), '\x00', decode(repeat('a0', 48), 'hex'), '\x00', 50000000000000000);
+
+INSERT INTO sync_committees (period, validatorindex, committeeindex) VALUES
+    (878, 0, 0),
+    (878, 1, 1),
+    (879, 2, 0),
+    (879, 0, 1);
+
+INSERT INTO validator_stats (validatorindex, day, end_balance, missed_attestations_total, participated_sync_total, missed_sync_total, orphaned_sync_total, deposits_total, deposits_amount_total, withdrawals_total, withdrawals_amount_total, cl_rewards_gwei_total, el_rewards_wei_total, mev_rewards_wei_total) VALUES
+    (0, 999, 32009000000, 5, 10, 2, 0, 1, 32000000000, 3, 45000000, 100000000, 1000, 2000),
+    (1, 999, 32019000000, 0, 0, 0, 0, 1, 32000000000, 2, 40000000, 90000000, 0, 0),
+    (2, 999, 32004500000, 1, 4, 1, 1, 1, 32000000000, 0, 0, 80000000, 500, 500);
+
+INSERT INTO validator_stats_status (day, status) VALUES (999, true);
+
+INSERT INTO epochs (epoch, proposerslashingscount, attesterslashingscount, attestationscount, depositscount, voluntaryexitscount, validatorscount, averagevalidatorbalance, totalvalidatorbalance, finalized) VALUES
+    (225224, 0, 0, 0, 0, 0, 4, 32004500000, 128018000000, true);
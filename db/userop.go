@@ -0,0 +1,369 @@
+package db
+
+import (
+	"context"
+	"eth2-exporter/types"
+	"eth2-exporter/utils"
+	"fmt"
+	"html/template"
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+
+	gcp_bigtable "cloud.google.com/go/bigtable"
+	"github.com/coocood/freecache"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	USEROP_SENDER    IndexFilter = "SENDER"
+	USEROP_PAYMASTER IndexFilter = "PAYMASTER"
+	USEROP_BUNDLER   IndexFilter = "BUNDLER"
+)
+
+// the abis of the canonical erc-4337 entry points, only the parts required for indexing user operations are included
+const (
+	entryPointV06UserOperation = `[{"name":"sender","type":"address"},{"name":"nonce","type":"uint256"},{"name":"initCode","type":"bytes"},{"name":"callData","type":"bytes"},{"name":"callGasLimit","type":"uint256"},{"name":"verificationGasLimit","type":"uint256"},{"name":"preVerificationGas","type":"uint256"},{"name":"maxFeePerGas","type":"uint256"},{"name":"maxPriorityFeePerGas","type":"uint256"},{"name":"paymasterAndData","type":"bytes"},{"name":"signature","type":"bytes"}]`
+	entryPointV07UserOperation = `[{"name":"sender","type":"address"},{"name":"nonce","type":"uint256"},{"name":"initCode","type":"bytes"},{"name":"callData","type":"bytes"},{"name":"accountGasLimits","type":"bytes32"},{"name":"preVerificationGas","type":"uint256"},{"name":"gasFees","type":"bytes32"},{"name":"paymasterAndData","type":"bytes"},{"name":"signature","type":"bytes"}]`
+
+	entryPointV06Abi = `[{"type":"function","name":"handleOps","inputs":[{"name":"ops","type":"tuple[]","components":` + entryPointV06UserOperation + `},{"name":"beneficiary","type":"address"}],"outputs":[]},` +
+		`{"type":"function","name":"handleAggregatedOps","inputs":[{"name":"opsPerAggregator","type":"tuple[]","components":[{"name":"userOps","type":"tuple[]","components":` + entryPointV06UserOperation + `},{"name":"aggregator","type":"address"},{"name":"signature","type":"bytes"}]},{"name":"beneficiary","type":"address"}],"outputs":[]},` + entryPointEventsAbi
+	entryPointV07Abi = `[{"type":"function","name":"handleOps","inputs":[{"name":"ops","type":"tuple[]","components":` + entryPointV07UserOperation + `},{"name":"beneficiary","type":"address"}],"outputs":[]},` +
+		`{"type":"function","name":"handleAggregatedOps","inputs":[{"name":"opsPerAggregator","type":"tuple[]","components":[{"name":"userOps","type":"tuple[]","components":` + entryPointV07UserOperation + `},{"name":"aggregator","type":"address"},{"name":"signature","type":"bytes"}]},{"name":"beneficiary","type":"address"}],"outputs":[]},` + entryPointEventsAbi
+
+	entryPointEventsAbi = `{"type":"event","name":"UserOperationEvent","anonymous":false,"inputs":[{"name":"userOpHash","type":"bytes32","indexed":true},{"name":"sender","type":"address","indexed":true},{"name":"paymaster","type":"address","indexed":true},{"name":"nonce","type":"uint256","indexed":false},{"name":"success","type":"bool","indexed":false},{"name":"actualGasCost","type":"uint256","indexed":false},{"name":"actualGasUsed","type":"uint256","indexed":false}]},` +
+		`{"type":"event","name":"UserOperationRevertReason","anonymous":false,"inputs":[{"name":"userOpHash","type":"bytes32","indexed":true},{"name":"sender","type":"address","indexed":true},{"name":"nonce","type":"uint256","indexed":false},{"name":"revertReason","type":"bytes","indexed":false}]}]`
+)
+
+var entryPointAbis = map[common.Address]abi.ABI{
+	common.HexToAddress("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789"): mustParseAbi(entryPointV06Abi),
+	common.HexToAddress("0x0000000071727De22E5E9d8BAf0edAc6f37da032"): mustParseAbi(entryPointV07Abi),
+}
+
+var (
+	userOperationEventTopic        = crypto.Keccak256Hash([]byte("UserOperationEvent(bytes32,address,address,uint256,bool,uint256,uint256)"))
+	userOperationRevertReasonTopic = crypto.Keccak256Hash([]byte("UserOperationRevertReason(bytes32,address,uint256,bytes)"))
+)
+
+// TransformUserOperations accepts an eth1 block and creates bigtable mutations for all erc-4337 user operations executed by the canonical entry points.
+// User operations are extracted from the UserOperationEvent logs, the call data and beneficiary are taken from the handleOps or handleAggregatedOps call
+// if the bundle was sent to the entry point directly.
+// It writes user operations to the table data:
+// Row:    <chainID>:USEROP:<userOpHash>
+// Family: f
+// Column: data
+// Cell:   Proto<Eth1UserOperationIndexed>
+//
+// It indexes user operations by:
+// Row:    <chainID>:I:USEROP:<SENDER_ADDRESS>:SENDER:<reversePaddedBlockNumber>:<paddedTxIndex>:<PaddedLogIndex>
+// Family: f
+// Column: <chainID>:USEROP:<userOpHash>
+// Cell:   nil
+//
+// Row:    <chainID>:I:USEROP:<PAYMASTER_ADDRESS>:PAYMASTER:<reversePaddedBlockNumber>:<paddedTxIndex>:<PaddedLogIndex>
+// Family: f
+// Column: <chainID>:USEROP:<userOpHash>
+// Cell:   nil
+//
+// Row:    <chainID>:I:USEROP:<BUNDLER_ADDRESS>:BUNDLER:<reversePaddedBlockNumber>:<paddedTxIndex>:<PaddedLogIndex>
+// Family: f
+// Column: <chainID>:USEROP:<userOpHash>
+// Cell:   nil
+//
+// The bundler is the sender of the transaction, the padded tx and log indexes have a fixed width like the log index
+func (bigtable *Bigtable) TransformUserOperations(blk *types.Eth1Block, cache *freecache.Cache) (bulkData *types.BulkMutations, bulkMetadataUpdates *types.BulkMutations, err error) {
+	bulkData = &types.BulkMutations{}
+	bulkMetadataUpdates = &types.BulkMutations{}
+
+	blockReversed := reversedPaddedBlockNumber(blk.GetNumber())
+	for i, tx := range blk.GetTransactions() {
+		if i >= TX_PER_BLOCK_LIMIT {
+			return nil, nil, fmt.Errorf("unexpected number of transactions in block expected at most %d but got: %v, tx: %x", TX_PER_BLOCK_LIMIT-1, i, tx.GetHash())
+		}
+		iReversed := reversePaddedIndex(i, TX_PER_BLOCK_LIMIT-1)
+
+		var ops []*types.Eth1UserOperationIndexed
+		var logIndexes []int
+		revertReasons := make(map[string][]byte)
+		for j, log := range tx.GetLogs() {
+			if j >= ITX_PER_TX_LIMIT {
+				return nil, nil, fmt.Errorf("unexpected number of logs in block expected at most %d but got: %v tx: %x", ITX_PER_TX_LIMIT-1, j, tx.GetHash())
+			}
+			entryPointAbi, found := entryPointAbis[common.BytesToAddress(log.GetAddress())]
+			if !found || len(log.GetTopics()) < 3 {
+				continue
+			}
+
+			switch common.BytesToHash(log.GetTopics()[0]) {
+			case userOperationEventTopic:
+				if len(log.GetTopics()) != 4 {
+					continue
+				}
+				values, err := entryPointAbi.Events["UserOperationEvent"].Inputs.NonIndexed().Unpack(log.GetData())
+				if err != nil {
+					logger.Warnf("error decoding UserOperationEvent in tx %x: %v", tx.GetHash(), err)
+					continue
+				}
+				op := &types.Eth1UserOperationIndexed{
+					Hash:          log.GetTopics()[1],
+					TxHash:        tx.GetHash(),
+					BlockNumber:   blk.GetNumber(),
+					Time:          blk.GetTime(),
+					EntryPoint:    log.GetAddress(),
+					Sender:        common.BytesToAddress(log.GetTopics()[2]).Bytes(),
+					Bundler:       tx.GetFrom(),
+					Nonce:         values[0].(*big.Int).Bytes(),
+					Success:       values[1].(bool),
+					ActualGasCost: values[2].(*big.Int).Bytes(),
+					ActualGasUsed: values[3].(*big.Int).Bytes(),
+					TxIndex:       uint64(i),
+					LogIndex:      uint64(j),
+				}
+				if paymaster := common.BytesToAddress(log.GetTopics()[3]); paymaster != (common.Address{}) {
+					op.Paymaster = paymaster.Bytes()
+				}
+				ops = append(ops, op)
+				logIndexes = append(logIndexes, j)
+			case userOperationRevertReasonTopic:
+				values, err := entryPointAbi.Events["UserOperationRevertReason"].Inputs.NonIndexed().Unpack(log.GetData())
+				if err != nil {
+					logger.Warnf("error decoding UserOperationRevertReason in tx %x: %v", tx.GetHash(), err)
+					continue
+				}
+				revertReasons[string(log.GetTopics()[1])] = values[1].([]byte)
+			}
+		}
+		if len(ops) == 0 {
+			continue
+		}
+
+		beneficiary, callData := decodeHandleOps(tx)
+		for k, op := range ops {
+			op.Beneficiary = beneficiary
+			op.CallData = callData[fmt.Sprintf("%x:%x", op.Sender, op.Nonce)]
+			op.RevertReason = revertReasons[string(op.Hash)]
+
+			key := fmt.Sprintf("%s:USEROP:%x", bigtable.chainId, op.Hash)
+			b, err := proto.Marshal(op)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			mut := gcp_bigtable.NewMutation()
+			mut.Set(DEFAULT_FAMILY, DATA_COLUMN, gcp_bigtable.Timestamp(0), b)
+
+			bulkData.Keys = append(bulkData.Keys, key)
+			bulkData.Muts = append(bulkData.Muts, mut)
+
+			jReversed := reversePaddedIndex(logIndexes[k], ITX_PER_TX_LIMIT-1)
+			indexes := []string{
+				fmt.Sprintf("%s:I:USEROP:%x:%s:%s:%s:%s", bigtable.chainId, op.Sender, USEROP_SENDER, blockReversed, iReversed, jReversed),
+				fmt.Sprintf("%s:I:USEROP:%x:%s:%s:%s:%s", bigtable.chainId, op.Bundler, USEROP_BUNDLER, blockReversed, iReversed, jReversed),
+			}
+			if len(op.Paymaster) > 0 {
+				indexes = append(indexes, fmt.Sprintf("%s:I:USEROP:%x:%s:%s:%s:%s", bigtable.chainId, op.Paymaster, USEROP_PAYMASTER, blockReversed, iReversed, jReversed))
+			}
+
+			for _, idx := range indexes {
+				mut := gcp_bigtable.NewMutation()
+				mut.Set(DEFAULT_FAMILY, key, gcp_bigtable.Timestamp(0), nil)
+
+				bulkData.Keys = append(bulkData.Keys, idx)
+				bulkData.Muts = append(bulkData.Muts, mut)
+			}
+		}
+	}
+
+	return bulkData, bulkMetadataUpdates, nil
+}
+
+func mustParseAbi(data string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(data))
+	if err != nil {
+		logger.Fatalf("error parsing entry point abi: %v", err)
+	}
+	return parsed
+}
+
+// decodeHandleOps decodes a handleOps or handleAggregatedOps call to an entry point and returns the beneficiary and the call data of the user operations by <sender>:<nonce>
+func decodeHandleOps(tx *types.Eth1Transaction) ([]byte, map[string][]byte) {
+	callData := make(map[string][]byte)
+
+	entryPointAbi, found := entryPointAbis[common.BytesToAddress(tx.GetTo())]
+	if !found || len(tx.GetData()) < 4 {
+		return nil, callData
+	}
+	method, err := entryPointAbi.MethodById(tx.GetData()[:4])
+	if err != nil || (method.Name != "handleOps" && method.Name != "handleAggregatedOps") {
+		return nil, callData
+	}
+	values, err := method.Inputs.Unpack(tx.GetData()[4:])
+	if err != nil {
+		logger.Warnf("error decoding %v call of tx %x: %v", method.Name, tx.GetHash(), err)
+		return nil, callData
+	}
+
+	// aggregated bundles group the user operations by their signature aggregator
+	opsLists := []reflect.Value{reflect.ValueOf(values[0])}
+	if method.Name == "handleAggregatedOps" {
+		opsLists = opsLists[:0]
+		opsPerAggregator := reflect.ValueOf(values[0])
+		for i := 0; i < opsPerAggregator.Len(); i++ {
+			opsLists = append(opsLists, opsPerAggregator.Index(i).FieldByName("UserOps"))
+		}
+	}
+
+	// the user operation structs differ between the entry point versions, only the common fields are read
+	for _, ops := range opsLists {
+		for i := 0; i < ops.Len(); i++ {
+			op := ops.Index(i)
+			sender, _ := op.FieldByName("Sender").Interface().(common.Address)
+			nonce, _ := op.FieldByName("Nonce").Interface().(*big.Int)
+			data, _ := op.FieldByName("CallData").Interface().([]byte)
+			if nonce == nil {
+				continue
+			}
+			callData[fmt.Sprintf("%x:%x", sender.Bytes(), nonce.Bytes())] = data
+		}
+	}
+	beneficiary, _ := values[1].(common.Address)
+	return beneficiary.Bytes(), callData
+}
+
+// GetUserOperation returns a user operation by its hash, nil is returned if the user operation has not been indexed
+func (bigtable *Bigtable) GetUserOperation(hash []byte) (*types.Eth1UserOperationIndexed, error) {
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second*30))
+	defer cancel()
+
+	row, err := bigtable.tableData.ReadRow(ctx, fmt.Sprintf("%s:USEROP:%x", bigtable.chainId, hash), gcp_bigtable.RowFilter(gcp_bigtable.LatestNFilter(1)))
+	if err != nil {
+		return nil, err
+	}
+	if len(row[DEFAULT_FAMILY]) == 0 {
+		return nil, nil
+	}
+
+	op := &types.Eth1UserOperationIndexed{}
+	err = proto.Unmarshal(row[DEFAULT_FAMILY][0].Value, op)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing Eth1UserOperationIndexed data for hash %x: %w", hash, err)
+	}
+	return op, nil
+}
+
+// GetUserOperationsForAddress returns the user operations of an address in desc order, role selects whether the address acted as sender, paymaster or bundler
+func (bigtable *Bigtable) GetUserOperationsForAddress(address []byte, role IndexFilter, pageToken string, limit int64) ([]*types.Eth1UserOperationIndexed, string, error) {
+
+	tmr := time.AfterFunc(REPORT_TIMEOUT, func() {
+		logger.WithFields(logrus.Fields{
+			"address":   address,
+			"role":      role,
+			"pageToken": pageToken,
+			"limit":     limit,
+		}).Warnf("%s call took longer than %v", utils.GetCurrentFuncName(), REPORT_TIMEOUT)
+	})
+	defer tmr.Stop()
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second*30))
+	defer cancel()
+
+	prefix := fmt.Sprintf("%s:I:USEROP:%x:%s:", bigtable.chainId, address, role)
+	rowRange := gcp_bigtable.PrefixRange(prefix)
+	if pageToken != "" {
+		if !strings.HasPrefix(pageToken, prefix) {
+			return nil, "", fmt.Errorf("page token %v does not match the address", pageToken)
+		}
+		// add \x00 to the row range such that we skip the previous value
+		rowRange = gcp_bigtable.NewRange(pageToken+"\x00", prefixSuccessor(prefix, 5))
+	}
+
+	keys := make([]string, 0, limit)
+	indexes := make([]string, 0, limit)
+	err := bigtable.tableData.ReadRows(ctx, rowRange, func(row gcp_bigtable.Row) bool {
+		keys = append(keys, strings.TrimPrefix(row[DEFAULT_FAMILY][0].Column, "f:"))
+		indexes = append(indexes, row.Key())
+		return true
+	}, gcp_bigtable.LimitRows(limit))
+	if err != nil {
+		return nil, "", err
+	}
+	if len(keys) == 0 {
+		return []*types.Eth1UserOperationIndexed{}, "", nil
+	}
+
+	keysMap := make(map[string]*types.Eth1UserOperationIndexed, len(keys))
+	err = bigtable.tableData.ReadRows(ctx, gcp_bigtable.RowList(keys), func(row gcp_bigtable.Row) bool {
+		op := &types.Eth1UserOperationIndexed{}
+		err := proto.Unmarshal(row[DEFAULT_FAMILY][0].Value, op)
+		if err != nil {
+			logrus.Fatalf("error parsing Eth1UserOperationIndexed data: %v", err)
+		}
+		keysMap[row.Key()] = op
+		return true
+	})
+	if err != nil {
+		logger.WithError(err).WithField("prefix", prefix).WithField("limit", limit).Errorf("error reading rows in bigtable_eth1 / GetUserOperationsForAddress")
+		return nil, "", err
+	}
+
+	ops := make([]*types.Eth1UserOperationIndexed, 0, len(keys))
+	for _, key := range keys {
+		if op := keysMap[key]; op != nil {
+			ops = append(ops, op)
+		}
+	}
+
+	lastKey := indexes[len(indexes)-1]
+	if int64(len(keys)) < limit {
+		lastKey = ""
+	}
+	return ops, lastKey, nil
+}
+
+func (bigtable *Bigtable) GetAddressUserOperationsTableData(address []byte, role IndexFilter, pageToken string) (*types.DataTableResponse, error) {
+	ops, lastKey, err := bigtable.GetUserOperationsForAddress(address, role, pageToken, DefaultInfScrollRows)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]string)
+	for _, op := range ops {
+		names[string(op.Sender)] = ""
+		if len(op.Paymaster) > 0 {
+			names[string(op.Paymaster)] = ""
+		}
+		names[string(op.Bundler)] = ""
+	}
+	names, _, err = BigtableClient.GetAddressesNamesArMetadata(&names, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	tableData := make([][]interface{}, len(ops))
+	for i, op := range ops {
+		paymaster := template.HTML("-")
+		if len(op.Paymaster) > 0 {
+			paymaster = utils.FormatAddressWithLimitsInAddressPageTable(address, op.Paymaster, names[string(op.Paymaster)], true, digitLimitInAddressPagesTable, nameLimitInAddressPagesTable, true)
+		}
+		tableData[i] = []interface{}{
+			utils.FormatUserOperationHash(op.Hash, op.Success),
+			utils.FormatTransactionHash(op.TxHash, true),
+			utils.FormatBlockNumber(op.BlockNumber),
+			utils.FormatTimestamp(op.Time.AsTime().Unix()),
+			utils.FormatAddressWithLimitsInAddressPageTable(address, op.Sender, names[string(op.Sender)], true, digitLimitInAddressPagesTable, nameLimitInAddressPagesTable, true),
+			paymaster,
+			utils.FormatAddressWithLimitsInAddressPageTable(address, op.Bundler, names[string(op.Bundler)], false, digitLimitInAddressPagesTable, nameLimitInAddressPagesTable, true),
+			utils.FormatAmount(new(big.Int).SetBytes(op.ActualGasCost), utils.Config.Frontend.ElCurrency, 6),
+		}
+	}
+
+	return &types.DataTableResponse{
+		Data:        tableData,
+		PagingToken: lastKey,
+	}, nil
+}
@@ -0,0 +1,278 @@
+package db
+
+import (
+	"bytes"
+	"eth2-exporter/types"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/coocood/freecache"
+	"github.com/ethereum/go-ethereum/common"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	entryPointV06 = common.HexToAddress("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789")
+	entryPointV07 = common.HexToAddress("0x0000000071727De22E5E9d8BAf0edAc6f37da032")
+)
+
+type testUserOperationV06 struct {
+	Sender               common.Address
+	Nonce                *big.Int
+	InitCode             []byte
+	CallData             []byte
+	CallGasLimit         *big.Int
+	VerificationGasLimit *big.Int
+	PreVerificationGas   *big.Int
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+	PaymasterAndData     []byte
+	Signature            []byte
+}
+
+type testUserOperationV07 struct {
+	Sender             common.Address
+	Nonce              *big.Int
+	InitCode           []byte
+	CallData           []byte
+	AccountGasLimits   [32]byte
+	PreVerificationGas *big.Int
+	GasFees            [32]byte
+	PaymasterAndData   []byte
+	Signature          []byte
+}
+
+func testUserOperation(entryPoint common.Address, sender byte, nonce int64) interface{} {
+	if entryPoint == entryPointV06 {
+		return testUserOperationV06{
+			Sender:               common.BytesToAddress([]byte{sender}),
+			Nonce:                big.NewInt(nonce),
+			CallData:             []byte{sender, byte(nonce)},
+			CallGasLimit:         big.NewInt(1),
+			VerificationGasLimit: big.NewInt(1),
+			PreVerificationGas:   big.NewInt(1),
+			MaxFeePerGas:         big.NewInt(1),
+			MaxPriorityFeePerGas: big.NewInt(1),
+		}
+	}
+	return testUserOperationV07{
+		Sender:             common.BytesToAddress([]byte{sender}),
+		Nonce:              big.NewInt(nonce),
+		CallData:           []byte{sender, byte(nonce)},
+		PreVerificationGas: big.NewInt(1),
+	}
+}
+
+// packHandleOps returns the call data of a handleOps call, or of a handleAggregatedOps call with one aggregator per op if aggregated is set
+func packHandleOps(t *testing.T, entryPoint common.Address, aggregated bool, beneficiary common.Address, ops ...interface{}) []byte {
+	t.Helper()
+
+	var data []byte
+	var err error
+	switch {
+	case entryPoint == entryPointV06 && !aggregated:
+		v06 := make([]testUserOperationV06, 0, len(ops))
+		for _, op := range ops {
+			v06 = append(v06, op.(testUserOperationV06))
+		}
+		data, err = entryPointAbis[entryPoint].Pack("handleOps", v06, beneficiary)
+	case entryPoint == entryPointV06:
+		type opsPerAggregator struct {
+			UserOps    []testUserOperationV06
+			Aggregator common.Address
+			Signature  []byte
+		}
+		perAggregator := make([]opsPerAggregator, 0, len(ops))
+		for i, op := range ops {
+			perAggregator = append(perAggregator, opsPerAggregator{UserOps: []testUserOperationV06{op.(testUserOperationV06)}, Aggregator: common.BytesToAddress([]byte{0xa0 + byte(i)})})
+		}
+		data, err = entryPointAbis[entryPoint].Pack("handleAggregatedOps", perAggregator, beneficiary)
+	case !aggregated:
+		v07 := make([]testUserOperationV07, 0, len(ops))
+		for _, op := range ops {
+			v07 = append(v07, op.(testUserOperationV07))
+		}
+		data, err = entryPointAbis[entryPoint].Pack("handleOps", v07, beneficiary)
+	default:
+		type opsPerAggregator struct {
+			UserOps    []testUserOperationV07
+			Aggregator common.Address
+			Signature  []byte
+		}
+		perAggregator := make([]opsPerAggregator, 0, len(ops))
+		for i, op := range ops {
+			perAggregator = append(perAggregator, opsPerAggregator{UserOps: []testUserOperationV07{op.(testUserOperationV07)}, Aggregator: common.BytesToAddress([]byte{0xa0 + byte(i)})})
+		}
+		data, err = entryPointAbis[entryPoint].Pack("handleAggregatedOps", perAggregator, beneficiary)
+	}
+	if err != nil {
+		t.Fatalf("error packing call data: %v", err)
+	}
+	return data
+}
+
+func TestDecodeHandleOps(t *testing.T) {
+	beneficiary := common.BytesToAddress([]byte{0xbe})
+
+	tests := []struct {
+		Name       string
+		EntryPoint common.Address
+		Aggregated bool
+		Selector   string
+	}{
+		{"v0.6 handleOps", entryPointV06, false, "1fad948c"},
+		{"v0.6 handleAggregatedOps", entryPointV06, true, "4b1d7cf5"},
+		{"v0.7 handleOps", entryPointV07, false, "765e827f"},
+		{"v0.7 handleAggregatedOps", entryPointV07, true, "dbed18e0"},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			data := packHandleOps(t, test.EntryPoint, test.Aggregated, beneficiary, testUserOperation(test.EntryPoint, 1, 0), testUserOperation(test.EntryPoint, 2, 5))
+			if fmt.Sprintf("%x", data[:4]) != test.Selector {
+				t.Fatalf("expected selector %v, got %x", test.Selector, data[:4])
+			}
+
+			decodedBeneficiary, callData := decodeHandleOps(&types.Eth1Transaction{To: test.EntryPoint.Bytes(), Data: data})
+			if !bytes.Equal(decodedBeneficiary, beneficiary.Bytes()) {
+				t.Errorf("expected beneficiary %x, got %x", beneficiary, decodedBeneficiary)
+			}
+			expected := map[string][]byte{
+				fmt.Sprintf("%x:", common.BytesToAddress([]byte{1}).Bytes()):   {1, 0},
+				fmt.Sprintf("%x:05", common.BytesToAddress([]byte{2}).Bytes()): {2, 5},
+			}
+			if len(callData) != len(expected) {
+				t.Fatalf("expected the call data of %v user operations, got %v", len(expected), callData)
+			}
+			for key, data := range expected {
+				if !bytes.Equal(callData[key], data) {
+					t.Errorf("expected call data %x for %v, got %x", data, key, callData[key])
+				}
+			}
+		})
+	}
+
+	// bundles that are not sent to an entry point directly are not decoded
+	data := packHandleOps(t, entryPointV07, false, beneficiary, testUserOperation(entryPointV07, 1, 0))
+	decodedBeneficiary, callData := decodeHandleOps(&types.Eth1Transaction{To: common.BytesToAddress([]byte{0xff}).Bytes(), Data: data})
+	if decodedBeneficiary != nil || len(callData) != 0 {
+		t.Errorf("expected no user operations for a call to another contract, got %x %v", decodedBeneficiary, callData)
+	}
+	// the call data of one entry point version does not decode with the abi of the other one
+	decodedBeneficiary, callData = decodeHandleOps(&types.Eth1Transaction{To: entryPointV06.Bytes(), Data: data})
+	if decodedBeneficiary != nil || len(callData) != 0 {
+		t.Errorf("expected no user operations for a v0.7 call to the v0.6 entry point, got %x %v", decodedBeneficiary, callData)
+	}
+}
+
+func TestTransformUserOperations(t *testing.T) {
+	bt := newEmbeddedTestBigtable(t)
+
+	bundler := bytes.Repeat([]byte{0xb0}, 20)
+	beneficiary := common.BytesToAddress([]byte{0xbe})
+	paymaster := common.BytesToAddress([]byte{0x9a})
+	opHash := func(b byte) []byte {
+		return bytes.Repeat([]byte{b}, 32)
+	}
+	userOperationEvent := func(entryPoint common.Address, hash []byte, sender byte, paymaster common.Address, nonce int64, success bool) *types.Eth1Log {
+		data, err := entryPointAbis[entryPoint].Events["UserOperationEvent"].Inputs.NonIndexed().Pack(big.NewInt(nonce), success, big.NewInt(1000), big.NewInt(100))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &types.Eth1Log{
+			Address: entryPoint.Bytes(),
+			Topics:  [][]byte{userOperationEventTopic.Bytes(), hash, common.BytesToHash([]byte{sender}).Bytes(), common.BytesToHash(paymaster.Bytes()).Bytes()},
+			Data:    data,
+		}
+	}
+	revertReasonData, err := entryPointAbis[entryPointV07].Events["UserOperationRevertReason"].Inputs.NonIndexed().Pack(big.NewInt(5), []byte("reverted"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	block := &types.Eth1Block{
+		Number: 10,
+		Hash:   bytes.Repeat([]byte{0x10}, 32),
+		Time:   timestamppb.New(time.Unix(1700000000, 0)),
+		Transactions: []*types.Eth1Transaction{
+			{
+				Hash: bytes.Repeat([]byte{0x11}, 32),
+				From: bundler,
+				To:   entryPointV06.Bytes(),
+				Data: packHandleOps(t, entryPointV06, true, beneficiary, testUserOperation(entryPointV06, 1, 0)),
+				Logs: []*types.Eth1Log{
+					userOperationEvent(entryPointV06, opHash(1), 1, common.Address{}, 0, true),
+				},
+			},
+			{
+				Hash: bytes.Repeat([]byte{0x12}, 32),
+				From: bundler,
+				To:   entryPointV07.Bytes(),
+				Data: packHandleOps(t, entryPointV07, false, beneficiary, testUserOperation(entryPointV07, 1, 1), testUserOperation(entryPointV07, 2, 5)),
+				Logs: []*types.Eth1Log{
+					userOperationEvent(entryPointV07, opHash(2), 1, paymaster, 1, true),
+					{Address: entryPointV07.Bytes(), Topics: [][]byte{userOperationRevertReasonTopic.Bytes(), opHash(3), common.BytesToHash([]byte{2}).Bytes()}, Data: revertReasonData},
+					userOperationEvent(entryPointV07, opHash(3), 2, common.Address{}, 5, false),
+					// events of other contracts are ignored
+					userOperationEvent(common.BytesToAddress([]byte{0xff}), opHash(4), 2, common.Address{}, 6, true),
+				},
+			},
+		},
+	}
+	err = bt.indexBlock(block, []func(blk *types.Eth1Block, cache *freecache.Cache) (*types.BulkMutations, *types.BulkMutations, error){bt.TransformUserOperations}, freecache.NewCache(1024*1024))
+	if err != nil {
+		t.Fatalf("error indexing block: %v", err)
+	}
+
+	op, err := bt.GetUserOperation(opHash(1))
+	if err != nil {
+		t.Fatalf("error getting user operation: %v", err)
+	}
+	if op == nil || !bytes.Equal(op.EntryPoint, entryPointV06.Bytes()) || !bytes.Equal(op.CallData, []byte{1, 0}) || !bytes.Equal(op.Beneficiary, beneficiary.Bytes()) || len(op.Paymaster) != 0 || !op.Success {
+		t.Errorf("unexpected user operation of an aggregated v0.6 bundle: %v", op)
+	}
+
+	op, err = bt.GetUserOperation(opHash(3))
+	if err != nil {
+		t.Fatalf("error getting user operation: %v", err)
+	}
+	if op == nil || !bytes.Equal(op.CallData, []byte{2, 5}) || op.Success || string(op.RevertReason) != "reverted" || op.TxIndex != 1 || op.LogIndex != 2 {
+		t.Errorf("unexpected reverted user operation of a v0.7 bundle: %v", op)
+	}
+
+	op, err = bt.GetUserOperation(opHash(4))
+	if err != nil || op != nil {
+		t.Errorf("expected no user operation for the event of another contract, got %v %v", op, err)
+	}
+
+	formatOps := func(ops []*types.Eth1UserOperationIndexed) string {
+		s := ""
+		for _, op := range ops {
+			s += fmt.Sprintf("%x ", op.Hash[:1])
+		}
+		return s
+	}
+	tests := []struct {
+		Name     string
+		Address  []byte
+		Role     IndexFilter
+		Expected string
+	}{
+		{"sender", common.BytesToAddress([]byte{1}).Bytes(), USEROP_SENDER, "02 01 "},
+		{"paymaster", paymaster.Bytes(), USEROP_PAYMASTER, "02 "},
+		{"bundler", bundler, USEROP_BUNDLER, "03 02 01 "},
+		{"bundler as sender", bundler, USEROP_SENDER, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			ops, _, err := bt.GetUserOperationsForAddress(test.Address, test.Role, "", 10)
+			if err != nil {
+				t.Fatalf("error getting user operations: %v", err)
+			}
+			if formatOps(ops) != test.Expected {
+				t.Errorf("expected user operations %q, got %q", test.Expected, formatOps(ops))
+			}
+		})
+	}
+}
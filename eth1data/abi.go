@@ -0,0 +1,172 @@
+package eth1data
+
+import (
+	"bytes"
+	"encoding/json"
+	"eth2-exporter/db"
+	"eth2-exporter/types"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	geth_types "github.com/ethereum/go-ethereum/core/types"
+)
+
+// DecodeCallData decodes the input parameters of a contract call using the abi of the called contract.
+// If the abi is not available or does not contain the method, the method signature is looked up in the signature database instead.
+// nil is returned if the call data could not be decoded
+func DecodeCallData(meta *types.ContractMetadata, data []byte) *types.Eth1DecodedCallData {
+	if len(data) < 4 {
+		return nil
+	}
+
+	if meta != nil && meta.ABI != nil {
+		if method, err := meta.ABI.MethodById(data[:4]); err == nil {
+			decoded, err := decodeMethodInputs(method, data[4:])
+			if err == nil {
+				decoded.Verified = true
+				return decoded
+			}
+			logger.Warnf("error decoding call data of method [%v] with contract abi: %v", method.Sig, err)
+		}
+	}
+
+	sig, err := db.BigtableClient.GetSignature(fmt.Sprintf("0x%x", data[:4]), types.MethodSignature)
+	if err != nil || sig == nil {
+		return nil
+	}
+	method, err := methodFromSignature(*sig)
+	if err != nil || !bytes.Equal(method.ID, data[:4]) {
+		return nil
+	}
+	decoded, err := decodeMethodInputs(method, data[4:])
+	if err != nil {
+		return nil
+	}
+	// the argument names of a signature are made up, only keep the types
+	decoded.Method = method.Sig
+	for i := range decoded.Inputs {
+		decoded.Inputs[i].Name = ""
+	}
+	return decoded
+}
+
+// DecodeRevertReason decodes the output of a reverted call. Custom errors are decoded using the abi of the called contract,
+// Error(string) and Panic(uint256) reverts are decoded without an abi. An empty string is returned if the output could not be decoded
+func DecodeRevertReason(meta *types.ContractMetadata, output []byte) string {
+	if len(output) < 4 {
+		return ""
+	}
+
+	if meta != nil && meta.ABI != nil {
+		if abiError, err := meta.ABI.ErrorByID([4]byte(output[:4])); err == nil {
+			values, err := abiError.Inputs.Unpack(output[4:])
+			if err == nil {
+				args := make([]string, 0, len(values))
+				for i, input := range abiError.Inputs {
+					args = append(args, fmt.Sprintf("%s: %s", input.Name, formatDecodedValue(input.Type, values[i]).Value))
+				}
+				return fmt.Sprintf("%s(%s)", abiError.Name, strings.Join(args, ", "))
+			}
+		}
+	}
+
+	errorMsg, err := abi.UnpackRevert(output)
+	if err != nil {
+		return ""
+	}
+	return errorMsg
+}
+
+// DecodeLog decodes the arguments of an event log using the abi of the emitting contract.
+// If the abi is not available or does not contain the event, only the event name is looked up in the signature database
+func DecodeLog(meta *types.ContractMetadata, log *geth_types.Log) *types.Eth1EventData {
+	eth1Event := &types.Eth1EventData{
+		Address: log.Address,
+		Topics:  log.Topics,
+		Data:    log.Data,
+	}
+	if len(log.Topics) == 0 {
+		return eth1Event
+	}
+
+	if meta != nil && meta.ABI != nil {
+		if event, err := meta.ABI.EventByID(log.Topics[0]); err == nil {
+			boundContract := bind.NewBoundContract(log.Address, *meta.ABI, nil, nil, nil)
+			logData := make(map[string]interface{})
+			err := boundContract.UnpackLogIntoMap(logData, event.Name, *log)
+			if err != nil {
+				logger.Warnf("error decoding event [%v] for tx [0x%x]", event.Name, log.TxHash)
+			}
+
+			eth1Event.Name = strings.Replace(event.String(), "event ", "", 1)
+			eth1Event.DecodedData = make(map[string]types.Eth1DecodedEventData, len(event.Inputs))
+			for _, input := range event.Inputs {
+				if val, found := logData[input.Name]; found {
+					eth1Event.DecodedData[input.Name] = formatDecodedValue(input.Type, val)
+				}
+			}
+			return eth1Event
+		}
+	}
+
+	eth1Event.Name = db.BigtableClient.GetEventLabel(log.Topics[0][:])
+	return eth1Event
+}
+
+func decodeMethodInputs(method *abi.Method, data []byte) (*types.Eth1DecodedCallData, error) {
+	values, err := method.Inputs.Unpack(data)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded := &types.Eth1DecodedCallData{
+		Method: strings.Replace(method.String(), "function ", "", 1),
+		Inputs: make([]types.Eth1DecodedArgument, 0, len(values)),
+	}
+	for i, input := range method.Inputs {
+		decoded.Inputs = append(decoded.Inputs, types.Eth1DecodedArgument{
+			Name:                 input.Name,
+			Eth1DecodedEventData: formatDecodedValue(input.Type, values[i]),
+		})
+	}
+	return decoded, nil
+}
+
+// methodFromSignature creates an abi method from a text signature like transfer(address,uint256)
+func methodFromSignature(signature string) (*abi.Method, error) {
+	selector, err := abi.ParseSelector(signature)
+	if err != nil {
+		return nil, err
+	}
+	abiJson, err := json.Marshal([]abi.SelectorMarshaling{selector})
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := abi.JSON(bytes.NewReader(abiJson))
+	if err != nil {
+		return nil, err
+	}
+	method, found := parsed.Methods[selector.Name]
+	if !found {
+		return nil, fmt.Errorf("method %v not found in parsed signature %v", selector.Name, signature)
+	}
+	return &method, nil
+}
+
+func formatDecodedValue(typ abi.Type, val interface{}) types.Eth1DecodedEventData {
+	decoded := types.Eth1DecodedEventData{
+		Type:  typ.String(),
+		Raw:   fmt.Sprintf("0x%x", val),
+		Value: fmt.Sprintf("%v", val),
+	}
+	if typ.T == abi.AddressTy {
+		decoded.Address = val.(common.Address)
+	}
+	if strings.HasPrefix(decoded.Type, "byte") {
+		decoded.Value = decoded.Raw
+	}
+	return decoded
+}
@@ -1,7 +1,6 @@
 package eth1data
 
 import (
-	"bytes"
 	"context"
 	"encoding/hex"
 	"errors"
@@ -16,8 +15,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/ethereum/go-ethereum/accounts/abi"
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
 	geth_types "github.com/ethereum/go-ethereum/core/types"
@@ -83,6 +80,23 @@ func GetEth1Transaction(hash common.Hash, currency string) (*types.Eth1TxData, e
 		return nil, fmt.Errorf("error retrieving code data for tx recipient %v: %w", tx.To(), err)
 	}
 
+	type contractMetadataMapEntry struct {
+		err  error
+		meta *types.ContractMetadata
+	}
+	contractMetadataCache := make(map[common.Address]contractMetadataMapEntry)
+
+	var toMetadata *types.ContractMetadata
+	if txPageData.TargetIsContract && !txPageData.IsContractCreation {
+		cmEntry := contractMetadataMapEntry{}
+		cmEntry.meta, cmEntry.err = db.BigtableClient.GetContractMetadata(txPageData.To.Bytes())
+		contractMetadataCache[*txPageData.To] = cmEntry
+		if cmEntry.err == nil {
+			toMetadata = cmEntry.meta
+		}
+		txPageData.DecodedCallData = DecodeCallData(toMetadata, tx.Data())
+	}
+
 	header, err := getBlockHeaderByHash(ctx, receipt.BlockHash)
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving block header data for tx: %w", err)
@@ -139,10 +153,7 @@ func GetEth1Transaction(hash common.Hash, currency string) (*types.Eth1TxData, e
 		return nil, fmt.Errorf("failed to get parity trace for revert reason: %w", err)
 	}
 	if receipt.Status != 1 {
-		errorMsg, err := abi.UnpackRevert(utils.MustParseHex(data[0].Result.Output))
-		if err == nil {
-			txPageData.ErrorMsg = errorMsg
-		}
+		txPageData.ErrorMsg = DecodeRevertReason(toMetadata, utils.MustParseHex(data[0].Result.Output))
 	} else {
 		txPageData.Transfers, err = db.BigtableClient.GetArbitraryTokenTransfersForTransaction(tx.Hash().Bytes())
 		if err != nil {
@@ -164,77 +175,16 @@ func GetEth1Transaction(hash common.Hash, currency string) (*types.Eth1TxData, e
 		}
 	}
 
-	if len(receipt.Logs) > 0 {
-		var wasContractMetadataCached bool
-		type contractMetadataMapEntry struct {
-			err  error
-			meta *types.ContractMetadata
+	for _, log := range receipt.Logs {
+		cmEntry, wasContractMetadataCached := contractMetadataCache[log.Address]
+		if !wasContractMetadataCached {
+			cmEntry.meta, cmEntry.err = db.BigtableClient.GetContractMetadata(log.Address.Bytes())
+			contractMetadataCache[log.Address] = cmEntry
 		}
-		var cmEntry contractMetadataMapEntry
-		contractMetadataCache := make(map[common.Address]contractMetadataMapEntry)
-
-		for _, log := range receipt.Logs {
-			if cmEntry, wasContractMetadataCached = contractMetadataCache[log.Address]; !wasContractMetadataCached {
-				cmEntry.meta, cmEntry.err = db.BigtableClient.GetContractMetadata(log.Address.Bytes())
-				contractMetadataCache[log.Address] = cmEntry
-			}
-			if cmEntry.err != nil || cmEntry.meta == nil || cmEntry.meta.ABI == nil {
-				name := ""
-				if len(log.Topics) > 0 {
-					name = db.BigtableClient.GetEventLabel(log.Topics[0][:])
-				}
-				eth1Event := &types.Eth1EventData{
-					Address: log.Address,
-					Name:    name,
-					Topics:  log.Topics,
-					Data:    log.Data,
-				}
-
-				txPageData.Events = append(txPageData.Events, eth1Event)
-			} else {
-				boundContract := bind.NewBoundContract(*txPageData.To, *cmEntry.meta.ABI, nil, nil, nil)
-
-				for name, event := range cmEntry.meta.ABI.Events {
-					if log != nil && len(log.Topics) > 0 && bytes.Equal(event.ID.Bytes(), log.Topics[0].Bytes()) {
-						logData := make(map[string]interface{})
-						err := boundContract.UnpackLogIntoMap(logData, name, *log)
-
-						if err != nil {
-							logger.Warnf("error decoding event [%v] for tx [0x%x]", name, tx.Hash())
-						}
-
-						eth1Event := &types.Eth1EventData{
-							Address:     log.Address,
-							Name:        strings.Replace(event.String(), "event ", "", 1),
-							Topics:      log.Topics,
-							Data:        log.Data,
-							DecodedData: map[string]types.Eth1DecodedEventData{},
-						}
-						typeMap := make(map[string]string)
-						for _, input := range cmEntry.meta.ABI.Events[name].Inputs {
-							typeMap[input.Name] = input.Type.String()
-						}
-
-						for lName, val := range logData {
-							a := types.Eth1DecodedEventData{
-								Type:  typeMap[lName],
-								Raw:   fmt.Sprintf("0x%x", val),
-								Value: fmt.Sprintf("%v", val),
-							}
-							b := typeMap[lName]
-							if b == "address" {
-								a.Address = val.(common.Address)
-							}
-							if strings.HasPrefix(b, "byte") {
-								a.Value = a.Raw
-							}
-							eth1Event.DecodedData[lName] = a
-						}
-
-						txPageData.Events = append(txPageData.Events, eth1Event)
-					}
-				}
-			}
+		if cmEntry.err != nil {
+			txPageData.Events = append(txPageData.Events, DecodeLog(nil, log))
+		} else {
+			txPageData.Events = append(txPageData.Events, DecodeLog(cmEntry.meta, log))
 		}
 	}
 
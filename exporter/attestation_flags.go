@@ -0,0 +1,99 @@
+package exporter
+
+import (
+	"bytes"
+	"eth2-exporter/rpc"
+	"eth2-exporter/types"
+	"eth2-exporter/utils"
+	"fmt"
+	"math"
+)
+
+// getAttestationFlags computes the correctness and timeliness of the votes of all attestations included in the block.
+// The votes are compared to the canonical chain of the node, the timeliness follows the altair participation flags
+// (source within sqrt(SLOTS_PER_EPOCH) slots, target within SLOTS_PER_EPOCH slots or unlimited since deneb, head in the next slot).
+func getAttestationFlags(client rpc.Client, block *types.Block) (map[types.Slot]map[types.ValidatorIndex]types.AttestationFlags, error) {
+	flags := make(map[types.Slot]map[types.ValidatorIndex]types.AttestationFlags)
+	roots := make(map[uint64][]byte)
+
+	slotsPerEpoch := utils.Config.Chain.ClConfig.SlotsPerEpoch
+	minInclusionDelay := utils.Config.Chain.ClConfig.MinAttestationInclusionDelay
+	if minInclusionDelay == 0 {
+		minInclusionDelay = 1
+	}
+	maxSourceInclusionDelay := uint64(math.Sqrt(float64(slotsPerEpoch)))
+
+	for _, a := range block.Attestations {
+		if a.Data == nil || a.Data.Target == nil || a.Data.Slot >= block.Slot {
+			continue
+		}
+
+		headRoot, err := getCanonicalBlockRoot(client, a.Data.Slot, roots)
+		if err != nil {
+			return nil, err
+		}
+		targetRoot, err := getCanonicalBlockRoot(client, a.Data.Target.Epoch*slotsPerEpoch, roots)
+		if err != nil {
+			return nil, err
+		}
+
+		// the source of an included attestation always matches the justified checkpoint of the state
+		f := types.AttestationFlagCorrectSource
+		if bytes.Equal(a.Data.Target.Root, targetRoot) {
+			f |= types.AttestationFlagCorrectTarget
+			if bytes.Equal(a.Data.BeaconBlockRoot, headRoot) {
+				f |= types.AttestationFlagCorrectHead
+			}
+		}
+
+		inclusionDelay := block.Slot - a.Data.Slot
+		if inclusionDelay <= maxSourceInclusionDelay {
+			f |= types.AttestationFlagTimelySource
+		}
+		if f.Has(types.AttestationFlagCorrectTarget) && (utils.EpochOfSlot(a.Data.Slot) >= utils.Config.Chain.ClConfig.DenebForkEpoch || inclusionDelay <= slotsPerEpoch) {
+			f |= types.AttestationFlagTimelyTarget
+		}
+		if f.Has(types.AttestationFlagCorrectHead) && inclusionDelay == minInclusionDelay {
+			f |= types.AttestationFlagTimelyHead
+		}
+
+		if flags[types.Slot(a.Data.Slot)] == nil {
+			flags[types.Slot(a.Data.Slot)] = make(map[types.ValidatorIndex]types.AttestationFlags)
+		}
+		for _, validator := range a.Attesters {
+			flags[types.Slot(a.Data.Slot)][types.ValidatorIndex(validator)] |= f
+		}
+	}
+
+	return flags, nil
+}
+
+// getCanonicalBlockRoot returns the root of the canonical block at the slot, for missed slots the root of the last block before
+func getCanonicalBlockRoot(client rpc.Client, slot uint64, roots map[uint64][]byte) ([]byte, error) {
+	visited := []uint64{}
+	for s := slot; ; s-- {
+		root, ok := roots[s]
+		if !ok {
+			header, err := client.GetBlockHeader(s)
+			if err != nil {
+				return nil, fmt.Errorf("error retrieving block header at slot %v: %w", s, err)
+			}
+			if header != nil {
+				root = utils.MustParseHex(header.Data.Root)
+				ok = true
+			}
+		}
+
+		visited = append(visited, s)
+		if ok {
+			for _, v := range visited {
+				roots[v] = root
+			}
+			return root, nil
+		}
+
+		if s == 0 {
+			return nil, fmt.Errorf("error no canonical block found at or before slot %v", slot)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package exporter
+
+import (
+	"eth2-exporter/rpc"
+	"eth2-exporter/types"
+	"eth2-exporter/utils"
+	"fmt"
+	"testing"
+)
+
+// headerClient serves block headers for the slots in roots, all other slots are missed
+type headerClient struct {
+	rpc.Client
+	roots map[uint64]string
+}
+
+func (c *headerClient) GetBlockHeader(slot uint64) (*rpc.StandardBeaconHeaderResponse, error) {
+	root, ok := c.roots[slot]
+	if !ok {
+		return nil, nil
+	}
+	header := &rpc.StandardBeaconHeaderResponse{}
+	header.Data.Root = root
+	return header, nil
+}
+
+func TestGetAttestationFlags(t *testing.T) {
+	utils.Config = &types.Config{}
+	utils.Config.Chain.ClConfig.SlotsPerEpoch = 32
+	utils.Config.Chain.ClConfig.MinAttestationInclusionDelay = 1
+	utils.Config.Chain.ClConfig.DenebForkEpoch = 1000
+
+	root := func(slot uint64) []byte {
+		return utils.MustParseHex(fmt.Sprintf("0x%064x", slot))
+	}
+	// slot 33 was missed, so the canonical head at slot 33 is the block of slot 32
+	client := &headerClient{roots: map[uint64]string{}}
+	for _, slot := range []uint64{32, 34, 35, 40, 70} {
+		client.roots[slot] = fmt.Sprintf("0x%064x", slot)
+	}
+
+	attestation := func(slot, head, target uint64, validator uint64) *types.Attestation {
+		return &types.Attestation{
+			Attesters: []uint64{validator},
+			Data: &types.AttestationData{
+				Slot:            slot,
+				BeaconBlockRoot: root(head),
+				Source:          &types.Checkpoint{Epoch: 0, Root: root(0)},
+				Target:          &types.Checkpoint{Epoch: 1, Root: root(target)},
+			},
+		}
+	}
+
+	block := &types.Block{
+		Slot: 35,
+		Attestations: []*types.Attestation{
+			attestation(34, 34, 32, 1), // perfect vote
+			attestation(33, 32, 32, 2), // head of a missed slot is the previous block, but included late for the head reward
+			attestation(34, 33, 32, 3), // wrong head
+			attestation(34, 34, 31, 4), // wrong target
+		},
+	}
+	lateBlock := &types.Block{
+		Slot:         70,
+		Attestations: []*types.Attestation{attestation(34, 34, 32, 5)}, // target is not timely before deneb
+	}
+
+	flags, err := getAttestationFlags(client, block)
+	if err != nil {
+		t.Fatalf("error getting attestation flags: %v", err)
+	}
+	lateFlags, err := getAttestationFlags(client, lateBlock)
+	if err != nil {
+		t.Fatalf("error getting attestation flags: %v", err)
+	}
+
+	all := types.AttestationFlagCorrectSource | types.AttestationFlagCorrectTarget | types.AttestationFlagCorrectHead | types.AttestationFlagTimelySource | types.AttestationFlagTimelyTarget | types.AttestationFlagTimelyHead
+	tests := []struct {
+		name     string
+		actual   types.AttestationFlags
+		expected types.AttestationFlags
+	}{
+		{"perfect", flags[34][1], all},
+		{"missed slot head", flags[33][2], all &^ types.AttestationFlagTimelyHead},
+		{"wrong head", flags[34][3], all &^ (types.AttestationFlagCorrectHead | types.AttestationFlagTimelyHead)},
+		{"wrong target", flags[34][4], types.AttestationFlagCorrectSource | types.AttestationFlagTimelySource},
+		{"late", lateFlags[34][5], types.AttestationFlagCorrectSource | types.AttestationFlagCorrectTarget | types.AttestationFlagCorrectHead},
+	}
+	for _, tt := range tests {
+		if tt.actual != tt.expected {
+			t.Errorf("%v: expected flags %06b but got %06b", tt.name, tt.expected, tt.actual)
+		}
+		if !tt.actual.Known() {
+			t.Errorf("%v: flags of an included attestation should be known", tt.name)
+		}
+	}
+}
@@ -0,0 +1,299 @@
+package exporter
+
+import (
+	"bytes"
+	"eth2-exporter/db"
+	"eth2-exporter/metrics"
+	"eth2-exporter/rpc"
+	"eth2-exporter/types"
+	"eth2-exporter/utils"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// maxChainReorgAttempts is the number of times the handling of a chain reorg is attempted before it is left to the finalization check of the slot exporter
+const maxChainReorgAttempts = 3
+
+type pendingChainReorg struct {
+	event    *rpc.ChainEvent
+	attempts int
+}
+
+// chainEventQueue decouples the event stream of the beacon node from the slot export runs.
+// The stream is drained continuously so it never blocks while an export run is in progress. Head, block and finalized checkpoint events
+// only trigger the next run, they are coalesced to the latest event of their topic. Chain reorgs are kept in order until they have been handled.
+type chainEventQueue struct {
+	mu     sync.Mutex
+	reorgs []*pendingChainReorg
+	latest map[string]*rpc.ChainEvent
+	notify chan struct{}
+}
+
+func newChainEventQueue() *chainEventQueue {
+	return &chainEventQueue{
+		latest: make(map[string]*rpc.ChainEvent),
+		notify: make(chan struct{}, 1),
+	}
+}
+
+// subscribe pushes all events of the stream to the queue until the stream is closed
+func (q *chainEventQueue) subscribe(events chan *rpc.ChainEvent) {
+	go func() {
+		for ev := range events {
+			q.push(ev)
+		}
+	}()
+}
+
+func (q *chainEventQueue) push(ev *rpc.ChainEvent) {
+	// the arrival and import of every block is recorded, even if its event is coalesced with a later one
+	if ev.Topic == rpc.BlockGossipEventTopic && ev.BlockGossip != nil {
+		blockArrivals.addGossip(uint64(ev.BlockGossip.Slot), utils.MustParseHex(ev.BlockGossip.Block), ev.ReceivedTs)
+		return
+	}
+	if ev.Topic == rpc.BlockEventTopic && ev.Block != nil {
+		blockArrivals.addImport(uint64(ev.Block.Slot), utils.MustParseHex(ev.Block.Block), ev.ReceivedTs)
+	}
+
+	q.mu.Lock()
+	if ev.Topic == rpc.ChainReorgEventTopic {
+		q.reorgs = append(q.reorgs, &pendingChainReorg{event: ev})
+	} else {
+		q.latest[ev.Topic] = ev
+	}
+	q.mu.Unlock()
+
+	q.signal()
+}
+
+// retry puts a chain reorg that could not be handled back in front of the queue
+func (q *chainEventQueue) retry(reorg *pendingChainReorg) {
+	q.mu.Lock()
+	q.reorgs = append([]*pendingChainReorg{reorg}, q.reorgs...)
+	q.mu.Unlock()
+
+	q.signal()
+}
+
+func (q *chainEventQueue) signal() {
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+// pop returns and removes all pending chain reorgs in their order and the latest event of every other topic
+func (q *chainEventQueue) pop() ([]*pendingChainReorg, []*rpc.ChainEvent) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	reorgs := q.reorgs
+	q.reorgs = nil
+
+	events := make([]*rpc.ChainEvent, 0, len(q.latest))
+	for _, topic := range []string{rpc.HeadEventTopic, rpc.BlockEventTopic, rpc.FinalizedCheckpointEventTopic} {
+		if ev := q.latest[topic]; ev != nil {
+			events = append(events, ev)
+		}
+	}
+	q.latest = make(map[string]*rpc.ChainEvent)
+
+	return reorgs, events
+}
+
+// waitForNextRun blocks until either the timeout has passed or the beacon node announced an event that requires a new slot export run.
+// Chain reorgs are handled right away so the following run already works on the new canonical chain, failed attempts are retried on the next call.
+func waitForNextRun(client rpc.Client, queue *chainEventQueue, timeout time.Duration) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			return
+		case <-queue.notify:
+			reorgs, events := queue.pop()
+			for i, reorg := range reorgs {
+				reorg.attempts++
+				err := handleChainReorg(client, reorg.event.ChainReorg, reorg.event.ReceivedTs)
+				if err == nil {
+					continue
+				}
+				fields := map[string]interface{}{"slot": uint64(reorg.event.ChainReorg.Slot), "depth": uint64(reorg.event.ChainReorg.Depth), "attempts": reorg.attempts}
+				if reorg.attempts < maxChainReorgAttempts {
+					utils.LogError(err, "error handling chain reorg, retrying", 0, fields)
+					// later reorgs build on the failed one, they are retried together
+					for j := len(reorgs) - 1; j >= i; j-- {
+						queue.retry(reorgs[j])
+					}
+					break
+				}
+				utils.LogError(err, "error handling chain reorg, leaving it to the finalization check", 0, fields)
+			}
+			for _, ev := range events {
+				switch ev.Topic {
+				case rpc.HeadEventTopic, rpc.BlockEventTopic:
+					logger.Infof("received %v event for slot %v", ev.Topic, getChainEventSlot(ev))
+				case rpc.FinalizedCheckpointEventTopic:
+					logger.Infof("received finalized checkpoint event for epoch %v", uint64(ev.FinalizedCheckpoint.Epoch))
+				}
+			}
+			if len(reorgs) > 0 || len(events) > 0 {
+				return
+			}
+		}
+	}
+}
+
+func getChainEventSlot(ev *rpc.ChainEvent) uint64 {
+	if ev.Head != nil {
+		return uint64(ev.Head.Slot)
+	}
+	if ev.Block != nil {
+		return uint64(ev.Block.Slot)
+	}
+	return 0
+}
+
+// handleChainReorg rolls back all slots affected by a reorg in postgres and bigtable, re-exports them from the new head and records the reorg.
+// Bigtable is not covered by the postgres tx, so the orphaned blocks are reverted in bigtable before anything is written to postgres.
+// If any step fails the tx is rolled back and the orphaned blocks are still canonical in postgres, so a retry finds and reverts them again.
+// The bigtable reverts only delete the cells written by the orphaned blocks, repeating them before the slots are re-exported is safe.
+func handleChainReorg(client rpc.Client, reorg *rpc.StreamedChainReorgEventData, ts time.Time) error {
+	start := time.Now()
+	defer func() {
+		metrics.TaskDuration.WithLabelValues("exporter_handle_chain_reorg").Observe(time.Since(start).Seconds())
+	}()
+
+	reorgSlot := uint64(reorg.Slot)
+	depth := uint64(reorg.Depth)
+
+	firstSlot := uint64(0)
+	if reorgSlot+1 > depth {
+		firstSlot = reorgSlot + 1 - depth
+	}
+
+	logger.WithFields(logrus.Fields{
+		"slot":         reorgSlot,
+		"depth":        depth,
+		"oldHeadBlock": reorg.OldHeadBlock,
+		"newHeadBlock": reorg.NewHeadBlock,
+	}).Warnf("chain reorg detected, re-exporting slots %v to %v", firstSlot, reorgSlot)
+	metrics.Tasks.WithLabelValues("exporter_chain_reorg").Inc()
+
+	head, err := client.GetChainHead()
+	if err != nil {
+		return fmt.Errorf("error retrieving chain head: %w", err)
+	}
+
+	tx, err := db.WriterDb.Beginx()
+	if err != nil {
+		return fmt.Errorf("error starting tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	dbSlots, err := db.GetCanonicalSlotsInRange(firstSlot, reorgSlot, tx)
+	if err != nil {
+		return err
+	}
+	dbSlotsMap := make(map[uint64]*db.GetAllNonFinalizedSlotsRow, len(dbSlots))
+	for _, dbSlot := range dbSlots {
+		dbSlotsMap[dbSlot.Slot] = dbSlot
+	}
+
+	exportSlots := make([]uint64, 0, depth)
+	orphaned := make([]*db.GetAllNonFinalizedSlotsRow, 0, depth)
+	for slot := firstSlot; slot <= reorgSlot; slot++ {
+		header, err := client.GetBlockHeader(slot)
+		if err != nil {
+			return fmt.Errorf("error retrieving block header for slot %v: %w", slot, err)
+		}
+
+		dbSlot := dbSlotsMap[slot]
+		if dbSlot != nil && dbSlot.Finalized {
+			return fmt.Errorf("reorg of slot %v conflicts with finalized data in the db", slot)
+		}
+
+		if dbSlot != nil && header != nil && bytes.Equal(dbSlot.BlockRoot, utils.MustParseHex(header.Data.Root)) {
+			// slot is part of both chains, nothing to do
+			continue
+		}
+
+		if dbSlot != nil {
+			orphaned = append(orphaned, dbSlot)
+		}
+		exportSlots = append(exportSlots, slot)
+	}
+
+	for _, dbSlot := range orphaned {
+		logger.Infof("rolling back orphaned block 0x%x at slot %v in bigtable", dbSlot.BlockRoot, dbSlot.Slot)
+		err := revertOrphanedBlock(client, dbSlot)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, dbSlot := range orphaned {
+		err := db.SetBlockOrphaned(dbSlot.Slot, dbSlot.BlockRoot, tx)
+		if err != nil {
+			return err
+		}
+
+		err = saveMissedSlotReason(client, dbSlot.Slot, dbSlot.BlockRoot, tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, slot := range exportSlots {
+		err = ExportSlot(client, slot, utils.EpochOfSlot(slot) == head.HeadEpoch, tx)
+		if err != nil {
+			return fmt.Errorf("error exporting slot %v: %w", slot, err)
+		}
+	}
+
+	err = db.SaveChainReorg(&types.ChainReorg{
+		Slot:         reorgSlot,
+		Depth:        depth,
+		OldHeadBlock: utils.MustParseHex(reorg.OldHeadBlock),
+		NewHeadBlock: utils.MustParseHex(reorg.NewHeadBlock),
+		OldHeadState: utils.MustParseHex(reorg.OldHeadState),
+		NewHeadState: utils.MustParseHex(reorg.NewHeadState),
+		Epoch:        uint64(reorg.Epoch),
+		Ts:           ts,
+	}, tx)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error committing tx: %w", err)
+	}
+
+	logger.Infof("handled chain reorg at slot %v with depth %v in %v", reorgSlot, depth, time.Since(start))
+	return nil
+}
+
+// revertOrphanedBlock removes the duties an orphaned block fulfilled from bigtable
+func revertOrphanedBlock(client rpc.Client, dbSlot *db.GetAllNonFinalizedSlotsRow) error {
+	// the node keeps non-finalized orphaned blocks, we need the full block to know which duties it fulfilled
+	block, err := client.GetBlockByBlockroot(dbSlot.BlockRoot)
+	if err != nil {
+		return fmt.Errorf("error retrieving orphaned block 0x%x at slot %v: %w", dbSlot.BlockRoot, dbSlot.Slot, err)
+	}
+
+	if len(block.BlockRoot) != 32 {
+		logger.Warnf("orphaned block 0x%x at slot %v is no longer available on the node, unable to revert its duties in bigtable", dbSlot.BlockRoot, dbSlot.Slot)
+		return nil
+	}
+
+	err = db.BigtableClient.RevertBlock(block)
+	if err != nil {
+		return fmt.Errorf("error reverting orphaned block 0x%x at slot %v in bigtable: %w", dbSlot.BlockRoot, dbSlot.Slot, err)
+	}
+	return nil
+}
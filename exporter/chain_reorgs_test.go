@@ -0,0 +1,131 @@
+package exporter
+
+import (
+	"encoding/json"
+	"eth2-exporter/rpc"
+	"eth2-exporter/types"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// unavailableClient fails to retrieve the chain head, so every attempt to handle a chain reorg fails before the db is touched
+type unavailableClient struct {
+	rpc.Client
+	calls int
+}
+
+func (c *unavailableClient) GetChainHead() (*types.ChainHead, error) {
+	c.calls++
+	return nil, fmt.Errorf("node unavailable")
+}
+
+func headEvent(t *testing.T, slot uint64) *rpc.ChainEvent {
+	ev := &rpc.ChainEvent{Topic: rpc.HeadEventTopic, Head: &rpc.StreamedHeadEventData{}, ReceivedTs: time.Now()}
+	err := json.Unmarshal([]byte(fmt.Sprintf(`{"slot":"%d"}`, slot)), ev.Head)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ev
+}
+
+func reorgEvent(t *testing.T, slot uint64) *rpc.ChainEvent {
+	ev := &rpc.ChainEvent{Topic: rpc.ChainReorgEventTopic, ChainReorg: &rpc.StreamedChainReorgEventData{}, ReceivedTs: time.Now()}
+	err := json.Unmarshal([]byte(fmt.Sprintf(`{"slot":"%d","depth":"1"}`, slot)), ev.ChainReorg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ev
+}
+
+func TestChainEventQueue(t *testing.T) {
+	blockArrivals = &blockArrivalTracker{arrivals: make(map[uint64][]*blockArrival)}
+
+	// the stream is drained while no export run waits for events
+	events := make(chan *rpc.ChainEvent)
+	queue := newChainEventQueue()
+	queue.subscribe(events)
+	for slot := uint64(1); slot <= 1000; slot++ {
+		select {
+		case events <- headEvent(t, slot):
+		case <-time.After(time.Second):
+			t.Fatalf("event stream blocked at slot %v", slot)
+		}
+	}
+	close(events)
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		_, pending := queue.pop()
+		if len(pending) == 1 && getChainEventSlot(pending[0]) == 1000 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the head events to be coalesced to the latest one, got %v", pending)
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	// chain reorgs are kept in order, all other events are coalesced per topic
+	queue = newChainEventQueue()
+	queue.push(headEvent(t, 10))
+	queue.push(reorgEvent(t, 10))
+	gossipTs := time.Now()
+	queue.push(&rpc.ChainEvent{Topic: rpc.BlockGossipEventTopic, BlockGossip: &rpc.StreamedBlockGossipEventData{Block: "0x0b"}, ReceivedTs: gossipTs})
+	queue.push(&rpc.ChainEvent{Topic: rpc.BlockEventTopic, Block: &rpc.StreamedBlockEventData{Block: "0x0b"}, ReceivedTs: gossipTs.Add(time.Second)})
+	queue.push(headEvent(t, 11))
+	queue.push(reorgEvent(t, 11))
+
+	reorgs, pending := queue.pop()
+	if len(reorgs) != 2 || reorgs[0].event.ChainReorg.Slot != 10 || reorgs[1].event.ChainReorg.Slot != 11 {
+		t.Errorf("expected the reorgs at slots 10 and 11 in order, got %v", reorgs)
+	}
+	if len(pending) != 2 || pending[0].Topic != rpc.HeadEventTopic || getChainEventSlot(pending[0]) != 11 || pending[1].Topic != rpc.BlockEventTopic {
+		t.Errorf("expected the latest head and block event, got %v", pending)
+	}
+	if arrivals, _ := blockArrivals.get(0); len(arrivals) != 1 || !arrivals[0].gossipTs.Equal(gossipTs) || !arrivals[0].importTs.Equal(gossipTs.Add(time.Second)) {
+		t.Errorf("expected the gossip and import of the block to be tracked, got %v", arrivals)
+	}
+
+	reorgs, pending = queue.pop()
+	if len(reorgs) != 0 || len(pending) != 0 {
+		t.Errorf("expected an empty queue, got %v %v", reorgs, pending)
+	}
+}
+
+func TestWaitForNextRunRetriesChainReorgs(t *testing.T) {
+	client := &unavailableClient{}
+	queue := newChainEventQueue()
+
+	start := time.Now()
+	waitForNextRun(client, queue, time.Millisecond*50)
+	if time.Since(start) < time.Millisecond*50 {
+		t.Errorf("expected to wait for the timeout without events")
+	}
+
+	// a failed reorg is put back in front of the queue together with the reorgs following it
+	queue.push(reorgEvent(t, 10))
+	queue.push(reorgEvent(t, 11))
+	waitForNextRun(client, queue, time.Minute)
+	if client.calls != 1 {
+		t.Fatalf("expected 1 attempt to handle the reorg, got %v", client.calls)
+	}
+	queue.mu.Lock()
+	if len(queue.reorgs) != 2 || queue.reorgs[0].event.ChainReorg.Slot != 10 || queue.reorgs[0].attempts != 1 || queue.reorgs[1].attempts != 0 {
+		t.Errorf("expected both reorgs to be queued again in order, got %v", queue.reorgs)
+	}
+	queue.mu.Unlock()
+
+	// the retries run right away, a reorg is given up after maxChainReorgAttempts so the next one is handled
+	for i := 0; i < maxChainReorgAttempts-1; i++ {
+		waitForNextRun(client, queue, time.Minute)
+	}
+	if client.calls != maxChainReorgAttempts+1 {
+		t.Errorf("expected %v attempts, got %v", maxChainReorgAttempts+1, client.calls)
+	}
+	queue.mu.Lock()
+	if len(queue.reorgs) != 1 || queue.reorgs[0].event.ChainReorg.Slot != 11 || queue.reorgs[0].attempts != 1 {
+		t.Errorf("expected only the second reorg to be left, got %v", queue.reorgs)
+	}
+	queue.mu.Unlock()
+}
@@ -36,6 +36,10 @@ func Start(client rpc.Client) {
 	if utils.Config.MevBoostRelayExporter.Enabled {
 		go mevBoostRelaysExporter()
 	}
+
+	if utils.Config.Indexer.LightClientExporter.Enabled {
+		go lightClientUpdatesExporter(client)
+	}
 	// wait until the beacon-node is available
 	for {
 		head, err := client.GetChainHead()
@@ -49,6 +53,11 @@ func Start(client rpc.Client) {
 
 	firstRun := true
 
+	// the event stream triggers export runs as soon as the node imports a new block and announces reorgs and finalization
+	chainEvents := newChainEventQueue()
+	chainEvents.subscribe(client.GetChainEventsChan())
+	blockArrivals.start(time.Now())
+
 	minWaitTimeBetweenRuns := time.Second * time.Duration(utils.Config.Chain.ClConfig.SecondsPerSlot)
 	for {
 		start := time.Now()
@@ -60,12 +69,12 @@ func Start(client rpc.Client) {
 		}
 
 		logrus.Info("update run completed")
+		services.ReportStatus("slotExporter", "Running", nil)
+
 		elapsed := time.Since(start)
 		if elapsed < minWaitTimeBetweenRuns {
-			time.Sleep(minWaitTimeBetweenRuns - elapsed)
+			waitForNextRun(client, chainEvents, minWaitTimeBetweenRuns-elapsed)
 		}
-
-		services.ReportStatus("slotExporter", "Running", nil)
 	}
 }
 
@@ -0,0 +1,155 @@
+package exporter
+
+import (
+	"eth2-exporter/db"
+	"eth2-exporter/golden"
+	"eth2-exporter/rpc"
+	"eth2-exporter/types"
+	"eth2-exporter/utils"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// the fixtures in testdata are an excerpt of mainnet: the proposed slot 32005 with a single attestation and the missed slot 32006 of epoch 1000,
+// and the sync committee of period 300
+const (
+	defaultFixturesDir   = "testdata/fixtures"
+	defaultFixturesChain = "mainnet"
+)
+
+var (
+	defaultTestSlots       = []uint64{32005, 32006}
+	defaultTestSyncPeriods = []uint64{300}
+)
+
+// setupFixtureTest connects to the test postgres and bigtable instances of the config referenced by EXPLORER_TEST_CONFIG
+// and returns a client replaying the beacon node fixtures in EXPLORER_TEST_FIXTURES, the fixtures in testdata are used if it is not set.
+// The tests are skipped if no config is set, fixtures can be recorded by setting indexer.recordFixturesDir in the config of the explorer.
+func setupFixtureTest(t *testing.T) rpc.Client {
+	configPath := os.Getenv("EXPLORER_TEST_CONFIG")
+	if configPath == "" {
+		t.Skip("EXPLORER_TEST_CONFIG is required for fixture tests")
+	}
+	fixturesDir := os.Getenv("EXPLORER_TEST_FIXTURES")
+	if fixturesDir == "" {
+		fixturesDir = defaultFixturesDir
+	}
+
+	cfg := &types.Config{}
+	err := utils.ReadConfig(cfg, configPath)
+	if err != nil {
+		t.Fatalf("error reading config file: %v", err)
+	}
+	utils.Config = cfg
+	if fixturesDir == defaultFixturesDir && cfg.Chain.ClConfig.ConfigName != defaultFixturesChain {
+		t.Skipf("the fixtures in %v require the %v chain config, got %v", defaultFixturesDir, defaultFixturesChain, cfg.Chain.ClConfig.ConfigName)
+	}
+
+	if db.WriterDb == nil {
+		dbConfig := &types.DatabaseConfig{
+			Username:     cfg.WriterDatabase.Username,
+			Password:     cfg.WriterDatabase.Password,
+			Name:         cfg.WriterDatabase.Name,
+			Host:         cfg.WriterDatabase.Host,
+			Port:         cfg.WriterDatabase.Port,
+			MaxOpenConns: cfg.WriterDatabase.MaxOpenConns,
+			MaxIdleConns: cfg.WriterDatabase.MaxIdleConns,
+		}
+		db.MustInitDB(dbConfig, dbConfig)
+		err = db.ApplyEmbeddedDbSchema(-2)
+		if err != nil {
+			t.Fatalf("error applying db schema: %v", err)
+		}
+	}
+
+	if db.BigtableClient == nil {
+		bt, err := db.InitBigtable(cfg.Bigtable.Project, cfg.Bigtable.Instance, fmt.Sprintf("%d", cfg.Chain.ClConfig.DepositChainID), cfg.RedisCacheEndpoint)
+		if err != nil {
+			t.Fatalf("error connecting to bigtable: %v", err)
+		}
+		db.BigtableClient = bt
+	}
+
+	client, err := rpc.NewFixtureClient(fixturesDir, new(big.Int).SetUint64(cfg.Chain.ClConfig.DepositChainID))
+	if err != nil {
+		t.Fatalf("error creating fixture client: %v", err)
+	}
+	return client
+}
+
+// fixtureTestCases returns the comma separated uint64 values of the given env variable,
+// the cases of the fixtures in testdata are returned if neither the variable nor EXPLORER_TEST_FIXTURES is set
+func fixtureTestCases(t *testing.T, env string, defaults []uint64) []uint64 {
+	if os.Getenv(env) == "" && os.Getenv("EXPLORER_TEST_FIXTURES") == "" {
+		return defaults
+	}
+	values := []uint64{}
+	for _, s := range strings.Split(os.Getenv(env), ",") {
+		if s == "" {
+			continue
+		}
+		value, err := strconv.ParseUint(strings.TrimSpace(s), 10, 64)
+		if err != nil {
+			t.Fatalf("invalid value %v in %v: %v", s, env, err)
+		}
+		values = append(values, value)
+	}
+	if len(values) == 0 {
+		t.Skipf("%v is required for this fixture test", env)
+	}
+	return values
+}
+
+func TestExportSlot(t *testing.T) {
+	client := setupFixtureTest(t)
+
+	for _, slot := range fixtureTestCases(t, "EXPLORER_TEST_SLOTS", defaultTestSlots) {
+		tx, err := db.WriterDb.Beginx()
+		if err != nil {
+			t.Fatalf("error starting tx: %v", err)
+		}
+		err = ExportSlot(client, slot, false, tx)
+		if err != nil {
+			tx.Rollback()
+			t.Fatalf("error exporting slot %v: %v", slot, err)
+		}
+		err = tx.Commit()
+		if err != nil {
+			t.Fatalf("error committing tx: %v", err)
+		}
+
+		golden.CompareRows(t, db.WriterDb, fmt.Sprintf("slot_%d_blocks", slot), `
+			SELECT epoch, slot, ENCODE(blockroot, 'hex') AS blockroot, ENCODE(parentroot, 'hex') AS parentroot, ENCODE(stateroot, 'hex') AS stateroot,
+				proposer, status, graffiti_text, attestationscount, depositscount, voluntaryexitscount, proposerslashingscount, attesterslashingscount,
+				syncaggregate_participation, exec_block_number, ENCODE(exec_block_hash, 'hex') AS exec_block_hash, withdrawalcount
+			FROM blocks WHERE slot = $1 ORDER BY blockroot`, slot)
+		golden.CompareRows(t, db.WriterDb, fmt.Sprintf("slot_%d_attestations", slot), `
+			SELECT block_slot, block_index, slot, committeeindex, ENCODE(beaconblockroot, 'hex') AS beaconblockroot, source_epoch, target_epoch, validators
+			FROM blocks_attestations WHERE block_slot = $1 ORDER BY block_index`, slot)
+		golden.CompareRows(t, db.WriterDb, fmt.Sprintf("slot_%d_deposits", slot), `
+			SELECT block_slot, block_index, ENCODE(publickey, 'hex') AS publickey, ENCODE(withdrawalcredentials, 'hex') AS withdrawalcredentials, amount
+			FROM blocks_deposits WHERE block_slot = $1 ORDER BY block_index`, slot)
+		golden.CompareRows(t, db.WriterDb, fmt.Sprintf("slot_%d_withdrawals", slot), `
+			SELECT block_slot, withdrawalindex, validatorindex, ENCODE(address, 'hex') AS address, amount
+			FROM blocks_withdrawals WHERE block_slot = $1 ORDER BY withdrawalindex`, slot)
+	}
+}
+
+func TestExportSyncCommitteeAtPeriod(t *testing.T) {
+	client := setupFixtureTest(t)
+
+	for _, period := range fixtureTestCases(t, "EXPLORER_TEST_SYNC_PERIODS", defaultTestSyncPeriods) {
+		err := ExportSyncCommitteeAtPeriod(client, period, nil)
+		if err != nil {
+			t.Fatalf("error exporting sync committee of period %v: %v", period, err)
+		}
+
+		golden.CompareRows(t, db.WriterDb, fmt.Sprintf("sync_committee_%d", period), `
+			SELECT period, validatorindex, committeeindex
+			FROM sync_committees WHERE period = $1 ORDER BY committeeindex`, period)
+	}
+}
@@ -0,0 +1,174 @@
+package exporter
+
+import (
+	"bytes"
+	"eth2-exporter/db"
+	"eth2-exporter/rpc"
+	"eth2-exporter/types"
+	"eth2-exporter/utils"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// blockArrivalsRetention is the number of slots the arrival times of announced blocks are kept for, missed slots are classified well before that
+const blockArrivalsRetention = 4096
+
+// blockArrival holds the time a block passed the gossip validation of the beacon node and the time it has been imported, either can be zero
+// if the node did not announce the respective event, e.g. because the block was received via sync or failed the import
+type blockArrival struct {
+	blockRoot []byte
+	gossipTs  time.Time
+	importTs  time.Time
+}
+
+// ts returns the time the block arrived at the node, the import time is only used if the block was not received via gossip
+func (a *blockArrival) ts() time.Time {
+	if !a.gossipTs.IsZero() {
+		return a.gossipTs
+	}
+	return a.importTs
+}
+
+// blockArrivalTracker keeps the time at which the beacon node received and imported the blocks of the recent slots via the event stream
+type blockArrivalTracker struct {
+	mu        sync.Mutex
+	tracking  bool
+	firstSlot uint64 // arrivals are complete for all slots starting at firstSlot
+	arrivals  map[uint64][]*blockArrival
+}
+
+var blockArrivals = &blockArrivalTracker{
+	arrivals: make(map[uint64][]*blockArrival),
+}
+
+// start marks the subscription to the event stream, only slots beginning afterwards are tracked completely
+func (t *blockArrivalTracker) start(ts time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.tracking = true
+	t.firstSlot = utils.TimeToSlot(uint64(ts.Unix())) + 1
+}
+
+// addGossip records the time a block passed the gossip validation of the node
+func (t *blockArrivalTracker) addGossip(slot uint64, blockRoot []byte, ts time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	a := t.getOrAdd(slot, blockRoot)
+	if a.gossipTs.IsZero() {
+		a.gossipTs = ts
+	}
+}
+
+// addImport records the time a block has been imported by the node
+func (t *blockArrivalTracker) addImport(slot uint64, blockRoot []byte, ts time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	a := t.getOrAdd(slot, blockRoot)
+	if a.importTs.IsZero() {
+		a.importTs = ts
+	}
+}
+
+// getOrAdd returns the arrival of the block and adds it if the block has not been seen before, t.mu must be held by the caller
+func (t *blockArrivalTracker) getOrAdd(slot uint64, blockRoot []byte) *blockArrival {
+	for _, a := range t.arrivals[slot] {
+		if bytes.Equal(a.blockRoot, blockRoot) {
+			return a
+		}
+	}
+	a := &blockArrival{blockRoot: blockRoot}
+	t.arrivals[slot] = append(t.arrivals[slot], a)
+
+	if slot > blockArrivalsRetention {
+		cutoff := slot - blockArrivalsRetention
+		for s := range t.arrivals {
+			if s < cutoff {
+				delete(t.arrivals, s)
+			}
+		}
+		if t.firstSlot < cutoff {
+			t.firstSlot = cutoff
+		}
+	}
+	return a
+}
+
+// get returns the blocks announced for the slot and whether the slot has been tracked at all
+func (t *blockArrivalTracker) get(slot uint64) ([]*blockArrival, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	arrivals := make([]*blockArrival, 0, len(t.arrivals[slot]))
+	for _, a := range t.arrivals[slot] {
+		arrival := *a
+		arrivals = append(arrivals, &arrival)
+	}
+	return arrivals, t.tracking && slot >= t.firstSlot
+}
+
+// classifyMissedSlot determines why a slot ended up without a canonical block. blockRoot is the root of the orphaned block in the db
+// and empty if the db has no block for the slot. An empty reason is returned if the event stream did not cover the slot.
+func classifyMissedSlot(client rpc.Client, slot uint64, blockRoot []byte) (types.MissedSlotReason, error) {
+	arrivals, tracked := blockArrivals.get(slot)
+
+	// blocks arriving after the attestation deadline do not receive the votes of the committee of their slot
+	deadline := utils.SlotToTime(slot).Add(time.Second * time.Duration(utils.Config.Chain.ClConfig.SecondsPerSlot) / 3)
+
+	if len(blockRoot) == 32 {
+		// the block has been imported by the node, so it was valid but has been reorged out
+		for _, a := range arrivals {
+			if bytes.Equal(a.blockRoot, blockRoot) && a.ts().After(deadline) {
+				return types.MissedSlotReasonLate, nil
+			}
+		}
+		return types.MissedSlotReasonOrphaned, nil
+	}
+
+	if len(arrivals) == 0 {
+		if tracked {
+			return types.MissedSlotReasonNotSeen, nil
+		}
+		return "", nil
+	}
+
+	// the node received a block for the slot that never became canonical, the first one is the one the proposer published
+	arrival := arrivals[0]
+	if arrival.importTs.IsZero() {
+		// blocks pass the gossip validation before their payload is executed, the node drops them if the execution turns out to be invalid.
+		// The node is asked as well in case the block event of the import has not been received.
+		block, err := client.GetBlockByBlockroot(arrival.blockRoot)
+		if err != nil {
+			return "", fmt.Errorf("error retrieving gossiped block 0x%x at slot %v: %w", arrival.blockRoot, slot, err)
+		}
+		if len(block.BlockRoot) != 32 {
+			return types.MissedSlotReasonInvalidExecution, nil
+		}
+	}
+	if arrival.ts().After(deadline) {
+		return types.MissedSlotReasonLate, nil
+	}
+	return types.MissedSlotReasonOrphaned, nil
+}
+
+// saveMissedSlotReason classifies a missed or orphaned block and stores the result, nothing is stored if the reason cannot be determined
+func saveMissedSlotReason(client rpc.Client, slot uint64, blockRoot []byte, tx *sqlx.Tx) error {
+	classifyRoot := blockRoot
+	if len(blockRoot) < 32 {
+		classifyRoot = nil
+	}
+	reason, err := classifyMissedSlot(client, slot, classifyRoot)
+	if err != nil {
+		return err
+	}
+	if reason == "" {
+		return nil
+	}
+	logger.Infof("classified missed slot %v as %v", slot, reason)
+	return db.SetBlockMissedReason(slot, blockRoot, reason, tx)
+}
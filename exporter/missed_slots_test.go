@@ -0,0 +1,92 @@
+package exporter
+
+import (
+	"eth2-exporter/rpc"
+	"eth2-exporter/types"
+	"eth2-exporter/utils"
+	"testing"
+	"time"
+)
+
+// blockrootClient serves the blocks in blocks by their root, all other blocks are unknown to the node
+type blockrootClient struct {
+	rpc.Client
+	blocks map[string]*types.Block
+}
+
+func (c *blockrootClient) GetBlockByBlockroot(blockroot []byte) (*types.Block, error) {
+	block, ok := c.blocks[string(blockroot)]
+	if !ok {
+		return &types.Block{}, nil
+	}
+	return block, nil
+}
+
+func TestClassifyMissedSlot(t *testing.T) {
+	utils.Config = &types.Config{}
+	utils.Config.Chain.GenesisTimestamp = 1000
+	utils.Config.Chain.ClConfig.SecondsPerSlot = 12
+
+	slotStart := func(slot uint64) time.Time {
+		return time.Unix(int64(1000+slot*12), 0)
+	}
+	root := func(b byte) []byte {
+		r := make([]byte, 32)
+		r[31] = b
+		return r
+	}
+
+	blockArrivals = &blockArrivalTracker{arrivals: make(map[uint64][]*blockArrival)}
+	blockArrivals.start(slotStart(9))
+
+	blockArrivals.addGossip(11, root(11), slotStart(11).Add(time.Second))
+	blockArrivals.addImport(11, root(11), slotStart(11).Add(time.Second*2))
+	// the block arrived in time but its import took until after the attestation deadline
+	blockArrivals.addGossip(12, root(12), slotStart(12).Add(time.Second))
+	blockArrivals.addImport(12, root(12), slotStart(12).Add(time.Second*6))
+	blockArrivals.addGossip(13, root(13), slotStart(13).Add(time.Second))
+	blockArrivals.addGossip(14, root(14), slotStart(14).Add(time.Second*8))
+	blockArrivals.addImport(14, root(14), slotStart(14).Add(time.Second*9))
+	blockArrivals.addGossip(16, root(16), slotStart(16).Add(time.Second*6))
+	blockArrivals.addImport(16, root(16), slotStart(16).Add(time.Second*7))
+	// the block event of the import has been missed but the node has the block
+	blockArrivals.addGossip(17, root(17), slotStart(17).Add(time.Second*5))
+	// the block was received via sync and not via gossip
+	blockArrivals.addImport(18, root(18), slotStart(18).Add(time.Second*5))
+
+	client := &blockrootClient{blocks: map[string]*types.Block{
+		string(root(11)): {Slot: 11, BlockRoot: root(11)},
+		string(root(12)): {Slot: 12, BlockRoot: root(12)},
+		string(root(16)): {Slot: 16, BlockRoot: root(16)},
+		string(root(17)): {Slot: 17, BlockRoot: root(17)},
+		string(root(18)): {Slot: 18, BlockRoot: root(18)},
+	}}
+
+	tests := []struct {
+		name      string
+		slot      uint64
+		blockRoot []byte
+		expected  types.MissedSlotReason
+	}{
+		{"slot before the subscription", 5, nil, ""},
+		{"no block announced", 10, nil, types.MissedSlotReasonNotSeen},
+		{"gossiped in time", 11, nil, types.MissedSlotReasonOrphaned},
+		{"gossiped in time but imported after the attestation deadline", 12, nil, types.MissedSlotReasonOrphaned},
+		{"gossiped but never imported", 13, nil, types.MissedSlotReasonInvalidExecution},
+		{"orphaned block that arrived late", 14, root(14), types.MissedSlotReasonLate},
+		{"orphaned block without arrival", 15, root(15), types.MissedSlotReasonOrphaned},
+		{"gossiped after the attestation deadline", 16, nil, types.MissedSlotReasonLate},
+		{"gossiped late without import event", 17, nil, types.MissedSlotReasonLate},
+		{"imported after the attestation deadline without gossip", 18, nil, types.MissedSlotReasonLate},
+	}
+
+	for _, tt := range tests {
+		reason, err := classifyMissedSlot(client, tt.slot, tt.blockRoot)
+		if err != nil {
+			t.Fatalf("%v: unexpected error: %v", tt.name, err)
+		}
+		if reason != tt.expected {
+			t.Errorf("%v: expected reason %q, got %q", tt.name, tt.expected, reason)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package exporter
 
 import (
+	"bytes"
 	"database/sql"
 	"encoding/json"
 	"eth2-exporter/db"
@@ -27,6 +28,12 @@ type BidTrace struct {
 	Value                types.WeiString `json:"value"`
 }
 
+// relayBidsLookback limits how many slots behind the head the bids of a relay are fetched, relays only keep their bids for a limited time
+const relayBidsLookback = 7200
+
+// relayBidsBatchSize is the max number of slots for which the bids are fetched from a relay per run
+const relayBidsBatchSize = 100
+
 func mevBoostRelaysExporter() {
 	var relays []types.Relay
 	for {
@@ -102,6 +109,14 @@ func singleRelayExport(r types.Relay, wg *sync.WaitGroup, mux *sync.Mutex) {
 	}
 
 	r.Logger.Infof("finished syncing payloads from relay")
+
+	// the bids are not part of the spec every relay has to implement, so a failure does not count as failed export
+	err = exportRelayBids(r)
+	if err != nil {
+		r.Logger.Warnf("failed to export bids for relay: %v", err)
+		return
+	}
+	r.Logger.Infof("finished syncing bids from relay")
 }
 
 func fetchDeliveredPayloads(r types.Relay, offset uint64) ([]BidTrace, error) {
@@ -270,6 +285,114 @@ func retrieveAndInsertPayloadsFromRelay(r types.Relay, low_bound uint64, high_bo
 	return tx.Commit()
 }
 
+func fetchReceivedBids(r types.Relay, slot uint64) ([]BidTrace, error) {
+	var bids []BidTrace
+	url := fmt.Sprintf("%s/relay/v1/data/bidtraces/builder_blocks_received?slot=%v", r.Endpoint, slot)
+	r.Logger.Debugf("calling %v", url)
+
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving received bids: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error retrieving received bids: unexpected status code %v", resp.StatusCode)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&bids)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding received bids: %w", err)
+	}
+
+	return bids, nil
+}
+
+// exportRelayBids stores the highest bid a relay received for every proposed slot since the last run. Only bids that build
+// on the parent of the canonical block are taken into account, as those are the ones the proposer could have chosen.
+func exportRelayBids(r types.Relay) error {
+	var lastSlot uint64
+	err := db.ReaderDb.Get(&lastSlot, `SELECT last_bids_export_slot FROM relays WHERE tag_id = $1 AND endpoint = $2`, r.ID, r.Endpoint)
+	if err != nil {
+		return fmt.Errorf("error retrieving last bids export slot: %w", err)
+	}
+
+	var headSlot uint64
+	err = db.ReaderDb.Get(&headSlot, `SELECT COALESCE(MAX(slot), 0) FROM blocks`)
+	if err != nil {
+		return fmt.Errorf("error retrieving head slot: %w", err)
+	}
+	if headSlot > relayBidsLookback && lastSlot < headSlot-relayBidsLookback {
+		lastSlot = headSlot - relayBidsLookback
+	}
+
+	var slots []struct {
+		Slot           uint64 `db:"slot"`
+		ExecParentHash []byte `db:"exec_parent_hash"`
+	}
+	err = db.ReaderDb.Select(&slots, `
+		SELECT slot, exec_parent_hash
+		FROM blocks
+		WHERE slot > $1 AND status = '1' AND exec_block_hash IS NOT NULL
+		ORDER BY slot
+		LIMIT $2`, lastSlot, relayBidsBatchSize)
+	if err != nil {
+		return fmt.Errorf("error retrieving proposed slots: %w", err)
+	}
+
+	for _, s := range slots {
+		bids, err := fetchReceivedBids(r, s.Slot)
+		if err != nil {
+			return err
+		}
+
+		var best *BidTrace
+		count := 0
+		for i := range bids {
+			if bids[i].Slot != s.Slot || !bytes.Equal(utils.MustParseHex(bids[i].ParentHash), s.ExecParentHash) {
+				continue
+			}
+			count++
+			if best == nil || bids[i].Value.BigInt().Cmp(best.Value.BigInt()) > 0 {
+				best = &bids[i]
+			}
+		}
+
+		tx, err := db.WriterDb.Begin()
+		if err != nil {
+			return fmt.Errorf("error starting db transaction: %w", err)
+		}
+		if best != nil {
+			_, err = tx.Exec(`
+				INSERT INTO relays_bids (block_slot, tag_id, exec_block_hash, builder_pubkey, value, bids_count)
+				VALUES ($1, $2, $3, $4, $5, $6)
+				ON CONFLICT (block_slot, tag_id) DO UPDATE SET
+					exec_block_hash = excluded.exec_block_hash,
+					builder_pubkey = excluded.builder_pubkey,
+					value = excluded.value,
+					bids_count = excluded.bids_count`,
+				s.Slot, r.ID, utils.MustParseHex(best.BlockHash), utils.MustParseHex(best.BuilderPubkey), best.Value, count)
+			if err != nil {
+				tx.Rollback()
+				return fmt.Errorf("error inserting best bid of slot %v: %w", s.Slot, err)
+			}
+		}
+		_, err = tx.Exec(`UPDATE relays SET last_bids_export_slot = $1 WHERE tag_id = $2 AND endpoint = $3`, s.Slot, r.ID, r.Endpoint)
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("error updating last bids export slot: %w", err)
+		}
+		err = tx.Commit()
+		if err != nil {
+			return fmt.Errorf("error committing bids of slot %v: %w", s.Slot, err)
+		}
+
+		// sleep for a bit to not kill the relay
+		time.Sleep(time.Millisecond * 250)
+	}
+	return nil
+}
+
 func shouldTryToExportRelay(r types.Relay) bool {
 	if r.ExportFailureCount == 0 {
 		return true
@@ -144,6 +144,12 @@ func RunSlotExporter(client rpc.Client, firstRun bool) error {
 				if err != nil {
 					return fmt.Errorf("error setting slot %v as finalized (missed): %w", dbSlot.Slot, err)
 				}
+				if !dbSlot.MissedReason.Valid {
+					err = saveMissedSlotReason(client, dbSlot.Slot, dbSlot.BlockRoot, tx)
+					if err != nil {
+						return fmt.Errorf("error classifying missed slot %v: %w", dbSlot.Slot, err)
+					}
+				}
 			} else if header == nil && len(dbSlot.BlockRoot) == 32 {
 				// slot has been orphaned, mark the slot as orphaned
 				logger.Infof("setting slot %v as finalized (orphaned)", dbSlot.Slot)
@@ -151,6 +157,12 @@ func RunSlotExporter(client rpc.Client, firstRun bool) error {
 				if err != nil {
 					return fmt.Errorf("error setting block %v as finalized (orphaned): %w", dbSlot.Slot, err)
 				}
+				if !dbSlot.MissedReason.Valid {
+					err = saveMissedSlotReason(client, dbSlot.Slot, dbSlot.BlockRoot, tx)
+					if err != nil {
+						return fmt.Errorf("error classifying orphaned slot %v: %w", dbSlot.Slot, err)
+					}
+				}
 			} else if header != nil && !bytes.Equal(utils.MustParseHex(header.Data.Root), dbSlot.BlockRoot) {
 				// we have a different block root for the slot in the db, mark the currently present one as orphaned and write the new one
 				logger.Infof("setting slot %v as orphaned and exporting new slot", dbSlot.Slot)
@@ -158,6 +170,12 @@ func RunSlotExporter(client rpc.Client, firstRun bool) error {
 				if err != nil {
 					return fmt.Errorf("error setting block %v as finalized (orphaned): %w", dbSlot.Slot, err)
 				}
+				if !dbSlot.MissedReason.Valid {
+					err = saveMissedSlotReason(client, dbSlot.Slot, dbSlot.BlockRoot, tx)
+					if err != nil {
+						return fmt.Errorf("error classifying orphaned slot %v: %w", dbSlot.Slot, err)
+					}
+				}
 				err = ExportSlot(client, dbSlot.Slot, utils.EpochOfSlot(dbSlot.Slot) == head.HeadEpoch, tx)
 				if err != nil {
 					return fmt.Errorf("error exporting slot %v: %w", dbSlot.Slot, err)
@@ -198,6 +216,12 @@ func RunSlotExporter(client rpc.Client, firstRun bool) error {
 				if err != nil {
 					return fmt.Errorf("error exporting slot %v: %w", dbSlot.Slot, err)
 				}
+			} else if len(dbSlot.BlockRoot) < 32 && !dbSlot.MissedReason.Valid && dbSlot.Slot+2 <= head.HeadSlot {
+				// classify the missed slot while the node still keeps the non-canonical blocks announced for it
+				err := saveMissedSlotReason(client, dbSlot.Slot, dbSlot.BlockRoot, tx)
+				if err != nil {
+					return fmt.Errorf("error classifying missed slot %v: %w", dbSlot.Slot, err)
+				}
 			}
 		}
 	}
@@ -377,6 +401,18 @@ func ExportSlot(client rpc.Client, slot uint64, isHeadEpoch bool, tx *sqlx.Tx) e
 		return fmt.Errorf("error exporting sync committee duties to bigtable for slot %v: %w", block.Slot, err)
 	}
 
+	// save the vote flags of the included attestations next to the attestation duties
+	if len(block.Attestations) > 0 {
+		attFlags, err := getAttestationFlags(client, block)
+		if err != nil {
+			return fmt.Errorf("error computing attestation flags for slot %v: %w", block.Slot, err)
+		}
+		err = db.BigtableClient.SaveAttestationFlags(block.Slot, attFlags)
+		if err != nil {
+			return fmt.Errorf("error exporting attestation flags to bigtable for slot %v: %w", block.Slot, err)
+		}
+	}
+
 	// save the proposal to bigtable
 	err = db.BigtableClient.SaveProposal(block)
 	if err != nil {
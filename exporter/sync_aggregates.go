@@ -0,0 +1,81 @@
+package exporter
+
+import (
+	"database/sql"
+	"eth2-exporter/db"
+	"eth2-exporter/metrics"
+	"eth2-exporter/rpc"
+	"eth2-exporter/types"
+	"eth2-exporter/utils"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// lightClientUpdatesExporter stores the sync aggregates of the light client updates the beacon node serves to light clients.
+// The node has to run with the light client server enabled, otherwise no updates are announced.
+func lightClientUpdatesExporter(client rpc.Client) {
+	events := client.GetLightClientEventsChan()
+	for ev := range events {
+		err := exportLightClientUpdate(ev)
+		if err != nil {
+			utils.LogError(err, "error exporting light client update", 0, map[string]interface{}{"topic": ev.Topic, "slot": uint64(ev.Update.Data.SignatureSlot)})
+		}
+	}
+}
+
+func exportLightClientUpdate(ev *rpc.LightClientEvent) error {
+	start := time.Now()
+	defer func() {
+		metrics.TaskDuration.WithLabelValues("exporter_light_client_update").Observe(time.Since(start).Seconds())
+	}()
+
+	data := ev.Update.Data
+	bits := []byte(data.SyncAggregate.SyncCommitteeBits)
+	if utils.Config.Chain.ClConfig.SyncCommitteeSize != uint64(len(bits)*8) {
+		return fmt.Errorf("sync-aggregate-bits-size does not match sync-committee-size: %v != %v", len(bits)*8, utils.Config.Chain.ClConfig.SyncCommitteeSize)
+	}
+
+	participating := 0
+	for i := 0; i < int(utils.Config.Chain.ClConfig.SyncCommitteeSize); i++ {
+		if utils.BitAtVector(bits, i) {
+			participating++
+		}
+	}
+
+	attestedBlockRoot, err := data.AttestedHeader.Beacon.BlockRoot()
+	if err != nil {
+		return fmt.Errorf("error computing attested block root: %w", err)
+	}
+
+	// the sync aggregate is signed by the committee of the signature slot, not the one of the attested slot
+	slot := uint64(data.SignatureSlot)
+	agg := &types.LightClientSyncAggregate{
+		Slot:                   slot,
+		Period:                 utils.SyncPeriodOfEpoch(utils.EpochOfSlot(slot)),
+		AttestedSlot:           uint64(data.AttestedHeader.Beacon.Slot),
+		AttestedBlockRoot:      attestedBlockRoot,
+		SyncCommitteeBits:      bits,
+		SyncCommitteeSignature: []byte(data.SyncAggregate.SyncCommitteeSignature),
+		Participation:          float64(participating) / float64(utils.Config.Chain.ClConfig.SyncCommitteeSize),
+		Ts:                     ev.ReceivedTs,
+	}
+	if data.FinalizedHeader != nil {
+		agg.FinalizedSlot = sql.NullInt64{Int64: int64(data.FinalizedHeader.Beacon.Slot), Valid: true}
+	}
+
+	err = db.SaveLightClientSyncAggregate(agg)
+	if err != nil {
+		return err
+	}
+
+	metrics.Tasks.WithLabelValues("exporter_light_client_" + ev.Topic).Inc()
+	logger.WithFields(logrus.Fields{
+		"topic":         ev.Topic,
+		"slot":          slot,
+		"attestedSlot":  agg.AttestedSlot,
+		"participation": agg.Participation,
+	}).Debugf("exported light client update")
+	return nil
+}
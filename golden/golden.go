@@ -0,0 +1,62 @@
+// Package golden compares the results of tests with the golden files in the testdata directory of the tested package.
+// Run the tests with -update to write the current results to the golden files.
+package golden
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var update = flag.Bool("update", false, "update the golden files of the tests")
+
+// CompareRows serializes the rows returned by the query as json and compares them to the golden file with the given name
+func CompareRows(t *testing.T, db *sqlx.DB, name string, query string, args ...interface{}) {
+	t.Helper()
+
+	rows := []string{}
+	err := db.Select(&rows, fmt.Sprintf("SELECT row_to_json(t)::TEXT FROM (%s) t", query), args...)
+	if err != nil {
+		t.Fatalf("error querying rows for %v: %v", name, err)
+	}
+	parsed := make([]json.RawMessage, 0, len(rows))
+	for _, row := range rows {
+		parsed = append(parsed, json.RawMessage(row))
+	}
+	actual, err := json.MarshalIndent(parsed, "", "  ")
+	if err != nil {
+		t.Fatalf("error marshalling rows for %v: %v", name, err)
+	}
+
+	Compare(t, name, actual)
+}
+
+// Compare compares actual to the golden file testdata/<name>.golden.json, the golden file is overwritten if the tests run with -update
+func Compare(t *testing.T, name string, actual []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name+".golden.json")
+	if *update {
+		err := os.MkdirAll("testdata", 0755)
+		if err == nil {
+			err = os.WriteFile(path, actual, 0644)
+		}
+		if err != nil {
+			t.Fatalf("error updating golden file %v: %v", path, err)
+		}
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("error reading golden file %v: %v", path, err)
+	}
+	if string(expected) != string(actual) {
+		t.Errorf("%v does not match golden file %v:\n%s", name, path, actual)
+	}
+}
@@ -0,0 +1,279 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"eth2-exporter/db"
+	"eth2-exporter/services"
+	"eth2-exporter/types"
+	"eth2-exporter/utils"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/lib/pq"
+)
+
+// maximum number of alert rules of a user per network
+const maxUserAlertRules = 25
+
+// maximum number of validators an alert rule can be evaluated for
+const maxAlertRuleValidators = 1000
+
+// UserAlertRules godoc
+// @Summary Get the alert rules of the user
+// @Tags User
+// @Produce json
+// @Success 200 {object} types.ApiResponse{data=[]types.AlertRule}
+// @Failure 500 {object} types.ApiResponse
+// @Security ApiKeyAuth
+// @Router /api/v1/user/alerts [get]
+func UserAlertRules(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	j := json.NewEncoder(w)
+	user := getUser(r)
+
+	rules, err := db.GetAlertRules(user.UserID, utils.GetNetwork())
+	if err != nil {
+		logger.WithError(err).Errorf("error getting alert rules of user %v", user.UserID)
+		sendServerErrorResponse(w, r.URL.String(), "could not retrieve alert rules")
+		return
+	}
+
+	SendOKResponse(j, r.URL.String(), []interface{}{rules})
+}
+
+// UserAlertRuleCreate godoc
+// @Summary Create an alert rule that notifies the user once a metric of a validator or a set of validators violates a threshold
+// @Description Effectiveness and sync participation are aggregated over all validators of the rule and window_epochs epochs (max 225),
+// @Description balance (in ETH) and days since the last proposal are evaluated for every validator of the rule
+// @Description Dashboards are not stored on the server, they are defined by the validators in their url. A rule for a dashboard is created
+// @Description with the validators of the dashboard and has to be updated with the new validators when the dashboard changes
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param rule body types.AlertRuleRequest true "metric: effectiveness, sync_participation, balance or days_since_proposal; operator: below or above"
+// @Success 200 {object} types.ApiResponse{data=types.AlertRule}
+// @Failure 400 {object} types.ApiResponse
+// @Failure 500 {object} types.ApiResponse
+// @Security ApiKeyAuth
+// @Router /api/v1/user/alerts [post]
+func UserAlertRuleCreate(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	j := json.NewEncoder(w)
+	user := getUser(r)
+
+	req := &types.AlertRuleRequest{}
+	err := json.NewDecoder(r.Body).Decode(req)
+	if err != nil {
+		SendBadRequestResponse(w, r.URL.String(), "error decoding request body")
+		return
+	}
+
+	rules, err := db.GetAlertRules(user.UserID, utils.GetNetwork())
+	if err != nil {
+		logger.WithError(err).Errorf("error getting alert rules of user %v", user.UserID)
+		sendServerErrorResponse(w, r.URL.String(), "could not create alert rule")
+		return
+	}
+	if len(rules) >= maxUserAlertRules {
+		SendBadRequestResponse(w, r.URL.String(), fmt.Sprintf("you can not create more than %v alert rules", maxUserAlertRules))
+		return
+	}
+
+	rule := &types.AlertRule{
+		UserID: user.UserID,
+		Active: true,
+	}
+	if !applyAlertRuleRequest(w, r, rule, req) {
+		return
+	}
+
+	ruleID, err := db.AddAlertRule(rule, utils.GetNetwork())
+	if err != nil {
+		logger.WithError(err).Errorf("error adding alert rule of user %v", user.UserID)
+		sendServerErrorResponse(w, r.URL.String(), "could not create alert rule")
+		return
+	}
+
+	rule, err = db.GetAlertRule(user.UserID, ruleID, utils.GetNetwork())
+	if err != nil {
+		logger.WithError(err).Errorf("error getting alert rule %v of user %v", ruleID, user.UserID)
+		sendServerErrorResponse(w, r.URL.String(), "could not retrieve alert rule")
+		return
+	}
+
+	SendOKResponse(j, r.URL.String(), []interface{}{rule})
+}
+
+// UserAlertRuleUpdate godoc
+// @Summary Update an alert rule of the user, an updated rule notifies again even if it had already been triggered
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param ruleID path int true "ID of the alert rule"
+// @Param rule body types.AlertRuleRequest true "The new settings of the rule, set active to false to pause the rule"
+// @Success 200 {object} types.ApiResponse{data=types.AlertRule}
+// @Failure 400 {object} types.ApiResponse
+// @Failure 500 {object} types.ApiResponse
+// @Security ApiKeyAuth
+// @Router /api/v1/user/alerts/{ruleID} [post]
+func UserAlertRuleUpdate(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	j := json.NewEncoder(w)
+	user := getUser(r)
+
+	ruleID, err := strconv.ParseUint(mux.Vars(r)["ruleID"], 10, 64)
+	if err != nil {
+		SendBadRequestResponse(w, r.URL.String(), "invalid alert rule id")
+		return
+	}
+
+	req := &types.AlertRuleRequest{}
+	err = json.NewDecoder(r.Body).Decode(req)
+	if err != nil {
+		SendBadRequestResponse(w, r.URL.String(), "error decoding request body")
+		return
+	}
+
+	rule, err := db.GetAlertRule(user.UserID, ruleID, utils.GetNetwork())
+	if errors.Is(err, sql.ErrNoRows) {
+		SendBadRequestResponse(w, r.URL.String(), "alert rule not found")
+		return
+	}
+	if err != nil {
+		logger.WithError(err).Errorf("error getting alert rule %v of user %v", ruleID, user.UserID)
+		sendServerErrorResponse(w, r.URL.String(), "could not update alert rule")
+		return
+	}
+
+	if !applyAlertRuleRequest(w, r, rule, req) {
+		return
+	}
+
+	err = db.UpdateAlertRule(rule, utils.GetNetwork())
+	if err != nil {
+		logger.WithError(err).Errorf("error updating alert rule %v of user %v", ruleID, user.UserID)
+		sendServerErrorResponse(w, r.URL.String(), "could not update alert rule")
+		return
+	}
+
+	rule, err = db.GetAlertRule(user.UserID, ruleID, utils.GetNetwork())
+	if err != nil {
+		logger.WithError(err).Errorf("error getting alert rule %v of user %v", ruleID, user.UserID)
+		sendServerErrorResponse(w, r.URL.String(), "could not retrieve alert rule")
+		return
+	}
+
+	SendOKResponse(j, r.URL.String(), []interface{}{rule})
+}
+
+// UserAlertRuleDelete godoc
+// @Summary Delete an alert rule of the user
+// @Tags User
+// @Produce json
+// @Param ruleID path int true "ID of the alert rule"
+// @Success 200 {object} types.ApiResponse
+// @Failure 400 {object} types.ApiResponse
+// @Failure 500 {object} types.ApiResponse
+// @Security ApiKeyAuth
+// @Router /api/v1/user/alerts/{ruleID}/delete [post]
+func UserAlertRuleDelete(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	j := json.NewEncoder(w)
+	user := getUser(r)
+
+	ruleID, err := strconv.ParseUint(mux.Vars(r)["ruleID"], 10, 64)
+	if err != nil {
+		SendBadRequestResponse(w, r.URL.String(), "invalid alert rule id")
+		return
+	}
+
+	err = db.DeleteAlertRule(user.UserID, ruleID, utils.GetNetwork())
+	if err != nil {
+		logger.WithError(err).Errorf("error deleting alert rule %v of user %v", ruleID, user.UserID)
+		sendServerErrorResponse(w, r.URL.String(), "could not delete alert rule")
+		return
+	}
+
+	SendOKResponse(j, r.URL.String(), nil)
+}
+
+// applyAlertRuleRequest validates the request and applies it to the rule, a bad request response is sent if the request is invalid
+func applyAlertRuleRequest(w http.ResponseWriter, r *http.Request, rule *types.AlertRule, req *types.AlertRuleRequest) bool {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" || len(req.Name) > 100 {
+		SendBadRequestResponse(w, r.URL.String(), "the name of the alert rule must be between 1 and 100 characters long")
+		return false
+	}
+
+	validMetric := false
+	for _, metric := range types.AlertRuleMetrics {
+		if req.Metric == metric {
+			validMetric = true
+			break
+		}
+	}
+	if !validMetric {
+		SendBadRequestResponse(w, r.URL.String(), fmt.Sprintf("invalid metric %v", req.Metric))
+		return false
+	}
+
+	if req.Operator != types.AlertRuleOperatorBelow && req.Operator != types.AlertRuleOperatorAbove {
+		SendBadRequestResponse(w, r.URL.String(), fmt.Sprintf("invalid operator %v, only %v and %v are supported", req.Operator, types.AlertRuleOperatorBelow, types.AlertRuleOperatorAbove))
+		return false
+	}
+
+	if req.Threshold < 0 || ((req.Metric == types.AlertRuleMetricEffectiveness || req.Metric == types.AlertRuleMetricSyncParticipation) && req.Threshold > 100) {
+		SendBadRequestResponse(w, r.URL.String(), fmt.Sprintf("invalid threshold %v for metric %v", req.Threshold, req.Metric))
+		return false
+	}
+
+	if req.WindowEpochs == 0 {
+		req.WindowEpochs = 1
+	}
+	if req.WindowEpochs > services.MaxAlertRuleWindowEpochs {
+		SendBadRequestResponse(w, r.URL.String(), fmt.Sprintf("the window of an alert rule can not be longer than %v epochs", services.MaxAlertRuleWindowEpochs))
+		return false
+	}
+
+	validators := make(pq.Int64Array, 0, len(req.Validators))
+	seen := make(map[uint64]bool, len(req.Validators))
+	for _, validator := range req.Validators {
+		if seen[validator] {
+			continue
+		}
+		seen[validator] = true
+		validators = append(validators, int64(validator))
+	}
+	if len(validators) == 0 || len(validators) > maxAlertRuleValidators {
+		SendBadRequestResponse(w, r.URL.String(), fmt.Sprintf("an alert rule must contain between 1 and %v validators", maxAlertRuleValidators))
+		return false
+	}
+
+	var count int
+	err := db.ReaderDb.Get(&count, `SELECT COUNT(*) FROM validators WHERE validatorindex = ANY($1)`, validators)
+	if err != nil {
+		logger.WithError(err).Error("error counting validators of alert rule")
+		sendServerErrorResponse(w, r.URL.String(), "could not validate validators of alert rule")
+		return false
+	}
+	if count != len(validators) {
+		SendBadRequestResponse(w, r.URL.String(), "the alert rule contains unknown validators")
+		return false
+	}
+
+	rule.Name = req.Name
+	rule.Validators = validators
+	rule.Metric = req.Metric
+	rule.Operator = req.Operator
+	rule.Threshold = req.Threshold
+	rule.WindowEpochs = req.WindowEpochs
+	if req.Active != nil {
+		rule.Active = *req.Active
+	}
+	return true
+}
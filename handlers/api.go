@@ -739,6 +739,118 @@ func ApiSyncCommittee(w http.ResponseWriter, r *http.Request) {
 	returnQueryResults(rows, w, r)
 }
 
+// ApiSyncCommitteeParticipation godoc
+// @Summary Get the sync-aggregate participation of a sync-committee
+// @Tags SyncCommittee
+// @Description Returns the participation of every sync-committee member in the sync-aggregates of a sync-period, sorted by sync-committee-index.
+// @Description Only slots for which the beacon node served a light client update are taken into account.
+// @Description Rewards, penalties and income are in gwei.
+// @Produce json
+// @Param period path string true "Period ('latest' for latest period)"
+// @Success 200 {object} types.ApiResponse{data=types.APISyncCommitteeParticipationResponse}
+// @Failure 400 {object} types.ApiResponse
+// @Router /api/v1/sync_committee/{period}/participation [get]
+func ApiSyncCommitteeParticipation(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	j := json.NewEncoder(w)
+	vars := mux.Vars(r)
+
+	period, err := strconv.ParseUint(vars["period"], 10, 64)
+	if err != nil && vars["period"] != "latest" {
+		SendBadRequestResponse(w, r.URL.String(), "invalid period provided")
+		return
+	}
+
+	if vars["period"] == "latest" {
+		period = utils.SyncPeriodOfEpoch(services.LatestEpoch())
+	}
+
+	startEpoch := utils.FirstEpochOfSyncPeriod(period)
+	if startEpoch < utils.Config.Chain.ClConfig.AltairForkEpoch {
+		startEpoch = utils.Config.Chain.ClConfig.AltairForkEpoch
+	}
+	endEpoch := utils.FirstEpochOfSyncPeriod(period+1) - 1
+
+	var committee []struct {
+		ValidatorIndex uint64 `db:"validatorindex"`
+		CommitteeIndex uint64 `db:"committeeindex"`
+	}
+	err = db.ReaderDb.Select(&committee, `SELECT validatorindex, committeeindex FROM sync_committees WHERE period = $1 ORDER BY committeeindex`, period)
+	if err != nil {
+		logger.WithError(err).WithField("url", r.URL.String()).Errorf("error querying db")
+		SendBadRequestResponse(w, r.URL.String(), "could not retrieve db results")
+		return
+	}
+	if len(committee) == 0 {
+		SendBadRequestResponse(w, r.URL.String(), "no sync-committee found for the provided period")
+		return
+	}
+
+	aggs, err := db.GetLightClientSyncAggregatesForPeriod(period)
+	if err != nil {
+		logger.WithError(err).WithField("url", r.URL.String()).Errorf("error retrieving sync aggregates")
+		SendBadRequestResponse(w, r.URL.String(), "could not retrieve db results")
+		return
+	}
+
+	var balances []struct {
+		Epoch         uint64 `db:"epoch"`
+		EligibleEther uint64 `db:"eligibleether"`
+	}
+	err = db.ReaderDb.Select(&balances, `SELECT epoch, COALESCE(eligibleether, 0) AS eligibleether FROM epochs WHERE epoch >= $1 AND epoch <= $2`, startEpoch, endEpoch)
+	if err != nil {
+		logger.WithError(err).WithField("url", r.URL.String()).Errorf("error retrieving eligible ether of epochs")
+		SendBadRequestResponse(w, r.URL.String(), "could not retrieve db results")
+		return
+	}
+	rewardPerEpoch := make(map[uint64]uint64, len(balances))
+	for _, b := range balances {
+		rewardPerEpoch[b.Epoch] = utils.SyncCommitteeParticipantReward(b.EligibleEther)
+	}
+
+	data := &types.APISyncCommitteeParticipationResponse{
+		Period:       period,
+		StartEpoch:   startEpoch,
+		EndEpoch:     endEpoch,
+		TrackedSlots: uint64(len(aggs)),
+		Members:      make([]*types.APISyncCommitteeMemberParticipation, 0, len(committee)),
+	}
+	for _, member := range committee {
+		data.Members = append(data.Members, &types.APISyncCommitteeMemberParticipation{
+			CommitteeIndex: member.CommitteeIndex,
+			ValidatorIndex: member.ValidatorIndex,
+			MissedSlots:    []uint64{},
+		})
+	}
+
+	for _, agg := range aggs {
+		data.Participation += agg.Participation
+		reward := rewardPerEpoch[utils.EpochOfSlot(agg.Slot)]
+		for i, member := range data.Members {
+			if utils.BitAtVector(agg.SyncCommitteeBits, i) {
+				member.Participated++
+				member.Rewards += reward
+			} else {
+				member.Missed++
+				member.MissedSlots = append(member.MissedSlots, agg.Slot)
+				member.Penalties += reward
+			}
+		}
+	}
+	if len(aggs) > 0 {
+		data.Participation /= float64(len(aggs))
+	}
+	for _, member := range data.Members {
+		if member.Participated+member.Missed > 0 {
+			member.ParticipationRate = float64(member.Participated) / float64(member.Participated+member.Missed)
+		}
+		member.Income = int64(member.Rewards) - int64(member.Penalties)
+	}
+
+	SendOKResponse(j, r.URL.String(), []interface{}{data})
+}
+
 // ApiValidatorQueue godoc
 // @Summary Get the current validator queue
 // @Tags Validator
@@ -2119,6 +2231,176 @@ func ApiValidatorBlsChange(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// ApiValidatorBlockValues godoc
+// @Summary Compare the value captured with the last 100 proposals of up to 100 validators to the best bid the relays received for the slot
+// @Tags Validator
+// @Description The captured value is the value delivered by the relay or the tx fees of a locally built block. Only bids on top of the same parent block are taken into account, the bid values are null if no relay bids are known for the slot.
+// @Produce  json
+// @Param  indexOrPubkey path string true "Up to 100 validator indicesOrPubkeys, comma separated"
+// @Success 200 {object} types.ApiResponse{data=[]types.ApiValidatorBlockValueResponse}
+// @Failure 400 {object} types.ApiResponse
+// @Router /api/v1/validator/{indexOrPubkey}/blockvalues [get]
+func ApiValidatorBlockValues(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	vars := mux.Vars(r)
+	maxValidators := getUserPremium(r).MaxValidators
+
+	queryIndices, err := parseApiValidatorParamToIndices(vars["indexOrPubkey"], maxValidators)
+	if err != nil {
+		SendBadRequestResponse(w, r.URL.String(), err.Error())
+		return
+	}
+
+	data, err := db.GetValidatorsProposerBlockValues(queryIndices, 100)
+	if err != nil {
+		logger.Errorf("error retrieving validators block values for %v route: %v", r.URL.String(), err)
+		SendBadRequestResponse(w, r.URL.String(), "could not retrieve db results")
+		return
+	}
+	err = fillProposerBlockValues(data)
+	if err != nil {
+		logger.Errorf("error retrieving validators block values for %v route: %v", r.URL.String(), err)
+		SendBadRequestResponse(w, r.URL.String(), "could not retrieve db results")
+		return
+	}
+
+	dataFormatted := make([]*types.ApiValidatorBlockValueResponse, 0, len(data))
+	for _, d := range data {
+		dataFormatted = append(dataFormatted, &types.ApiValidatorBlockValueResponse{
+			Epoch:           utils.EpochOfSlot(d.Slot),
+			Slot:            d.Slot,
+			ValidatorIndex:  d.Proposer,
+			ExecBlockNumber: d.ExecBlockNumber,
+			ExecBlockHash:   fmt.Sprintf("0x%x", d.ExecBlockHash),
+			Relay:           d.RelayTag.String,
+			CapturedValue:   d.CapturedValue,
+			BestBidRelay:    d.BestBidRelayTag.String,
+			BestBidValue:    d.BestBidValue.BigInt(),
+			LeftOnTable:     d.LeftOnTable,
+		})
+	}
+
+	response := &types.ApiResponse{}
+	response.Status = "OK"
+
+	response.Data = dataFormatted
+
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sendServerErrorResponse(w, r.URL.String(), "could not serialize data results")
+		return
+	}
+}
+
+// ApiValidatorWithdrawalRequests godoc
+// @Summary Gets the execution layer triggered withdrawal requests for up to 100 validators
+// @Tags Validator
+// @Description An amount of 0 requests the full exit of the validator.
+// @Produce  json
+// @Param  indexOrPubkey path string true "Up to 100 validator indicesOrPubkeys, comma separated"
+// @Success 200 {object} types.ApiResponse{data=[]types.ApiValidatorWithdrawalRequestResponse}
+// @Failure 400 {object} types.ApiResponse
+// @Router /api/v1/validator/{indexOrPubkey}/withdrawal_requests [get]
+func ApiValidatorWithdrawalRequests(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	vars := mux.Vars(r)
+	maxValidators := getUserPremium(r).MaxValidators
+
+	pubkeys, err := parseApiValidatorParamToPubkeys(vars["indexOrPubkey"], maxValidators)
+	if err != nil {
+		SendBadRequestResponse(w, r.URL.String(), err.Error())
+		return
+	}
+
+	data, err := db.GetValidatorsWithdrawalRequests(pubkeys)
+	if err != nil {
+		logger.Errorf("error retrieving validators withdrawal requests for %v route: %v", r.URL.String(), err)
+		SendBadRequestResponse(w, r.URL.String(), "could not retrieve db results")
+		return
+	}
+
+	dataFormatted := make([]*types.ApiValidatorWithdrawalRequestResponse, 0, len(data))
+	for _, d := range data {
+		dataFormatted = append(dataFormatted, &types.ApiValidatorWithdrawalRequestResponse{
+			Epoch:           utils.EpochOfSlot(d.BlockSlot),
+			Slot:            d.BlockSlot,
+			BlockRoot:       fmt.Sprintf("0x%x", d.BlockRoot),
+			RequestIndex:    d.RequestIndex,
+			SourceAddress:   fmt.Sprintf("0x%x", d.SourceAddress),
+			ValidatorPubkey: fmt.Sprintf("0x%x", d.ValidatorPubkey),
+			ValidatorIndex:  d.ValidatorIndex,
+			Amount:          d.Amount,
+		})
+	}
+
+	response := &types.ApiResponse{}
+	response.Status = "OK"
+
+	response.Data = dataFormatted
+
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sendServerErrorResponse(w, r.URL.String(), "could not serialize data results")
+		return
+	}
+}
+
+// ApiValidatorConsolidations godoc
+// @Summary Gets the consolidation requests in which one of up to 100 validators is either source or target
+// @Tags Validator
+// @Produce  json
+// @Param  indexOrPubkey path string true "Up to 100 validator indicesOrPubkeys, comma separated"
+// @Success 200 {object} types.ApiResponse{data=[]types.ApiValidatorConsolidationResponse}
+// @Failure 400 {object} types.ApiResponse
+// @Router /api/v1/validator/{indexOrPubkey}/consolidations [get]
+func ApiValidatorConsolidations(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	vars := mux.Vars(r)
+	maxValidators := getUserPremium(r).MaxValidators
+
+	pubkeys, err := parseApiValidatorParamToPubkeys(vars["indexOrPubkey"], maxValidators)
+	if err != nil {
+		SendBadRequestResponse(w, r.URL.String(), err.Error())
+		return
+	}
+
+	data, err := db.GetValidatorsConsolidationRequests(pubkeys)
+	if err != nil {
+		logger.Errorf("error retrieving validators consolidation requests for %v route: %v", r.URL.String(), err)
+		SendBadRequestResponse(w, r.URL.String(), "could not retrieve db results")
+		return
+	}
+
+	dataFormatted := make([]*types.ApiValidatorConsolidationResponse, 0, len(data))
+	for _, d := range data {
+		dataFormatted = append(dataFormatted, &types.ApiValidatorConsolidationResponse{
+			Epoch:         utils.EpochOfSlot(d.BlockSlot),
+			Slot:          d.BlockSlot,
+			BlockRoot:     fmt.Sprintf("0x%x", d.BlockRoot),
+			RequestIndex:  d.RequestIndex,
+			SourceAddress: fmt.Sprintf("0x%x", d.SourceAddress),
+			SourcePubkey:  fmt.Sprintf("0x%x", d.SourcePubkey),
+			SourceIndex:   d.SourceIndex,
+			TargetPubkey:  fmt.Sprintf("0x%x", d.TargetPubkey),
+			TargetIndex:   d.TargetIndex,
+		})
+	}
+
+	response := &types.ApiResponse{}
+	response.Status = "OK"
+
+	response.Data = dataFormatted
+
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sendServerErrorResponse(w, r.URL.String(), "could not serialize data results")
+		return
+	}
+}
+
 // ApiValidator godoc
 // @Summary Get the balance history of up to 100 validators
 // @Tags Validator
@@ -2544,6 +2826,7 @@ func ApiValidatorDeposits(w http.ResponseWriter, r *http.Request) {
 
 // ApiValidatorAttestations godoc
 // @Summary Get all attestations during the last 100 epochs for up to 100 validators
+// @Description Included attestations also show whether their head, target and source votes were correct and timely, which decides the attestation rewards since altair.
 // @Tags Validator
 // @Produce  json
 // @Param  indexOrPubkey path string true "Up to 100 validator indicesOrPubkeys, comma separated"
@@ -2576,7 +2859,7 @@ func ApiValidatorAttestations(w http.ResponseWriter, r *http.Request) {
 	for validatorIndex, balances := range history {
 		for _, attestation := range balances {
 			epochAtStartOfTheWeek := (attestation.Epoch / epochsPerWeek) * epochsPerWeek
-			responseData = append(responseData, &types.ApiValidatorAttestationsResponse{
+			data := &types.ApiValidatorAttestationsResponse{
 				AttesterSlot:   attestation.AttesterSlot,
 				CommitteeIndex: 0,
 				Epoch:          attestation.Epoch,
@@ -2586,7 +2869,21 @@ func ApiValidatorAttestations(w http.ResponseWriter, r *http.Request) {
 				Week:           attestation.Epoch / epochsPerWeek,
 				WeekStart:      utils.EpochToTime(epochAtStartOfTheWeek),
 				WeekEnd:        utils.EpochToTime(epochAtStartOfTheWeek + epochsPerWeek),
-			})
+			}
+			if attestation.Status == 1 && attestation.Flags.Known() {
+				flag := func(f types.AttestationFlags) *bool {
+					v := attestation.Flags.Has(f)
+					return &v
+				}
+				data.CorrectHead = flag(types.AttestationFlagCorrectHead)
+				data.CorrectTarget = flag(types.AttestationFlagCorrectTarget)
+				data.CorrectSource = flag(types.AttestationFlagCorrectSource)
+				data.TimelyHead = flag(types.AttestationFlagTimelyHead)
+				data.TimelyTarget = flag(types.AttestationFlagTimelyTarget)
+				data.TimelySource = flag(types.AttestationFlagTimelySource)
+				data.MissedRewards = attestation.Flags.MissedRewards()
+			}
+			responseData = append(responseData, data)
 		}
 	}
 
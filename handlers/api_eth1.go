@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"eth2-exporter/db"
+	"eth2-exporter/eth1data"
 	"eth2-exporter/price"
 	"eth2-exporter/services"
 	"eth2-exporter/types"
@@ -17,6 +18,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	geth_types "github.com/ethereum/go-ethereum/core/types"
 	"github.com/gorilla/mux"
 	"github.com/lib/pq"
 	"github.com/shopspring/decimal"
@@ -408,6 +410,428 @@ func ApiEth1AddressERC20Tokens(w http.ResponseWriter, r *http.Request) {
 	SendOKResponse(json.NewEncoder(w), r.URL.String(), []interface{}{response})
 }
 
+// ApiEth1AddressBalanceHistory godoc
+// @Summary Returns the daily balance history of an Ethereum address
+// @Tags Execution
+// @Description Returns the balance of an Ethereum address at the start of each of the last days (UTC) followed by the current balance, or the balance after a specific block if the block is provided. The balances are derived from the current balance of the address and its indexed balance changes by transactions, internal transactions, fees, rewards, withdrawals and ERC20 transfers.
+// @Produce json
+// @Param address path string true "provide an Ethereum address consists of an optional 0x prefix followed by 40 hexadecimal characters". It can also be a valid ENS name.
+// @Param token query string false "ERC20 token address, the native balance is returned if omitted"
+// @Param days query int false "number of days (ranging from 1 to 365)" default(30)
+// @Param block query int false "block number, returns the balance after this block instead of the daily history"
+// @Success 200 {object} types.ApiResponse{data=types.ApiEth1BalanceHistoryResponse}
+// @Failure 400 {object} types.ApiResponse
+// @Router /api/v1/execution/address/{address}/balancehistory [get]
+func ApiEth1AddressBalanceHistory(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	vars := mux.Vars(r)
+	q := r.URL.Query()
+
+	address := ReplaceEnsNameWithAddress(vars["address"])
+	address = strings.Replace(address, "0x", "", -1)
+	address = strings.ToLower(address)
+
+	if !utils.IsEth1Address(address) {
+		SendBadRequestResponse(w, r.URL.String(), "error invalid address. An Ethereum address consists of an optional 0x prefix followed by 40 hexadecimal characters.")
+		return
+	}
+
+	token := []byte{0x0}
+	if len(q.Get("token")) > 0 {
+		tokenQuery := strings.ToLower(strings.Replace(q.Get("token"), "0x", "", -1))
+		if !utils.IsEth1Address(tokenQuery) {
+			SendBadRequestResponse(w, r.URL.String(), "error invalid token query param. A token address consists of an optional 0x prefix followed by 40 hexadecimal characters.")
+			return
+		}
+		token = common.FromHex(tokenQuery)
+	}
+
+	response := types.ApiEth1BalanceHistoryResponse{
+		Address: fmt.Sprintf("0x%s", address),
+		Token:   fmt.Sprintf("0x%x", token),
+	}
+
+	if len(q.Get("block")) > 0 {
+		block, err := strconv.ParseUint(q.Get("block"), 10, 64)
+		if err != nil {
+			SendBadRequestResponse(w, r.URL.String(), "error invalid block query param. Block must be a block number.")
+			return
+		}
+		entry, err := getAddressBalanceAtBlock(common.FromHex(address), token, block)
+		if err != nil {
+			logger.Errorf("error retrieving balance at block %v for address: %v route: %v err: %v", block, address, r.URL.String(), err)
+			sendServerErrorResponse(w, r.URL.String(), "error could not get balance history for address")
+			return
+		}
+		response.Balances = []types.ApiEth1BalanceHistoryEntry{*entry}
+		SendOKResponse(json.NewEncoder(w), r.URL.String(), []interface{}{response})
+		return
+	}
+
+	days := int64(30)
+	if len(q.Get("days")) > 0 {
+		var err error
+		days, err = strconv.ParseInt(q.Get("days"), 10, 64)
+		if err != nil || days < 1 || days > 365 {
+			SendBadRequestResponse(w, r.URL.String(), "error invalid days query param. Days must be a number ranging from 1 to 365.")
+			return
+		}
+	}
+
+	times, balances, err := getAddressBalanceHistory(common.FromHex(address), token, int(days))
+	if err != nil {
+		logger.Errorf("error retrieving balance history for address: %v route: %v err: %v", address, r.URL.String(), err)
+		sendServerErrorResponse(w, r.URL.String(), "error could not get balance history for address")
+		return
+	}
+
+	response.Balances = make([]types.ApiEth1BalanceHistoryEntry, 0, len(times))
+	for i, t := range times {
+		response.Balances = append(response.Balances, types.ApiEth1BalanceHistoryEntry{
+			Timestamp: t.Unix(),
+			Balance:   balances[i].String(),
+		})
+	}
+
+	SendOKResponse(json.NewEncoder(w), r.URL.String(), []interface{}{response})
+}
+
+// getAddressBalanceAtBlock returns the balance of an address for a token after the given block, scaled by the decimals of the token
+func getAddressBalanceAtBlock(address []byte, token []byte, block uint64) (*types.ApiEth1BalanceHistoryEntry, error) {
+	decimals, err := getTokenDecimals(token)
+	if err != nil {
+		return nil, err
+	}
+
+	balances, err := db.BigtableClient.GetBalanceHistoryForAddressAtBlocks(address, token, []uint64{block})
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &types.ApiEth1BalanceHistoryEntry{
+		Block:   block,
+		Balance: decimal.NewFromBigInt(balances[0], -int32(decimals)).String(),
+	}
+	blocks, err := db.BigtableClient.GetBlocksIndexedMultiple([]uint64{block}, 1)
+	if err != nil {
+		return nil, fmt.Errorf("error getting block %v: %w", block, err)
+	}
+	if len(blocks) > 0 {
+		entry.Timestamp = blocks[0].GetTime().AsTime().Unix()
+	}
+	return entry, nil
+}
+
+// ApiEth1TokenHolders godoc
+// @Summary Returns the largest holders of an ERC20 token
+// @Tags Execution
+// @Description Returns the holders of an ERC20 token ordered by balance, largest first, together with their share of the total supply and the number of addresses holding the token. Supports pagination via the returned page_token.
+// @Produce json
+// @Param token path string true "ERC20 token address, consists of an optional 0x prefix followed by 40 hexadecimal characters"
+// @Param limit query int false "data limit (ranging from 1 to 100)" default(25)
+// @Param pageToken query string false "page token returned by the previous request"
+// @Success 200 {object} types.ApiResponse{data=types.ApiEth1TokenHoldersResponse}
+// @Failure 400 {object} types.ApiResponse
+// @Router /api/v1/execution/token/{token}/holders [get]
+func ApiEth1TokenHolders(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	vars := mux.Vars(r)
+	q := r.URL.Query()
+
+	token := strings.ToLower(strings.Replace(vars["token"], "0x", "", -1))
+	if !utils.IsEth1Address(token) {
+		SendBadRequestResponse(w, r.URL.String(), "error invalid token. A token address consists of an optional 0x prefix followed by 40 hexadecimal characters.")
+		return
+	}
+
+	limit, err := strconv.ParseInt(q.Get("limit"), 10, 64)
+	if err != nil || limit <= 0 {
+		limit = db.DefaultInfScrollRows
+	} else if limit > 100 {
+		limit = 100
+	}
+
+	errFields := map[string]interface{}{"route": r.URL.String(), "token": token}
+
+	metadata, err := db.BigtableClient.GetERC20MetadataForAddress(common.FromHex(token))
+	if err != nil {
+		utils.LogError(err, "error could not get metadata for token", 0, errFields)
+		sendServerErrorResponse(w, r.URL.String(), "error could not get metadata for token")
+		return
+	}
+
+	holders, pageToken, err := db.BigtableClient.GetTokenHolders(common.FromHex(token), q.Get("pageToken"), limit)
+	if err != nil {
+		utils.LogError(err, "error could not get holders for token", 0, errFields)
+		sendServerErrorResponse(w, r.URL.String(), "error could not get holders for token")
+		return
+	}
+
+	holderCount, err := db.BigtableClient.GetTokenHolderCount(common.FromHex(token))
+	if err != nil {
+		utils.LogError(err, "error could not get holder count for token", 0, errFields)
+		sendServerErrorResponse(w, r.URL.String(), "error could not get holder count for token")
+		return
+	}
+
+	tokenDiv := decimal.NewFromBigInt(big.NewInt(1), int32(new(big.Int).SetBytes(metadata.Decimals).Int64()))
+	response := types.ApiEth1TokenHoldersResponse{
+		Token:       fmt.Sprintf("0x%s", token),
+		HolderCount: holderCount,
+		Holders:     make([]types.ApiEth1TokenHolderResponse, 0, len(holders)),
+		PageToken:   pageToken,
+	}
+	for _, h := range holders {
+		response.Holders = append(response.Holders, types.ApiEth1TokenHolderResponse{
+			Address: fmt.Sprintf("0x%x", h.Address),
+			Balance: decimal.NewFromBigInt(new(big.Int).SetBytes(h.Balance), 0).Div(tokenDiv).String(),
+			Share:   db.TokenSupplyShare(h.Balance, metadata.TotalSupply),
+		})
+	}
+
+	SendOKResponse(json.NewEncoder(w), r.URL.String(), []interface{}{response})
+}
+
+// ApiEth1Logs godoc
+// @Summary Returns the event logs matching the given filters
+// @Tags Execution
+// @Description Returns the event logs emitted by an address and/or with a topic0, newest first. Either address or topic0 is required, the remaining topics and the block range narrow the results down. Event arguments are decoded if the abi of the emitting contract is known. Supports pagination via the returned page_token.
+// @Produce json
+// @Param address query string false "emitting contract address, consists of an optional 0x prefix followed by 40 hexadecimal characters. It can also be a valid ENS name."
+// @Param topic0 query string false "first topic (event signature hash) of the log, consists of an optional 0x prefix followed by 64 hexadecimal characters"
+// @Param topic1 query string false "second topic of the log"
+// @Param topic2 query string false "third topic of the log"
+// @Param topic3 query string false "fourth topic of the log"
+// @Param fromBlock query int false "lowest block number (inclusive)"
+// @Param toBlock query int false "highest block number (inclusive)"
+// @Param limit query int false "data limit (ranging from 1 to 100)" default(25)
+// @Param pageToken query string false "page token returned by the previous request"
+// @Success 200 {object} types.ApiResponse{data=types.ApiEth1LogsResponse}
+// @Failure 400 {object} types.ApiResponse
+// @Router /api/v1/execution/logs [get]
+func ApiEth1Logs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	q := r.URL.Query()
+	filter := &types.Eth1LogFilter{}
+
+	address := q.Get("address")
+	if address != "" {
+		address = strings.ToLower(strings.Replace(ReplaceEnsNameWithAddress(address), "0x", "", -1))
+		if !utils.IsEth1Address(address) {
+			SendBadRequestResponse(w, r.URL.String(), "error invalid address. An Ethereum address consists of an optional 0x prefix followed by 40 hexadecimal characters.")
+			return
+		}
+		filter.Address = common.FromHex(address)
+	}
+
+	for i := range filter.Topics {
+		topic := q.Get(fmt.Sprintf("topic%d", i))
+		if topic == "" {
+			continue
+		}
+		if !utils.IsEth1Tx(topic) {
+			SendBadRequestResponse(w, r.URL.String(), fmt.Sprintf("error invalid topic%d. A topic consists of an optional 0x prefix followed by 64 hexadecimal characters.", i))
+			return
+		}
+		filter.Topics[i] = common.FromHex(topic)
+	}
+
+	if len(filter.Address) == 0 && len(filter.Topics[0]) == 0 {
+		SendBadRequestResponse(w, r.URL.String(), "error either address or topic0 is required")
+		return
+	}
+
+	var err error
+	if q.Get("fromBlock") != "" {
+		filter.FromBlock, err = strconv.ParseUint(q.Get("fromBlock"), 10, 64)
+		if err != nil {
+			SendBadRequestResponse(w, r.URL.String(), "error invalid fromBlock")
+			return
+		}
+	}
+	if q.Get("toBlock") != "" {
+		filter.ToBlock, err = strconv.ParseUint(q.Get("toBlock"), 10, 64)
+		if err != nil {
+			SendBadRequestResponse(w, r.URL.String(), "error invalid toBlock")
+			return
+		}
+	}
+	if filter.ToBlock > 0 && filter.FromBlock > filter.ToBlock {
+		SendBadRequestResponse(w, r.URL.String(), "error fromBlock must not be greater than toBlock")
+		return
+	}
+
+	limit, err := strconv.ParseInt(q.Get("limit"), 10, 64)
+	if err != nil || limit <= 0 {
+		limit = db.DefaultInfScrollRows
+	} else if limit > 100 {
+		limit = 100
+	}
+
+	logs, pageToken, err := db.BigtableClient.GetEth1Logs(filter, q.Get("pageToken"), limit)
+	if err != nil {
+		utils.LogError(err, "error could not get logs", 0, map[string]interface{}{"route": r.URL.String()})
+		sendServerErrorResponse(w, r.URL.String(), "error could not get logs")
+		return
+	}
+
+	response := types.ApiEth1LogsResponse{
+		Logs:      make([]types.ApiEth1LogResponse, 0, len(logs)),
+		PageToken: pageToken,
+	}
+	contractMetadata := make(map[common.Address]*types.ContractMetadata)
+	for _, l := range logs {
+		topics := make([]string, 0, len(l.Topics))
+		log := &geth_types.Log{
+			Address: common.BytesToAddress(l.Address),
+			Topics:  make([]common.Hash, 0, len(l.Topics)),
+			Data:    l.Data,
+			TxHash:  common.BytesToHash(l.ParentHash),
+		}
+		for _, topic := range l.Topics {
+			topics = append(topics, fmt.Sprintf("0x%x", topic))
+			log.Topics = append(log.Topics, common.BytesToHash(topic))
+		}
+
+		meta, found := contractMetadata[log.Address]
+		if !found {
+			meta, err = db.BigtableClient.GetContractMetadata(l.Address)
+			if err != nil {
+				meta = nil
+			}
+			contractMetadata[log.Address] = meta
+		}
+		event := eth1data.DecodeLog(meta, log)
+
+		entry := types.ApiEth1LogResponse{
+			Address:     fmt.Sprintf("0x%x", l.Address),
+			BlockNumber: l.BlockNumber,
+			Timestamp:   l.Time.AsTime().Unix(),
+			TxHash:      fmt.Sprintf("0x%x", l.ParentHash),
+			TxIndex:     l.TxIndex,
+			LogIndex:    l.LogIndex,
+			Topics:      topics,
+			Data:        fmt.Sprintf("0x%x", l.Data),
+			Event:       event.Name,
+		}
+		if len(event.DecodedData) > 0 {
+			entry.DecodedData = make(map[string]string, len(event.DecodedData))
+			for name, value := range event.DecodedData {
+				entry.DecodedData[name] = value.Value
+			}
+		}
+		response.Logs = append(response.Logs, entry)
+	}
+
+	SendOKResponse(json.NewEncoder(w), r.URL.String(), []interface{}{response})
+}
+
+// ApiEth1AddressDeployedContracts godoc
+// @Summary Returns the contracts deployed by an address
+// @Tags Execution
+// @Description Returns the contracts deployed by an address, newest first. This includes contracts created directly by transactions of the address as well as contracts created by the address acting as a factory. Supports pagination via the returned page_token.
+// @Produce json
+// @Param address path string true "Address, consists of an optional 0x prefix followed by 40 hexadecimal characters"
+// @Param limit query int false "data limit (ranging from 1 to 100)" default(25)
+// @Param pageToken query string false "page token returned by the previous request"
+// @Success 200 {object} types.ApiResponse{data=types.ApiEth1ContractDeploymentsResponse}
+// @Failure 400 {object} types.ApiResponse
+// @Router /api/v1/execution/address/{address}/deployedcontracts [get]
+func ApiEth1AddressDeployedContracts(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	vars := mux.Vars(r)
+	q := r.URL.Query()
+
+	address := strings.ToLower(strings.Replace(vars["address"], "0x", "", -1))
+	if !utils.IsEth1Address(address) {
+		SendBadRequestResponse(w, r.URL.String(), "error invalid address. An address consists of an optional 0x prefix followed by 40 hexadecimal characters.")
+		return
+	}
+
+	limit, err := strconv.ParseInt(q.Get("limit"), 10, 64)
+	if err != nil || limit <= 0 {
+		limit = db.DefaultInfScrollRows
+	} else if limit > 100 {
+		limit = 100
+	}
+
+	deployments, pageToken, err := db.BigtableClient.GetContractDeploymentsForAddress(common.FromHex(address), q.Get("pageToken"), limit)
+	if err != nil {
+		utils.LogError(err, "error could not get deployed contracts for address", 0, map[string]interface{}{"route": r.URL.String(), "address": address})
+		sendServerErrorResponse(w, r.URL.String(), "error could not get deployed contracts for address")
+		return
+	}
+
+	SendOKResponse(json.NewEncoder(w), r.URL.String(), []interface{}{formatContractDeploymentsForApiResponse(deployments, pageToken)})
+}
+
+// ApiEth1ContractsByCodeHash godoc
+// @Summary Returns the contracts with the same runtime code
+// @Tags Execution
+// @Description Returns the contracts whose runtime bytecode has the given keccak256 hash, newest first. Supports pagination via the returned page_token.
+// @Produce json
+// @Param codeHash path string true "Code hash, consists of an optional 0x prefix followed by 64 hexadecimal characters"
+// @Param limit query int false "data limit (ranging from 1 to 100)" default(25)
+// @Param pageToken query string false "page token returned by the previous request"
+// @Success 200 {object} types.ApiResponse{data=types.ApiEth1ContractDeploymentsResponse}
+// @Failure 400 {object} types.ApiResponse
+// @Router /api/v1/execution/contracts/codehash/{codeHash} [get]
+func ApiEth1ContractsByCodeHash(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	vars := mux.Vars(r)
+	q := r.URL.Query()
+
+	codeHash := strings.ToLower(strings.Replace(vars["codeHash"], "0x", "", -1))
+	if !utils.IsEth1Tx(codeHash) {
+		SendBadRequestResponse(w, r.URL.String(), "error invalid code hash. A code hash consists of an optional 0x prefix followed by 64 hexadecimal characters.")
+		return
+	}
+
+	limit, err := strconv.ParseInt(q.Get("limit"), 10, 64)
+	if err != nil || limit <= 0 {
+		limit = db.DefaultInfScrollRows
+	} else if limit > 100 {
+		limit = 100
+	}
+
+	deployments, pageToken, err := db.BigtableClient.GetContractDeploymentsForCodeHash(common.FromHex(codeHash), q.Get("pageToken"), limit)
+	if err != nil {
+		utils.LogError(err, "error could not get contracts for code hash", 0, map[string]interface{}{"route": r.URL.String(), "codeHash": codeHash})
+		sendServerErrorResponse(w, r.URL.String(), "error could not get contracts for code hash")
+		return
+	}
+
+	SendOKResponse(json.NewEncoder(w), r.URL.String(), []interface{}{formatContractDeploymentsForApiResponse(deployments, pageToken)})
+}
+
+func formatContractDeploymentsForApiResponse(deployments []*types.Eth1ContractDeploymentIndexed, pageToken string) types.ApiEth1ContractDeploymentsResponse {
+	response := types.ApiEth1ContractDeploymentsResponse{
+		Contracts: make([]types.ApiEth1ContractDeploymentResponse, 0, len(deployments)),
+		PageToken: pageToken,
+	}
+	for _, d := range deployments {
+		contract := types.ApiEth1ContractDeploymentResponse{
+			Address:        fmt.Sprintf("0x%x", d.Address),
+			Deployer:       fmt.Sprintf("0x%x", d.Deployer),
+			TxHash:         fmt.Sprintf("0x%x", d.TxHash),
+			BlockNumber:    d.BlockNumber,
+			Timestamp:      d.Time.AsTime().Unix(),
+			CodeHash:       fmt.Sprintf("0x%x", d.CodeHash),
+			CreationMethod: d.CreationMethod,
+		}
+		if len(d.Factory) > 0 {
+			contract.Factory = fmt.Sprintf("0x%x", d.Factory)
+		}
+		response.Contracts = append(response.Contracts, contract)
+	}
+	return response
+}
+
 func formatBlocksForApiResponse(blocks []*types.Eth1BlockIndexed, relaysData map[common.Hash]types.RelaysData, beaconDataMap map[uint64]types.ExecBlockProposer, sortFunc func(i, j types.ExecutionBlockApiResponse) bool) []types.ExecutionBlockApiResponse {
 	results := []types.ExecutionBlockApiResponse{}
 
@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"database/sql"
+	"eth2-exporter/db"
+	"eth2-exporter/templates"
+	"eth2-exporter/types"
+	"eth2-exporter/utils"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/csrf"
+	"github.com/gorilla/mux"
+)
+
+// Load Contract ABI page, abis uploaded by users are used once an admin approved them, admins see the pending submissions
+func ContractAbi(w http.ResponseWriter, r *http.Request) {
+	user, _, err := getUserSession(r)
+	if err != nil {
+		utils.LogError(err, "error retrieving session", 0)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	templateFiles := append(layoutTemplateFiles, "user/contract_abi.html")
+	var userTemplate = templates.GetTemplate(templateFiles...)
+
+	w.Header().Set("Content-Type", "text/html")
+
+	data := InitPageData(w, r, "user", "/user/contract_abi", "Contract ABI", templateFiles)
+
+	pageData := types.ContractAbiPageData{}
+	pageData.CsrfField = csrf.TemplateField(r)
+	pageData.IsAdmin = user.UserGroup == "ADMIN"
+	if pageData.IsAdmin {
+		pageData.Submissions, err = db.GetPendingContractAbiSubmissions()
+		if err != nil {
+			logger.Warnf("error retrieving pending contract abi submissions: %v", err)
+		}
+	}
+
+	address := strings.ToLower(strings.Replace(r.URL.Query().Get("address"), "0x", "", -1))
+	if utils.IsEth1Address(address) {
+		pageData.Address = address
+		metadata, err := db.BigtableClient.GetContractMetadata(common.FromHex(address))
+		if err != nil {
+			logger.Warnf("error retrieving contract metadata for address %v: %v", address, err)
+		}
+		pageData.Metadata = metadata
+	}
+	data.Data = pageData
+
+	if handleTemplateError(w, r, "contract_abi.go", "ContractAbi", "", userTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+// Upload a verified ABI for a contract, uploads of users that are not admins are stored for review
+func ContractAbiPost(w http.ResponseWriter, r *http.Request) {
+	user, _, err := getUserSession(r)
+	if err != nil {
+		utils.LogError(err, "error retrieving session", 0)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		utils.LogError(err, "error parsing form", 0)
+		http.Redirect(w, r, "/user/contract_abi?error=parsingForm", http.StatusSeeOther)
+		return
+	}
+
+	address := strings.ToLower(strings.Replace(r.FormValue("address"), "0x", "", -1))
+	if !utils.IsEth1Address(address) {
+		http.Redirect(w, r, "/user/contract_abi?error=invalidAddress", http.StatusSeeOther)
+		return
+	}
+
+	metadata, err := utils.ParseContractMetadata([]byte(r.FormValue("abi")))
+	if err != nil {
+		logger.Warnf("error parsing uploaded abi for address %v: %v", address, err)
+		http.Redirect(w, r, fmt.Sprintf("/user/contract_abi?address=0x%s&error=invalidAbi", address), http.StatusSeeOther)
+		return
+	}
+	name := strings.TrimSpace(r.FormValue("name"))
+	if name != "" {
+		metadata.Name = name
+	}
+
+	if user.UserGroup != "ADMIN" {
+		err = db.SaveContractAbiSubmission(user.UserID, common.FromHex(address), name, r.FormValue("abi"))
+		if err != nil {
+			utils.LogError(err, "error saving contract abi submission", 0, map[string]interface{}{"address": address})
+			http.Redirect(w, r, fmt.Sprintf("/user/contract_abi?address=0x%s&error=saveFailed", address), http.StatusSeeOther)
+			return
+		}
+		utils.SetFlash(w, r, authSessionName, "Thank you, the ABI will be used to decode the contract once it has been reviewed.")
+		http.Redirect(w, r, fmt.Sprintf("/user/contract_abi?address=0x%s", address), http.StatusSeeOther)
+		return
+	}
+
+	err = db.BigtableClient.SaveVerifiedContractMetadata(common.FromHex(address), metadata)
+	if err != nil {
+		utils.LogError(err, "error saving verified contract abi", 0, map[string]interface{}{"address": address})
+		http.Redirect(w, r, fmt.Sprintf("/user/contract_abi?address=0x%s&error=saveFailed", address), http.StatusSeeOther)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/user/contract_abi?address=0x%s", address), http.StatusSeeOther)
+}
+
+// Approve or reject an ABI submitted by a user
+func ContractAbiReviewPost(w http.ResponseWriter, r *http.Request) {
+	if isAdmin, _ := handleAdminPermissions(w, r); !isAdmin {
+		return
+	}
+
+	id, err := strconv.ParseUint(mux.Vars(r)["submissionID"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid submission id", http.StatusBadRequest)
+		return
+	}
+	status := r.FormValue("status")
+	if status != types.ContractAbiSubmissionApproved && status != types.ContractAbiSubmissionRejected {
+		http.Error(w, "Invalid status", http.StatusBadRequest)
+		return
+	}
+
+	submission, err := db.GetContractAbiSubmission(id)
+	if err == sql.ErrNoRows {
+		http.Error(w, "Submission not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		utils.LogError(err, "error retrieving contract abi submission", 0, map[string]interface{}{"id": id})
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if status == types.ContractAbiSubmissionApproved {
+		metadata, err := utils.ParseContractMetadata([]byte(submission.Abi))
+		if err != nil {
+			logger.Warnf("error parsing submitted abi %v for address %x: %v", id, submission.Address, err)
+			http.Redirect(w, r, "/user/contract_abi?error=invalidAbi", http.StatusSeeOther)
+			return
+		}
+		if submission.Name != "" {
+			metadata.Name = submission.Name
+		}
+
+		err = db.BigtableClient.SaveVerifiedContractMetadata(submission.Address, metadata)
+		if err != nil {
+			utils.LogError(err, "error saving verified contract abi", 0, map[string]interface{}{"address": fmt.Sprintf("%x", submission.Address)})
+			http.Redirect(w, r, "/user/contract_abi?error=saveFailed", http.StatusSeeOther)
+			return
+		}
+	}
+
+	err = db.SetContractAbiSubmissionStatus(id, status)
+	if err != nil {
+		utils.LogError(err, "error updating contract abi submission", 0, map[string]interface{}{"id": id})
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/user/contract_abi", http.StatusSeeOther)
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"eth2-exporter/db"
@@ -10,12 +11,14 @@ import (
 	"eth2-exporter/utils"
 	"fmt"
 	"html/template"
+	"math/big"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gorilla/mux"
+	"github.com/shopspring/decimal"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -56,9 +59,10 @@ func Eth1Address(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	g := new(errgroup.Group)
-	g.SetLimit(11)
+	g.SetLimit(12)
 
 	isContract := false
+	var deployment *types.Eth1ContractDeploymentIndexed
 	txns := &types.DataTableResponse{}
 	blobs := &types.DataTableResponse{}
 	internal := &types.DataTableResponse{}
@@ -68,6 +72,9 @@ func Eth1Address(w http.ResponseWriter, r *http.Request) {
 	blocksMined := &types.DataTableResponse{}
 	unclesMined := &types.DataTableResponse{}
 	withdrawals := &types.DataTableResponse{}
+	userOps := &types.DataTableResponse{}
+	sponsoredOps := &types.DataTableResponse{}
+	bundledOps := &types.DataTableResponse{}
 	withdrawalSummary := template.HTML("0")
 
 	g.Go(func() error {
@@ -81,6 +88,14 @@ func Eth1Address(w http.ResponseWriter, r *http.Request) {
 		}
 		return nil
 	})
+	g.Go(func() error {
+		var err error
+		deployment, err = db.BigtableClient.GetContractDeployment(addressBytes)
+		if err != nil {
+			return fmt.Errorf("GetContractDeployment: %w", err)
+		}
+		return nil
+	})
 	g.Go(func() error {
 		var err error
 		txns, err = db.BigtableClient.GetAddressTransactionsTableData(addressBytes, "")
@@ -153,6 +168,30 @@ func Eth1Address(w http.ResponseWriter, r *http.Request) {
 		}
 		return nil
 	})
+	g.Go(func() error {
+		var err error
+		userOps, err = db.BigtableClient.GetAddressUserOperationsTableData(addressBytes, db.USEROP_SENDER, "")
+		if err != nil {
+			return fmt.Errorf("GetAddressUserOperationsTableData: %w", err)
+		}
+		return nil
+	})
+	g.Go(func() error {
+		var err error
+		sponsoredOps, err = db.BigtableClient.GetAddressUserOperationsTableData(addressBytes, db.USEROP_PAYMASTER, "")
+		if err != nil {
+			return fmt.Errorf("GetAddressUserOperationsTableData: %w", err)
+		}
+		return nil
+	})
+	g.Go(func() error {
+		var err error
+		bundledOps, err = db.BigtableClient.GetAddressUserOperationsTableData(addressBytes, db.USEROP_BUNDLER, "")
+		if err != nil {
+			return fmt.Errorf("GetAddressUserOperationsTableData: %w", err)
+		}
+		return nil
+	})
 	g.Go(func() error {
 		sumWithdrawals, err := db.GetAddressWithdrawalsTotal(addressBytes)
 		if err != nil {
@@ -240,11 +279,36 @@ func Eth1Address(w http.ResponseWriter, r *http.Request) {
 			Data: withdrawals,
 		})
 	}
+	if userOps != nil && len(userOps.Data) != 0 {
+		tabs = append(tabs, types.Eth1AddressPageTabs{
+			Id:   "userOps",
+			Href: "#userOps",
+			Text: "User Ops",
+			Data: userOps,
+		})
+	}
+	if sponsoredOps != nil && len(sponsoredOps.Data) != 0 {
+		tabs = append(tabs, types.Eth1AddressPageTabs{
+			Id:   "sponsoredUserOps",
+			Href: "#sponsoredUserOps",
+			Text: "Sponsored User Ops",
+			Data: sponsoredOps,
+		})
+	}
+	if bundledOps != nil && len(bundledOps.Data) != 0 {
+		tabs = append(tabs, types.Eth1AddressPageTabs{
+			Id:   "bundledUserOps",
+			Href: "#bundledUserOps",
+			Text: "Bundled User Ops",
+			Data: bundledOps,
+		})
+	}
 
 	data.Data = types.Eth1AddressPageData{
 		Address:            address,
 		EnsName:            ensData.Domain,
 		IsContract:         isContract,
+		Deployment:         deployment,
 		QRCode:             pngStr,
 		QRCodeInverse:      pngStrInverse,
 		Metadata:           metadata,
@@ -256,6 +320,9 @@ func Eth1Address(w http.ResponseWriter, r *http.Request) {
 		Erc721Table:        erc721,
 		Erc1155Table:       erc1155,
 		WithdrawalsTable:   withdrawals,
+		UserOpsTable:       userOps,
+		SponsoredOpsTable:  sponsoredOps,
+		BundledOpsTable:    bundledOps,
 		BlocksMinedTable:   blocksMined,
 		UnclesMinedTable:   unclesMined,
 		EtherValue:         utils.FormatPricedValue(utils.WeiBytesToEther(metadata.EthBalance.Balance), utils.Config.Frontend.ElCurrency, currency),
@@ -432,6 +499,46 @@ func Eth1AddressInternalTransactions(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// userOperationRoles maps the address page tabs to the role of the address in the user operations
+var userOperationRoles = map[string]db.IndexFilter{
+	"userOps":          db.USEROP_SENDER,
+	"sponsoredUserOps": db.USEROP_PAYMASTER,
+	"bundledUserOps":   db.USEROP_BUNDLER,
+}
+
+func Eth1AddressUserOperations(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	q := r.URL.Query()
+	address, err := lowerAddressFromRequest(w, r)
+	if err != nil {
+		return
+	}
+	addressBytes := common.FromHex(address)
+
+	errFields := map[string]interface{}{
+		"route": r.URL.String()}
+
+	role, found := userOperationRoles[mux.Vars(r)["role"]]
+	if !found {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+
+	pageToken := q.Get("pageToken")
+	data, err := db.BigtableClient.GetAddressUserOperationsTableData(addressBytes, role, pageToken)
+	if err != nil {
+		utils.LogError(err, "error getting eth1 user operation table data", 0, errFields)
+	}
+
+	err = json.NewEncoder(w).Encode(data)
+	if err != nil {
+		utils.LogError(err, "error enconding json response", 0, errFields)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
+
 func Eth1AddressErc20Transactions(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
@@ -513,6 +620,77 @@ func Eth1AddressErc1155Transactions(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// Eth1AddressBalanceHistory returns the daily native balance of an address as chart series
+func Eth1AddressBalanceHistory(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	address, err := lowerAddressFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	errFields := map[string]interface{}{
+		"route": r.URL.String()}
+
+	times, balances, err := getAddressBalanceHistory(common.FromHex(address), []byte{0x0}, 90)
+	if err != nil {
+		utils.LogError(err, "error getting address balance history", 0, errFields)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	series := make([][2]float64, 0, len(times))
+	for i, t := range times {
+		series = append(series, [2]float64{float64(t.UnixMilli()), balances[i].InexactFloat64()})
+	}
+
+	err = json.NewEncoder(w).Encode(series)
+	if err != nil {
+		utils.LogError(err, "error enconding json response", 0, errFields)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// getAddressBalanceHistory returns the balances of an address for a token at the start of each of the last days and the current balance.
+// The balances are scaled by the decimals of the token, native balances are requested with the token 00
+func getAddressBalanceHistory(address []byte, token []byte, days int) ([]time.Time, []decimal.Decimal, error) {
+	decimals, err := getTokenDecimals(token)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	today := time.Now().UTC().Truncate(utils.Day)
+	times := make([]time.Time, 0, days+1)
+	for i := days - 1; i >= 0; i-- {
+		times = append(times, today.Add(-utils.Day*time.Duration(i)))
+	}
+	times = append(times, time.Now().UTC())
+
+	balances, err := db.BigtableClient.GetBalanceHistoryForAddress(address, token, times)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	scaled := make([]decimal.Decimal, 0, len(balances))
+	for _, b := range balances {
+		scaled = append(scaled, decimal.NewFromBigInt(b, -int32(decimals)))
+	}
+	return times, scaled, nil
+}
+
+// getTokenDecimals returns the decimals of an erc20 token, native balances are requested with the token 00
+func getTokenDecimals(token []byte) (int64, error) {
+	if bytes.Equal(token, []byte{0x0}) {
+		return 18, nil
+	}
+	metadata, err := db.BigtableClient.GetERC20MetadataForAddress(token)
+	if err != nil {
+		return 0, fmt.Errorf("error getting metadata for token %x: %w", token, err)
+	}
+	return new(big.Int).SetBytes(metadata.Decimals).Int64(), nil
+}
+
 // takes the "address" parameter from the request and transforms it to lower case. The ENS name can be used instead of the address
 func lowerAddressFromRequest(w http.ResponseWriter, r *http.Request) (string, error) {
 	vars := mux.Vars(r)
@@ -32,6 +32,7 @@ func Eth1Block(w http.ResponseWriter, r *http.Request) {
 		"slot/overview.html",
 		"slot/execTransactions.html",
 		"slot/blobs.html",
+		"slot/executionRequests.html",
 		"slot/withdrawals.html")
 	var blockTemplate = templates.GetTemplate(
 		blockTemplateFiles...,
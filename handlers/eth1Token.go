@@ -19,6 +19,9 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
+// number of top holders shown individually in the holder distribution chart of the token page
+const tokenHolderDistributionLimit = 10
+
 func Eth1Token(w http.ResponseWriter, r *http.Request) {
 	templateFiles := append(layoutTemplateFiles, "execution/token.html")
 	var eth1TokenTemplate = templates.GetTemplate(templateFiles...)
@@ -33,12 +36,14 @@ func Eth1Token(w http.ResponseWriter, r *http.Request) {
 	// symbol := GetCurrencySymbol(r)
 
 	g := new(errgroup.Group)
-	g.SetLimit(3)
+	g.SetLimit(6)
 
 	var txns *types.DataTableResponse
 	var metadata *types.ERC20Metadata
 	var balance *types.Eth1AddressBalance
-	// var holders *types.DataTableResponse
+	var holders *types.DataTableResponse
+	var topHolders []*types.Eth1AddressBalance
+	var holderCount uint64
 
 	g.Go(func() error {
 		var err error
@@ -46,6 +51,24 @@ func Eth1Token(w http.ResponseWriter, r *http.Request) {
 		return err
 	})
 
+	g.Go(func() error {
+		var err error
+		holders, err = db.BigtableClient.GetTokenHoldersTableData(token, "")
+		return err
+	})
+
+	g.Go(func() error {
+		var err error
+		topHolders, _, err = db.BigtableClient.GetTokenHolders(token, "", tokenHolderDistributionLimit)
+		return err
+	})
+
+	g.Go(func() error {
+		var err error
+		holderCount, err = db.BigtableClient.GetTokenHolderCount(token)
+		return err
+	})
+
 	g.Go(func() error {
 		var err error
 		metadata, err = db.BigtableClient.GetERC20MetadataForAddress(token)
@@ -88,17 +111,32 @@ func Eth1Token(w http.ResponseWriter, r *http.Request) {
 	tokenSupply := decimal.NewFromBigInt(new(big.Int).SetBytes(metadata.TotalSupply), 0).DivRound(tokenDiv, 18)
 	tokenMarketCapUsd := tokenPriceUsd.Mul(tokenSupply)
 
+	// the distribution chart shows the share of the top holders and of all remaining holders combined
+	holderDistribution := make([]types.Eth1TokenHolderShare, 0, len(topHolders)+1)
+	remainingShare := 1.0
+	for _, h := range topHolders {
+		share := db.TokenSupplyShare(h.Balance, metadata.TotalSupply)
+		holderDistribution = append(holderDistribution, types.Eth1TokenHolderShare{Name: fmt.Sprintf("0x%x", h.Address), Share: share})
+		remainingShare -= share
+	}
+	if len(topHolders) > 0 && remainingShare > 0 {
+		holderDistribution = append(holderDistribution, types.Eth1TokenHolderShare{Name: "Others", Share: remainingShare})
+	}
+
 	data.Data = types.Eth1TokenPageData{
-		Token:          fmt.Sprintf("%x", token),
-		Address:        fmt.Sprintf("%x", address),
-		TransfersTable: txns,
-		Metadata:       metadata,
-		Balance:        balance,
-		QRCode:         pngStr,
-		QRCodeInverse:  pngStrInverse,
-		MarketCap:      template.HTML("$" + utils.FormatThousandsEnglish(tokenMarketCapUsd.StringFixed(2))),
-		Supply:         template.HTML(utils.FormatThousandsEnglish(tokenSupply.StringFixed(6))),
-		Price:          template.HTML("$" + utils.FormatThousandsEnglish(tokenPriceUsd.StringFixed(6))),
+		Token:              fmt.Sprintf("%x", token),
+		Address:            fmt.Sprintf("%x", address),
+		TransfersTable:     txns,
+		HoldersTable:       holders,
+		HolderDistribution: holderDistribution,
+		Holders:            utils.FormatAddCommas(holderCount),
+		Metadata:           metadata,
+		Balance:            balance,
+		QRCode:             pngStr,
+		QRCodeInverse:      pngStrInverse,
+		MarketCap:          template.HTML("$" + utils.FormatThousandsEnglish(tokenMarketCapUsd.StringFixed(2))),
+		Supply:             template.HTML(utils.FormatThousandsEnglish(tokenSupply.StringFixed(6))),
+		Price:              template.HTML("$" + utils.FormatThousandsEnglish(tokenPriceUsd.StringFixed(6))),
 	}
 
 	if handleTemplateError(w, r, "eth1Token.go", "Eth1Token", "Done", eth1TokenTemplate.ExecuteTemplate(w, "layout", data)) != nil {
@@ -131,3 +169,91 @@ func Eth1TokenTransfers(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+func Eth1TokenHolders(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	q := r.URL.Query()
+	vars := mux.Vars(r)
+
+	token := common.FromHex(strings.TrimPrefix(vars["token"], "0x"))
+
+	data, err := db.BigtableClient.GetTokenHoldersTableData(token, q.Get("pageToken"))
+	if err != nil {
+		logger.Errorf("error getting token holders table data for %v route: %v", r.URL.String(), err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(data)
+	if err != nil {
+		logger.Errorf("error enconding json response for %v route: %v", r.URL.String(), err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// Eth1TokenNft renders the detail page of a single ERC721 or ERC1155 token id with its resolved metadata
+func Eth1TokenNft(w http.ResponseWriter, r *http.Request) {
+	templateFiles := append(layoutTemplateFiles, "execution/nft.html")
+	var eth1NftTemplate = templates.GetTemplate(templateFiles...)
+
+	w.Header().Set("Content-Type", "text/html")
+	vars := mux.Vars(r)
+	token := common.FromHex(strings.TrimPrefix(vars["token"], "0x"))
+	tokenId, ok := new(big.Int).SetString(vars["id"], 10)
+	if !ok {
+		handleNotFoundHtml(w, r)
+		return
+	}
+
+	metadata, err := db.BigtableClient.GetNftMetadata(token, tokenId)
+	if err != nil {
+		logger.Errorf("error retrieving nft metadata for %v route: %v", r.URL.String(), err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	data := InitPageData(w, r, "blockchain", "/token", fmt.Sprintf("Token 0x%x #%s", token, tokenId.String()), templateFiles)
+	data.Data = types.Eth1NftPageData{
+		Token:    fmt.Sprintf("%x", token),
+		TokenId:  tokenId.String(),
+		Metadata: metadata,
+	}
+
+	if handleTemplateError(w, r, "eth1Token.go", "Eth1TokenNft", "Done", eth1NftTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+// Eth1TokenNftImage returns the cached thumbnail of an nft
+func Eth1TokenNftImage(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	token := common.FromHex(strings.TrimPrefix(vars["token"], "0x"))
+	tokenId, ok := new(big.Int).SetString(vars["id"], 10)
+	if !ok {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+
+	metadata, err := db.BigtableClient.GetNftMetadata(token, tokenId)
+	if err != nil {
+		logger.Errorf("error retrieving nft metadata for %v route: %v", r.URL.String(), err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	if metadata == nil || len(metadata.Image) == 0 {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", metadata.ImageFormat)
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	// svg thumbnails are served as they are, make sure that they can not run scripts when they are opened directly
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; sandbox")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	_, err = w.Write(metadata.Image)
+	if err != nil {
+		logger.Errorf("error writing nft image for %v route: %v", r.URL.String(), err)
+	}
+}
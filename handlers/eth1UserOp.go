@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"eth2-exporter/db"
+	"eth2-exporter/eth1data"
+	"eth2-exporter/templates"
+	"eth2-exporter/types"
+	"eth2-exporter/utils"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+)
+
+// Eth1UserOperation will show an erc-4337 user operation using a go template
+func Eth1UserOperation(w http.ResponseWriter, r *http.Request) {
+	templateFiles := append(layoutTemplateFiles, "execution/userop.html")
+	var userOpTemplate = templates.GetTemplate(templateFiles...)
+
+	w.Header().Set("Content-Type", "text/html")
+	vars := mux.Vars(r)
+	hash := strings.ToLower(strings.TrimPrefix(vars["hash"], "0x"))
+	if !utils.IsEth1Tx(hash) {
+		handleNotFoundHtml(w, r)
+		return
+	}
+	hashBytes := common.FromHex(hash)
+
+	op, err := db.BigtableClient.GetUserOperation(hashBytes)
+	if err != nil {
+		logger.Errorf("error retrieving user operation for %v route: %v", r.URL.String(), err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	if op == nil {
+		handleNotFoundHtml(w, r)
+		return
+	}
+
+	pageData := types.Eth1UserOperationPageData{
+		Hash:        op.Hash,
+		Success:     op.Success,
+		TxHash:      op.TxHash,
+		BlockNumber: op.BlockNumber,
+		Timestamp:   op.Time.AsTime(),
+		EntryPoint:  common.BytesToAddress(op.EntryPoint),
+		Sender:      common.BytesToAddress(op.Sender),
+		Bundler:     common.BytesToAddress(op.Bundler),
+		Nonce:       new(big.Int).SetBytes(op.Nonce).String(),
+		GasCost:     utils.FormatAmount(new(big.Int).SetBytes(op.ActualGasCost), utils.Config.Frontend.ElCurrency, 6),
+		GasUsed:     utils.FormatAddCommas(new(big.Int).SetBytes(op.ActualGasUsed).Uint64()),
+	}
+	if len(op.Paymaster) > 0 {
+		paymaster := common.BytesToAddress(op.Paymaster)
+		pageData.Paymaster = &paymaster
+	}
+	if len(op.Beneficiary) > 0 {
+		beneficiary := common.BytesToAddress(op.Beneficiary)
+		pageData.Beneficiary = &beneficiary
+	}
+
+	pageData.SenderName, err = db.BigtableClient.GetAddressName(op.Sender)
+	if err != nil {
+		logger.Warnf("error retrieving name of user operation sender %x: %v", op.Sender, err)
+	}
+	pageData.BundlerName, err = db.BigtableClient.GetAddressName(op.Bundler)
+	if err != nil {
+		logger.Warnf("error retrieving name of user operation bundler %x: %v", op.Bundler, err)
+	}
+	if pageData.Paymaster != nil {
+		pageData.PaymasterName, err = db.BigtableClient.GetAddressName(op.Paymaster)
+		if err != nil {
+			logger.Warnf("error retrieving name of user operation paymaster %x: %v", op.Paymaster, err)
+		}
+	}
+
+	// the call data of a user operation is executed by the smart account of the sender
+	senderMetadata, err := db.BigtableClient.GetContractMetadata(op.Sender)
+	if err != nil {
+		logger.Warnf("error retrieving contract metadata of user operation sender %x: %v", op.Sender, err)
+	}
+	if len(op.CallData) > 0 {
+		pageData.CallData = fmt.Sprintf("0x%x", op.CallData)
+		pageData.DecodedCallData = eth1data.DecodeCallData(senderMetadata, op.CallData)
+	}
+	if len(op.RevertReason) > 0 {
+		pageData.RevertReason = eth1data.DecodeRevertReason(senderMetadata, op.RevertReason)
+		if pageData.RevertReason == "" {
+			pageData.RevertReason = fmt.Sprintf("0x%x", op.RevertReason)
+		}
+	}
+
+	data := InitPageData(w, r, "blockchain", "/userop", fmt.Sprintf("User Operation 0x%x", hashBytes), templateFiles)
+	data.Data = pageData
+
+	if handleTemplateError(w, r, "eth1UserOp.go", "Eth1UserOperation", "Done", userOpTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
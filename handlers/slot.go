@@ -42,6 +42,7 @@ func Slot(w http.ResponseWriter, r *http.Request) {
 		"slot/proposerSlashing.html",
 		"slot/exits.html",
 		"slot/blobs.html",
+		"slot/executionRequests.html",
 		"components/timestamp.html",
 		"slot/overview.html",
 		"slot/execTransactions.html")
@@ -366,6 +367,44 @@ func GetSlotPageData(blockSlot uint64) (*types.BlockPageData, error) {
 		return nil, fmt.Errorf("error retrieving block proposer slashings data: %v", err)
 	}
 
+	slotPageData.DepositRequests, err = db.GetSlotDepositRequests(slotPageData.Slot)
+	if err != nil {
+		return nil, err
+	}
+
+	slotPageData.WithdrawalRequests, err = db.GetSlotWithdrawalRequests(slotPageData.Slot)
+	if err != nil {
+		return nil, err
+	}
+
+	slotPageData.ConsolidationRequests, err = db.GetSlotConsolidationRequests(slotPageData.Slot)
+	if err != nil {
+		return nil, err
+	}
+
+	slotPageData.Reorgs, err = db.GetChainReorgsForSlot(slotPageData.Slot)
+	if err != nil {
+		return nil, err
+	}
+
+	if slotPageData.Status == 1 {
+		slotPageData.BlockValue, err = db.GetSlotProposerBlockValue(slotPageData.Slot)
+		if err != nil {
+			return nil, err
+		}
+		if slotPageData.BlockValue != nil {
+			err = fillProposerBlockValues([]*types.ProposerBlockValue{slotPageData.BlockValue})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	slotPageData.RelayBids, err = db.GetSlotRelayBids(slotPageData.Slot)
+	if err != nil {
+		return nil, err
+	}
+
 	err = db.ReaderDb.Select(&slotPageData.SyncCommittee, "SELECT validatorindex FROM sync_committees WHERE period = $1 ORDER BY committeeindex", utils.SyncPeriodOfEpoch(slotPageData.Epoch))
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving sync-committee of block %v: %v", slotPageData.Slot, err)
@@ -374,6 +413,44 @@ func GetSlotPageData(blockSlot uint64) (*types.BlockPageData, error) {
 	return &slotPageData, nil
 }
 
+// fillProposerBlockValues sets the captured value and the value left on the table of the blocks. Blocks that were not
+// delivered by a relay are valued by the tx fees of the execution block, like for the proposer rewards.
+func fillProposerBlockValues(values []*types.ProposerBlockValue) error {
+	localBlocks := []uint64{}
+	for _, v := range values {
+		if !v.RelayTag.Valid {
+			localBlocks = append(localBlocks, v.ExecBlockNumber)
+		}
+	}
+
+	txRewards := make(map[uint64]*big.Int, len(localBlocks))
+	if len(localBlocks) > 0 {
+		blocks, err := db.BigtableClient.GetBlocksIndexedMultiple(localBlocks, uint64(len(localBlocks)))
+		if err != nil {
+			return fmt.Errorf("error retrieving execution blocks for block values: %w", err)
+		}
+		for _, b := range blocks {
+			txRewards[b.Number] = new(big.Int).SetBytes(b.TxReward)
+		}
+	}
+
+	for _, v := range values {
+		if v.RelayTag.Valid {
+			v.CapturedValue = v.DeliveredValue.BigInt()
+		} else {
+			v.CapturedValue = txRewards[v.ExecBlockNumber]
+		}
+
+		if v.CapturedValue != nil && v.BestBidValue.Int != nil {
+			v.LeftOnTable = new(big.Int).Sub(v.BestBidValue.BigInt(), v.CapturedValue)
+			if v.LeftOnTable.Sign() < 0 {
+				v.LeftOnTable.SetInt64(0)
+			}
+		}
+	}
+	return nil
+}
+
 // SlotDepositData returns the deposits for a specific slot
 func SlotDepositData(w http.ResponseWriter, r *http.Request) {
 	currency := GetCurrency(r)
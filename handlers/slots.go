@@ -121,6 +121,7 @@ func GetSlotsTableData(draw, start, length uint64, search string, searchForEmpty
 				blocks.attesterslashingscount, 
 				blocks.syncaggregate_participation, 
 				blocks.status, 
+				blocks.missed_reason,
 				COALESCE((SELECT SUM(ARRAY_LENGTH(validators, 1)) FROM blocks_attestations WHERE beaconblockroot = blocks.blockroot), 0) AS votes,
 				blocks.graffiti,
 				COALESCE(validator_names.name, '') AS name
@@ -221,6 +222,7 @@ func GetSlotsTableData(draw, start, length uint64, search string, searchForEmpty
 				blocks.attesterslashingscount, 
 				blocks.syncaggregate_participation, 
 				blocks.status, 
+				blocks.missed_reason,
 				COALESCE((SELECT SUM(ARRAY_LENGTH(validators, 1)) FROM blocks_attestations WHERE beaconblockroot = blocks.blockroot), 0) AS votes, 
 				blocks.graffiti,
 				COALESCE(validator_names.name, '') AS name,
@@ -252,7 +254,7 @@ func GetSlotsTableData(draw, start, length uint64, search string, searchForEmpty
 		tableData[i] = []interface{}{
 			utils.FormatEpoch(b.Epoch),
 			utils.FormatBlockSlot(b.Slot),
-			utils.FormatBlockStatus(b.Status, b.Slot),
+			utils.FormatBlockStatus(b.Status, b.Slot) + utils.FormatMissedSlotReason(types.MissedSlotReason(b.MissedReason.String)),
 			utils.FormatTimestamp(utils.SlotToTime(b.Slot).Unix()),
 			validatorName,
 			b.Attestations,
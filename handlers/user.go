@@ -124,6 +124,13 @@ func UserSettings(w http.ResponseWriter, r *http.Request) {
 	userSettingsData.Emerald = &utils.Config.Frontend.Stripe.Emerald
 	userSettingsData.Diamond = &utils.Config.Frontend.Stripe.Diamond
 	userSettingsData.ShareMonitoringData = statsSharing
+	userSettingsData.TelegramBotName = utils.Config.Notifications.TelegramBotName
+	if userSettingsData.TelegramBotName != "" {
+		userSettingsData.Telegram, err = db.GetUserTelegram(user.UserID)
+		if err != nil {
+			logger.Errorf("Error retrieving the linked telegram chat for user: %v %v", user.UserID, err)
+		}
+	}
 	userSettingsData.Flashes = utils.GetFlashes(w, r, authSessionName)
 	userSettingsData.CsrfField = csrf.TemplateField(r)
 
@@ -144,6 +151,43 @@ func UserSettings(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// UserTelegramLinkPost creates a link token and forwards the user to the telegram bot which links the chat to the account once it is started
+func UserTelegramLinkPost(w http.ResponseWriter, r *http.Request) {
+	user := getUser(r)
+
+	if utils.Config.Notifications.TelegramBotName == "" {
+		utils.SetFlash(w, r, authSessionName, "Error: Telegram notifications are not available.")
+		http.Redirect(w, r, "/user/settings", http.StatusSeeOther)
+		return
+	}
+
+	token, err := db.CreateUserTelegramLinkToken(user.UserID)
+	if err != nil {
+		utils.LogError(err, "error creating telegram link token", 0, map[string]interface{}{"userID": user.UserID})
+		utils.SetFlash(w, r, authSessionName, "Error: Something went wrong linking your telegram account, please try again in a bit.")
+		http.Redirect(w, r, "/user/settings", http.StatusSeeOther)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("https://t.me/%s?start=%s", utils.Config.Notifications.TelegramBotName, token), http.StatusSeeOther)
+}
+
+// UserTelegramUnlinkPost removes the linked telegram chat of the user
+func UserTelegramUnlinkPost(w http.ResponseWriter, r *http.Request) {
+	user := getUser(r)
+
+	err := db.UnlinkUserTelegram(user.UserID)
+	if err != nil {
+		utils.LogError(err, "error unlinking telegram chat", 0, map[string]interface{}{"userID": user.UserID})
+		utils.SetFlash(w, r, authSessionName, "Error: Something went wrong unlinking your telegram account, please try again in a bit.")
+		http.Redirect(w, r, "/user/settings", http.StatusSeeOther)
+		return
+	}
+
+	utils.SetFlash(w, r, authSessionName, "Your telegram account has been unlinked.")
+	http.Redirect(w, r, "/user/settings", http.StatusSeeOther)
+}
+
 // GenerateAPIKey generates an API key for users that do not yet have a key.
 func GenerateAPIKey(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "text/html")
@@ -622,6 +666,7 @@ func UserNotificationsCenter(w http.ResponseWriter, r *http.Request) {
 	email := false
 	push := false
 	webhook := false
+	telegram := false
 	for _, ch := range notificationChannels {
 		if ch.Channel == types.EmailNotificationChannel {
 			email = true
@@ -632,6 +677,9 @@ func UserNotificationsCenter(w http.ResponseWriter, r *http.Request) {
 		if ch.Channel == types.WebhookNotificationChannel {
 			webhook = true
 		}
+		if ch.Channel == types.TelegramNotificationChannel {
+			telegram = true
+		}
 	}
 
 	if !email {
@@ -652,6 +700,32 @@ func UserNotificationsCenter(w http.ResponseWriter, r *http.Request) {
 			Active:  true,
 		})
 	}
+	if !telegram && utils.Config.Notifications.TelegramBotName != "" {
+		notificationChannels = append(notificationChannels, types.UserNotificationChannels{
+			Channel: types.TelegramNotificationChannel,
+			Active:  true,
+		})
+	}
+
+	deliveryModes, err := db.GetNotificationDeliveryModes([]uint64{user.UserID})
+	if err != nil {
+		logger.Errorf("error retrieving notification delivery modes for user %v: %v ", user.UserID, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	for i, ch := range notificationChannels {
+		notificationChannels[i].DeliveryMode = types.NotificationDeliveryImmediate
+		for _, digestChannel := range types.DigestNotificationChannels {
+			if ch.Channel == digestChannel {
+				notificationChannels[i].SupportsDigest = true
+			}
+		}
+		for _, mode := range deliveryModes[user.UserID] {
+			if mode.Channel == ch.Channel && mode.EventName == "" {
+				notificationChannels[i].DeliveryMode = mode.Mode
+			}
+		}
+	}
 
 	events := make([]types.EventNameCheckbox, 0)
 	for _, ev := range types.AddWatchlistEvents {
@@ -694,6 +768,8 @@ func UserNotificationsCenter(w http.ResponseWriter, r *http.Request) {
 	userNotificationsCenterData.NotificationChannelsModal = types.NotificationChannelsModal{
 		CsrfField:            csrf.TemplateField(r),
 		NotificationChannels: notificationChannels,
+		DeliveryModes:        types.NotificationDeliveryModes,
+		DeliveryModeLabels:   types.NotificationDeliveryModeLabels,
 	}
 	userNotificationsCenterData.NetworkEventModal = types.NetworkEventModal{
 		CsrfField: csrf.TemplateField(r),
@@ -886,6 +962,8 @@ func UserSubscriptionsData(w http.ResponseWriter, r *http.Request) {
 			pubkey = template.HTML(`<a href="/rewards">report</a>`)
 		} else if strings.HasPrefix(string(sub.EventName), "monitoring_") {
 			pubkey = utils.FormatMachineName(sub.EventFilter)
+		} else if strings.TrimPrefix(sub.EventName, utils.GetNetwork()+":") == string(types.ValidatorAlertRuleEventName) {
+			pubkey = template.HTML(fmt.Sprintf("Alert rule #%v", template.HTMLEscapeString(sub.EventFilter)))
 		}
 		if sub.EventName != string(types.ValidatorBalanceDecreasedEventName) {
 			tableData = append(tableData, []interface{}{
@@ -2349,6 +2427,93 @@ func UserNotificationsSubscribed(w http.ResponseWriter, r *http.Request) {
 	SendOKResponse(j, r.URL.String(), []interface{}{subs})
 }
 
+// UserNotificationDeliveryModes godoc
+// @Summary Get how the notifications of the user are delivered per channel and event
+// @Tags User
+// @Produce json
+// @Success 200 {object} types.ApiResponse{data=[]types.UserNotificationDeliveryMode}
+// @Failure 500 {object} types.ApiResponse
+// @Security ApiKeyAuth
+// @Router /api/v1/user/notifications/delivery [get]
+func UserNotificationDeliveryModes(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	j := json.NewEncoder(w)
+	user := getUser(r)
+
+	modes, err := db.GetNotificationDeliveryModes([]uint64{user.UserID})
+	if err != nil {
+		logger.WithError(err).Errorf("error getting notification delivery modes of user %v", user.UserID)
+		sendServerErrorResponse(w, r.URL.String(), "could not retrieve notification delivery modes")
+		return
+	}
+
+	res := modes[user.UserID]
+	if res == nil {
+		res = []types.UserNotificationDeliveryMode{}
+	}
+	SendOKResponse(j, r.URL.String(), []interface{}{res})
+}
+
+// UserNotificationDeliveryModesPost godoc
+// @Summary Set how the notifications of the user are delivered per channel and event
+// @Description Notifications of email, push, telegram and webhooks (including discord, slack, matrix and ntfy webhooks) can be delivered immediately or in an hourly, daily or weekly digest.
+// @Description An empty event_name sets the default of the channel, an empty mode removes the mode of an event so that the default of the channel applies again.
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param modes body []types.UserNotificationDeliveryMode true "mode: immediate, hourly, daily or weekly"
+// @Success 200 {object} types.ApiResponse
+// @Failure 400 {object} types.ApiResponse
+// @Failure 500 {object} types.ApiResponse
+// @Security ApiKeyAuth
+// @Router /api/v1/user/notifications/delivery [post]
+func UserNotificationDeliveryModesPost(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	j := json.NewEncoder(w)
+	user := getUser(r)
+
+	modes := []types.UserNotificationDeliveryMode{}
+	err := json.NewDecoder(r.Body).Decode(&modes)
+	if err != nil {
+		SendBadRequestResponse(w, r.URL.String(), "error decoding request body")
+		return
+	}
+
+	for _, mode := range modes {
+		supported := false
+		for _, ch := range types.DigestNotificationChannels {
+			if mode.Channel == ch {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			SendBadRequestResponse(w, r.URL.String(), fmt.Sprintf("the delivery of channel %v can not be changed", mode.Channel))
+			return
+		}
+		if mode.EventName != "" {
+			_, err := types.EventNameFromString(mode.EventName)
+			if err != nil {
+				SendBadRequestResponse(w, r.URL.String(), fmt.Sprintf("invalid event name %v", mode.EventName))
+				return
+			}
+		}
+		if mode.Mode != "" && !isValidNotificationDeliveryMode(mode.Mode) {
+			SendBadRequestResponse(w, r.URL.String(), fmt.Sprintf("invalid delivery mode %v", mode.Mode))
+			return
+		}
+	}
+
+	err = db.SetNotificationDeliveryModes(user.UserID, modes)
+	if err != nil {
+		logger.WithError(err).Errorf("error setting notification delivery modes of user %v", user.UserID)
+		sendServerErrorResponse(w, r.URL.String(), "could not update notification delivery modes")
+		return
+	}
+
+	SendOKResponse(j, r.URL.String(), nil)
+}
+
 func MobileDeviceDeletePOST(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Set("Content-Type", "application/json")
@@ -2452,7 +2617,8 @@ func NotificationWebhookPage(w http.ResponseWriter, r *http.Request) {
 			event_names,
 			destination,
 			request,
-			response
+			response,
+			secret
 		FROM users_webhooks
 		WHERE user_id = $1;
 	`, user.UserID)
@@ -2528,6 +2694,11 @@ func NotificationWebhookPage(w http.ResponseWriter, r *http.Request) {
 			EventName:  types.MonitoringMachineCpuLoadEventName,
 			Active:     utils.ElementExists(wh.EventNames, string(types.MonitoringMachineCpuLoadEventName)),
 		})
+		events = append(events, types.EventNameCheckbox{
+			EventLabel: "Alert Rule",
+			EventName:  types.ValidatorAlertRuleEventName,
+			Active:     utils.ElementExists(wh.EventNames, string(types.ValidatorAlertRuleEventName)),
+		})
 
 		isDiscord := false
 
@@ -2561,15 +2732,39 @@ func NotificationWebhookPage(w http.ResponseWriter, r *http.Request) {
 			hostname = wh.Url
 		}
 
+		destination := "webhook"
+		if wh.Destination.Valid && wh.Destination.String != "" {
+			destination = wh.Destination.String
+		}
+
+		// only the payloads of generic webhooks are signed and recorded, their failed attempts are the attempts of the latest failed delivery
+		secret := ""
+		retries := wh.Retries
+		var deliveries []types.UserWebhookDelivery
+		if destination == string(types.WebhookNotificationChannel) {
+			secret = wh.Secret.String
+			deliveries, err = db.GetWebhookDeliveries(user.UserID, wh.ID, 25)
+			if err != nil {
+				logger.WithError(err).Errorf("error getting deliveries of webhook %v", wh.ID)
+			}
+			retries = 0
+			if len(deliveries) > 0 && (!deliveries[0].StatusCode.Valid || deliveries[0].StatusCode.Int64 >= 400) {
+				retries = uint64(deliveries[0].Attempt)
+			}
+		}
+
 		webhookRows = append(webhookRows, types.UserWebhookRow{
 			ID:           wh.ID,
-			Retries:      template.HTML(fmt.Sprintf("%d", wh.Retries)),
+			Retries:      template.HTML(fmt.Sprintf("%d", retries)),
 			UrlFull:      wh.Url,
 			Url:          template.HTML(fmt.Sprintf(`<span>%v</span><span style="margin-left: .5rem;">%v</span>`, hostname, utils.CopyButtonText(wh.Url))),
 			LastSent:     ls,
 			Events:       events,
 			Discord:      isDiscord,
+			Destination:  template.HTML(destination),
 			CsrfField:    csrf.TemplateField(r),
+			Secret:       secret,
+			Deliveries:   deliveries,
 			WebhookError: whErr,
 		})
 
@@ -2622,6 +2817,10 @@ func NotificationWebhookPage(w http.ResponseWriter, r *http.Request) {
 		EventLabel: "Machine CPU",
 		EventName:  types.MonitoringMachineCpuLoadEventName,
 	})
+	events = append(events, types.EventNameCheckbox{
+		EventLabel: "Alert Rule",
+		EventName:  types.ValidatorAlertRuleEventName,
+	})
 
 	pageData.Events = events
 
@@ -2634,6 +2833,19 @@ func NotificationWebhookPage(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// isValidWebhookDestination checks if the requests of a webhook can be adapted to the destination
+func isValidWebhookDestination(destination string) bool {
+	if destination == string(types.WebhookNotificationChannel) || destination == string(types.WebhookDiscordNotificationChannel) {
+		return true
+	}
+	for _, ch := range types.WebhookChatNotificationChannels {
+		if destination == string(ch) {
+			return true
+		}
+	}
+	return false
+}
+
 func UsersAddWebhook(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "text/html")
 	user := getUser(r)
@@ -2669,11 +2881,15 @@ func UsersAddWebhook(w http.ResponseWriter, r *http.Request) {
 	monitoringMachineOffline := r.FormValue(string(types.MonitoringMachineOfflineEventName)) == "on"
 	monitoringHddAlmostfull := r.FormValue(string(types.MonitoringMachineDiskAlmostFullEventName)) == "on"
 	monitoringCpuLoad := r.FormValue(string(types.MonitoringMachineCpuLoadEventName)) == "on"
+	validatorAlertRule := r.FormValue(string(types.ValidatorAlertRuleEventName)) == "on"
 	discord := r.FormValue("discord") == "on"
 
 	if discord {
 		destination = "webhook_discord"
 	}
+	if isValidWebhookDestination(r.FormValue("destination")) {
+		destination = r.FormValue("destination")
+	}
 
 	all := r.FormValue("all") == "on"
 
@@ -2689,6 +2905,7 @@ func UsersAddWebhook(w http.ResponseWriter, r *http.Request) {
 	events[string(types.MonitoringMachineOfflineEventName)] = monitoringMachineOffline
 	events[string(types.MonitoringMachineDiskAlmostFullEventName)] = monitoringHddAlmostfull
 	events[string(types.MonitoringMachineCpuLoadEventName)] = monitoringCpuLoad
+	events[string(types.ValidatorAlertRuleEventName)] = validatorAlertRule
 
 	eventNames := make([]string, 0)
 
@@ -2754,7 +2971,15 @@ func UsersAddWebhook(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, err = tx.Exec(`INSERT INTO users_webhooks (user_id, url, event_names, destination) VALUES ($1, $2, $3, $4)`, user.UserID, urlForm, pq.StringArray(eventNames), destination)
+	secret, err := db.GenerateWebhookSecret()
+	if err != nil {
+		logger.WithError(err).Errorf("error generating the secret of a new webhook")
+		utils.SetFlash(w, r, authSessionName, "Error: Something went wrong adding your webhook, please try again in a bit.")
+		http.Redirect(w, r, "/user/webhooks", http.StatusSeeOther)
+		return
+	}
+
+	_, err = tx.Exec(`INSERT INTO users_webhooks (user_id, url, event_names, destination, secret) VALUES ($1, $2, $3, $4, $5)`, user.UserID, urlForm, pq.StringArray(eventNames), destination, secret)
 	if err != nil {
 		logger.WithError(err).Errorf("error inserting a new webhook for user")
 		utils.SetFlash(w, r, authSessionName, "Error: Something went wrong adding your webhook, please try again in a bit.")
@@ -2804,11 +3029,15 @@ func UsersEditWebhook(w http.ResponseWriter, r *http.Request) {
 	monitoringMachineOffline := r.FormValue(string(types.MonitoringMachineOfflineEventName)) == "on"
 	monitoringHddAlmostfull := r.FormValue(string(types.MonitoringMachineDiskAlmostFullEventName)) == "on"
 	monitoringCpuLoad := r.FormValue(string(types.MonitoringMachineCpuLoadEventName)) == "on"
+	validatorAlertRule := r.FormValue(string(types.ValidatorAlertRuleEventName)) == "on"
 	discord := r.FormValue("discord") == "on"
 
 	if discord {
 		destination = "webhook_discord"
 	}
+	if isValidWebhookDestination(r.FormValue("destination")) {
+		destination = r.FormValue("destination")
+	}
 
 	all := r.FormValue("all") == "on"
 
@@ -2824,6 +3053,7 @@ func UsersEditWebhook(w http.ResponseWriter, r *http.Request) {
 	events[string(types.MonitoringMachineOfflineEventName)] = monitoringMachineOffline
 	events[string(types.MonitoringMachineDiskAlmostFullEventName)] = monitoringHddAlmostfull
 	events[string(types.MonitoringMachineCpuLoadEventName)] = monitoringCpuLoad
+	events[string(types.ValidatorAlertRuleEventName)] = validatorAlertRule
 
 	eventNames := make([]string, 0)
 
@@ -2911,6 +3141,84 @@ func UsersDeleteWebhook(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, "/user/webhooks", http.StatusSeeOther)
 }
 
+// UsersRedeliverWebhook queues a recorded delivery of a webhook again, it is sent to the current url of the webhook
+func UsersRedeliverWebhook(w http.ResponseWriter, r *http.Request) {
+	user := getUser(r)
+	vars := mux.Vars(r)
+
+	webhookID, err := strconv.ParseUint(vars["webhookID"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid webhook id", http.StatusBadRequest)
+		return
+	}
+	deliveryID, err := strconv.ParseUint(vars["deliveryID"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid delivery id", http.StatusBadRequest)
+		return
+	}
+
+	delivery, err := db.GetWebhookDelivery(user.UserID, webhookID, deliveryID)
+	if err == sql.ErrNoRows {
+		utils.SetFlash(w, r, authSessionName, "Error: The delivery does not exist anymore.")
+		http.Redirect(w, r, "/user/webhooks", http.StatusSeeOther)
+		return
+	}
+	if err != nil {
+		logger.WithError(err).Errorf("error getting webhook delivery %v", deliveryID)
+		utils.SetFlash(w, r, authSessionName, "Error: Something went wrong redelivering your webhook, please try again in a bit.")
+		http.Redirect(w, r, "/user/webhooks", http.StatusSeeOther)
+		return
+	}
+
+	var content types.TransitWebhookContent
+	err = json.Unmarshal([]byte(delivery.Payload), &content)
+	if err != nil {
+		logger.WithError(err).Errorf("error unmarshalling payload of webhook delivery %v", deliveryID)
+		utils.SetFlash(w, r, authSessionName, "Error: Something went wrong redelivering your webhook, please try again in a bit.")
+		http.Redirect(w, r, "/user/webhooks", http.StatusSeeOther)
+		return
+	}
+
+	err = db.QueueWebhookRedelivery(user.UserID, webhookID, &content)
+	if err == sql.ErrNoRows {
+		utils.SetFlash(w, r, authSessionName, "Error: The webhook does not exist anymore.")
+		http.Redirect(w, r, "/user/webhooks", http.StatusSeeOther)
+		return
+	}
+	if err != nil {
+		logger.WithError(err).Errorf("error queuing redelivery of webhook delivery %v", deliveryID)
+		utils.SetFlash(w, r, authSessionName, "Error: Something went wrong redelivering your webhook, please try again in a bit.")
+		http.Redirect(w, r, "/user/webhooks", http.StatusSeeOther)
+		return
+	}
+
+	utils.SetFlash(w, r, authSessionName, "The delivery has been queued and will be sent again shortly.")
+	http.Redirect(w, r, "/user/webhooks", http.StatusSeeOther)
+}
+
+// UsersRotateWebhookSecret replaces the secret the payloads of a webhook are signed with
+func UsersRotateWebhookSecret(w http.ResponseWriter, r *http.Request) {
+	user := getUser(r)
+	vars := mux.Vars(r)
+
+	webhookID, err := strconv.ParseUint(vars["webhookID"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid webhook id", http.StatusBadRequest)
+		return
+	}
+
+	err = db.RotateWebhookSecret(user.UserID, webhookID)
+	if err != nil {
+		logger.WithError(err).Errorf("error rotating secret of webhook %v", webhookID)
+		utils.SetFlash(w, r, authSessionName, "Error: Something went wrong rotating your webhook secret, please try again in a bit.")
+		http.Redirect(w, r, "/user/webhooks", http.StatusSeeOther)
+		return
+	}
+
+	utils.SetFlash(w, r, authSessionName, "The webhook secret has been rotated.")
+	http.Redirect(w, r, "/user/webhooks", http.StatusSeeOther)
+}
+
 // UsersNotificationChannel
 // Accepts form encoded values channel and active to set the global notification settings for a user
 func UsersNotificationChannels(w http.ResponseWriter, r *http.Request) {
@@ -2926,6 +3234,7 @@ func UsersNotificationChannels(w http.ResponseWriter, r *http.Request) {
 	channelEmail := r.FormValue(string(types.EmailNotificationChannel))
 	channelPush := r.FormValue(string(types.PushNotificationChannel))
 	channelWebhook := r.FormValue(string(types.WebhookNotificationChannel))
+	channelTelegram := r.FormValue(string(types.TelegramNotificationChannel))
 
 	tx, err := db.FrontendWriterDB.Beginx()
 	if err != nil {
@@ -2953,6 +3262,14 @@ func UsersNotificationChannels(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
+	if utils.Config.Notifications.TelegramBotName != "" {
+		_, err = tx.Exec(`INSERT INTO users_notification_channels (user_id, channel, active) VALUES ($1, $2, $3) ON CONFLICT (user_id, channel) DO UPDATE SET active = $3`, user.UserID, types.TelegramNotificationChannel, channelTelegram == "on")
+		if err != nil {
+			logger.WithError(err).Error("error updating users_notification_channels")
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+	}
 
 	err = tx.Commit()
 	if err != nil {
@@ -2961,9 +3278,37 @@ func UsersNotificationChannels(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// the delivery mode set in the modal is the default of the channel, modes of single events are managed via the api
+	deliveryModes := make([]types.UserNotificationDeliveryMode, 0, len(types.DigestNotificationChannels))
+	for _, ch := range types.DigestNotificationChannels {
+		mode := types.NotificationDeliveryMode(r.FormValue(string(ch) + "_delivery"))
+		if !isValidNotificationDeliveryMode(mode) {
+			continue
+		}
+		deliveryModes = append(deliveryModes, types.UserNotificationDeliveryMode{
+			Channel: ch,
+			Mode:    mode,
+		})
+	}
+	err = db.SetNotificationDeliveryModes(user.UserID, deliveryModes)
+	if err != nil {
+		logger.WithError(err).Error("error updating users_notification_delivery_modes")
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
 	http.Redirect(w, r, "/user/notifications", http.StatusSeeOther)
 }
 
+func isValidNotificationDeliveryMode(mode types.NotificationDeliveryMode) bool {
+	for _, m := range types.NotificationDeliveryModes {
+		if mode == m {
+			return true
+		}
+	}
+	return false
+}
+
 // UserSettings renders the user-template
 func UserGlobalNotification(w http.ResponseWriter, r *http.Request) {
 	isAdmin, user := handleAdminPermissions(w, r)
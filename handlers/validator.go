@@ -460,6 +460,16 @@ func Validator(w http.ResponseWriter, r *http.Request) {
 			}
 			validatorPageData.BLSChange = blsChange
 
+			validatorPageData.WithdrawalRequests, err = db.GetValidatorsWithdrawalRequests([][]byte{validatorPageData.PublicKey})
+			if err != nil {
+				return fmt.Errorf("error getting validator withdrawal requests from db: %w", err)
+			}
+
+			validatorPageData.ConsolidationRequests, err = db.GetValidatorsConsolidationRequests([][]byte{validatorPageData.PublicKey})
+			if err != nil {
+				return fmt.Errorf("error getting validator consolidation requests from db: %w", err)
+			}
+
 			if bytes.Equal(validatorPageData.WithdrawCredentials[:1], []byte{0x00}) && blsChange != nil {
 				// blsChanges are only possible afters cappeala
 				validatorPageData.IsWithdrawableAddress = true
@@ -695,6 +705,40 @@ func Validator(w http.ResponseWriter, r *http.Request) {
 		return nil
 	})
 
+	g.Go(func() error {
+		blockValues, err := db.GetValidatorsProposerBlockValues([]uint64{index}, 10)
+		if err != nil {
+			return fmt.Errorf("error getting validator block values: %w", err)
+		}
+		err = fillProposerBlockValues(blockValues)
+		if err != nil {
+			return err
+		}
+		validatorPageData.BlockValues = blockValues
+		return nil
+	})
+
+	g.Go(func() error {
+		if validatorPageData.ActivationEpoch > validatorPageData.Epoch || isPreGenesis {
+			return nil
+		}
+		endEpoch := validatorPageData.Epoch
+		if validatorPageData.ExitEpoch != 9223372036854775807 && validatorPageData.ExitEpoch <= endEpoch {
+			endEpoch = validatorPageData.ExitEpoch - 1
+		}
+		startEpoch := validatorPageData.ActivationEpoch
+		if endEpoch > startEpoch+99 {
+			startEpoch = endEpoch - 99
+		}
+
+		attestationData, err := db.BigtableClient.GetValidatorAttestationHistory([]uint64{index}, startEpoch, endEpoch)
+		if err != nil {
+			return fmt.Errorf("error getting validator attestations data for epochs [%v - %v]: %w", startEpoch, endEpoch, err)
+		}
+		validatorPageData.AttestationCorrectness = getAttestationCorrectness(attestationData[index], startEpoch, endEpoch)
+		return nil
+	})
+
 	g.Go(func() error {
 		validatorPageData.SlotsPerSyncCommittee = utils.SlotsPerSyncCommittee()
 
@@ -926,6 +970,44 @@ func ValidatorDeposits(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// getAttestationCorrectness summarizes the vote flags of the included attestations, attestations exported before the flags
+// were tracked are not taken into account. Returns nil if none of the attestations has flags.
+func getAttestationCorrectness(attestations []*types.ValidatorAttestation, startEpoch, endEpoch uint64) *types.ValidatorAttestationCorrectness {
+	res := &types.ValidatorAttestationCorrectness{
+		StartEpoch:    startEpoch,
+		EndEpoch:      endEpoch,
+		MissedRewards: make(map[string]uint64),
+	}
+
+	correctHead, correctTarget, timelySource := 0, 0, 0
+	for _, attestation := range attestations {
+		if attestation.Status != 1 || !attestation.Flags.Known() {
+			continue
+		}
+		res.Attestations++
+		if attestation.Flags.Has(types.AttestationFlagCorrectHead) {
+			correctHead++
+		}
+		if attestation.Flags.Has(types.AttestationFlagCorrectTarget) {
+			correctTarget++
+		}
+		if attestation.Flags.Has(types.AttestationFlagTimelySource) {
+			timelySource++
+		}
+		for _, reason := range attestation.Flags.MissedRewards() {
+			res.MissedRewards[reason]++
+		}
+	}
+
+	if res.Attestations == 0 {
+		return nil
+	}
+	res.CorrectHeadRate = float64(correctHead) / float64(res.Attestations)
+	res.CorrectTargetRate = float64(correctTarget) / float64(res.Attestations)
+	res.TimelySourceRate = float64(timelySource) / float64(res.Attestations)
+	return res
+}
+
 // ValidatorAttestationInclusionEffectiveness returns a validator's effectiveness in json
 func ValidatorAttestationInclusionEffectiveness(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
@@ -1204,6 +1286,7 @@ func ValidatorAttestations(w http.ResponseWriter, r *http.Request) {
 				utils.FormatTimestamp(utils.SlotToTime(history.AttesterSlot).Unix()),
 				utils.FormatAttestationInclusionSlot(history.InclusionSlot),
 				utils.FormatInclusionDelay(history.InclusionSlot, history.Delay),
+				utils.FormatAttestationFlags(history.Status, history.Flags),
 			}
 		}
 	}
@@ -65,6 +65,28 @@ var (
 		Name: "notifications_sent",
 		Help: "Counter of notifications sent with the channel and notification type in the label",
 	}, []string{"channel", "status"})
+	BeaconNodeRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "beacon_node_request_duration",
+		Help:    "Duration of beacon node requests in seconds by node and method.",
+		Buckets: []float64{.01, .05, .1, .5, 1, 5, 10, 30, 60, 120},
+	}, []string{"node", "method"})
+	BeaconNodeRequestErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "beacon_node_request_errors",
+		Help: "Counter of failed beacon node requests by node and method.",
+	}, []string{"node", "method"})
+	BeaconNodeHealthy = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "beacon_node_healthy",
+		Help: "Gauge that is 1 if the beacon node passed its last health check.",
+	}, []string{"node"})
+	BeaconNodeHeadSlot = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "beacon_node_head_slot",
+		Help: "Head slot of the beacon node as reported by its last health check.",
+	}, []string{"node"})
+	Eth1ChainReorgDepth = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "eth1_chain_reorg_depth",
+		Help:    "Number of blocks reverted by the eth1 indexer per chain reorg.",
+		Buckets: []float64{1, 2, 3, 4, 5, 10, 20, 50},
+	})
 )
 
 var logger = logrus.New().WithField("module", "metrics")
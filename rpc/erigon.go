@@ -9,6 +9,7 @@ import (
 	"eth2-exporter/utils"
 	"fmt"
 	"math/big"
+	"net/http"
 	"strings"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	geth_rpc "github.com/ethereum/go-ethereum/rpc"
 	"github.com/sirupsen/logrus"
@@ -54,6 +56,34 @@ func NewErigonClient(endpoint string) (*ErigonClient, error) {
 	}
 	client.ethClient = ethClient
 
+	return client.init()
+}
+
+// NewErigonClientWithFixtures creates an erigon client whose json-rpc requests are either recorded to or replayed from the given fixtures directory.
+// Recording requires an http endpoint as websocket and ipc connections cannot be intercepted.
+func NewErigonClientWithFixtures(endpoint string, dir string, mode FixtureMode) (*ErigonClient, error) {
+	logger.Infof("initializing erigon client at %v with fixtures in %v", endpoint, dir)
+	client := &ErigonClient{
+		endpoint: endpoint,
+	}
+
+	transport, err := NewFixtureTransport(dir, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcClient, err := geth_rpc.DialOptions(context.Background(), client.endpoint, geth_rpc.WithHTTPClient(&http.Client{Transport: transport}))
+	if err != nil {
+		return nil, fmt.Errorf("error dialing rpc node: %w", err)
+	}
+	client.rpcClient = rpcClient
+	client.ethClient = ethclient.NewClient(rpcClient)
+
+	return client.init()
+}
+
+func (client *ErigonClient) init() (*ErigonClient, error) {
+	var err error
 	client.multiChecker, err = NewBalance(common.HexToAddress("0xb1F8e55c7f64D203C1400B9D8555d050F94aDF39"), client.ethClient)
 	if err != nil {
 		return nil, fmt.Errorf("error initiation balance checker contract: %w", err)
@@ -263,6 +293,12 @@ func (client *ErigonClient) GetBlock(number int64, traceMode string) (*types.Eth
 					}
 
 					tracePb.From, tracePb.To, tracePb.Value, tracePb.Type = trace.ConvertFields()
+					if trace.Type == "create" {
+						tracePb.CreationMethod = trace.Action.CreationMethod
+						if code := common.FromHex(trace.Result.Code); len(code) > 0 {
+							tracePb.CodeHash = crypto.Keccak256(code)
+						}
+					}
 					c.Transactions[trace.TransactionPosition].Itx = append(c.Transactions[trace.TransactionPosition].Itx, tracePb)
 				}
 			}
@@ -286,6 +322,7 @@ func (client *ErigonClient) GetBlock(number int64, traceMode string) (*types.Eth
 					c.Transactions[trace.TransactionPosition].ErrorMsg = trace.Error
 				}
 
+				creationMethod := strings.ToLower(trace.Type)
 				if trace.Type == "CREATE2" {
 					trace.Type = "CREATE"
 				}
@@ -299,6 +336,11 @@ func (client *ErigonClient) GetBlock(number int64, traceMode string) (*types.Eth
 				tracePb.To = trace.To.Bytes()
 				tracePb.Value = common.FromHex(trace.Value)
 				if trace.Type == "CREATE" {
+					// the output of a successful create call is the runtime code of the deployed contract
+					tracePb.CreationMethod = creationMethod
+					if code := common.FromHex(trace.Output); len(code) > 0 && trace.Error == "" {
+						tracePb.CodeHash = crypto.Keccak256(code)
+					}
 				} else if trace.Type == "SELFDESTRUCT" {
 				} else if trace.Type == "SUICIDE" {
 				} else if trace.Type == "CALL" || trace.Type == "DELEGATECALL" || trace.Type == "STATICCALL" {
@@ -349,6 +391,7 @@ func (client *ErigonClient) GetBlock(number int64, traceMode string) (*types.Eth
 				Data:    l.Data,
 				Removed: l.Removed,
 				Topics:  make([][]byte, 0, len(l.Topics)),
+				Index:   uint64(l.Index),
 			}
 
 			for _, t := range l.Topics {
@@ -388,6 +431,44 @@ func (client *ErigonClient) GetLatestEth1BlockNumber() (uint64, error) {
 	return latestBlock.NumberU64(), nil
 }
 
+// SubscribeNewHeads subscribes to the newHeads of the node, which requires a websocket or ipc connection.
+// If wsEndpoint is empty the subscription is created on the endpoint of the client
+func (client *ErigonClient) SubscribeNewHeads(ctx context.Context, wsEndpoint string, heads chan<- *geth_types.Header) (ethereum.Subscription, error) {
+	return subscribeNewHeads(ctx, client.ethClient, wsEndpoint, heads)
+}
+
+// headSubscription closes the websocket connection dialed for a newHeads subscription once the subscription ends
+type headSubscription struct {
+	ethereum.Subscription
+	ethClient *ethclient.Client
+}
+
+func (sub *headSubscription) Unsubscribe() {
+	sub.Subscription.Unsubscribe()
+	sub.ethClient.Close()
+}
+
+func subscribeNewHeads(ctx context.Context, ethClient *ethclient.Client, wsEndpoint string, heads chan<- *geth_types.Header) (ethereum.Subscription, error) {
+	if wsEndpoint == "" {
+		sub, err := ethClient.SubscribeNewHead(ctx, heads)
+		if err != nil {
+			return nil, fmt.Errorf("error subscribing to new heads: %w", err)
+		}
+		return sub, nil
+	}
+
+	wsClient, err := ethclient.DialContext(ctx, wsEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error dialing websocket endpoint: %w", err)
+	}
+	sub, err := wsClient.SubscribeNewHead(ctx, heads)
+	if err != nil {
+		wsClient.Close()
+		return nil, fmt.Errorf("error subscribing to new heads: %w", err)
+	}
+	return &headSubscription{Subscription: sub, ethClient: wsClient}, nil
+}
+
 type GethTraceCallResultWrapper struct {
 	Result *GethTraceCallResult
 }
@@ -445,18 +526,19 @@ func (client *ErigonClient) TraceGeth(blockHash common.Hash) ([]*GethTraceCallRe
 
 type ParityTraceResult struct {
 	Action struct {
-		CallType      string `json:"callType"`
-		From          string `json:"from"`
-		Gas           string `json:"gas"`
-		Input         string `json:"input"`
-		To            string `json:"to"`
-		Value         string `json:"value"`
-		Init          string `json:"init"`
-		Address       string `json:"address"`
-		Balance       string `json:"balance"`
-		RefundAddress string `json:"refundAddress"`
-		Author        string `json:"author"`
-		RewardType    string `json:"rewardType"`
+		CallType       string `json:"callType"`
+		From           string `json:"from"`
+		Gas            string `json:"gas"`
+		Input          string `json:"input"`
+		To             string `json:"to"`
+		Value          string `json:"value"`
+		Init           string `json:"init"`
+		Address        string `json:"address"`
+		Balance        string `json:"balance"`
+		RefundAddress  string `json:"refundAddress"`
+		Author         string `json:"author"`
+		RewardType     string `json:"rewardType"`
+		CreationMethod string `json:"creationMethod"`
 	} `json:"action"`
 	BlockHash   string `json:"blockHash"`
 	BlockNumber int    `json:"blockNumber"`
@@ -523,6 +605,15 @@ func (client *ErigonClient) TraceParityTx(txHash string) ([]*ParityTraceResult,
 }
 
 func (client *ErigonClient) GetBalances(pairs []*types.Eth1AddressBalance, addressIndex, tokenIndex int) ([]*types.Eth1AddressBalance, error) {
+	return client.getBalances(pairs, "latest", 0)
+}
+
+// GetBalancesAtBlock retrieves the balances of the address / token pairs from the state after the given block
+func (client *ErigonClient) GetBalancesAtBlock(pairs []*types.Eth1AddressBalance, block uint64) ([]*types.Eth1AddressBalance, error) {
+	return client.getBalances(pairs, hexutil.EncodeUint64(block), block)
+}
+
+func (client *ErigonClient) getBalances(pairs []*types.Eth1AddressBalance, blockArg string, blockNumber uint64) ([]*types.Eth1AddressBalance, error) {
 	batchElements := make([]geth_rpc.BatchElem, 0, len(pairs))
 
 	ret := make([]*types.Eth1AddressBalance, len(pairs))
@@ -536,8 +627,9 @@ func (client *ErigonClient) GetBalances(pairs []*types.Eth1AddressBalance, addre
 		result := ""
 
 		ret[i] = &types.Eth1AddressBalance{
-			Address: pair.Address,
-			Token:   pair.Token,
+			Address:     pair.Address,
+			Token:       pair.Token,
+			BlockNumber: blockNumber,
 		}
 
 		// logger.Infof("retrieving balance for %x / %x", ret[i].Address, ret[i].Token)
@@ -545,7 +637,7 @@ func (client *ErigonClient) GetBalances(pairs []*types.Eth1AddressBalance, addre
 		if len(pair.Token) < 20 {
 			batchElements = append(batchElements, geth_rpc.BatchElem{
 				Method: "eth_getBalance",
-				Args:   []interface{}{common.BytesToAddress(pair.Address), "latest"},
+				Args:   []interface{}{common.BytesToAddress(pair.Address), blockArg},
 				Result: &result,
 			})
 		} else {
@@ -558,7 +650,7 @@ func (client *ErigonClient) GetBalances(pairs []*types.Eth1AddressBalance, addre
 
 			batchElements = append(batchElements, geth_rpc.BatchElem{
 				Method: "eth_call",
-				Args:   []interface{}{toCallArg(msg), "latest"},
+				Args:   []interface{}{toCallArg(msg), blockArg},
 				Result: &result,
 			})
 		}
@@ -0,0 +1,339 @@
+package rpc
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"eth2-exporter/types"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// FixtureMode defines whether a fixture transport records responses of a live node or replays previously recorded ones
+type FixtureMode int
+
+const (
+	FixtureModeRecord FixtureMode = iota
+	FixtureModeReplay
+)
+
+// recordedResponse is the on-disk format of a single fixture
+type recordedResponse struct {
+	Method     string          `json:"method"`
+	Url        string          `json:"url"`
+	Request    json.RawMessage `json:"request,omitempty"`
+	StatusCode int             `json:"statusCode"`
+	Body       json.RawMessage `json:"body"`
+}
+
+// jsonRpcMessage is used to normalize json-rpc requests and responses, the id of a message is replaced by its position within the batch
+type jsonRpcMessage struct {
+	Version string          `json:"jsonrpc,omitempty"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   json.RawMessage `json:"error,omitempty"`
+}
+
+// FixtureTransport is a http.RoundTripper that records beacon api and json-rpc responses to a fixtures directory or replays them from it
+type FixtureTransport struct {
+	dir  string
+	mode FixtureMode
+	next http.RoundTripper
+}
+
+// NewFixtureTransport creates a transport that records to or replays from the given directory
+func NewFixtureTransport(dir string, mode FixtureMode) (*FixtureTransport, error) {
+	if mode == FixtureModeRecord {
+		err := os.MkdirAll(dir, 0755)
+		if err != nil {
+			return nil, fmt.Errorf("error creating fixtures directory %v: %w", dir, err)
+		}
+	} else {
+		_, err := os.Stat(dir)
+		if err != nil {
+			return nil, fmt.Errorf("error accessing fixtures directory %v: %w", dir, err)
+		}
+	}
+	return &FixtureTransport{
+		dir:  dir,
+		mode: mode,
+		next: http.DefaultTransport,
+	}, nil
+}
+
+var fixtureNameSanitizer = regexp.MustCompile(`[^a-zA-Z0-9_.-]+`)
+
+func (t *FixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, fmt.Errorf("error reading request body: %w", err)
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	var messages []*jsonRpcMessage
+	isBatch := false
+	if len(reqBody) > 0 {
+		var err error
+		messages, isBatch, err = parseJsonRpcMessages(reqBody)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing json-rpc request: %w", err)
+		}
+	}
+
+	name, normalizedRequest, err := fixtureName(req, messages)
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(t.dir, name)
+
+	if t.mode == FixtureModeReplay {
+		return t.replay(req, path, messages, isBatch)
+	}
+	return t.record(req, path, normalizedRequest, messages)
+}
+
+func (t *FixtureTransport) replay(req *http.Request, path string, messages []*jsonRpcMessage, isBatch bool) (*http.Response, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("no fixture recorded for %v %v: %w", req.Method, req.URL.RequestURI(), err)
+	}
+
+	var recorded recordedResponse
+	err = json.Unmarshal(data, &recorded)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing fixture %v: %w", path, err)
+	}
+
+	body := []byte(recorded.Body)
+	if len(messages) > 0 {
+		// restore the ids of the current request
+		responses, _, err := parseJsonRpcMessages(body)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing recorded json-rpc response %v: %w", path, err)
+		}
+		for _, response := range responses {
+			var index int
+			err := json.Unmarshal(response.ID, &index)
+			if err != nil || index < 0 || index >= len(messages) {
+				return nil, fmt.Errorf("invalid message index %s in fixture %v", response.ID, path)
+			}
+			response.ID = messages[index].ID
+		}
+		if isBatch {
+			body, err = json.Marshal(responses)
+		} else if len(responses) == 1 {
+			body, err = json.Marshal(responses[0])
+		}
+		if err != nil {
+			return nil, err
+		}
+	} else if len(body) > 0 && body[0] == '"' {
+		// non json responses are recorded as json string
+		var text string
+		err = json.Unmarshal(body, &text)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing recorded response %v: %w", path, err)
+		}
+		body = []byte(text)
+	}
+
+	return &http.Response{
+		Status:        http.StatusText(recorded.StatusCode),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+func (t *FixtureTransport) record(req *http.Request, path string, normalizedRequest []byte, messages []*jsonRpcMessage) (*http.Response, error) {
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	recordedBody := body
+	if len(messages) > 0 {
+		// replace the ids of the responses by the position of the matching request
+		responses, isBatch, err := parseJsonRpcMessages(body)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing json-rpc response: %w", err)
+		}
+		for _, response := range responses {
+			for i, message := range messages {
+				if bytes.Equal(message.ID, response.ID) {
+					response.ID = json.RawMessage(fmt.Sprintf("%d", i))
+					break
+				}
+			}
+		}
+		if isBatch {
+			recordedBody, err = json.Marshal(responses)
+		} else {
+			recordedBody, err = json.Marshal(responses[0])
+		}
+		if err != nil {
+			return nil, err
+		}
+	} else if !json.Valid(recordedBody) {
+		// error responses of the beacon api are not necessarily json
+		recordedBody, err = json.Marshal(string(recordedBody))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := json.MarshalIndent(&recordedResponse{
+		Method:     req.Method,
+		Url:        req.URL.RequestURI(),
+		Request:    normalizedRequest,
+		StatusCode: res.StatusCode,
+		Body:       recordedBody,
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.WriteFile(path, data, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("error writing fixture %v: %w", path, err)
+	}
+
+	return res, nil
+}
+
+// fixtureName derives a stable file name from a request, beacon api requests are named by their url, json-rpc requests by their methods and params
+func fixtureName(req *http.Request, messages []*jsonRpcMessage) (string, []byte, error) {
+	if len(messages) == 0 {
+		name := strings.Trim(fixtureNameSanitizer.ReplaceAllString(req.URL.RequestURI(), "_"), "_")
+		if len(name) > 200 {
+			name = fmt.Sprintf("%s_%x", name[:100], sha256.Sum256([]byte(name)))
+		}
+		return fmt.Sprintf("%s_%s.json", strings.ToLower(req.Method), name), nil, nil
+	}
+
+	methods := make([]string, 0, len(messages))
+	normalized := make([]*jsonRpcMessage, 0, len(messages))
+	for i, message := range messages {
+		methods = append(methods, message.Method)
+		normalized = append(normalized, &jsonRpcMessage{
+			ID:     json.RawMessage(fmt.Sprintf("%d", i)),
+			Method: message.Method,
+			Params: message.Params,
+		})
+	}
+	normalizedRequest, err := json.Marshal(normalized)
+	if err != nil {
+		return "", nil, err
+	}
+
+	prefix := methods[0]
+	if len(methods) > 1 {
+		prefix = fmt.Sprintf("batch_%d_%s", len(methods), methods[0])
+	}
+	return fmt.Sprintf("jsonrpc_%s_%x.json", fixtureNameSanitizer.ReplaceAllString(prefix, "_"), sha256.Sum256(normalizedRequest)), normalizedRequest, nil
+}
+
+func parseJsonRpcMessages(data []byte) ([]*jsonRpcMessage, bool, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		var messages []*jsonRpcMessage
+		err := json.Unmarshal(data, &messages)
+		return messages, true, err
+	}
+	message := &jsonRpcMessage{}
+	err := json.Unmarshal(data, message)
+	return []*jsonRpcMessage{message}, false, err
+}
+
+// FixtureClient is a Client that replays beacon api responses recorded by a LighthouseClient in recorder mode.
+// As the event stream cannot be replayed, the event channels never emit.
+type FixtureClient struct {
+	*LighthouseClient
+}
+
+// NewFixtureClient creates a Client that serves all requests from the given fixtures directory
+func NewFixtureClient(dir string, chainID *big.Int) (*FixtureClient, error) {
+	transport, err := NewFixtureTransport(dir, FixtureModeReplay)
+	if err != nil {
+		return nil, err
+	}
+	// the endpoint is never dialed, it is only used to build request urls
+	client, err := NewLighthouseClient("http://fixtures", chainID)
+	if err != nil {
+		return nil, err
+	}
+	client.transport = transport
+	return &FixtureClient{LighthouseClient: client}, nil
+}
+
+func (fc *FixtureClient) GetNewBlockChan() chan *types.Block {
+	return make(chan *types.Block)
+}
+
+func (fc *FixtureClient) GetChainEventsChan() chan *ChainEvent {
+	return make(chan *ChainEvent)
+}
+
+func (fc *FixtureClient) GetLightClientEventsChan() chan *LightClientEvent {
+	return make(chan *LightClientEvent)
+}
+
+// FixtureEth1Client is an Eth1Client that replays json-rpc responses recorded by an ErigonClient in recorder mode
+type FixtureEth1Client struct {
+	client *ErigonClient
+}
+
+// NewFixtureEth1Client creates an Eth1Client that serves all requests from the given fixtures directory
+func NewFixtureEth1Client(dir string) (*FixtureEth1Client, error) {
+	client, err := NewErigonClientWithFixtures("http://fixtures", dir, FixtureModeReplay)
+	if err != nil {
+		return nil, err
+	}
+	return &FixtureEth1Client{client: client}, nil
+}
+
+func (fc *FixtureEth1Client) GetBlock(number uint64) (*types.Eth1Block, *types.GetBlockTimings, error) {
+	return fc.client.GetBlock(int64(number), "geth")
+}
+
+func (fc *FixtureEth1Client) GetLatestEth1BlockNumber() (uint64, error) {
+	return fc.client.GetLatestEth1BlockNumber()
+}
+
+func (fc *FixtureEth1Client) GetChainID() *big.Int {
+	return fc.client.GetChainID()
+}
+
+func (fc *FixtureEth1Client) Close() {
+	fc.client.Close()
+}
+
+// GetErigonClient returns the underlying replaying ErigonClient for calls that are not part of the Eth1Client interface
+func (fc *FixtureEth1Client) GetErigonClient() *ErigonClient {
+	return fc.client
+}
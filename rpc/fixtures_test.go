@@ -0,0 +1,146 @@
+package rpc
+
+import (
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	geth_rpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+func TestFixtureClientReplaysBeaconApi(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/eth/v1/node/syncing" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"data":{"is_syncing":false,"head_slot":"4242","sync_distance":"0"}}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+
+	recorder, err := NewLighthouseClient(server.URL, big.NewInt(1))
+	if err != nil {
+		t.Fatalf("error creating lighthouse client: %v", err)
+	}
+	err = recorder.RecordFixtures(dir)
+	if err != nil {
+		t.Fatalf("error enabling recorder mode: %v", err)
+	}
+	recorded, err := recorder.GetSyncingStatus()
+	if err != nil {
+		t.Fatalf("error recording syncing status: %v", err)
+	}
+	_, err = recorder.get(server.URL + "/eth/v1/beacon/headers/1")
+	if err != errNotFound {
+		t.Fatalf("expected not found error while recording, got %v", err)
+	}
+
+	server.Close()
+
+	replayer, err := NewFixtureClient(dir, big.NewInt(1))
+	if err != nil {
+		t.Fatalf("error creating fixture client: %v", err)
+	}
+	replayed, err := replayer.GetSyncingStatus()
+	if err != nil {
+		t.Fatalf("error replaying syncing status: %v", err)
+	}
+	if replayed.Data.HeadSlot != recorded.Data.HeadSlot || replayed.Data.HeadSlot != 4242 {
+		t.Errorf("unexpected head slot %v, recorded %v", replayed.Data.HeadSlot, recorded.Data.HeadSlot)
+	}
+	_, err = replayer.get(replayer.endpoint + "/eth/v1/beacon/headers/1")
+	if err != errNotFound {
+		t.Errorf("expected not found error while replaying, got %v", err)
+	}
+	_, err = replayer.get(replayer.endpoint + "/eth/v1/beacon/headers/2")
+	if err == nil {
+		t.Errorf("expected error for a request without fixture")
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests to the node, got %v", requests)
+	}
+}
+
+func TestFixtureEth1ClientReplaysJsonRpc(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		messages, isBatch, err := parseJsonRpcMessages(body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		responses := make([]*jsonRpcMessage, 0, len(messages))
+		for _, message := range messages {
+			result := `"0x1"`
+			if message.Method == "eth_getBalance" {
+				var params []string
+				json.Unmarshal(message.Params, &params)
+				result = `"0x` + params[0][2:6] + `"`
+			}
+			responses = append(responses, &jsonRpcMessage{Version: "2.0", ID: message.ID, Result: json.RawMessage(result)})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if isBatch {
+			json.NewEncoder(w).Encode(responses)
+		} else {
+			json.NewEncoder(w).Encode(responses[0])
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	addresses := []string{"0x1234000000000000000000000000000000000000", "0xabcd000000000000000000000000000000000000"}
+
+	recorder, err := NewErigonClientWithFixtures(server.URL, dir, FixtureModeRecord)
+	if err != nil {
+		t.Fatalf("error creating recording erigon client: %v", err)
+	}
+	batch := make([]geth_rpc.BatchElem, 0, len(addresses))
+	for _, address := range addresses {
+		batch = append(batch, geth_rpc.BatchElem{Method: "eth_getBalance", Args: []interface{}{address, "latest"}, Result: new(hexutil.Big)})
+	}
+	err = recorder.rpcClient.BatchCall(batch)
+	if err != nil {
+		t.Fatalf("error recording batch call: %v", err)
+	}
+	recorder.Close()
+	server.Close()
+
+	replayer, err := NewFixtureEth1Client(dir)
+	if err != nil {
+		t.Fatalf("error creating fixture eth1 client: %v", err)
+	}
+	defer replayer.Close()
+
+	if replayer.GetChainID().Uint64() != 1 {
+		t.Errorf("unexpected chain id %v", replayer.GetChainID())
+	}
+
+	// the ids of a replayed batch differ from the recorded ones
+	batch = make([]geth_rpc.BatchElem, 0, len(addresses))
+	for _, address := range addresses {
+		batch = append(batch, geth_rpc.BatchElem{Method: "eth_getBalance", Args: []interface{}{address, "latest"}, Result: new(hexutil.Big)})
+	}
+	err = replayer.GetErigonClient().rpcClient.BatchCall(batch)
+	if err != nil {
+		t.Fatalf("error replaying batch call: %v", err)
+	}
+	expected := []int64{0x1234, 0xabcd}
+	for i, elem := range batch {
+		if elem.Error != nil {
+			t.Errorf("unexpected error in batch element %v: %v", i, elem.Error)
+			continue
+		}
+		if elem.Result.(*hexutil.Big).ToInt().Int64() != expected[i] {
+			t.Errorf("unexpected balance %v for %v", elem.Result.(*hexutil.Big).ToInt(), addresses[i])
+		}
+	}
+}
@@ -221,6 +221,7 @@ func (client *GethClient) GetBlock(number int64) (*types.Eth1Block, *types.GetBl
 				Data:    l.Data,
 				Removed: l.Removed,
 				Topics:  make([][]byte, 0, len(l.Topics)),
+				Index:   uint64(l.Index),
 			}
 
 			for _, t := range l.Topics {
@@ -245,6 +246,12 @@ func (client *GethClient) GetLatestEth1BlockNumber() (uint64, error) {
 	return latestBlock.NumberU64(), nil
 }
 
+// SubscribeNewHeads subscribes to the newHeads of the node, which requires a websocket or ipc connection.
+// If wsEndpoint is empty the subscription is created on the endpoint of the client
+func (client *GethClient) SubscribeNewHeads(ctx context.Context, wsEndpoint string, heads chan<- *geth_types.Header) (ethereum.Subscription, error) {
+	return subscribeNewHeads(ctx, client.ethClient, wsEndpoint, heads)
+}
+
 func (client *GethClient) TraceGeth(blockHash common.Hash) ([]*GethTraceCallResult, error) {
 	var res []*GethTraceCallResult
 
@@ -14,8 +14,11 @@ type Client interface {
 	GetValidatorQueue() (*types.ValidatorQueue, error)
 	GetEpochAssignments(epoch uint64) (*types.EpochAssignments, error)
 	GetBlockBySlot(slot uint64) (*types.Block, error)
+	GetBlockByBlockroot(blockroot []byte) (*types.Block, error)
 	GetValidatorParticipation(epoch uint64) (*types.ValidatorParticipation, error)
 	GetNewBlockChan() chan *types.Block
+	GetChainEventsChan() chan *ChainEvent
+	GetLightClientEventsChan() chan *LightClientEvent
 	GetSyncCommittee(stateID string, epoch uint64) (*StandardSyncCommittee, error)
 	GetBalancesForEpoch(epoch int64) (map[uint64]uint64, error)
 	GetValidatorState(epoch uint64) (*StandardValidatorsResponse, error)
@@ -17,6 +17,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/donovanhide/eventsource"
 	gtypes "github.com/ethereum/go-ethereum/core/types"
 	"golang.org/x/sync/errgroup"
@@ -36,6 +37,7 @@ type LighthouseClient struct {
 	slotsCache          *lru.Cache
 	slotsCacheMux       *sync.Mutex
 	signer              gtypes.Signer
+	transport           http.RoundTripper
 }
 
 // NewLighthouseClient is used to create a new Lighthouse client
@@ -53,43 +55,185 @@ func NewLighthouseClient(endpoint string, chainID *big.Int) (*LighthouseClient,
 	return client, nil
 }
 
+// RecordFixtures enables the recorder mode of the client, all beacon api responses are written to the given directory so they can be replayed by a FixtureClient
+func (lc *LighthouseClient) RecordFixtures(dir string) error {
+	transport, err := NewFixtureTransport(dir, FixtureModeRecord)
+	if err != nil {
+		return err
+	}
+	lc.transport = transport
+	return nil
+}
+
 func (lc *LighthouseClient) GetNewBlockChan() chan *types.Block {
 	blkCh := make(chan *types.Block, 10)
 	go func() {
-		stream, err := eventsource.Subscribe(fmt.Sprintf("%s/eth/v1/events?topics=head", lc.endpoint), "")
-
+		err := lc.streamNewBlocks(blkCh, nil)
 		if err != nil {
 			utils.LogFatal(err, "getting eventsource stream error", 0)
 		}
-		defer stream.Close()
+	}()
+	return blkCh
+}
 
-		for {
+// streamNewBlocks pushes the block of every head event of the node to blkCh until done is closed
+func (lc *LighthouseClient) streamNewBlocks(blkCh chan<- *types.Block, done <-chan struct{}) error {
+	stream, err := eventsource.Subscribe(fmt.Sprintf("%s/eth/v1/events?topics=head", lc.endpoint), "")
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	for {
+		select {
+		case <-done:
+			return nil
+		// It is important to register to Errors, otherwise the stream does not reconnect if the connection was lost
+		case err := <-stream.Errors:
+			utils.LogError(err, "Lighthouse connection error (will automatically retry to connect)", 0)
+		case e := <-stream.Events:
+			// logger.Infof("retrieved %v via event stream", e.Data())
+			var parsed StreamedBlockEventData
+			err = json.Unmarshal([]byte(e.Data()), &parsed)
+			if err != nil {
+				logger.Warnf("failed to decode block event: %v", err)
+				continue
+			}
+
+			logger.Infof("retrieving data for slot %v", parsed.Slot)
+			block, err := lc.GetBlockBySlot(uint64(parsed.Slot))
+			if err != nil {
+				logger.Warnf("failed to fetch block for slot %d: %v", uint64(parsed.Slot), err)
+				continue
+			}
+			logger.Infof("retrieved block for slot %v", parsed.Slot)
+			// logger.Infof("pushing block %v", blk.Slot)
 			select {
-			// It is important to register to Errors, otherwise the stream does not reconnect if the connection was lost
-			case err := <-stream.Errors:
-				utils.LogError(err, "Lighthouse connection error (will automatically retry to connect)", 0)
-			case e := <-stream.Events:
-				// logger.Infof("retrieved %v via event stream", e.Data())
-				var parsed StreamedBlockEventData
-				err = json.Unmarshal([]byte(e.Data()), &parsed)
-				if err != nil {
-					logger.Warnf("failed to decode block event: %v", err)
-					continue
-				}
+			case blkCh <- block:
+			case <-done:
+				return nil
+			}
+		}
+	}
+}
 
-				logger.Infof("retrieving data for slot %v", parsed.Slot)
-				block, err := lc.GetBlockBySlot(uint64(parsed.Slot))
-				if err != nil {
-					logger.Warnf("failed to fetch block for slot %d: %v", uint64(parsed.Slot), err)
-					continue
-				}
-				logger.Infof("retrieved block for slot %v", parsed.Slot)
-				// logger.Infof("pushing block %v", blk.Slot)
-				blkCh <- block
+// GetChainEventsChan subscribes to the head, block_gossip, block, chain_reorg and finalized_checkpoint topics of the beacon node event stream
+func (lc *LighthouseClient) GetChainEventsChan() chan *ChainEvent {
+	evCh := make(chan *ChainEvent, 100)
+	go func() {
+		err := lc.streamChainEvents(evCh, nil)
+		if err != nil {
+			utils.LogFatal(err, "getting eventsource stream error", 0)
+		}
+	}()
+	return evCh
+}
+
+// streamChainEvents pushes the decoded chain events of the node to evCh until done is closed
+func (lc *LighthouseClient) streamChainEvents(evCh chan<- *ChainEvent, done <-chan struct{}) error {
+	stream, err := eventsource.Subscribe(fmt.Sprintf("%s/eth/v1/events?topics=%s,%s,%s,%s,%s", lc.endpoint, HeadEventTopic, BlockGossipEventTopic, BlockEventTopic, ChainReorgEventTopic, FinalizedCheckpointEventTopic), "")
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	for {
+		select {
+		case <-done:
+			return nil
+		// It is important to register to Errors, otherwise the stream does not reconnect if the connection was lost
+		case err := <-stream.Errors:
+			utils.LogError(err, "Lighthouse connection error (will automatically retry to connect)", 0)
+		case e := <-stream.Events:
+			ev := &ChainEvent{
+				Topic:      e.Event(),
+				ReceivedTs: time.Now(),
+			}
+
+			switch ev.Topic {
+			case HeadEventTopic:
+				ev.Head = &StreamedHeadEventData{}
+				err = json.Unmarshal([]byte(e.Data()), ev.Head)
+			case BlockGossipEventTopic:
+				ev.BlockGossip = &StreamedBlockGossipEventData{}
+				err = json.Unmarshal([]byte(e.Data()), ev.BlockGossip)
+			case BlockEventTopic:
+				ev.Block = &StreamedBlockEventData{}
+				err = json.Unmarshal([]byte(e.Data()), ev.Block)
+			case ChainReorgEventTopic:
+				ev.ChainReorg = &StreamedChainReorgEventData{}
+				err = json.Unmarshal([]byte(e.Data()), ev.ChainReorg)
+			case FinalizedCheckpointEventTopic:
+				ev.FinalizedCheckpoint = &StreamedFinalizedCheckpointEventData{}
+				err = json.Unmarshal([]byte(e.Data()), ev.FinalizedCheckpoint)
+			default:
+				logger.Warnf("received event with unexpected topic %v", ev.Topic)
+				continue
+			}
+			if err != nil {
+				logger.Warnf("failed to decode %v event: %v", ev.Topic, err)
+				continue
+			}
+
+			select {
+			case evCh <- ev:
+			case <-done:
+				return nil
 			}
 		}
+	}
+}
+
+// GetLightClientEventsChan subscribes to the light client updates the node serves to light clients
+func (lc *LighthouseClient) GetLightClientEventsChan() chan *LightClientEvent {
+	evCh := make(chan *LightClientEvent, 100)
+	go func() {
+		err := lc.streamLightClientEvents(evCh, nil)
+		if err != nil {
+			utils.LogFatal(err, "getting eventsource stream error", 0)
+		}
 	}()
-	return blkCh
+	return evCh
+}
+
+// streamLightClientEvents pushes the light client updates of the node to evCh until done is closed
+func (lc *LighthouseClient) streamLightClientEvents(evCh chan<- *LightClientEvent, done <-chan struct{}) error {
+	stream, err := eventsource.Subscribe(fmt.Sprintf("%s/eth/v1/events?topics=%s,%s", lc.endpoint, LightClientFinalityUpdateEventTopic, LightClientOptimisticUpdateEventTopic), "")
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	for {
+		select {
+		case <-done:
+			return nil
+		// It is important to register to Errors, otherwise the stream does not reconnect if the connection was lost
+		case err := <-stream.Errors:
+			utils.LogError(err, "Lighthouse connection error (will automatically retry to connect)", 0)
+		case e := <-stream.Events:
+			if e.Event() != LightClientFinalityUpdateEventTopic && e.Event() != LightClientOptimisticUpdateEventTopic {
+				logger.Warnf("received event with unexpected topic %v", e.Event())
+				continue
+			}
+
+			ev := &LightClientEvent{
+				Topic:      e.Event(),
+				ReceivedTs: time.Now(),
+			}
+			err = json.Unmarshal([]byte(e.Data()), &ev.Update)
+			if err != nil {
+				logger.Warnf("failed to decode %v event: %v", ev.Topic, err)
+				continue
+			}
+
+			select {
+			case evCh <- ev:
+			case <-done:
+				return nil
+			}
+		}
+	}
 }
 
 // GetChainHead gets the chain head from Lighthouse
@@ -307,6 +451,12 @@ func (lc *LighthouseClient) GetValidatorState(epoch uint64) (*StandardValidators
 
 // GetEpochData will get the epoch data from Lighthouse RPC api
 func (lc *LighthouseClient) GetEpochData(epoch uint64, skipHistoricBalances bool) (*types.EpochData, error) {
+	return lc.getEpochData(epoch, skipHistoricBalances, lc.GetValidatorParticipation)
+}
+
+// getEpochData retrieves the epoch data from the standard beacon api of the node. The participation of the epoch is only served by the
+// lighthouse specific api, so it is retrieved via getParticipation which allows to query it from a different node.
+func (lc *LighthouseClient) getEpochData(epoch uint64, skipHistoricBalances bool, getParticipation func(epoch uint64) (*types.ValidatorParticipation, error)) (*types.EpochData, error) {
 	wg := &errgroup.Group{}
 	mux := &sync.Mutex{}
 
@@ -399,7 +549,7 @@ func (lc *LighthouseClient) GetEpochData(epoch uint64, skipHistoricBalances bool
 	if epoch < head.HeadEpoch {
 		wg.Go(func() error {
 			var err error
-			data.EpochParticipationStats, err = lc.GetValidatorParticipation(epoch)
+			data.EpochParticipationStats, err = getParticipation(epoch)
 			if err != nil {
 				if strings.HasSuffix(err.Error(), "can't be retrieved as it hasn't finished yet") { // should no longer happen
 					logger.Warnf("error retrieving epoch participation statistics for epoch %v: %v", epoch, err)
@@ -1111,26 +1261,22 @@ func (lc *LighthouseClient) blockFromResponse(parsedHeaders *StandardBeaconHeade
 			Signature: utils.MustParseHex(attestation.Signature),
 		}
 
-		aggregationBits := bitfield.Bitlist(a.AggregationBits)
 		assignments, err := lc.GetEpochAssignments(a.Data.Slot / utils.Config.Chain.ClConfig.SlotsPerEpoch)
 		if err != nil {
 			return nil, fmt.Errorf("error receiving epoch assignment for epoch %v: %w", a.Data.Slot/utils.Config.Chain.ClConfig.SlotsPerEpoch, err)
 		}
 
-		for i := uint64(0); i < aggregationBits.Len(); i++ {
-			if aggregationBits.BitAt(i) {
-				validator, found := assignments.AttestorAssignments[utils.FormatAttestorAssignmentKey(a.Data.Slot, a.Data.CommitteeIndex, i)]
-				if !found { // This should never happen!
-					validator = 0
-					logger.Errorf("error retrieving assigned validator for attestation %v of block %v for slot %v committee index %v member index %v", i, block.Slot, a.Data.Slot, a.Data.CommitteeIndex, i)
-				}
-				a.Attesters = append(a.Attesters, validator)
+		committees := attestationCommittees(utils.MustParseHex(attestation.CommitteeBits), a.Data.CommitteeIndex)
+		a.Attesters, err = attestationAttesters(assignments.AttestorAssignments, a.Data.Slot, committees, bitfield.Bitlist(a.AggregationBits))
+		if err != nil { // This should never happen!
+			logger.Errorf("error retrieving assigned validators for attestation %v of block %v: %v", i, block.Slot, err)
+		}
 
-				if block.AttestationDuties[types.ValidatorIndex(validator)] == nil {
-					block.AttestationDuties[types.ValidatorIndex(validator)] = []types.Slot{types.Slot(a.Data.Slot)}
-				} else {
-					block.AttestationDuties[types.ValidatorIndex(validator)] = append(block.AttestationDuties[types.ValidatorIndex(validator)], types.Slot(a.Data.Slot))
-				}
+		for _, validator := range a.Attesters {
+			if block.AttestationDuties[types.ValidatorIndex(validator)] == nil {
+				block.AttestationDuties[types.ValidatorIndex(validator)] = []types.Slot{types.Slot(a.Data.Slot)}
+			} else {
+				block.AttestationDuties[types.ValidatorIndex(validator)] = append(block.AttestationDuties[types.ValidatorIndex(validator)], types.Slot(a.Data.Slot))
 			}
 		}
 
@@ -1168,6 +1314,37 @@ func (lc *LighthouseClient) blockFromResponse(parsedHeaders *StandardBeaconHeade
 		}
 	}
 
+	if requests := parsedBlock.Message.Body.ExecutionRequests; requests != nil {
+		block.DepositRequests = make([]*types.DepositRequest, len(requests.Deposits))
+		for i, d := range requests.Deposits {
+			block.DepositRequests[i] = &types.DepositRequest{
+				Pubkey:                d.Pubkey,
+				WithdrawalCredentials: d.WithdrawalCredentials,
+				Amount:                uint64(d.Amount),
+				Signature:             d.Signature,
+				Index:                 uint64(d.Index),
+			}
+		}
+
+		block.WithdrawalRequests = make([]*types.WithdrawalRequest, len(requests.Withdrawals))
+		for i, w := range requests.Withdrawals {
+			block.WithdrawalRequests[i] = &types.WithdrawalRequest{
+				SourceAddress:   w.SourceAddress,
+				ValidatorPubkey: w.ValidatorPubkey,
+				Amount:          uint64(w.Amount),
+			}
+		}
+
+		block.ConsolidationRequests = make([]*types.ConsolidationRequest, len(requests.Consolidations))
+		for i, c := range requests.Consolidations {
+			block.ConsolidationRequests[i] = &types.ConsolidationRequest{
+				SourceAddress: c.SourceAddress,
+				SourcePubkey:  c.SourcePubkey,
+				TargetPubkey:  c.TargetPubkey,
+			}
+		}
+	}
+
 	return block, nil
 }
 
@@ -1252,6 +1429,20 @@ func (lc *LighthouseClient) GetSyncCommittee(stateID string, epoch uint64) (*Sta
 	return &parsedSyncCommittees.Data, nil
 }
 
+// GetSyncingStatus returns the sync status of the node
+func (lc *LighthouseClient) GetSyncingStatus() (*StandardSyncingResponse, error) {
+	res, err := lc.get(fmt.Sprintf("%s/eth/v1/node/syncing", lc.endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving syncing status: %w", err)
+	}
+	var parsed StandardSyncingResponse
+	err = json.Unmarshal(res, &parsed)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing syncing status: %w", err)
+	}
+	return &parsed, nil
+}
+
 func (lc *LighthouseClient) GetBlobSidecars(stateID string) (*StandardBlobSidecarsResponse, error) {
 	res, err := lc.get(fmt.Sprintf("%s/eth/v1/beacon/blob_sidecars/%s", lc.endpoint, stateID))
 	if err != nil {
@@ -1270,7 +1461,7 @@ var errNotFound = errors.New("not found 404")
 func (lc *LighthouseClient) get(url string) ([]byte, error) {
 	// t0 := time.Now()
 	// defer func() { fmt.Println(url, time.Since(t0)) }()
-	client := &http.Client{Timeout: time.Minute * 2}
+	client := &http.Client{Timeout: time.Minute * 2, Transport: lc.transport}
 	resp, err := client.Get(url)
 	if err != nil {
 		return nil, err
@@ -1390,6 +1581,116 @@ type StreamedBlockEventData struct {
 	ExecutionOptimistic bool      `json:"execution_optimistic"`
 }
 
+// StreamedBlockGossipEventData is sent as soon as a block passed the gossip validation, before it is imported
+type StreamedBlockGossipEventData struct {
+	Slot  uint64Str `json:"slot"`
+	Block string    `json:"block"`
+}
+
+const (
+	HeadEventTopic                = "head"
+	BlockGossipEventTopic         = "block_gossip"
+	BlockEventTopic               = "block"
+	ChainReorgEventTopic          = "chain_reorg"
+	FinalizedCheckpointEventTopic = "finalized_checkpoint"
+)
+
+// ChainEvent holds a single decoded event of the beacon node event stream, only the field matching the topic is set
+type ChainEvent struct {
+	Topic               string
+	ReceivedTs          time.Time
+	Head                *StreamedHeadEventData
+	BlockGossip         *StreamedBlockGossipEventData
+	Block               *StreamedBlockEventData
+	ChainReorg          *StreamedChainReorgEventData
+	FinalizedCheckpoint *StreamedFinalizedCheckpointEventData
+}
+
+const (
+	LightClientFinalityUpdateEventTopic   = "light_client_finality_update"
+	LightClientOptimisticUpdateEventTopic = "light_client_optimistic_update"
+)
+
+// LightClientEvent holds a single light client update of the beacon node event stream, finality updates additionally contain the finalized header
+type LightClientEvent struct {
+	Topic      string
+	ReceivedTs time.Time
+	Update     StreamedLightClientUpdateEventData
+}
+
+type StreamedLightClientUpdateEventData struct {
+	Version string `json:"version"`
+	Data    struct {
+		AttestedHeader struct {
+			Beacon LightClientBeaconHeader `json:"beacon"`
+		} `json:"attested_header"`
+		FinalizedHeader *struct {
+			Beacon LightClientBeaconHeader `json:"beacon"`
+		} `json:"finalized_header,omitempty"`
+		SyncAggregate struct {
+			SyncCommitteeBits      bytesHexStr `json:"sync_committee_bits"`
+			SyncCommitteeSignature bytesHexStr `json:"sync_committee_signature"`
+		} `json:"sync_aggregate"`
+		SignatureSlot uint64Str `json:"signature_slot"`
+	} `json:"data"`
+}
+
+type LightClientBeaconHeader struct {
+	Slot          uint64Str   `json:"slot"`
+	ProposerIndex uint64Str   `json:"proposer_index"`
+	ParentRoot    bytesHexStr `json:"parent_root"`
+	StateRoot     bytesHexStr `json:"state_root"`
+	BodyRoot      bytesHexStr `json:"body_root"`
+}
+
+// BlockRoot returns the hash tree root of the header which is the root of the block it belongs to
+func (h *LightClientBeaconHeader) BlockRoot() ([]byte, error) {
+	header := &phase0.BeaconBlockHeader{
+		Slot:          phase0.Slot(h.Slot),
+		ProposerIndex: phase0.ValidatorIndex(h.ProposerIndex),
+	}
+	if len(h.ParentRoot) != 32 || len(h.StateRoot) != 32 || len(h.BodyRoot) != 32 {
+		return nil, fmt.Errorf("invalid root length in light client header of slot %v", h.Slot)
+	}
+	copy(header.ParentRoot[:], h.ParentRoot)
+	copy(header.StateRoot[:], h.StateRoot)
+	copy(header.BodyRoot[:], h.BodyRoot)
+
+	root, err := header.HashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+	return root[:], nil
+}
+
+type StreamedHeadEventData struct {
+	Slot                      uint64Str `json:"slot"`
+	Block                     string    `json:"block"`
+	State                     string    `json:"state"`
+	EpochTransition           bool      `json:"epoch_transition"`
+	PreviousDutyDependentRoot string    `json:"previous_duty_dependent_root"`
+	CurrentDutyDependentRoot  string    `json:"current_duty_dependent_root"`
+	ExecutionOptimistic       bool      `json:"execution_optimistic"`
+}
+
+type StreamedChainReorgEventData struct {
+	Slot                uint64Str `json:"slot"`
+	Depth               uint64Str `json:"depth"`
+	OldHeadBlock        string    `json:"old_head_block"`
+	NewHeadBlock        string    `json:"new_head_block"`
+	OldHeadState        string    `json:"old_head_state"`
+	NewHeadState        string    `json:"new_head_state"`
+	Epoch               uint64Str `json:"epoch"`
+	ExecutionOptimistic bool      `json:"execution_optimistic"`
+}
+
+type StreamedFinalizedCheckpointEventData struct {
+	Block               string    `json:"block"`
+	State               string    `json:"state"`
+	Epoch               uint64Str `json:"epoch"`
+	ExecutionOptimistic bool      `json:"execution_optimistic"`
+}
+
 type StandardProposerDuty struct {
 	Pubkey         string    `json:"pubkey"`
 	ValidatorIndex uint64Str `json:"validator_index"`
@@ -1492,8 +1793,10 @@ type AttesterSlashing struct {
 
 type Attestation struct {
 	AggregationBits string `json:"aggregation_bits"`
-	Signature       string `json:"signature"`
-	Data            struct {
+	// CommitteeBits is present only after electra (EIP-7549), data.index is always 0 then
+	CommitteeBits string `json:"committee_bits,omitempty"`
+	Signature     string `json:"signature"`
+	Data          struct {
 		Slot            uint64Str `json:"slot"`
 		Index           uint64Str `json:"index"`
 		BeaconBlockRoot string    `json:"beacon_block_root"`
@@ -1508,6 +1811,49 @@ type Attestation struct {
 	} `json:"data"`
 }
 
+// attestationCommittees returns the indices of the committees an attestation has been aggregated over.
+// Since electra the committees are set in the committee bits (a bitvector of MAX_COMMITTEES_PER_SLOT), before that the committee is data.index.
+func attestationCommittees(committeeBits []byte, committeeIndex uint64) []uint64 {
+	if len(committeeBits) == 0 {
+		return []uint64{committeeIndex}
+	}
+
+	committees := []uint64{}
+	for i := uint64(0); i < uint64(len(committeeBits))*8; i++ {
+		if committeeBits[i/8]&(1<<(i%8)) != 0 {
+			committees = append(committees, i)
+		}
+	}
+	return committees
+}
+
+// attestationAttesters maps the aggregation bits of an attestation to the attesting validators.
+// The aggregation bits span the concatenation of all committees in ascending committee order, so the bits of a committee start after the members of the previous ones.
+// Validators that have been found are returned along with an error if the bits do not match the committee assignments.
+func attestationAttesters(attestorAssignments map[string]uint64, slot uint64, committees []uint64, aggregationBits bitfield.Bitlist) ([]uint64, error) {
+	attesters := []uint64{}
+	offset := uint64(0)
+	for _, committee := range committees {
+		for member := uint64(0); ; member++ {
+			validator, found := attestorAssignments[utils.FormatAttestorAssignmentKey(slot, committee, member)]
+			if !found {
+				if member == 0 {
+					return attesters, fmt.Errorf("no assignments found for slot %v committee index %v", slot, committee)
+				}
+				offset += member
+				break
+			}
+			if offset+member < aggregationBits.Len() && aggregationBits.BitAt(offset+member) {
+				attesters = append(attesters, validator)
+			}
+		}
+	}
+	if offset != aggregationBits.Len() {
+		return attesters, fmt.Errorf("aggregation bits of length %v do not match the committee sizes %v of slot %v committee indices %v", aggregationBits.Len(), offset, slot, committees)
+	}
+	return attesters, nil
+}
+
 type Deposit struct {
 	Proof []string `json:"proof"`
 	Data  struct {
@@ -1577,6 +1923,32 @@ type SignedBLSToExecutionChange struct {
 	Signature bytesHexStr `json:"signature"`
 }
 
+type ExecutionRequests struct {
+	Deposits       []DepositRequest       `json:"deposits"`
+	Withdrawals    []WithdrawalRequest    `json:"withdrawals"`
+	Consolidations []ConsolidationRequest `json:"consolidations"`
+}
+
+type DepositRequest struct {
+	Pubkey                bytesHexStr `json:"pubkey"`
+	WithdrawalCredentials bytesHexStr `json:"withdrawal_credentials"`
+	Amount                uint64Str   `json:"amount"`
+	Signature             bytesHexStr `json:"signature"`
+	Index                 uint64Str   `json:"index"`
+}
+
+type WithdrawalRequest struct {
+	SourceAddress   bytesHexStr `json:"source_address"`
+	ValidatorPubkey bytesHexStr `json:"validator_pubkey"`
+	Amount          uint64Str   `json:"amount"`
+}
+
+type ConsolidationRequest struct {
+	SourceAddress bytesHexStr `json:"source_address"`
+	SourcePubkey  bytesHexStr `json:"source_pubkey"`
+	TargetPubkey  bytesHexStr `json:"target_pubkey"`
+}
+
 type AnySignedBlock struct {
 	Message struct {
 		Slot          uint64Str `json:"slot"`
@@ -1604,6 +1976,9 @@ type AnySignedBlock struct {
 
 			// present only after deneb
 			BlobKZGCommitments []bytesHexStr `json:"blob_kzg_commitments"`
+
+			// present only after electra
+			ExecutionRequests *ExecutionRequests `json:"execution_requests,omitempty"`
 		} `json:"body"`
 	} `json:"message"`
 	Signature bytesHexStr `json:"signature"`
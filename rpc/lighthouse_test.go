@@ -0,0 +1,53 @@
+package rpc
+
+import (
+	"eth2-exporter/utils"
+	"fmt"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+)
+
+func TestAttestationAttesters(t *testing.T) {
+	// committee 0 has the validators 100-102, committee 2 has the validators 200-203
+	assignments := map[string]uint64{}
+	for i := uint64(0); i < 3; i++ {
+		assignments[utils.FormatAttestorAssignmentKey(10, 0, i)] = 100 + i
+	}
+	for i := uint64(0); i < 4; i++ {
+		assignments[utils.FormatAttestorAssignmentKey(10, 2, i)] = 200 + i
+	}
+
+	tests := []struct {
+		Name           string
+		CommitteeBits  []byte
+		CommitteeIndex uint64
+		Bits           []uint64
+		Length         uint64
+		Expected       string
+		Err            bool
+	}{
+		{"phase0", nil, 2, []uint64{0, 3}, 4, "[200 203]", false},
+		{"electra single committee", []byte{0x04, 0, 0, 0, 0, 0, 0, 0}, 0, []uint64{1}, 4, "[201]", false},
+		{"electra two committees", []byte{0x05, 0, 0, 0, 0, 0, 0, 0}, 0, []uint64{0, 2, 3, 6}, 7, "[100 102 200 203]", false},
+		{"length mismatch", nil, 0, []uint64{0}, 4, "[100]", true},
+		{"unknown committee", []byte{0x02, 0, 0, 0, 0, 0, 0, 0}, 0, nil, 1, "[]", true},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			aggregationBits := bitfield.NewBitlist(test.Length)
+			for _, bit := range test.Bits {
+				aggregationBits.SetBitAt(bit, true)
+			}
+
+			committees := attestationCommittees(test.CommitteeBits, test.CommitteeIndex)
+			attesters, err := attestationAttesters(assignments, 10, committees, aggregationBits)
+			if (err != nil) != test.Err {
+				t.Errorf("expected error %v, got %v", test.Err, err)
+			}
+			if fmt.Sprint(attesters) != test.Expected {
+				t.Errorf("expected attesters %v, got %v", test.Expected, attesters)
+			}
+		})
+	}
+}
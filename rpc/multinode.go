@@ -0,0 +1,441 @@
+package rpc
+
+import (
+	"bytes"
+	"errors"
+	"eth2-exporter/metrics"
+	"eth2-exporter/types"
+	"eth2-exporter/utils"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+)
+
+// BeaconNodeConfig describes a single beacon node used by the MultiNodeClient
+type BeaconNodeConfig struct {
+	Endpoint string
+	Type     string // lighthouse, teku, prysm, nimbus or lodestar
+}
+
+var supportedBeaconNodeTypes = map[string]bool{
+	"lighthouse": true,
+	"teku":       true,
+	"prysm":      true,
+	"nimbus":     true,
+	"lodestar":   true,
+}
+
+var errNoLighthouseNode = errors.New("no lighthouse node configured")
+
+type beaconNode struct {
+	endpoint string
+	nodeType string
+	client   *LighthouseClient
+
+	mux          *sync.RWMutex
+	healthy      bool
+	headSlot     uint64
+	syncDistance uint64
+}
+
+// MultiNodeClient implements the Client interface on top of several beacon nodes that expose the standard beacon api.
+// Calls are routed to the most synced healthy node and fail over to the next node on errors.
+type MultiNodeClient struct {
+	nodes      []*beaconNode
+	crossCheck bool
+}
+
+// NewMultiNodeClient is used to create a new client that fails over between the given beacon nodes.
+// If crossCheck is set the block roots returned by GetBlockBySlot and GetEpochData are verified against a second node.
+func NewMultiNodeClient(nodeConfigs []BeaconNodeConfig, chainID *big.Int, crossCheck bool) (*MultiNodeClient, error) {
+	if len(nodeConfigs) == 0 {
+		return nil, fmt.Errorf("no beacon nodes configured")
+	}
+
+	mc := &MultiNodeClient{
+		nodes:      make([]*beaconNode, 0, len(nodeConfigs)),
+		crossCheck: crossCheck,
+	}
+
+	for _, nodeConfig := range nodeConfigs {
+		if !supportedBeaconNodeTypes[nodeConfig.Type] {
+			return nil, fmt.Errorf("unsupported beacon node type %v for node %v", nodeConfig.Type, nodeConfig.Endpoint)
+		}
+		client, err := NewLighthouseClient(nodeConfig.Endpoint, chainID)
+		if err != nil {
+			return nil, fmt.Errorf("error creating client for node %v: %w", nodeConfig.Endpoint, err)
+		}
+		mc.nodes = append(mc.nodes, &beaconNode{
+			endpoint: nodeConfig.Endpoint,
+			nodeType: nodeConfig.Type,
+			client:   client,
+			mux:      &sync.RWMutex{},
+		})
+	}
+
+	mc.checkNodes()
+	go mc.healthChecker()
+
+	return mc, nil
+}
+
+func healthCheckInterval() time.Duration {
+	interval := time.Second * time.Duration(utils.Config.Chain.ClConfig.SecondsPerSlot)
+	if interval == 0 {
+		interval = time.Second * 12
+	}
+	return interval
+}
+
+func (mc *MultiNodeClient) healthChecker() {
+	interval := healthCheckInterval()
+	for {
+		time.Sleep(interval)
+		mc.checkNodes()
+	}
+}
+
+func (mc *MultiNodeClient) checkNodes() {
+	wg := &sync.WaitGroup{}
+	for _, node := range mc.nodes {
+		wg.Add(1)
+		go func(node *beaconNode) {
+			defer wg.Done()
+			node.check()
+		}(node)
+	}
+	wg.Wait()
+}
+
+func (node *beaconNode) check() {
+	status, err := node.client.GetSyncingStatus()
+
+	node.mux.Lock()
+	defer node.mux.Unlock()
+
+	if err != nil {
+		logger.Warnf("health check of beacon node %v failed: %v", node.endpoint, err)
+		node.healthy = false
+	} else {
+		node.healthy = !status.Data.IsSyncing
+		node.headSlot = uint64(status.Data.HeadSlot)
+		node.syncDistance = uint64(status.Data.SyncDistance)
+		if status.Data.IsSyncing {
+			logger.Warnf("beacon node %v is syncing (head slot %v, sync distance %v)", node.endpoint, node.headSlot, node.syncDistance)
+		}
+	}
+
+	if node.healthy {
+		metrics.BeaconNodeHealthy.WithLabelValues(node.endpoint).Set(1)
+	} else {
+		metrics.BeaconNodeHealthy.WithLabelValues(node.endpoint).Set(0)
+	}
+	metrics.BeaconNodeHeadSlot.WithLabelValues(node.endpoint).Set(float64(node.headSlot))
+}
+
+func (node *beaconNode) isHealthy() bool {
+	node.mux.RLock()
+	defer node.mux.RUnlock()
+	return node.healthy
+}
+
+// orderedNodes returns all healthy nodes sorted by their head slot, followed by the unhealthy nodes as last resort
+func (mc *MultiNodeClient) orderedNodes() []*beaconNode {
+	type nodeState struct {
+		node     *beaconNode
+		healthy  bool
+		headSlot uint64
+	}
+
+	states := make([]nodeState, 0, len(mc.nodes))
+	for _, node := range mc.nodes {
+		node.mux.RLock()
+		states = append(states, nodeState{node: node, healthy: node.healthy, headSlot: node.headSlot})
+		node.mux.RUnlock()
+	}
+
+	sort.SliceStable(states, func(i, j int) bool {
+		if states[i].healthy != states[j].healthy {
+			return states[i].healthy
+		}
+		return states[i].headSlot > states[j].headSlot
+	})
+
+	nodes := make([]*beaconNode, 0, len(states))
+	for _, s := range states {
+		nodes = append(nodes, s.node)
+	}
+	return nodes
+}
+
+// healthyNodesExcept returns the healthy nodes in routing order without the given node
+func (mc *MultiNodeClient) healthyNodesExcept(exclude *beaconNode) []*beaconNode {
+	nodes := make([]*beaconNode, 0, len(mc.nodes))
+	for _, node := range mc.orderedNodes() {
+		node.mux.RLock()
+		healthy := node.healthy
+		node.mux.RUnlock()
+		if healthy && node != exclude {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// callNodes executes the call on the best node and fails over to the remaining nodes on errors
+func callNodes[T any](mc *MultiNodeClient, method string, nodes []*beaconNode, call func(client *LighthouseClient) (T, error)) (T, *beaconNode, error) {
+	var lastErr error
+	for _, node := range nodes {
+		start := time.Now()
+		res, err := call(node.client)
+		metrics.BeaconNodeRequestDuration.WithLabelValues(node.endpoint, method).Observe(time.Since(start).Seconds())
+		if err != nil {
+			metrics.BeaconNodeRequestErrors.WithLabelValues(node.endpoint, method).Inc()
+			logger.Warnf("error calling %v on beacon node %v, failing over to the next node: %v", method, node.endpoint, err)
+			lastErr = err
+			continue
+		}
+		return res, node, nil
+	}
+
+	var empty T
+	return empty, nil, fmt.Errorf("error calling %v on all %v beacon nodes: %w", method, len(nodes), lastErr)
+}
+
+func multiNodeCall[T any](mc *MultiNodeClient, method string, call func(client *LighthouseClient) (T, error)) (T, error) {
+	res, _, err := callNodes(mc, method, mc.orderedNodes(), call)
+	return res, err
+}
+
+// verifyBlockRoot checks that a second healthy node agrees on the block root of a slot
+func (mc *MultiNodeClient) verifyBlockRoot(slot uint64, blockRoot []byte, source *beaconNode) error {
+	others := mc.healthyNodesExcept(source)
+	if len(others) == 0 {
+		logger.Warnf("unable to cross-check block root of slot %v, no second healthy beacon node available", slot)
+		return nil
+	}
+
+	header, node, err := callNodes(mc, "GetBlockHeader", others, func(client *LighthouseClient) (*StandardBeaconHeaderResponse, error) {
+		return client.GetBlockHeader(slot)
+	})
+	if err != nil {
+		return fmt.Errorf("error cross-checking block root of slot %v: %w", slot, err)
+	}
+
+	var otherRoot []byte
+	if header != nil {
+		otherRoot = utils.MustParseHex(header.Data.Root)
+	}
+
+	// missed slots are stored with a dummy block root
+	if len(blockRoot) != 32 && otherRoot == nil {
+		return nil
+	}
+
+	if !bytes.Equal(blockRoot, otherRoot) {
+		return fmt.Errorf("block root mismatch at slot %v: node %v returned 0x%x, node %v returned 0x%x", slot, source.endpoint, blockRoot, node.endpoint, otherRoot)
+	}
+	return nil
+}
+
+func (mc *MultiNodeClient) GetChainHead() (*types.ChainHead, error) {
+	return multiNodeCall(mc, "GetChainHead", func(client *LighthouseClient) (*types.ChainHead, error) {
+		return client.GetChainHead()
+	})
+}
+
+// GetEpochData retrieves the epoch data from the most synced node, the participation of the epoch is retrieved from the lighthouse nodes only
+func (mc *MultiNodeClient) GetEpochData(epoch uint64, skipHistoricBalances bool) (*types.EpochData, error) {
+	data, node, err := callNodes(mc, "GetEpochData", mc.orderedNodes(), func(client *LighthouseClient) (*types.EpochData, error) {
+		return client.getEpochData(epoch, skipHistoricBalances, mc.getEpochParticipation)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if mc.crossCheck {
+		for slot, blocks := range data.Blocks {
+			for _, block := range blocks {
+				if block.Status != 1 {
+					continue
+				}
+				err := mc.verifyBlockRoot(slot, block.BlockRoot, node)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	return data, nil
+}
+
+func (mc *MultiNodeClient) GetValidatorQueue() (*types.ValidatorQueue, error) {
+	return multiNodeCall(mc, "GetValidatorQueue", func(client *LighthouseClient) (*types.ValidatorQueue, error) {
+		return client.GetValidatorQueue()
+	})
+}
+
+func (mc *MultiNodeClient) GetEpochAssignments(epoch uint64) (*types.EpochAssignments, error) {
+	return multiNodeCall(mc, "GetEpochAssignments", func(client *LighthouseClient) (*types.EpochAssignments, error) {
+		return client.GetEpochAssignments(epoch)
+	})
+}
+
+func (mc *MultiNodeClient) GetBlockBySlot(slot uint64) (*types.Block, error) {
+	block, node, err := callNodes(mc, "GetBlockBySlot", mc.orderedNodes(), func(client *LighthouseClient) (*types.Block, error) {
+		return client.GetBlockBySlot(slot)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if mc.crossCheck {
+		err := mc.verifyBlockRoot(slot, block.BlockRoot, node)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return block, nil
+}
+
+func (mc *MultiNodeClient) GetBlockByBlockroot(blockroot []byte) (*types.Block, error) {
+	return multiNodeCall(mc, "GetBlockByBlockroot", func(client *LighthouseClient) (*types.Block, error) {
+		return client.GetBlockByBlockroot(blockroot)
+	})
+}
+
+// GetValidatorParticipation relies on the lighthouse specific validator inclusion api, so only lighthouse nodes are queried
+func (mc *MultiNodeClient) GetValidatorParticipation(epoch uint64) (*types.ValidatorParticipation, error) {
+	nodes := make([]*beaconNode, 0, len(mc.nodes))
+	for _, node := range mc.orderedNodes() {
+		if node.nodeType == "lighthouse" {
+			nodes = append(nodes, node)
+		}
+	}
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("error retrieving validator participation for epoch %v: %w", epoch, errNoLighthouseNode)
+	}
+
+	res, _, err := callNodes(mc, "GetValidatorParticipation", nodes, func(client *LighthouseClient) (*types.ValidatorParticipation, error) {
+		return client.GetValidatorParticipation(epoch)
+	})
+	return res, err
+}
+
+// getEpochParticipation retrieves the participation of an epoch for the epoch data. Without a lighthouse node the participation cannot be
+// retrieved at all, so it is left empty instead of failing the epoch export. Errors of the lighthouse nodes are returned as usual.
+func (mc *MultiNodeClient) getEpochParticipation(epoch uint64) (*types.ValidatorParticipation, error) {
+	participation, err := mc.GetValidatorParticipation(epoch)
+	if errors.Is(err, errNoLighthouseNode) {
+		logger.Warnf("leaving the participation of epoch %v empty: %v", epoch, err)
+		return &types.ValidatorParticipation{
+			Epoch:                   epoch,
+			GlobalParticipationRate: 0.0,
+			VotedEther:              0,
+			EligibleEther:           0,
+		}, nil
+	}
+	return participation, err
+}
+
+// GetNewBlockChan subscribes to the event stream of the most synced node and resubscribes to another node if it turns unhealthy
+func (mc *MultiNodeClient) GetNewBlockChan() chan *types.Block {
+	blkCh := make(chan *types.Block, 10)
+	go mc.streamFromBestNode("new blocks", healthCheckInterval(), func(node *beaconNode, done <-chan struct{}) error {
+		return node.client.streamNewBlocks(blkCh, done)
+	})
+	return blkCh
+}
+
+// GetChainEventsChan subscribes to the event stream of the most synced node and resubscribes to another node if it turns unhealthy
+func (mc *MultiNodeClient) GetChainEventsChan() chan *ChainEvent {
+	evCh := make(chan *ChainEvent, 100)
+	go mc.streamFromBestNode("chain events", healthCheckInterval(), func(node *beaconNode, done <-chan struct{}) error {
+		return node.client.streamChainEvents(evCh, done)
+	})
+	return evCh
+}
+
+// GetLightClientEventsChan subscribes to the event stream of the most synced node and resubscribes to another node if it turns unhealthy
+func (mc *MultiNodeClient) GetLightClientEventsChan() chan *LightClientEvent {
+	evCh := make(chan *LightClientEvent, 100)
+	go mc.streamFromBestNode("light client events", healthCheckInterval(), func(node *beaconNode, done <-chan struct{}) error {
+		return node.client.streamLightClientEvents(evCh, done)
+	})
+	return evCh
+}
+
+// streamFromBestNode runs the subscription on the best node and checks the node every interval.
+// Once the subscribed node turns unhealthy while a healthy node is available, or the subscription fails, the stream is moved to the best node.
+// The stream is not moved between healthy nodes, events that are emitted while switching nodes can be lost.
+func (mc *MultiNodeClient) streamFromBestNode(stream string, interval time.Duration, subscribe func(node *beaconNode, done <-chan struct{}) error) {
+	type subscriptionError struct {
+		done chan struct{}
+		err  error
+	}
+
+	var current *beaconNode
+	var done chan struct{}
+	errCh := make(chan *subscriptionError)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		best := mc.orderedNodes()[0]
+		if current == nil || (best != current && !current.isHealthy() && best.isHealthy()) {
+			if current != nil {
+				logger.Warnf("beacon node %v is unhealthy, moving the %v stream to beacon node %v", current.endpoint, stream, best.endpoint)
+				close(done)
+			}
+			current = best
+			done = make(chan struct{})
+			go func(node *beaconNode, done chan struct{}) {
+				err := subscribe(node, done)
+				if err != nil {
+					errCh <- &subscriptionError{done: done, err: fmt.Errorf("error subscribing to the %v stream of beacon node %v: %w", stream, node.endpoint, err)}
+				}
+			}(current, done)
+		}
+
+		select {
+		case <-ticker.C:
+		case e := <-errCh:
+			// errors of subscriptions that have already been moved to another node are ignored
+			if e.done == done {
+				logger.Error(e.err)
+				close(done)
+				current = nil
+				<-ticker.C
+			}
+		}
+	}
+}
+
+func (mc *MultiNodeClient) GetSyncCommittee(stateID string, epoch uint64) (*StandardSyncCommittee, error) {
+	return multiNodeCall(mc, "GetSyncCommittee", func(client *LighthouseClient) (*StandardSyncCommittee, error) {
+		return client.GetSyncCommittee(stateID, epoch)
+	})
+}
+
+func (mc *MultiNodeClient) GetBalancesForEpoch(epoch int64) (map[uint64]uint64, error) {
+	return multiNodeCall(mc, "GetBalancesForEpoch", func(client *LighthouseClient) (map[uint64]uint64, error) {
+		return client.GetBalancesForEpoch(epoch)
+	})
+}
+
+func (mc *MultiNodeClient) GetValidatorState(epoch uint64) (*StandardValidatorsResponse, error) {
+	return multiNodeCall(mc, "GetValidatorState", func(client *LighthouseClient) (*StandardValidatorsResponse, error) {
+		return client.GetValidatorState(epoch)
+	})
+}
+
+func (mc *MultiNodeClient) GetBlockHeader(slot uint64) (*StandardBeaconHeaderResponse, error) {
+	return multiNodeCall(mc, "GetBlockHeader", func(client *LighthouseClient) (*StandardBeaconHeaderResponse, error) {
+		return client.GetBlockHeader(slot)
+	})
+}
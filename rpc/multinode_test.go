@@ -0,0 +1,139 @@
+package rpc
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStreamFromBestNode(t *testing.T) {
+	newNode := func(endpoint string, headSlot uint64) *beaconNode {
+		return &beaconNode{endpoint: endpoint, mux: &sync.RWMutex{}, healthy: true, headSlot: headSlot}
+	}
+	setNode := func(node *beaconNode, healthy bool, headSlot uint64) {
+		node.mux.Lock()
+		defer node.mux.Unlock()
+		node.healthy = healthy
+		node.headSlot = headSlot
+	}
+	nodeA := newNode("a", 10)
+	nodeB := newNode("b", 9)
+	mc := &MultiNodeClient{nodes: []*beaconNode{nodeA, nodeB}}
+
+	type subscription struct {
+		endpoint string
+		done     <-chan struct{}
+	}
+	subscriptions := make(chan *subscription, 10)
+	fail := make(chan struct{})
+	go mc.streamFromBestNode("test", time.Millisecond*10, func(node *beaconNode, done <-chan struct{}) error {
+		subscriptions <- &subscription{endpoint: node.endpoint, done: done}
+		select {
+		case <-done:
+			return nil
+		case <-fail:
+			return fmt.Errorf("stream closed")
+		}
+	})
+
+	expectSubscription := func(endpoint string) *subscription {
+		t.Helper()
+		select {
+		case sub := <-subscriptions:
+			if sub.endpoint != endpoint {
+				t.Fatalf("expected a subscription to node %v, got %v", endpoint, sub.endpoint)
+			}
+			return sub
+		case <-time.After(time.Second):
+			t.Fatalf("expected a subscription to node %v", endpoint)
+		}
+		return nil
+	}
+	expectNoSubscription := func() {
+		t.Helper()
+		select {
+		case sub := <-subscriptions:
+			t.Fatalf("expected no new subscription, got one to node %v", sub.endpoint)
+		case <-time.After(time.Millisecond * 50):
+		}
+	}
+
+	subA := expectSubscription("a")
+
+	// the stream stays on a healthy node even if another node is ahead
+	setNode(nodeB, true, 11)
+	expectNoSubscription()
+
+	// the stream is moved once the subscribed node turns unhealthy
+	setNode(nodeA, false, 10)
+	subB := expectSubscription("b")
+	select {
+	case <-subA.done:
+	case <-time.After(time.Second):
+		t.Fatalf("expected the subscription to node a to be stopped")
+	}
+
+	// the stream is kept on the unhealthy node if no healthy node is available
+	setNode(nodeA, false, 12)
+	setNode(nodeB, false, 11)
+	expectNoSubscription()
+
+	setNode(nodeA, true, 12)
+	subA = expectSubscription("a")
+	select {
+	case <-subB.done:
+	default:
+		t.Errorf("expected the subscription to node b to be stopped")
+	}
+
+	// a failed subscription is retried on the best node
+	fail <- struct{}{}
+	expectSubscription("a")
+	select {
+	case <-subA.done:
+	default:
+		t.Errorf("expected the failed subscription to be stopped")
+	}
+}
+
+func TestGetEpochParticipation(t *testing.T) {
+	// clients are only set for the nodes that may be queried, calling another node panics
+	teku := &beaconNode{endpoint: "teku", nodeType: "teku", mux: &sync.RWMutex{}, healthy: true, headSlot: 10}
+	prysm := &beaconNode{endpoint: "prysm", nodeType: "prysm", mux: &sync.RWMutex{}, healthy: true, headSlot: 9}
+
+	// without a lighthouse node the participation is left empty
+	mc := &MultiNodeClient{nodes: []*beaconNode{teku, prysm}}
+	participation, err := mc.getEpochParticipation(5)
+	if err != nil {
+		t.Fatalf("expected no error without a lighthouse node, got %v", err)
+	}
+	if participation.Epoch != 5 || participation.GlobalParticipationRate != 0 || participation.VotedEther != 0 || participation.EligibleEther != 0 {
+		t.Errorf("expected an empty participation of epoch 5, got %+v", participation)
+	}
+
+	// only the lighthouse node is queried and its errors are returned
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	client, err := NewLighthouseClient(server.URL, big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lighthouse := &beaconNode{endpoint: server.URL, nodeType: "lighthouse", client: client, mux: &sync.RWMutex{}, healthy: true, headSlot: 8}
+
+	mc = &MultiNodeClient{nodes: []*beaconNode{teku, lighthouse, prysm}}
+	_, err = mc.getEpochParticipation(5)
+	if err == nil {
+		t.Errorf("expected the error of the lighthouse node")
+	}
+	if requests == 0 {
+		t.Errorf("expected the lighthouse node to be queried")
+	}
+}
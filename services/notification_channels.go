@@ -0,0 +1,643 @@
+package services
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"eth2-exporter/db"
+	"eth2-exporter/metrics"
+	"eth2-exporter/types"
+	"eth2-exporter/utils"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const (
+	// maximum number of notifications that are bundled into a single chat message
+	maxChatMessagesPerRequest = 10
+	// maximum length of the markdown of a bundled chat message, telegram rejects messages longer than 4096 characters
+	maxChatMarkdownLength = 3000
+	// maximum time to wait if a chat service asks to slow down
+	maxChatRetryAfter = time.Minute
+)
+
+var markdownLinkRegex = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+
+// renderMarkdown renders the markdown of a notification for a chat service, text and links are rendered separately as the services use different syntax for links
+func renderMarkdown(markdown string, text func(string) string, link func(text, url string) string) string {
+	var sb strings.Builder
+	last := 0
+	for _, match := range markdownLinkRegex.FindAllStringSubmatchIndex(markdown, -1) {
+		sb.WriteString(text(markdown[last:match[0]]))
+		sb.WriteString(link(markdown[match[2]:match[3]], markdown[match[4]:match[5]]))
+		last = match[1]
+	}
+	sb.WriteString(text(markdown[last:]))
+	return sb.String()
+}
+
+// renderTelegramMessage renders the messages using the html parse mode of telegram
+func renderTelegramMessage(messages []types.ChatMessage) string {
+	parts := make([]string, 0, len(messages))
+	for _, m := range messages {
+		body := renderMarkdown(m.Markdown, html.EscapeString, func(text, url string) string {
+			return fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(url), html.EscapeString(text))
+		})
+		parts = append(parts, fmt.Sprintf("<b>%s</b>\n%s", html.EscapeString(m.Title), body))
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// renderSlackMessage renders the messages using the mrkdwn format of slack
+func renderSlackMessage(messages []types.ChatMessage) string {
+	escape := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace
+	parts := make([]string, 0, len(messages))
+	for _, m := range messages {
+		body := renderMarkdown(m.Markdown, escape, func(text, url string) string {
+			return fmt.Sprintf("<%s|%s>", url, escape(text))
+		})
+		parts = append(parts, fmt.Sprintf("*%s*\n%s", escape(m.Title), body))
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// renderMatrixMessage renders the messages as plain text body and as formatted html body of a matrix room message
+func renderMatrixMessage(messages []types.ChatMessage) (string, string) {
+	plain := make([]string, 0, len(messages))
+	formatted := make([]string, 0, len(messages))
+	for _, m := range messages {
+		plain = append(plain, fmt.Sprintf("%s\n%s", m.Title, renderMarkdown(m.Markdown, func(text string) string {
+			return text
+		}, func(text, url string) string {
+			return text
+		})))
+		body := renderMarkdown(m.Markdown, func(text string) string {
+			return strings.ReplaceAll(html.EscapeString(text), "\n", "<br>")
+		}, func(text, url string) string {
+			return fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(url), html.EscapeString(text))
+		})
+		formatted = append(formatted, fmt.Sprintf("<b>%s</b><br>%s", html.EscapeString(m.Title), body))
+	}
+	return strings.Join(plain, "\n\n"), strings.Join(formatted, "<br><br>")
+}
+
+// renderEmailMessage renders the messages as html body of an email, every message is a section with its title as heading like the events of a notification email
+func renderEmailMessage(messages []types.ChatMessage) string {
+	parts := make([]string, 0, len(messages))
+	for _, m := range messages {
+		body := renderMarkdown(m.Markdown, func(text string) string {
+			return strings.ReplaceAll(html.EscapeString(text), "\n", "<br>")
+		}, func(text, url string) string {
+			return fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(url), html.EscapeString(text))
+		})
+		parts = append(parts, fmt.Sprintf("%s<br>====<br><br>%s<br>", html.EscapeString(m.Title), body))
+	}
+	return strings.Join(parts, "<br>")
+}
+
+// renderPlainMessage renders the markdown of a message as plain text, links are replaced by their text
+func renderPlainMessage(message types.ChatMessage) string {
+	return renderMarkdown(message.Markdown, func(text string) string {
+		return text
+	}, func(text, url string) string {
+		return text
+	})
+}
+
+// renderNtfyMessage renders the title and the markdown body of a ntfy message
+func renderNtfyMessage(messages []types.ChatMessage) (string, string) {
+	if len(messages) == 1 {
+		return messages[0].Title, messages[0].Markdown
+	}
+	parts := make([]string, 0, len(messages))
+	for _, m := range messages {
+		parts = append(parts, fmt.Sprintf("**%s**\n%s", m.Title, m.Markdown))
+	}
+	return fmt.Sprintf("%v new notifications", len(messages)), strings.Join(parts, "\n\n")
+}
+
+// appendChatMessage adds the message to the last bundle or starts a new bundle if the last one is full
+func appendChatMessage(bundles []types.TransitChatContent, content types.TransitChatContent, message types.ChatMessage) []types.TransitChatContent {
+	if len(bundles) > 0 {
+		last := &bundles[len(bundles)-1]
+		length := len(message.Markdown)
+		for _, m := range last.Messages {
+			length += len(m.Markdown)
+		}
+		if len(last.Messages) < maxChatMessagesPerRequest && length <= maxChatMarkdownLength {
+			last.Messages = append(last.Messages, message)
+			return bundles
+		}
+	}
+	content.Messages = []types.ChatMessage{message}
+	return append(bundles, content)
+}
+
+func isWebhookChatDestination(destination string) bool {
+	for _, ch := range types.WebhookChatNotificationChannels {
+		if string(ch) == destination {
+			return true
+		}
+	}
+	return false
+}
+
+// getRetryAfter returns how long the chat service asked us to wait before sending the next request
+func getRetryAfter(resp *http.Response, body []byte) time.Duration {
+	retryAfter := time.Duration(0)
+	if seconds, err := strconv.ParseFloat(resp.Header.Get("Retry-After"), 64); err == nil {
+		retryAfter = time.Duration(seconds * float64(time.Second))
+	} else {
+		// matrix and telegram return the time to wait in the response body
+		var errResp struct {
+			RetryAfterMs int64 `json:"retry_after_ms"`
+			Parameters   struct {
+				RetryAfter int64 `json:"retry_after"`
+			} `json:"parameters"`
+		}
+		if json.Unmarshal(body, &errResp) == nil {
+			if errResp.RetryAfterMs > 0 {
+				retryAfter = time.Duration(errResp.RetryAfterMs) * time.Millisecond
+			} else {
+				retryAfter = time.Duration(errResp.Parameters.RetryAfter) * time.Second
+			}
+		}
+	}
+	if retryAfter > maxChatRetryAfter {
+		retryAfter = maxChatRetryAfter
+	}
+	return retryAfter
+}
+
+func queueTelegramNotifications(notificationsByUserID map[uint64]map[types.EventName][]types.Notification, useDB *sqlx.DB) error {
+	if utils.Config.Notifications.TelegramBotToken == "" {
+		return nil
+	}
+
+	userIDs := make([]int64, 0, len(notificationsByUserID))
+	for userID := range notificationsByUserID {
+		userIDs = append(userIDs, int64(userID))
+	}
+
+	var chats []struct {
+		UserID uint64 `db:"user_id"`
+		ChatID int64  `db:"chat_id"`
+	}
+	err := useDB.Select(&chats, `
+		SELECT
+			user_id,
+			chat_id
+		FROM
+			users_telegram
+		WHERE
+			user_id = ANY($1) AND chat_id IS NOT NULL AND user_id NOT IN (SELECT user_id from users_notification_channels WHERE active = false and channel = $2)
+	`, pq.Int64Array(userIDs), types.TelegramNotificationChannel)
+	if err != nil {
+		return fmt.Errorf("error querying users_telegram, err: %w", err)
+	}
+
+	for _, chat := range chats {
+		bundles := make([]types.TransitChatContent, 0)
+		for _, notifications := range notificationsByUserID[chat.UserID] {
+			for _, n := range notifications {
+				bundles = appendChatMessage(bundles, types.TransitChatContent{UserID: chat.UserID, ChatID: chat.ChatID}, types.ChatMessage{
+					Title:    n.GetTitle(),
+					Markdown: n.GetInfoMarkdown(),
+				})
+			}
+		}
+
+		for _, content := range bundles {
+			_, err = useDB.Exec(`INSERT INTO notification_queue (created, channel, content) VALUES (now(), $1, $2);`, types.TelegramNotificationChannel, content)
+			if err != nil {
+				logger.WithError(err).Errorf("error inserting into notification_queue (telegram)")
+				continue
+			}
+			metrics.NotificationsQueued.WithLabelValues(string(types.TelegramNotificationChannel), "multi").Inc()
+		}
+	}
+	return nil
+}
+
+func telegramApiUrl(method string) string {
+	return fmt.Sprintf("https://api.telegram.org/bot%s/%s", utils.Config.Notifications.TelegramBotToken, method)
+}
+
+// redactTelegramError removes the bot token from the request url contained in errors of the http client
+func redactTelegramError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) && utils.Config.Notifications.TelegramBotToken != "" {
+		return &url.Error{Op: urlErr.Op, URL: strings.ReplaceAll(urlErr.URL, utils.Config.Notifications.TelegramBotToken, "<token>"), Err: urlErr.Err}
+	}
+	return err
+}
+
+// telegramBotID returns the id of the bot, which is the part of the bot token before the colon
+func telegramBotID() (int64, error) {
+	id, _, _ := strings.Cut(utils.Config.Notifications.TelegramBotToken, ":")
+	return strconv.ParseInt(id, 10, 64)
+}
+
+// markChatNotificationsSent marks the notifications as sent before they are handed to the sending goroutines,
+// otherwise the next dispatch run would select and send them again while they are still being sent
+func markChatNotificationsSent(reqs []types.TransitChat) error {
+	ids := make([]uint64, 0, len(reqs))
+	for _, req := range reqs {
+		ids = append(ids, req.Id)
+	}
+	_, err := db.FrontendWriterDB.Exec(`UPDATE notification_queue SET sent = now() where id = ANY($1)`, pq.Array(ids))
+	return err
+}
+
+func sendTelegramNotifications(useDB *sqlx.DB) error {
+	if utils.Config.Notifications.TelegramBotToken == "" {
+		return nil
+	}
+
+	var notificationQueueItem []types.TransitChat
+
+	err := useDB.Select(&notificationQueueItem, `SELECT
+		id,
+		created,
+		sent,
+		channel,
+		content
+	FROM notification_queue WHERE sent IS null AND channel = $1 ORDER BY created ASC`, types.TelegramNotificationChannel)
+	if err != nil {
+		return fmt.Errorf("error querying notification queue, err: %w", err)
+	}
+	client := &http.Client{Timeout: time.Second * 30}
+
+	logger.Infof("processing %v telegram notifications", len(notificationQueueItem))
+
+	// telegram allows one message per second to the same chat, the messages of each chat are therefore sent sequentially
+	notifMap := make(map[int64][]types.TransitChat)
+	for _, n := range notificationQueueItem {
+		notifMap[n.Content.ChatID] = append(notifMap[n.Content.ChatID], n)
+	}
+
+	for chatID, reqs := range notifMap {
+		err := markChatNotificationsSent(reqs)
+		if err != nil {
+			logger.Warnf("failed to update sent for notifcations in queue: %v", err)
+			continue
+		}
+		go func(chatID int64, reqs []types.TransitChat) {
+			retries := 0
+			for i := 0; i < len(reqs); i++ {
+				if retries > 5 {
+					logger.Warnf("giving up sending telegram notifications to chat %v after %v retries", chatID, retries)
+					break // stop
+				}
+				// sleep between messages to stay within the rate limit of the chat
+				time.Sleep(time.Second * time.Duration(retries+1))
+
+				reqBody := new(bytes.Buffer)
+				err := json.NewEncoder(reqBody).Encode(map[string]interface{}{
+					"chat_id":                  chatID,
+					"text":                     renderTelegramMessage(reqs[i].Content.Messages),
+					"parse_mode":               "HTML",
+					"disable_web_page_preview": true,
+				})
+				if err != nil {
+					logger.Errorf("error marschalling telegram message: %v", err)
+					continue // skip
+				}
+
+				resp, err := client.Post(telegramApiUrl("sendMessage"), "application/json", reqBody)
+				if err != nil {
+					logger.Errorf("error sending telegram message: %v", redactTelegramError(err))
+					retries++
+					i-- // retry
+					continue
+				}
+				body, err := io.ReadAll(resp.Body)
+				resp.Body.Close()
+				if err != nil {
+					logger.Errorf("error reading body for telegram response: %v", err)
+				}
+				metrics.NotificationsSent.WithLabelValues(string(types.TelegramNotificationChannel), resp.Status).Inc()
+
+				switch {
+				case resp.StatusCode < 400:
+					retries = 0
+				case resp.StatusCode == http.StatusForbidden:
+					// the user blocked the bot or left the chat, the chat will not receive any messages anymore
+					logger.Infof("telegram chat %v is not reachable anymore, unlinking it: %s", chatID, body)
+					err := db.UnlinkTelegramChat(chatID)
+					if err != nil {
+						logger.Errorf("error unlinking telegram chat %v: %v", chatID, err)
+					}
+					return
+				case resp.StatusCode == http.StatusTooManyRequests:
+					retryAfter := getRetryAfter(resp, body)
+					logger.Warnf("could not send telegram message due to rate limit, retrying in %v", retryAfter)
+					time.Sleep(retryAfter)
+					retries++
+					i-- // retry
+				case resp.StatusCode >= 500:
+					retries++
+					i-- // retry
+				default:
+					// the request has been rejected, sending it again will not help
+					utils.LogError(nil, "error sending telegram message", 0, map[string]interface{}{"status": resp.Status, "body": string(body), "chatID": chatID})
+				}
+			}
+		}(chatID, reqs)
+	}
+
+	return nil
+}
+
+// newWebhookChatRequest creates the request for a bundle of messages sent to a slack, matrix or ntfy webhook
+func newWebhookChatRequest(channel string, n types.TransitChat) (*http.Request, error) {
+	webhookUrl, err := url.Parse(n.Content.Webhook.Url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url for webhook id %v: %w", n.Content.Webhook.ID, err)
+	}
+
+	switch types.NotificationChannel(channel) {
+	case types.WebhookSlackNotificationChannel:
+		reqBody := new(bytes.Buffer)
+		err := json.NewEncoder(reqBody).Encode(map[string]interface{}{
+			"text":   renderSlackMessage(n.Content.Messages),
+			"mrkdwn": true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		req, err := http.NewRequest(http.MethodPost, webhookUrl.String(), reqBody)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	case types.WebhookMatrixNotificationChannel:
+		// the webhook url is the send endpoint of the room, the queue id is used as transaction id so that retries do not post the message twice
+		// https://<homeserver>/_matrix/client/v3/rooms/<roomId>/send/m.room.message?access_token=<token>
+		plain, formatted := renderMatrixMessage(n.Content.Messages)
+		reqBody := new(bytes.Buffer)
+		err := json.NewEncoder(reqBody).Encode(map[string]interface{}{
+			"msgtype":        "m.notice",
+			"body":           plain,
+			"format":         "org.matrix.custom.html",
+			"formatted_body": formatted,
+		})
+		if err != nil {
+			return nil, err
+		}
+		query := webhookUrl.Query()
+		accessToken := query.Get("access_token")
+		query.Del("access_token")
+		webhookUrl.RawQuery = query.Encode()
+		webhookUrl.Path = strings.TrimSuffix(webhookUrl.Path, "/") + fmt.Sprintf("/notification-%d", n.Id)
+		webhookUrl.RawPath = ""
+
+		req, err := http.NewRequest(http.MethodPut, webhookUrl.String(), reqBody)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if accessToken != "" {
+			req.Header.Set("Authorization", "Bearer "+accessToken)
+		}
+		return req, nil
+	case types.WebhookNtfyNotificationChannel:
+		title, body := renderNtfyMessage(n.Content.Messages)
+		req, err := http.NewRequest(http.MethodPost, webhookUrl.String(), strings.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Title", title)
+		req.Header.Set("Markdown", "yes")
+		return req, nil
+	default:
+		return nil, fmt.Errorf("unknown chat webhook channel %v", channel)
+	}
+}
+
+// sendWebhookChatNotifications sends the queued notifications of a slack, matrix or ntfy webhook channel,
+// retries and rate limits are handled per webhook the same way as for discord webhooks
+func sendWebhookChatNotifications(useDB *sqlx.DB, channel types.NotificationChannel) error {
+	var notificationQueueItem []types.TransitChat
+
+	err := useDB.Select(&notificationQueueItem, `SELECT
+		id,
+		created,
+		sent,
+		channel,
+		content
+	FROM notification_queue WHERE sent IS null AND channel = $1 ORDER BY created ASC`, channel)
+	if err != nil {
+		return fmt.Errorf("error querying notification queue, err: %w", err)
+	}
+	client := &http.Client{Timeout: time.Second * 30}
+
+	logger.Infof("processing %v %v notifications", len(notificationQueueItem), channel)
+	webhookMap := make(map[uint64]types.UserWebhook)
+
+	notifMap := make(map[uint64][]types.TransitChat)
+	for _, n := range notificationQueueItem {
+		// purge the event from existence if the retry counter is over 5
+		if n.Content.Webhook.Retries > 5 {
+			db.FrontendWriterDB.Exec(`DELETE FROM notification_queue where id = $1`, n.Id)
+			continue
+		}
+		if _, exists := webhookMap[n.Content.Webhook.ID]; !exists {
+			webhookMap[n.Content.Webhook.ID] = n.Content.Webhook
+		}
+		notifMap[n.Content.Webhook.ID] = append(notifMap[n.Content.Webhook.ID], n)
+	}
+	for _, webhook := range webhookMap {
+		err := markChatNotificationsSent(notifMap[webhook.ID])
+		if err != nil {
+			logger.Warnf("failed to update sent for notifcations in queue: %v", err)
+			continue
+		}
+		go func(webhook types.UserWebhook, reqs []types.TransitChat) {
+			defer func() {
+				// update retries counters in db based on end result
+				_, err := useDB.Exec(`UPDATE users_webhooks SET retries = $1, last_sent = now() WHERE id = $2;`, webhook.Retries, webhook.ID)
+				if err != nil {
+					logger.Warnf("failed to update retries counter to %v for webhook %v: %v", webhook.Retries, webhook.ID, err)
+				}
+			}()
+
+			for i := 0; i < len(reqs); i++ {
+				if webhook.Retries > 5 {
+					break // stop
+				}
+				// sleep between retries
+				time.Sleep(time.Duration(webhook.Retries) * time.Second)
+
+				req, err := newWebhookChatRequest(string(channel), reqs[i])
+				if err != nil {
+					logger.Errorf("error creating %v webhook request: %v", channel, err)
+					continue // skip
+				}
+
+				resp, err := client.Do(req)
+				if err != nil {
+					logger.Errorf("error sending %v webhook request: %v", channel, err)
+				} else {
+					metrics.NotificationsSent.WithLabelValues(string(channel), resp.Status).Inc()
+				}
+				if resp != nil && resp.StatusCode < 400 {
+					resp.Body.Close()
+					webhook.Retries = 0
+				} else {
+					webhook.Retries++
+					var errResp types.ErrorResponse
+
+					if resp != nil {
+						b, err := io.ReadAll(resp.Body)
+						resp.Body.Close()
+						if err != nil {
+							logger.Errorf("error reading body for %v webhook response: %v", channel, err)
+						} else {
+							errResp.Body = string(b)
+						}
+						errResp.Status = resp.Status
+
+						if resp.StatusCode == http.StatusTooManyRequests {
+							retryAfter := getRetryAfter(resp, b)
+							logger.Warnf("could not push to %v webhook due to rate limit, retrying in %v. url: %v", channel, retryAfter, webhook.Url)
+							time.Sleep(retryAfter)
+						}
+					}
+
+					if resp == nil || resp.StatusCode != http.StatusTooManyRequests {
+						utils.LogError(nil, fmt.Sprintf("error pushing %v webhook", channel), 0, map[string]interface{}{"errResp.Body": errResp.Body, "webhook.Url": webhook.Url})
+					}
+					_, err = useDB.Exec(`UPDATE users_webhooks SET request = $2, response = $3 WHERE id = $1;`, webhook.ID, reqs[i].Content, errResp)
+					if err != nil {
+						logger.Errorf("error storing failure data in users_webhooks table: %v", err)
+					}
+
+					i-- // retry, IMPORTANT to be at the END of the ELSE, otherwise the wrong index will be used in the commands above!
+				}
+			}
+		}(webhook, notifMap[webhook.ID])
+	}
+
+	return nil
+}
+
+// processTelegramUpdates links telegram chats to user accounts. The link in the user settings starts a chat with the bot and sends
+// "/start <link token>", "/stop" unlinks the chat again
+func processTelegramUpdates() error {
+	if utils.Config.Notifications.TelegramBotToken == "" {
+		return nil
+	}
+
+	// the offset is stored in the db so that updates are not processed again after a restart
+	botID, err := telegramBotID()
+	if err != nil {
+		return fmt.Errorf("error parsing the bot id of the telegram bot token")
+	}
+	offset, err := db.GetTelegramUpdateOffset(botID)
+	if err != nil {
+		return fmt.Errorf("error getting telegram update offset: %w", err)
+	}
+
+	client := &http.Client{Timeout: time.Second * 30}
+	resp, err := client.Get(telegramApiUrl("getUpdates") + fmt.Sprintf("?offset=%d&allowed_updates=%s", offset, url.QueryEscape(`["message"]`)))
+	if err != nil {
+		return fmt.Errorf("error getting telegram updates: %w", redactTelegramError(err))
+	}
+	defer resp.Body.Close()
+
+	var updates struct {
+		Ok          bool   `json:"ok"`
+		Description string `json:"description"`
+		Result      []struct {
+			UpdateID int64 `json:"update_id"`
+			Message  *struct {
+				Text string `json:"text"`
+				Chat struct {
+					ID       int64  `json:"id"`
+					Type     string `json:"type"`
+					Username string `json:"username"`
+				} `json:"chat"`
+			} `json:"message"`
+		} `json:"result"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&updates)
+	if err != nil {
+		return fmt.Errorf("error decoding telegram updates: %w", err)
+	}
+	if !updates.Ok {
+		return fmt.Errorf("error getting telegram updates: %v", updates.Description)
+	}
+
+	for _, update := range updates.Result {
+		// the offset is moved before the update is processed, an update that fails will not be processed again
+		err = db.SetTelegramUpdateOffset(botID, update.UpdateID+1)
+		if err != nil {
+			return fmt.Errorf("error setting telegram update offset: %w", err)
+		}
+		if update.Message == nil {
+			continue
+		}
+		chatID := update.Message.Chat.ID
+		command := strings.Fields(update.Message.Text)
+		if len(command) == 0 {
+			continue
+		}
+
+		reply := ""
+		switch command[0] {
+		case "/start":
+			if len(command) < 2 {
+				reply = fmt.Sprintf("Use the link in your account settings on https://%v/user/settings to receive your notifications in this chat.", utils.Config.Frontend.SiteDomain)
+				break
+			}
+			userID, err := db.LinkUserTelegram(command[1], chatID, update.Message.Chat.Username)
+			if err == sql.ErrNoRows {
+				reply = "The link has expired, please create a new one in your account settings."
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("error linking telegram chat %v: %w", chatID, err)
+			}
+			logger.Infof("linked telegram chat %v to user %v", chatID, userID)
+			reply = fmt.Sprintf("Your %v account has been linked, you will receive your notifications in this chat. Send /stop to unlink it.", utils.Config.Frontend.SiteDomain)
+		case "/stop":
+			err := db.UnlinkTelegramChat(chatID)
+			if err != nil {
+				return fmt.Errorf("error unlinking telegram chat %v: %w", chatID, err)
+			}
+			reply = "This chat has been unlinked, you will not receive any notifications anymore."
+		default:
+			continue
+		}
+
+		reqBody := new(bytes.Buffer)
+		err := json.NewEncoder(reqBody).Encode(map[string]interface{}{
+			"chat_id": chatID,
+			"text":    reply,
+		})
+		if err != nil {
+			return fmt.Errorf("error marschalling telegram reply: %w", err)
+		}
+		replyResp, err := client.Post(telegramApiUrl("sendMessage"), "application/json", reqBody)
+		if err != nil {
+			logger.Warnf("error replying to telegram chat %v: %v", chatID, redactTelegramError(err))
+			continue
+		}
+		replyResp.Body.Close()
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"eth2-exporter/types"
+	"eth2-exporter/utils"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRenderChatMessages(t *testing.T) {
+	messages := []types.ChatMessage{
+		{
+			Title:    "Attestation Missed",
+			Markdown: "Validator [1](https://beaconcha.in/validator/1) missed an attestation at epoch [2](https://beaconcha.in/epoch/2) & <3>.",
+		},
+	}
+
+	telegram := renderTelegramMessage(messages)
+	expected := "<b>Attestation Missed</b>\nValidator <a href=\"https://beaconcha.in/validator/1\">1</a> missed an attestation at epoch <a href=\"https://beaconcha.in/epoch/2\">2</a> &amp; &lt;3&gt;."
+	if telegram != expected {
+		t.Errorf("expected telegram message %q, got %q", expected, telegram)
+	}
+
+	slack := renderSlackMessage(messages)
+	expected = "*Attestation Missed*\nValidator <https://beaconcha.in/validator/1|1> missed an attestation at epoch <https://beaconcha.in/epoch/2|2> &amp; &lt;3&gt;."
+	if slack != expected {
+		t.Errorf("expected slack message %q, got %q", expected, slack)
+	}
+
+	plain, _ := renderMatrixMessage(messages)
+	expected = "Attestation Missed\nValidator 1 missed an attestation at epoch 2 & <3>."
+	if plain != expected {
+		t.Errorf("expected matrix body %q, got %q", expected, plain)
+	}
+
+	email := renderEmailMessage(messages)
+	expected = "Attestation Missed<br>====<br><br>Validator <a href=\"https://beaconcha.in/validator/1\">1</a> missed an attestation at epoch <a href=\"https://beaconcha.in/epoch/2\">2</a> &amp; &lt;3&gt;.<br>"
+	if email != expected {
+		t.Errorf("expected email body %q, got %q", expected, email)
+	}
+
+	if text := renderPlainMessage(messages[0]); text != "Validator 1 missed an attestation at epoch 2 & <3>." {
+		t.Errorf("unexpected plain message %q", text)
+	}
+
+	title, body := renderNtfyMessage(append(messages, messages...))
+	if title != "2 new notifications" {
+		t.Errorf("unexpected ntfy title %q", title)
+	}
+	expected = "**Attestation Missed**\n" + messages[0].Markdown + "\n\n**Attestation Missed**\n" + messages[0].Markdown
+	if body != expected {
+		t.Errorf("expected ntfy body %q, got %q", expected, body)
+	}
+}
+
+func TestAppendChatMessage(t *testing.T) {
+	bundles := []types.TransitChatContent{}
+	for i := 0; i < maxChatMessagesPerRequest+1; i++ {
+		bundles = appendChatMessage(bundles, types.TransitChatContent{ChatID: 1}, types.ChatMessage{Title: "title", Markdown: "markdown"})
+	}
+	if len(bundles) != 2 || len(bundles[0].Messages) != maxChatMessagesPerRequest || len(bundles[1].Messages) != 1 {
+		t.Errorf("expected the messages to be split into bundles of %v, got %v bundles", maxChatMessagesPerRequest, len(bundles))
+	}
+	if bundles[1].ChatID != 1 {
+		t.Errorf("expected the bundle to keep the chat id")
+	}
+}
+
+type failingRoundTripper struct{}
+
+func (failingRoundTripper) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, fmt.Errorf("connection refused")
+}
+
+func TestRedactTelegramError(t *testing.T) {
+	utils.Config = &types.Config{}
+	utils.Config.Notifications.TelegramBotToken = "123456:secret-token"
+
+	client := &http.Client{Transport: failingRoundTripper{}}
+	_, err := client.Get(telegramApiUrl("getUpdates"))
+	if err == nil || !strings.Contains(err.Error(), "secret-token") {
+		t.Fatalf("expected an error containing the request url, got %v", err)
+	}
+	if redacted := redactTelegramError(err).Error(); strings.Contains(redacted, "secret-token") {
+		t.Errorf("expected the bot token to be redacted, got %v", redacted)
+	}
+
+	botID, err := telegramBotID()
+	if err != nil || botID != 123456 {
+		t.Errorf("expected bot id 123456, got %v %v", botID, err)
+	}
+}
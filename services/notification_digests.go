@@ -0,0 +1,580 @@
+package services
+
+import (
+	"encoding/hex"
+	"eth2-exporter/db"
+	"eth2-exporter/metrics"
+	"eth2-exporter/types"
+	"eth2-exporter/utils"
+	"fmt"
+	"html/template"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"firebase.google.com/go/messaging"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const (
+	// maximum number of validators of an event that are linked individually in a digest
+	maxDigestValidatorLinks = 10
+	// maximum number of validators of the dashboard link of an event in a digest
+	maxDigestDashboardValidators = 100
+	// maximum number of messages that are shown for an event that does not belong to validators
+	maxDigestEventMessages = 3
+)
+
+// notificationDigestPeriods maps the digest delivery modes to the period of postgres' date_trunc,
+// the periods start at the full hour, at midnight and on monday
+var notificationDigestPeriods = map[types.NotificationDeliveryMode]string{
+	types.NotificationDeliveryHourly: "hour",
+	types.NotificationDeliveryDaily:  "day",
+	types.NotificationDeliveryWeekly: "week",
+}
+
+// getDeliveryMode returns how the notifications of the event are delivered over the channel, the mode of the event takes precedence over the default of the channel
+func getDeliveryMode(modes []types.UserNotificationDeliveryMode, channel types.NotificationChannel, eventName types.EventName) types.NotificationDeliveryMode {
+	mode := types.NotificationDeliveryImmediate
+	for _, m := range modes {
+		if m.Channel != channel {
+			continue
+		}
+		if m.EventName == string(eventName) {
+			return m.Mode
+		}
+		if m.EventName == "" {
+			mode = m.Mode
+		}
+	}
+	return mode
+}
+
+// deferDigestNotifications saves the notifications that are delivered in a digest over the channel for the next digest of the user
+// and returns the notifications that are delivered immediately. Notifications that could not be saved are delivered immediately
+func deferDigestNotifications(notificationsByUserID map[uint64]map[types.EventName][]types.Notification, channel types.NotificationChannel, modesByUserID map[uint64][]types.UserNotificationDeliveryMode, useDB *sqlx.DB) map[uint64]map[types.EventName][]types.Notification {
+	immediateNotificationsByUserID := make(map[uint64]map[types.EventName][]types.Notification, len(notificationsByUserID))
+	for userID, userNotifications := range notificationsByUserID {
+		immediateNotifications := make(map[types.EventName][]types.Notification, len(userNotifications))
+		entries := make([]types.NotificationDigestEntry, 0)
+		digestEvents := make([]types.EventName, 0)
+		for event, notifications := range userNotifications {
+			mode := getDeliveryMode(modesByUserID[userID], channel, event)
+			if _, isDigest := notificationDigestPeriods[mode]; !isDigest {
+				immediateNotifications[event] = notifications
+				continue
+			}
+			for _, n := range notifications {
+				entries = append(entries, newNotificationDigestEntry(n, mode))
+			}
+			digestEvents = append(digestEvents, event)
+		}
+
+		if len(entries) > 0 {
+			err := saveNotificationDigestEntries(userID, channel, entries, useDB)
+			if err != nil {
+				logger.WithError(err).Errorf("error saving %v notifications of user %v for the next %v digest, delivering them immediately", len(entries), userID, channel)
+				for _, event := range digestEvents {
+					immediateNotifications[event] = userNotifications[event]
+				}
+			} else {
+				for _, event := range digestEvents {
+					metrics.NotificationsQueued.WithLabelValues(string(channel)+"_digest", string(event)).Add(float64(len(userNotifications[event])))
+				}
+			}
+		}
+
+		if len(immediateNotifications) > 0 {
+			immediateNotificationsByUserID[userID] = immediateNotifications
+		}
+	}
+	return immediateNotificationsByUserID
+}
+
+// newNotificationDigestEntry creates the digest entry of a notification, the validator is resolved from the pubkey of validator events
+func newNotificationDigestEntry(n types.Notification, mode types.NotificationDeliveryMode) types.NotificationDigestEntry {
+	entry := types.NotificationDigestEntry{
+		Mode:        mode,
+		EventName:   n.GetEventName(),
+		Epoch:       n.GetEpoch(),
+		EventFilter: n.GetEventFilter(),
+		Title:       n.GetTitle(),
+		Info:        n.GetInfoMarkdown(),
+	}
+	if len(entry.EventFilter) == 96 {
+		pubkey, err := hex.DecodeString(entry.EventFilter)
+		if err == nil {
+			index, err := GetIndexForPubkey(pubkey)
+			if err == nil {
+				entry.ValidatorIndex.Int64 = int64(index)
+				entry.ValidatorIndex.Valid = true
+			}
+		}
+	}
+	return entry
+}
+
+func saveNotificationDigestEntries(userID uint64, channel types.NotificationChannel, entries []types.NotificationDigestEntry, useDB *sqlx.DB) error {
+	modes := make(pq.StringArray, 0, len(entries))
+	eventNames := make(pq.StringArray, 0, len(entries))
+	epochs := make(pq.Int64Array, 0, len(entries))
+	filters := make(pq.StringArray, 0, len(entries))
+	validators := make(pq.Int64Array, 0, len(entries))
+	titles := make(pq.StringArray, 0, len(entries))
+	infos := make(pq.StringArray, 0, len(entries))
+	for _, e := range entries {
+		modes = append(modes, string(e.Mode))
+		eventNames = append(eventNames, string(e.EventName))
+		epochs = append(epochs, int64(e.Epoch))
+		filters = append(filters, e.EventFilter)
+		// -1 is stored as NULL, arrays of pq can not hold NULL values
+		validator := int64(-1)
+		if e.ValidatorIndex.Valid {
+			validator = e.ValidatorIndex.Int64
+		}
+		validators = append(validators, validator)
+		titles = append(titles, e.Title)
+		infos = append(infos, e.Info)
+	}
+
+	_, err := useDB.Exec(`
+		INSERT INTO notification_digest_entries (user_id, network, channel, mode, event_name, epoch, event_filter, validator_index, title, info)
+		SELECT $1, $2, $3, e.mode, e.event_name, e.epoch, e.event_filter, NULLIF(e.validator_index, -1), e.title, e.info
+		FROM UNNEST($4::TEXT[], $5::TEXT[], $6::INT[], $7::TEXT[], $8::INT[], $9::TEXT[], $10::TEXT[]) AS e(mode, event_name, epoch, event_filter, validator_index, title, info)`,
+		userID, utils.GetNetwork(), channel, modes, eventNames, epochs, filters, validators, titles, infos)
+	return err
+}
+
+// queueNotificationDigests queues one digest per user and channel for every digest period that has ended
+func queueNotificationDigests(useDB *sqlx.DB) error {
+	for mode, period := range notificationDigestPeriods {
+		tx, err := useDB.Beginx()
+		if err != nil {
+			return fmt.Errorf("error starting transaction: %w", err)
+		}
+
+		var entries []types.NotificationDigestEntry
+		err = tx.Select(&entries, `
+			SELECT id, user_id, channel, mode, event_name, epoch, event_filter, validator_index, title, info, created
+			FROM notification_digest_entries
+			WHERE network = $1 AND mode = $2 AND created < date_trunc($3, now())
+			ORDER BY id`,
+			utils.GetNetwork(), mode, period)
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("error getting %v notification digest entries: %w", mode, err)
+		}
+		if len(entries) == 0 {
+			tx.Rollback()
+			continue
+		}
+
+		entriesByChannel := make(map[types.NotificationChannel]map[uint64][]types.NotificationDigestEntry)
+		for _, e := range entries {
+			if entriesByChannel[e.Channel] == nil {
+				entriesByChannel[e.Channel] = make(map[uint64][]types.NotificationDigestEntry)
+			}
+			entriesByChannel[e.Channel][e.UserID] = append(entriesByChannel[e.Channel][e.UserID], e)
+		}
+
+		// only the entries of queued digests are deleted, entries of unknown channels are kept until a version that can deliver them runs
+		ids := make(pq.Int64Array, 0, len(entries))
+		for channel, entriesByUserID := range entriesByChannel {
+			switch channel {
+			case types.EmailNotificationChannel:
+				err = queueEmailDigests(tx, mode, entriesByUserID)
+			case types.PushNotificationChannel:
+				err = queuePushDigests(tx, mode, entriesByUserID)
+			case types.TelegramNotificationChannel:
+				err = queueTelegramDigests(tx, mode, entriesByUserID)
+			case types.WebhookNotificationChannel:
+				err = queueWebhookDigests(tx, mode, entriesByUserID)
+			default:
+				logger.Warnf("skipping %v digests of channel %v that does not support digests", mode, channel)
+				continue
+			}
+			if err != nil {
+				tx.Rollback()
+				return fmt.Errorf("error queuing %v %v digests: %w", mode, channel, err)
+			}
+			for _, userEntries := range entriesByUserID {
+				for _, e := range userEntries {
+					ids = append(ids, int64(e.ID))
+				}
+			}
+		}
+
+		_, err = tx.Exec(`DELETE FROM notification_digest_entries WHERE id = ANY($1)`, ids)
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("error deleting %v notification digest entries: %w", mode, err)
+		}
+
+		err = tx.Commit()
+		if err != nil {
+			return fmt.Errorf("error committing %v notification digests: %w", mode, err)
+		}
+		logger.Infof("queued %v digests of %v notifications", mode, len(ids))
+	}
+	return nil
+}
+
+func queueEmailDigests(tx *sqlx.Tx, mode types.NotificationDeliveryMode, entriesByUserID map[uint64][]types.NotificationDigestEntry) error {
+	userIDs := make([]uint64, 0, len(entriesByUserID))
+	for userID := range entriesByUserID {
+		userIDs = append(userIDs, userID)
+	}
+	emailsByUserID, err := db.GetUserEmailsByIds(userIDs)
+	if err != nil {
+		return fmt.Errorf("could not get emails: %w", err)
+	}
+
+	for userID, entries := range entriesByUserID {
+		userEmail, exists := emailsByUserID[userID]
+		if !exists {
+			continue
+		}
+
+		var msg types.Email
+		if utils.Config.Chain.Name != "mainnet" {
+			msg.Body += template.HTML(fmt.Sprintf("<b>Notice: This email contains notifications for the %s network!</b><br>", utils.Config.Chain.Name))
+		}
+		msg.Body += template.HTML(renderEmailMessage(renderNotificationDigest(mode, entries)))
+		msg.SubscriptionManageURL = template.HTML(fmt.Sprintf(`<a href="%v" style="color: white" onMouseOver="this.style.color='#F5B498'" onMouseOut="this.style.color='#FFFFFF'">Manage</a>`, "https://"+utils.Config.Frontend.SiteDomain+"/user/notifications"))
+
+		transitEmailContent := types.TransitEmailContent{
+			Address: userEmail,
+			Subject: fmt.Sprintf("%s: %s", utils.Config.Frontend.SiteDomain, notificationDigestTitle(mode)),
+			Email:   msg,
+		}
+
+		_, err = tx.Exec(`INSERT INTO notification_queue (created, channel, content) VALUES ($1, 'email', $2)`, time.Now(), transitEmailContent)
+		if err != nil {
+			return fmt.Errorf("error writing transit email to db: %w", err)
+		}
+		metrics.NotificationsQueued.WithLabelValues("email", "digest").Inc()
+	}
+	return nil
+}
+
+func queuePushDigests(tx *sqlx.Tx, mode types.NotificationDeliveryMode, entriesByUserID map[uint64][]types.NotificationDigestEntry) error {
+	userIDs := make([]uint64, 0, len(entriesByUserID))
+	for userID := range entriesByUserID {
+		userIDs = append(userIDs, userID)
+	}
+	tokensByUserID, err := db.GetUserPushTokenByIds(userIDs)
+	if err != nil {
+		return fmt.Errorf("could not get tokens: %w", err)
+	}
+
+	for userID, entries := range entriesByUserID {
+		userTokens, exists := tokensByUserID[userID]
+		if !exists {
+			continue
+		}
+
+		summary := make([]string, 0)
+		for _, event := range buildNotificationDigest(entries) {
+			summary = append(summary, fmt.Sprintf("%v %v", event.Count, types.GetDisplayableEventName(event.EventName)))
+		}
+		body := fmt.Sprintf("You received %v notifications in the last %v: %v", len(entries), notificationDigestPeriods[mode], strings.Join(summary, ", "))
+
+		var batch []*messaging.Message
+		for _, userToken := range userTokens {
+			notification := new(messaging.Notification)
+			notification.Title = fmt.Sprintf("%s%s", getNetwork(), notificationDigestTitle(mode))
+			notification.Body = body
+
+			message := new(messaging.Message)
+			message.Notification = notification
+			message.Token = userToken
+
+			message.APNS = new(messaging.APNSConfig)
+			message.APNS.Payload = new(messaging.APNSPayload)
+			message.APNS.Payload.Aps = new(messaging.Aps)
+			message.APNS.Payload.Aps.Sound = "default"
+
+			batch = append(batch, message)
+		}
+
+		transitPushContent := types.TransitPushContent{
+			Messages: batch,
+		}
+
+		_, err = tx.Exec(`INSERT INTO notification_queue (created, channel, content) VALUES ($1, 'push', $2)`, time.Now(), transitPushContent)
+		if err != nil {
+			return fmt.Errorf("error writing transit push notification to db: %w", err)
+		}
+		metrics.NotificationsQueued.WithLabelValues("push", "digest").Inc()
+	}
+	return nil
+}
+
+func queueTelegramDigests(tx *sqlx.Tx, mode types.NotificationDeliveryMode, entriesByUserID map[uint64][]types.NotificationDigestEntry) error {
+	if utils.Config.Notifications.TelegramBotToken == "" {
+		return nil
+	}
+
+	userIDs := make([]int64, 0, len(entriesByUserID))
+	for userID := range entriesByUserID {
+		userIDs = append(userIDs, int64(userID))
+	}
+
+	var chats []struct {
+		UserID uint64 `db:"user_id"`
+		ChatID int64  `db:"chat_id"`
+	}
+	err := tx.Select(&chats, `
+		SELECT
+			user_id,
+			chat_id
+		FROM
+			users_telegram
+		WHERE
+			user_id = ANY($1) AND chat_id IS NOT NULL AND user_id NOT IN (SELECT user_id from users_notification_channels WHERE active = false and channel = $2)
+	`, pq.Int64Array(userIDs), types.TelegramNotificationChannel)
+	if err != nil {
+		return fmt.Errorf("error querying users_telegram, err: %w", err)
+	}
+
+	for _, chat := range chats {
+		bundles := make([]types.TransitChatContent, 0)
+		for _, message := range renderNotificationDigest(mode, entriesByUserID[chat.UserID]) {
+			bundles = appendChatMessage(bundles, types.TransitChatContent{UserID: chat.UserID, ChatID: chat.ChatID}, message)
+		}
+
+		for _, content := range bundles {
+			_, err = tx.Exec(`INSERT INTO notification_queue (created, channel, content) VALUES (now(), $1, $2);`, types.TelegramNotificationChannel, content)
+			if err != nil {
+				return fmt.Errorf("error inserting into notification_queue (telegram): %w", err)
+			}
+		}
+		metrics.NotificationsQueued.WithLabelValues(string(types.TelegramNotificationChannel), "digest").Inc()
+	}
+	return nil
+}
+
+// queueWebhookDigests queues the digest of every webhook of the users, a webhook only receives the entries of the events it is subscribed to.
+// Discord and chat webhooks receive the rendered digest, generic webhooks receive an event per notified event with the summary of the digest as description
+func queueWebhookDigests(tx *sqlx.Tx, mode types.NotificationDeliveryMode, entriesByUserID map[uint64][]types.NotificationDigestEntry) error {
+	userIDs := make([]int64, 0, len(entriesByUserID))
+	for userID := range entriesByUserID {
+		userIDs = append(userIDs, int64(userID))
+	}
+
+	var webhooks []types.UserWebhook
+	err := tx.Select(&webhooks, `
+		SELECT
+			id,
+			user_id,
+			url,
+			retries,
+			event_names,
+			destination
+		FROM
+			users_webhooks
+		WHERE
+			user_id = ANY($1) AND user_id NOT IN (SELECT user_id from users_notification_channels WHERE active = false and channel = $2)
+	`, pq.Int64Array(userIDs), types.WebhookNotificationChannel)
+	if err != nil {
+		return fmt.Errorf("error querying users_webhooks, err: %w", err)
+	}
+
+	for _, w := range webhooks {
+		subscribed := make(map[string]bool, len(w.EventNames))
+		for _, event := range w.EventNames {
+			subscribed[event] = true
+		}
+		entries := make([]types.NotificationDigestEntry, 0)
+		for _, e := range entriesByUserID[w.UserID] {
+			if subscribed[string(e.EventName)] {
+				entries = append(entries, e)
+			}
+		}
+		if len(entries) == 0 {
+			continue
+		}
+		messages := renderNotificationDigest(mode, entries)
+
+		destination := w.Destination.String
+		switch {
+		case destination == "webhook_discord":
+			var content types.TransitDiscordContent
+			for i, message := range messages {
+				if i%10 == 0 {
+					if i > 0 {
+						_, err = tx.Exec(`INSERT INTO notification_queue (created, channel, content) VALUES (now(), 'webhook_discord', $1);`, content)
+						if err != nil {
+							return fmt.Errorf("error inserting into notification_queue (discord): %w", err)
+						}
+					}
+					content = types.TransitDiscordContent{
+						Webhook: w,
+						DiscordRequest: types.DiscordReq{
+							Username: utils.Config.Frontend.SiteDomain,
+						},
+					}
+				}
+				content.DiscordRequest.Embeds = append(content.DiscordRequest.Embeds, types.DiscordEmbed{
+					Type:        "rich",
+					Color:       "16745472",
+					Description: message.Markdown,
+					Title:       message.Title,
+				})
+			}
+			_, err = tx.Exec(`INSERT INTO notification_queue (created, channel, content) VALUES (now(), 'webhook_discord', $1);`, content)
+			if err != nil {
+				return fmt.Errorf("error inserting into notification_queue (discord): %w", err)
+			}
+		case isWebhookChatDestination(destination):
+			bundles := make([]types.TransitChatContent, 0)
+			for _, message := range messages {
+				bundles = appendChatMessage(bundles, types.TransitChatContent{Webhook: w}, message)
+			}
+			for _, content := range bundles {
+				_, err = tx.Exec(`INSERT INTO notification_queue (created, channel, content) VALUES (now(), $1, $2);`, destination, content)
+				if err != nil {
+					return fmt.Errorf("error inserting into notification_queue (%v): %w", destination, err)
+				}
+			}
+		default:
+			epoch := uint64(0)
+			for _, e := range entries {
+				if e.Epoch > epoch {
+					epoch = e.Epoch
+				}
+			}
+			// the messages after the overview are rendered from the events of the digest in the same order
+			for i, event := range buildNotificationDigest(entries) {
+				content := types.TransitWebhookContent{
+					Webhook: w,
+					Event: types.WebhookEvent{
+						Network:     utils.GetNetwork(),
+						Name:        string(event.EventName),
+						Title:       fmt.Sprintf("%v: %v", notificationDigestTitle(mode), messages[i+1].Title),
+						Description: renderPlainMessage(messages[i+1]),
+						Epoch:       epoch,
+					},
+				}
+				_, err = tx.Exec(`INSERT INTO notification_queue (created, channel, content) VALUES (now(), $1, $2);`, destination, content)
+				if err != nil {
+					return fmt.Errorf("error inserting into notification_queue (%v): %w", destination, err)
+				}
+			}
+		}
+		metrics.NotificationsQueued.WithLabelValues(destination, "digest").Inc()
+	}
+	return nil
+}
+
+func notificationDigestTitle(mode types.NotificationDeliveryMode) string {
+	return fmt.Sprintf("Your %v notification digest", mode)
+}
+
+// notificationDigestEvent summarizes the notifications of an event in a digest
+type notificationDigestEvent struct {
+	EventName  types.EventName
+	Count      int
+	Validators []uint64
+	Messages   []string
+}
+
+// buildNotificationDigest summarizes the entries of a digest per event, the events with the most notifications come first
+func buildNotificationDigest(entries []types.NotificationDigestEntry) []notificationDigestEvent {
+	eventsByName := make(map[types.EventName]*notificationDigestEvent)
+	validatorsByEvent := make(map[types.EventName]map[uint64]bool)
+	for _, e := range entries {
+		event, exists := eventsByName[e.EventName]
+		if !exists {
+			event = &notificationDigestEvent{EventName: e.EventName}
+			eventsByName[e.EventName] = event
+			validatorsByEvent[e.EventName] = make(map[uint64]bool)
+		}
+		event.Count++
+		if e.ValidatorIndex.Valid {
+			validator := uint64(e.ValidatorIndex.Int64)
+			if !validatorsByEvent[e.EventName][validator] {
+				validatorsByEvent[e.EventName][validator] = true
+				event.Validators = append(event.Validators, validator)
+			}
+		} else {
+			event.Messages = append(event.Messages, e.Info)
+		}
+	}
+
+	events := make([]notificationDigestEvent, 0, len(eventsByName))
+	for _, event := range eventsByName {
+		sort.Slice(event.Validators, func(i, j int) bool {
+			return event.Validators[i] < event.Validators[j]
+		})
+		events = append(events, *event)
+	}
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].Count != events[j].Count {
+			return events[i].Count > events[j].Count
+		}
+		return events[i].EventName < events[j].EventName
+	})
+	return events
+}
+
+// renderNotificationDigest renders a digest as chat messages, the first message is the overview of the digest followed by a message per event
+// with the number of notifications, the affected validators and a link to the dashboard of the affected validators
+func renderNotificationDigest(mode types.NotificationDeliveryMode, entries []types.NotificationDigestEntry) []types.ChatMessage {
+	messages := []types.ChatMessage{
+		{
+			Title:    notificationDigestTitle(mode),
+			Markdown: fmt.Sprintf("You received %v notifications in the last %v.", len(entries), notificationDigestPeriods[mode]),
+		},
+	}
+
+	for _, event := range buildNotificationDigest(entries) {
+		title, exists := types.EventLabel[event.EventName]
+		if !exists {
+			title = types.GetDisplayableEventName(event.EventName)
+		}
+
+		markdown := fmt.Sprintf("%v notifications", event.Count)
+		if len(event.Validators) > 0 {
+			links := make([]string, 0, maxDigestValidatorLinks)
+			indices := make([]string, 0, maxDigestDashboardValidators)
+			for i, validator := range event.Validators {
+				if i < maxDigestValidatorLinks {
+					links = append(links, fmt.Sprintf("[%[1]v](https://%[2]v/validator/%[1]v)", validator, utils.Config.Frontend.SiteDomain))
+				}
+				if i < maxDigestDashboardValidators {
+					indices = append(indices, strconv.FormatUint(validator, 10))
+				}
+			}
+			markdown += fmt.Sprintf(" for %v validators: %v", len(event.Validators), strings.Join(links, ", "))
+			if len(event.Validators) > maxDigestValidatorLinks {
+				markdown += fmt.Sprintf(" and %v more", len(event.Validators)-maxDigestValidatorLinks)
+			}
+			markdown += fmt.Sprintf(". [View dashboard](https://%v/dashboard?validators=%v)", utils.Config.Frontend.SiteDomain, strings.Join(indices, ","))
+		}
+		if len(event.Messages) > 0 {
+			shown := event.Messages
+			if len(shown) > maxDigestEventMessages {
+				// the latest messages are the most relevant ones
+				shown = shown[len(shown)-maxDigestEventMessages:]
+			}
+			if len(event.Validators) > 0 {
+				markdown += "\n"
+			} else {
+				markdown += ":\n"
+			}
+			markdown += strings.Join(shown, "\n")
+			if len(event.Messages) > maxDigestEventMessages {
+				markdown += fmt.Sprintf("\nand %v more", len(event.Messages)-maxDigestEventMessages)
+			}
+		}
+
+		messages = append(messages, types.ChatMessage{
+			Title:    title,
+			Markdown: markdown,
+		})
+	}
+	return messages
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"database/sql"
+	"eth2-exporter/types"
+	"testing"
+)
+
+func TestGetDeliveryMode(t *testing.T) {
+	modes := []types.UserNotificationDeliveryMode{
+		{Channel: types.EmailNotificationChannel, Mode: types.NotificationDeliveryDaily},
+		{Channel: types.EmailNotificationChannel, EventName: string(types.ValidatorGotSlashedEventName), Mode: types.NotificationDeliveryImmediate},
+		{Channel: types.TelegramNotificationChannel, EventName: string(types.ValidatorMissedAttestationEventName), Mode: types.NotificationDeliveryHourly},
+	}
+
+	tests := []struct {
+		Channel  types.NotificationChannel
+		Event    types.EventName
+		Expected types.NotificationDeliveryMode
+	}{
+		{types.EmailNotificationChannel, types.ValidatorMissedAttestationEventName, types.NotificationDeliveryDaily},
+		{types.EmailNotificationChannel, types.ValidatorGotSlashedEventName, types.NotificationDeliveryImmediate},
+		{types.TelegramNotificationChannel, types.ValidatorMissedAttestationEventName, types.NotificationDeliveryHourly},
+		{types.TelegramNotificationChannel, types.ValidatorGotSlashedEventName, types.NotificationDeliveryImmediate},
+		{types.PushNotificationChannel, types.ValidatorMissedAttestationEventName, types.NotificationDeliveryImmediate},
+	}
+	for _, test := range tests {
+		if mode := getDeliveryMode(modes, test.Channel, test.Event); mode != test.Expected {
+			t.Errorf("expected delivery mode %v for %v over %v, got %v", test.Expected, test.Event, test.Channel, mode)
+		}
+	}
+}
+
+func TestBuildNotificationDigest(t *testing.T) {
+	validator := func(index int64) sql.NullInt64 {
+		return sql.NullInt64{Int64: index, Valid: true}
+	}
+	entries := []types.NotificationDigestEntry{
+		{EventName: types.ValidatorMissedAttestationEventName, ValidatorIndex: validator(5)},
+		{EventName: types.ValidatorMissedAttestationEventName, ValidatorIndex: validator(2)},
+		{EventName: types.ValidatorMissedAttestationEventName, ValidatorIndex: validator(5)},
+		{EventName: types.MonitoringMachineOfflineEventName, Info: "machine offline"},
+	}
+
+	events := buildNotificationDigest(entries)
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %v", len(events))
+	}
+	if events[0].EventName != types.ValidatorMissedAttestationEventName || events[0].Count != 3 {
+		t.Errorf("expected 3 missed attestations first, got %v %v", events[0].Count, events[0].EventName)
+	}
+	if len(events[0].Validators) != 2 || events[0].Validators[0] != 2 || events[0].Validators[1] != 5 {
+		t.Errorf("expected the affected validators [2 5], got %v", events[0].Validators)
+	}
+	if events[1].Count != 1 || len(events[1].Validators) != 0 || len(events[1].Messages) != 1 || events[1].Messages[0] != "machine offline" {
+		t.Errorf("expected the message of the machine event, got %+v", events[1])
+	}
+}
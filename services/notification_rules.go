@@ -0,0 +1,409 @@
+package services
+
+import (
+	"database/sql"
+	"eth2-exporter/db"
+	"eth2-exporter/types"
+	"eth2-exporter/utils"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// alertRuleTriggeredState is stored as internal state of the subscription of a rule once it has been triggered,
+// no further notifications are sent for the rule until its metric is within the threshold again
+const alertRuleTriggeredState = "triggered"
+
+// MaxAlertRuleWindowEpochs is the maximum number of epochs the metrics of a rule are aggregated over (~1 day)
+const MaxAlertRuleWindowEpochs = 225
+
+// alertRuleValue is the value of the metric of a rule at an epoch, for metrics that are evaluated per validator
+// Validator is the validator of the rule that is closest to violating the threshold
+type alertRuleValue struct {
+	Value     float64
+	Validator uint64
+}
+
+type alertRuleNotification struct {
+	SubscriptionID  uint64
+	Rule            types.AlertRule
+	Epoch           uint64
+	Value           alertRuleValue
+	EventFilter     string
+	UnsubscribeHash sql.NullString
+}
+
+func (n *alertRuleNotification) GetLatestState() string {
+	return alertRuleTriggeredState
+}
+
+func (n *alertRuleNotification) GetUnsubscribeHash() string {
+	if n.UnsubscribeHash.Valid {
+		return n.UnsubscribeHash.String
+	}
+	return ""
+}
+
+func (n *alertRuleNotification) GetEmailAttachment() *types.EmailAttachment {
+	return nil
+}
+
+func (n *alertRuleNotification) GetSubscriptionID() uint64 {
+	return n.SubscriptionID
+}
+
+func (n *alertRuleNotification) GetEpoch() uint64 {
+	return n.Epoch
+}
+
+func (n *alertRuleNotification) GetEventName() types.EventName {
+	return types.ValidatorAlertRuleEventName
+}
+
+func (n *alertRuleNotification) GetInfo(includeUrl bool) string {
+	generalPart := fmt.Sprintf(`Your alert rule "%v" has been triggered at epoch %v: %v %v.`, n.Rule.Name, n.Epoch, n.subject(fmt.Sprint(n.Value.Validator)), n.condition())
+	if includeUrl {
+		if isPerValidatorAlertRuleMetric(n.Rule.Metric) || len(n.Rule.Validators) == 1 {
+			return generalPart + getUrlPart(n.Value.Validator)
+		}
+		return generalPart + fmt.Sprintf(` For more information visit: <a href='https://%[1]s%[2]s'>https://%[1]s%[2]s</a>.`, utils.Config.Frontend.SiteDomain, n.dashboardLink())
+	}
+	return generalPart
+}
+
+func (n *alertRuleNotification) GetTitle() string {
+	return "Alert Rule Triggered"
+}
+
+func (n *alertRuleNotification) GetEventFilter() string {
+	return n.EventFilter
+}
+
+func (n *alertRuleNotification) GetInfoMarkdown() string {
+	validatorLink := fmt.Sprintf(`[%[1]v](https://%[2]v/validator/%[1]v)`, n.Value.Validator, utils.Config.Frontend.SiteDomain)
+	generalPart := fmt.Sprintf(`Your alert rule "%[1]v" has been triggered at epoch [%[2]v](https://%[5]v/epoch/%[2]v): %[3]v %[4]v.`, n.Rule.Name, n.Epoch, n.subject(validatorLink), n.condition(), utils.Config.Frontend.SiteDomain)
+	if !isPerValidatorAlertRuleMetric(n.Rule.Metric) && len(n.Rule.Validators) > 1 {
+		generalPart += fmt.Sprintf(` [View dashboard](https://%v%v)`, utils.Config.Frontend.SiteDomain, n.dashboardLink())
+	}
+	return generalPart
+}
+
+// subject describes the metric and the validators the value belongs to, validator is the rendered index of a single validator
+func (n *alertRuleNotification) subject(validator string) string {
+	label := strings.ToLower(types.AlertRuleMetricLabels[n.Rule.Metric])
+	if isPerValidatorAlertRuleMetric(n.Rule.Metric) || len(n.Rule.Validators) == 1 {
+		return fmt.Sprintf("the %v of validator %v", label, validator)
+	}
+	return fmt.Sprintf("the %v of %v validators", label, len(n.Rule.Validators))
+}
+
+// condition describes the value of the metric in relation to the threshold of the rule
+func (n *alertRuleNotification) condition() string {
+	condition := fmt.Sprintf("is %v (%v %v)", formatAlertRuleValue(n.Rule.Metric, n.Value.Value), n.Rule.Operator, formatAlertRuleValue(n.Rule.Metric, n.Rule.Threshold))
+	if window := alertRuleWindow(n.Rule.WindowEpochs); !isPerValidatorAlertRuleMetric(n.Rule.Metric) && window > 1 {
+		condition += fmt.Sprintf(" over the last %v epochs", window)
+	}
+	return condition
+}
+
+func (n *alertRuleNotification) dashboardLink() string {
+	indices := make([]string, 0, len(n.Rule.Validators))
+	for _, validator := range n.Rule.Validators {
+		indices = append(indices, strconv.FormatInt(validator, 10))
+	}
+	return "/dashboard?validators=" + strings.Join(indices, ",")
+}
+
+func formatAlertRuleValue(metric types.AlertRuleMetric, value float64) string {
+	switch metric {
+	case types.AlertRuleMetricEffectiveness, types.AlertRuleMetricSyncParticipation:
+		return fmt.Sprintf("%.2f%%", value)
+	case types.AlertRuleMetricBalance:
+		return fmt.Sprintf("%.4f %v", value, utils.Config.Frontend.ClCurrency)
+	case types.AlertRuleMetricDaysSinceProposal:
+		return fmt.Sprintf("%.1f days", value)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// isPerValidatorAlertRuleMetric returns true for metrics that are evaluated for every validator of a rule,
+// all other metrics are aggregated over the validators of the rule
+func isPerValidatorAlertRuleMetric(metric types.AlertRuleMetric) bool {
+	return metric == types.AlertRuleMetricBalance || metric == types.AlertRuleMetricDaysSinceProposal
+}
+
+// collectAlertRuleNotifications evaluates the alert rules of all subscribed users at the epoch,
+// a rule triggers once when its threshold is violated and is reset once the metric is within the threshold again
+func collectAlertRuleNotifications(notificationsByUserID map[uint64]map[types.EventName][]types.Notification, epoch uint64) error {
+	_, subMap, err := db.GetSubsForEventFilter(types.ValidatorAlertRuleEventName)
+	if err != nil {
+		return fmt.Errorf("error getting subscriptions for alert rules %w", err)
+	}
+
+	ruleIDs := make([]uint64, 0, len(subMap))
+	for filter := range subMap {
+		ruleID, err := strconv.ParseUint(filter, 10, 64)
+		if err != nil {
+			logger.Warnf("error parsing alert rule id %v of subscription: %v", filter, err)
+			continue
+		}
+		ruleIDs = append(ruleIDs, ruleID)
+	}
+	if len(ruleIDs) == 0 {
+		return nil
+	}
+
+	rules, err := db.GetAlertRulesByIDs(ruleIDs)
+	if err != nil {
+		return fmt.Errorf("error getting alert rules: %w", err)
+	}
+
+	values, err := getAlertRuleValues(rules, epoch)
+	if err != nil {
+		return err
+	}
+
+	notifications, resetSubs, err := evaluateAlertRules(rules, values, subMap, epoch)
+	if err != nil {
+		return err
+	}
+	for _, n := range notifications {
+		addNotification(notificationsByUserID, n.Rule.UserID, n)
+	}
+
+	if len(resetSubs) > 0 {
+		_, err := db.FrontendWriterDB.Exec(`UPDATE users_subscriptions SET internal_state = NULL WHERE id = ANY($1)`, pq.Int64Array(resetSubs))
+		if err != nil {
+			return fmt.Errorf("error resetting internal state of alert rule subscriptions: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// evaluateAlertRules compares the values of the rules with their thresholds and returns the notifications for the subscriptions
+// of rules that have been triggered at the epoch. A triggered subscription is not notified again until its rule is within the threshold,
+// the ids of triggered subscriptions whose rule is within the threshold again are returned to reset their state.
+func evaluateAlertRules(rules []types.AlertRule, values map[uint64]alertRuleValue, subMap map[string][]types.Subscription, epoch uint64) ([]*alertRuleNotification, []int64, error) {
+	notifications := make([]*alertRuleNotification, 0)
+	resetSubs := make([]int64, 0)
+	for _, rule := range rules {
+		value, exists := values[rule.ID]
+		if !exists {
+			continue
+		}
+
+		filter := strconv.FormatUint(rule.ID, 10)
+		for _, sub := range subMap[filter] {
+			if sub.UserID == nil || sub.ID == nil {
+				return nil, nil, fmt.Errorf("error expected userId and subId to be defined but got user: %v, sub: %v", sub.UserID, sub.ID)
+			}
+			if *sub.UserID != rule.UserID {
+				continue
+			}
+			notified := sub.State.Valid && sub.State.String == alertRuleTriggeredState
+
+			if !rule.IsTriggeredBy(value.Value) {
+				if notified {
+					resetSubs = append(resetSubs, int64(*sub.ID))
+				}
+				continue
+			}
+			if notified || epoch < sub.CreatedEpoch || (sub.LastEpoch != nil && *sub.LastEpoch >= epoch) {
+				continue
+			}
+
+			notifications = append(notifications, &alertRuleNotification{
+				SubscriptionID:  *sub.ID,
+				Rule:            rule,
+				Epoch:           epoch,
+				Value:           value,
+				EventFilter:     filter,
+				UnsubscribeHash: sub.UnsubscribeHash,
+			})
+		}
+	}
+	return notifications, resetSubs, nil
+}
+
+// getAlertRuleValues returns the values of the metrics of the rules at the epoch, rules without data in their window are omitted.
+// Rules with the same metric and window are evaluated with a single query for all of their validators
+func getAlertRuleValues(rules []types.AlertRule, epoch uint64) (map[uint64]alertRuleValue, error) {
+	type ruleGroup struct {
+		Metric types.AlertRuleMetric
+		Window uint64
+	}
+
+	groups := make(map[ruleGroup][]types.AlertRule)
+	for _, rule := range rules {
+		group := ruleGroup{Metric: rule.Metric, Window: 1}
+		if !isPerValidatorAlertRuleMetric(rule.Metric) {
+			group.Window = alertRuleWindow(rule.WindowEpochs)
+		}
+		groups[group] = append(groups[group], rule)
+	}
+
+	values := make(map[uint64]alertRuleValue, len(rules))
+	for group, rules := range groups {
+		validatorsMap := make(map[uint64]bool)
+		for _, rule := range rules {
+			for _, validator := range rule.Validators {
+				validatorsMap[uint64(validator)] = true
+			}
+		}
+		validators := make([]uint64, 0, len(validatorsMap))
+		for validator := range validatorsMap {
+			validators = append(validators, validator)
+		}
+		if len(validators) == 0 {
+			continue
+		}
+
+		// attestations of the newest epoch can still be included in the next epoch and would count as missed,
+		// the effectiveness window therefore ends at the last epoch whose inclusion window is closed
+		endEpoch := epoch
+		if group.Metric == types.AlertRuleMetricEffectiveness {
+			if epoch == 0 {
+				continue
+			}
+			endEpoch = epoch - 1
+		}
+		startEpoch := uint64(0)
+		if endEpoch+1 > group.Window {
+			startEpoch = endEpoch + 1 - group.Window
+		}
+
+		switch group.Metric {
+		case types.AlertRuleMetricEffectiveness:
+			history, err := db.BigtableClient.GetValidatorAttestationHistory(validators, startEpoch, endEpoch)
+			if err != nil {
+				return nil, fmt.Errorf("error getting validator attestations from bigtable: %w", err)
+			}
+			for _, rule := range rules {
+				sum := 0.0
+				count := 0
+				for _, validator := range rule.Validators {
+					for _, attestation := range history[uint64(validator)] {
+						// missed attestations have an effectiveness of 0
+						if attestation.InclusionSlot > attestation.AttesterSlot {
+							sum += 1.0 / float64(attestation.InclusionSlot-attestation.AttesterSlot)
+						}
+						count++
+					}
+				}
+				if count > 0 {
+					values[rule.ID] = alertRuleValue{Value: sum / float64(count) * 100, Validator: uint64(rule.Validators[0])}
+				}
+			}
+		case types.AlertRuleMetricSyncParticipation:
+			stats, err := db.BigtableClient.GetValidatorSyncDutiesStatistics(validators, startEpoch, endEpoch)
+			if err != nil {
+				return nil, fmt.Errorf("error getting validator sync duties from bigtable: %w", err)
+			}
+			for _, rule := range rules {
+				participated := uint64(0)
+				missed := uint64(0)
+				for _, validator := range rule.Validators {
+					if s := stats[uint64(validator)]; s != nil {
+						participated += s.ParticipatedSync
+						missed += s.MissedSync
+					}
+				}
+				// rules without sync duties in their window are not evaluated, orphaned duties are not held against the validators
+				if participated+missed > 0 {
+					values[rule.ID] = alertRuleValue{Value: float64(participated) / float64(participated+missed) * 100, Validator: uint64(rule.Validators[0])}
+				}
+			}
+		case types.AlertRuleMetricBalance:
+			history, err := db.BigtableClient.GetValidatorBalanceHistory(validators, epoch, epoch)
+			if err != nil {
+				return nil, fmt.Errorf("error getting validator balances from bigtable: %w", err)
+			}
+			balances := make(map[uint64]float64, len(history))
+			for validator, balance := range history {
+				for _, b := range balance {
+					if b.Epoch == epoch {
+						balances[validator] = float64(b.Balance) / 1e9
+					}
+				}
+			}
+			for _, rule := range rules {
+				if value, exists := getPerValidatorAlertRuleValue(rule, balances); exists {
+					values[rule.ID] = value
+				}
+			}
+		case types.AlertRuleMetricDaysSinceProposal:
+			days, err := getDaysSinceLastProposal(validators, epoch)
+			if err != nil {
+				return nil, err
+			}
+			for _, rule := range rules {
+				if value, exists := getPerValidatorAlertRuleValue(rule, days); exists {
+					values[rule.ID] = value
+				}
+			}
+		default:
+			logger.Warnf("skipping %v alert rules with unknown metric %v", len(rules), group.Metric)
+		}
+	}
+
+	return values, nil
+}
+
+// getPerValidatorAlertRuleValue returns the value of the validator of the rule that is closest to violating the threshold,
+// this is the lowest value for rules that trigger below and the highest value for rules that trigger above the threshold
+func getPerValidatorAlertRuleValue(rule types.AlertRule, valuesByValidator map[uint64]float64) (alertRuleValue, bool) {
+	res := alertRuleValue{}
+	found := false
+	for _, validator := range rule.Validators {
+		value, exists := valuesByValidator[uint64(validator)]
+		if !exists {
+			continue
+		}
+		if !found ||
+			(rule.Operator == types.AlertRuleOperatorAbove && value > res.Value) ||
+			(rule.Operator != types.AlertRuleOperatorAbove && value < res.Value) {
+			res = alertRuleValue{Value: value, Validator: uint64(validator)}
+			found = true
+		}
+	}
+	return res, found
+}
+
+// getDaysSinceLastProposal returns the days since the last proposal of each active validator,
+// the days since the activation are returned for validators that have not proposed yet
+func getDaysSinceLastProposal(validators []uint64, epoch uint64) (map[uint64]float64, error) {
+	var lastProposals []struct {
+		ValidatorIndex uint64 `db:"validatorindex"`
+		Epoch          uint64 `db:"epoch"`
+	}
+	err := db.ReaderDb.Select(&lastProposals, `
+		SELECT v.validatorindex, COALESCE(MAX(b.epoch), v.activationepoch) AS epoch
+		FROM validators v
+		LEFT JOIN blocks b ON b.proposer = v.validatorindex AND b.status = '1' AND b.epoch <= $2
+		WHERE v.validatorindex = ANY($1) AND v.activationepoch <= $2 AND v.exitepoch > $2
+		GROUP BY v.validatorindex, v.activationepoch`,
+		pq.Array(validators), epoch)
+	if err != nil {
+		return nil, fmt.Errorf("error getting last proposals of validators: %w", err)
+	}
+
+	days := make(map[uint64]float64, len(lastProposals))
+	for _, p := range lastProposals {
+		days[p.ValidatorIndex] = float64((epoch-p.Epoch)*utils.Config.Chain.ClConfig.SlotsPerEpoch*utils.Config.Chain.ClConfig.SecondsPerSlot) / (60 * 60 * 24)
+	}
+	return days, nil
+}
+
+// alertRuleWindow returns the number of epochs the metric of a rule is aggregated over
+func alertRuleWindow(windowEpochs uint64) uint64 {
+	if windowEpochs == 0 {
+		return 1
+	}
+	if windowEpochs > MaxAlertRuleWindowEpochs {
+		return MaxAlertRuleWindowEpochs
+	}
+	return windowEpochs
+}
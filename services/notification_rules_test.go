@@ -0,0 +1,95 @@
+package services
+
+import (
+	"database/sql"
+	"eth2-exporter/types"
+	"testing"
+)
+
+func TestGetPerValidatorAlertRuleValue(t *testing.T) {
+	balances := map[uint64]float64{1: 32.01, 2: 31.85, 3: 32.2}
+
+	rule := types.AlertRule{Validators: []int64{1, 2, 3, 4}, Metric: types.AlertRuleMetricBalance, Operator: types.AlertRuleOperatorBelow, Threshold: 31.9}
+	value, exists := getPerValidatorAlertRuleValue(rule, balances)
+	if !exists || value.Validator != 2 || value.Value != 31.85 {
+		t.Errorf("expected the lowest balance of validator 2, got %+v", value)
+	}
+	if !rule.IsTriggeredBy(value.Value) {
+		t.Errorf("expected rule to be triggered by %v", value.Value)
+	}
+
+	rule.Operator = types.AlertRuleOperatorAbove
+	rule.Threshold = 32.5
+	value, exists = getPerValidatorAlertRuleValue(rule, balances)
+	if !exists || value.Validator != 3 || value.Value != 32.2 {
+		t.Errorf("expected the highest balance of validator 3, got %+v", value)
+	}
+	if rule.IsTriggeredBy(value.Value) {
+		t.Errorf("expected rule not to be triggered by %v", value.Value)
+	}
+
+	rule.Validators = []int64{4}
+	if _, exists := getPerValidatorAlertRuleValue(rule, balances); exists {
+		t.Errorf("expected no value for validators without data")
+	}
+}
+
+func TestAlertRuleWindow(t *testing.T) {
+	for window, expected := range map[uint64]uint64{0: 1, 3: 3, MaxAlertRuleWindowEpochs + 1: MaxAlertRuleWindowEpochs} {
+		if w := alertRuleWindow(window); w != expected {
+			t.Errorf("expected window %v for %v epochs, got %v", expected, window, w)
+		}
+	}
+}
+
+func TestEvaluateAlertRules(t *testing.T) {
+	subscription := func(id, userID uint64, state string, lastEpoch *uint64) types.Subscription {
+		return types.Subscription{
+			ID:          &id,
+			UserID:      &userID,
+			EventName:   string(types.ValidatorAlertRuleEventName),
+			EventFilter: "1",
+			LastEpoch:   lastEpoch,
+			State:       sql.NullString{String: state, Valid: state != ""},
+		}
+	}
+	rule := types.AlertRule{ID: 1, UserID: 10, Validators: []int64{1}, Metric: types.AlertRuleMetricEffectiveness, Operator: types.AlertRuleOperatorBelow, Threshold: 95}
+	lastEpoch := uint64(100)
+
+	tests := []struct {
+		Name     string
+		Value    float64
+		Sub      types.Subscription
+		Notified bool
+		Reset    bool
+	}{
+		{"triggered", 90, subscription(1, 10, "", nil), true, false},
+		{"already triggered", 90, subscription(1, 10, alertRuleTriggeredState, nil), false, false},
+		{"within threshold again", 99, subscription(1, 10, alertRuleTriggeredState, nil), false, true},
+		{"within threshold", 99, subscription(1, 10, "", nil), false, false},
+		{"already sent at the epoch", 90, subscription(1, 10, "", &lastEpoch), false, false},
+		{"subscription of another user", 90, subscription(1, 11, "", nil), false, false},
+	}
+	for _, test := range tests {
+		subMap := map[string][]types.Subscription{"1": {test.Sub}}
+		notifications, resetSubs, err := evaluateAlertRules([]types.AlertRule{rule}, map[uint64]alertRuleValue{1: {Value: test.Value, Validator: 1}}, subMap, 100)
+		if err != nil {
+			t.Fatalf("%v: error evaluating alert rules: %v", test.Name, err)
+		}
+		if notified := len(notifications) == 1; notified != test.Notified || len(notifications) > 1 {
+			t.Errorf("%v: expected notified %v, got %v notifications", test.Name, test.Notified, len(notifications))
+		}
+		if len(notifications) == 1 && (notifications[0].SubscriptionID != 1 || notifications[0].Epoch != 100 || notifications[0].GetLatestState() != alertRuleTriggeredState) {
+			t.Errorf("%v: unexpected notification %+v", test.Name, notifications[0])
+		}
+		if reset := len(resetSubs) == 1 && resetSubs[0] == 1; reset != test.Reset {
+			t.Errorf("%v: expected reset %v, got %v", test.Name, test.Reset, resetSubs)
+		}
+	}
+
+	// rules without data in their window are not evaluated and keep their state
+	notifications, resetSubs, err := evaluateAlertRules([]types.AlertRule{rule}, map[uint64]alertRuleValue{}, map[string][]types.Subscription{"1": {subscription(1, 10, alertRuleTriggeredState, nil)}}, 100)
+	if err != nil || len(notifications) != 0 || len(resetSubs) != 0 {
+		t.Errorf("expected rules without values to be skipped, got %v %v %v", notifications, resetSubs, err)
+	}
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
 	"crypto/sha256"
 	"database/sql"
 	"database/sql/driver"
@@ -21,6 +22,7 @@ import (
 	"html/template"
 	"io"
 	"math/big"
+	"math/rand"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -161,6 +163,16 @@ func notificationSender() {
 		}
 
 		logger.Info("lock obtained")
+		err = processTelegramUpdates()
+		if err != nil {
+			logger.WithError(err).Error("error processing telegram updates")
+		}
+
+		err = queueNotificationDigests(db.FrontendWriterDB)
+		if err != nil {
+			logger.WithError(err).Error("error queuing notification digests")
+		}
+
 		err = dispatchNotifications(db.FrontendWriterDB)
 		if err != nil {
 			logger.WithError(err).Error("error dispatching notifications")
@@ -275,6 +287,36 @@ func collectNotifications(epoch uint64) (map[uint64]map[types.EventName][]types.
 	}
 	logger.Infof("collecting withdrawal notifications took: %v", time.Since(start))
 
+	err = collectValidatorDidSlashNotifications(notificationsByUserID, epoch)
+	if err != nil {
+		metrics.Errors.WithLabelValues("notifications_collect_validator_did_slash").Inc()
+		return nil, fmt.Errorf("error collecting validator_did_slash notifications: %v", err)
+	}
+	logger.Infof("collecting validator did slash notifications took: %v", time.Since(start))
+
+	err = collectDepositNotifications(notificationsByUserID, epoch)
+	if err != nil {
+		metrics.Errors.WithLabelValues("notifications_collect_validator_deposit").Inc()
+		return nil, fmt.Errorf("error collecting deposit notifications: %v", err)
+	}
+	logger.Infof("collecting deposit notifications took: %v", time.Since(start))
+
+	if utils.Config.Notifications.ValidatorBalanceDecreasedNotificationsEnabled {
+		err = collectValidatorBalanceDecreasedNotifications(notificationsByUserID, epoch)
+		if err != nil {
+			metrics.Errors.WithLabelValues("notifications_collect_validator_balance_decreased").Inc()
+			return nil, fmt.Errorf("error collecting validator_balance_decreased notifications: %v", err)
+		}
+		logger.Infof("collecting validator balance decreased notifications took: %v", time.Since(start))
+	}
+
+	err = collectNetworkValidatorQueueNotifications(notificationsByUserID, epoch)
+	if err != nil {
+		metrics.Errors.WithLabelValues("notifications_collect_network_validator_queue").Inc()
+		return nil, fmt.Errorf("error collecting network validator queue notifications: %v", err)
+	}
+	logger.Infof("collecting network validator queue notifications took: %v", time.Since(start))
+
 	err = collectNetworkNotifications(notificationsByUserID, types.NetworkLivenessIncreasedEventName)
 	if err != nil {
 		metrics.Errors.WithLabelValues("notifications_collect_network").Inc()
@@ -332,9 +374,25 @@ func collectNotifications(epoch uint64) (map[uint64]map[types.EventName][]types.
 	}
 	logger.Infof("collecting sync committee took: %v", time.Since(start))
 
+	err = collectAlertRuleNotifications(notificationsByUserID, epoch)
+	if err != nil {
+		metrics.Errors.WithLabelValues("notifications_collect_alert_rules").Inc()
+		return nil, fmt.Errorf("error collecting alert rule notifications: %v", err)
+	}
+	logger.Infof("collecting alert rule notifications took: %v", time.Since(start))
+
 	return notificationsByUserID, nil
 }
 
+// addNotification adds a collected notification to the notifications of a user
+func addNotification(notificationsByUserID map[uint64]map[types.EventName][]types.Notification, userID uint64, n types.Notification) {
+	if _, exists := notificationsByUserID[userID]; !exists {
+		notificationsByUserID[userID] = map[types.EventName][]types.Notification{}
+	}
+	notificationsByUserID[userID][n.GetEventName()] = append(notificationsByUserID[userID][n.GetEventName()], n)
+	metrics.NotificationsCollected.WithLabelValues(string(n.GetEventName())).Inc()
+}
+
 func collectUserDbNotifications(epoch uint64) (map[uint64]map[types.EventName][]types.Notification, error) {
 	notificationsByUserID := map[uint64]map[types.EventName][]types.Notification{}
 	var err error
@@ -367,6 +425,20 @@ func collectUserDbNotifications(epoch uint64) (map[uint64]map[types.EventName][]
 		return nil, fmt.Errorf("error collecting Eth client memory notifications: %v", err)
 	}
 
+	// Monitoring (premium): eth1 fallback of the beaconnode in use
+	err = collectMonitoringMachineFallback(notificationsByUserID, types.MonitoringMachineSwitchedToETH1FallbackEventName, "beaconnode", epoch)
+	if err != nil {
+		metrics.Errors.WithLabelValues("notifications_collect_monitoring_machine_eth1_fallback").Inc()
+		return nil, fmt.Errorf("error collecting Eth client eth1 fallback notifications: %v", err)
+	}
+
+	// Monitoring (premium): eth2 fallback of the validator client in use
+	err = collectMonitoringMachineFallback(notificationsByUserID, types.MonitoringMachineSwitchedToETH2FallbackEventName, "validator", epoch)
+	if err != nil {
+		metrics.Errors.WithLabelValues("notifications_collect_monitoring_machine_eth2_fallback").Inc()
+		return nil, fmt.Errorf("error collecting Eth client eth2 fallback notifications: %v", err)
+	}
+
 	// New ETH clients
 	err = collectEthClientNotifications(notificationsByUserID, types.EthClientUpdateEventName)
 	if err != nil {
@@ -407,21 +479,36 @@ func queueNotifications(notificationsByUserID map[uint64]map[types.EventName][]t
 		}
 	}
 
-	err := queueEmailNotifications(notificationsByUserID, useDB)
+	userIDs := make([]uint64, 0, len(notificationsByUserID))
+	for userID := range notificationsByUserID {
+		userIDs = append(userIDs, userID)
+	}
+	// notifications of users that receive digests are saved for the next digest instead of being queued
+	deliveryModesByUserID, err := db.GetNotificationDeliveryModes(userIDs)
+	if err != nil {
+		logger.WithError(err).Error("error getting notification delivery modes, delivering all notifications immediately")
+	}
+
+	err = queueEmailNotifications(deferDigestNotifications(notificationsByUserID, types.EmailNotificationChannel, deliveryModesByUserID, useDB), useDB)
 	if err != nil {
 		logger.WithError(err).Error("error queuing email notifications")
 	}
 
-	err = queuePushNotification(notificationsByUserID, useDB)
+	err = queuePushNotification(deferDigestNotifications(notificationsByUserID, types.PushNotificationChannel, deliveryModesByUserID, useDB), useDB)
 	if err != nil {
 		logger.WithError(err).Error("error queuing push notifications")
 	}
 
-	err = queueWebhookNotifications(notificationsByUserID, useDB)
+	err = queueWebhookNotifications(deferDigestNotifications(notificationsByUserID, types.WebhookNotificationChannel, deliveryModesByUserID, useDB), useDB)
 	if err != nil {
 		logger.WithError(err).Error("error queuing webhook notifications")
 	}
 
+	err = queueTelegramNotifications(deferDigestNotifications(notificationsByUserID, types.TelegramNotificationChannel, deliveryModesByUserID, useDB), useDB)
+	if err != nil {
+		logger.WithError(err).Error("error queuing telegram notifications")
+	}
+
 	for _, events := range notificationsByUserID {
 		for _, notifications := range events {
 			for _, n := range notifications {
@@ -496,6 +583,18 @@ func dispatchNotifications(useDB *sqlx.DB) error {
 		return fmt.Errorf("error sending webhook discord notifications, err: %w", err)
 	}
 
+	for _, channel := range types.WebhookChatNotificationChannels {
+		err = sendWebhookChatNotifications(useDB, channel)
+		if err != nil {
+			return fmt.Errorf("error sending %v notifications, err: %w", channel, err)
+		}
+	}
+
+	err = sendTelegramNotifications(useDB)
+	if err != nil {
+		return fmt.Errorf("error sending telegram notifications, err: %w", err)
+	}
+
 	return nil
 }
 
@@ -511,6 +610,25 @@ func garbageCollectNotificationQueue(useDB *sqlx.DB) error {
 
 	logger.Infof("deleted %v rows from the notification_queue", rowsAffected)
 
+	rows, err = useDB.Exec(`DELETE FROM users_webhooks_deliveries WHERE ts < now() - INTERVAL '7 days'`)
+	if err != nil {
+		return fmt.Errorf("error deleting from users_webhooks_deliveries %w", err)
+	}
+
+	rowsAffected, _ = rows.RowsAffected()
+
+	logger.Infof("deleted %v rows from the users_webhooks_deliveries", rowsAffected)
+
+	// digest entries are queued at the end of their period at the latest, entries of networks that are no longer served remain
+	rows, err = useDB.Exec(`DELETE FROM notification_digest_entries WHERE created < now() - INTERVAL '14 days'`)
+	if err != nil {
+		return fmt.Errorf("error deleting from notification_digest_entries %w", err)
+	}
+
+	rowsAffected, _ = rows.RowsAffected()
+
+	logger.Infof("deleted %v rows from the notification_digest_entries", rowsAffected)
+
 	return nil
 }
 
@@ -832,6 +950,7 @@ func queueWebhookNotifications(notificationsByUserID map[uint64]map[types.EventN
 		}
 		// webhook => [] notifications
 		discordNotifMap := make(map[uint64][]types.TransitDiscordContent)
+		chatNotifMap := make(map[uint64][]types.TransitChatContent)
 		notifs := make([]types.TransitWebhook, 0)
 		// send the notifications to each registered webhook
 		for _, w := range webhooks {
@@ -845,7 +964,8 @@ func queueWebhookNotifications(notificationsByUserID map[uint64]map[types.EventN
 					}
 				}
 				if eventSubscribed {
-					if len(notifications) > 0 {
+					// generic webhooks retry every delivery with an exponential backoff, the retry counter only pauses discord and chat webhooks
+					if len(notifications) > 0 && w.Destination.Valid && w.Destination.String != string(types.WebhookNotificationChannel) {
 						// reset Retries
 						if w.Retries > 5 && w.LastSent.Valid && w.LastSent.Time.Add(time.Hour).Before(time.Now()) {
 							_, err = useDB.Exec(`UPDATE users_webhooks SET retries = 0 WHERE id = $1;`, w.ID)
@@ -903,6 +1023,11 @@ func queueWebhookNotifications(notificationsByUserID map[uint64]map[types.EventN
 								Title:       n.GetTitle(),
 								Fields:      fields,
 							})
+						} else if w.Destination.Valid && isWebhookChatDestination(w.Destination.String) {
+							chatNotifMap[w.ID] = appendChatMessage(chatNotifMap[w.ID], types.TransitChatContent{Webhook: w}, types.ChatMessage{
+								Title:    n.GetTitle(),
+								Markdown: n.GetInfoMarkdown(),
+							})
 						} else {
 							notifs = append(notifs, types.TransitWebhook{
 								Channel: w.Destination.String,
@@ -944,10 +1069,52 @@ func queueWebhookNotifications(notificationsByUserID map[uint64]map[types.EventN
 				}
 			}
 		}
+		// process chat notifs (slack, matrix, ntfy)
+		for _, cNotifs := range chatNotifMap {
+			for _, n := range cNotifs {
+				_, err = useDB.Exec(`INSERT INTO notification_queue (created, channel, content) VALUES (now(), $1, $2);`, n.Webhook.Destination.String, n)
+				if err != nil {
+					logger.WithError(err).Errorf("error inserting into webhooks_queue (%v)", n.Webhook.Destination.String)
+					continue
+				} else {
+					metrics.NotificationsQueued.WithLabelValues(n.Webhook.Destination.String, "multi").Inc()
+				}
+			}
+		}
 	}
 	return nil
 }
 
+const (
+	// delay before the first retry of a failed webhook delivery, the delay doubles with every attempt
+	webhookBackoffBase = time.Second * 10
+	// the last retry happens about half an hour after the first attempt, before the queue entry is garbage collected
+	maxWebhookAttempts = 8
+	// time after which a webhook delivery that is still in flight is picked up again
+	webhookClaimTimeout = time.Minute * 2
+	// maximum length of the response that is recorded for a delivery
+	maxWebhookResponseSnippet = 1024
+)
+
+// webhookRetryBackoff returns the delay before the next attempt of a delivery that failed attempt times,
+// up to half of the delay is added as jitter so that the retries of a failing endpoint are spread out
+func webhookRetryBackoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	backoff := webhookBackoffBase << (attempt - 1)
+	return backoff + time.Duration(rand.Int63n(int64(backoff)/2+1))
+}
+
+// signWebhookPayload returns the hex encoded HMAC-SHA256 of "<timestamp>.<payload>" using the secret of the webhook
+func signWebhookPayload(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
 func sendWebhookNotifications(useDB *sqlx.DB) error {
 	var notificationQueueItem []types.TransitWebhook
 
@@ -956,8 +1123,9 @@ func sendWebhookNotifications(useDB *sqlx.DB) error {
 		created,
 		sent,
 		channel,
-		content
-	FROM notification_queue WHERE sent IS null AND channel = 'webhook' ORDER BY created ASC`)
+		content,
+		attempts
+	FROM notification_queue WHERE sent IS null AND channel = 'webhook' AND (next_attempt IS null OR next_attempt <= now()) ORDER BY created ASC`)
 	if err != nil {
 		return fmt.Errorf("error querying notification queue, err: %w", err)
 	}
@@ -966,8 +1134,8 @@ func sendWebhookNotifications(useDB *sqlx.DB) error {
 	logger.Infof("processing %v webhook notifications", len(notificationQueueItem))
 
 	for _, n := range notificationQueueItem {
-		// do not retry after 5 attempts
-		if n.Content.Webhook.Retries > 5 {
+		_, err = url.Parse(n.Content.Webhook.Url)
+		if err != nil {
 			_, err := db.FrontendWriterDB.Exec(`DELETE FROM notification_queue WHERE id = $1`, n.Id)
 			if err != nil {
 				return fmt.Errorf("error deleting from notification queue: %w", err)
@@ -975,68 +1143,118 @@ func sendWebhookNotifications(useDB *sqlx.DB) error {
 			continue
 		}
 
-		reqBody := new(bytes.Buffer)
-
-		err := json.NewEncoder(reqBody).Encode(n.Content)
+		// claim the delivery so that it is not picked up again by the next run while the request is in flight
+		_, err = useDB.Exec(`UPDATE notification_queue SET next_attempt = now() + $2 * INTERVAL '1 second' WHERE id = $1`, n.Id, webhookClaimTimeout.Seconds())
 		if err != nil {
-			logger.WithError(err).Errorf("error marschalling webhook event")
+			return fmt.Errorf("error claiming notification queue entry: %w", err)
 		}
 
-		_, err = url.Parse(n.Content.Webhook.Url)
+		go deliverWebhookNotification(useDB, client, n)
+	}
+	return nil
+}
+
+// deliverWebhookNotification sends a signed webhook payload and records the attempt. Failed deliveries are retried with an exponential backoff
+// until maxWebhookAttempts is reached
+func deliverWebhookNotification(useDB *sqlx.DB, client *http.Client, n types.TransitWebhook) {
+	payload, err := json.Marshal(n.Content)
+	if err != nil {
+		logger.WithError(err).Errorf("error marschalling webhook event")
+		return
+	}
+
+	secret, err := db.GetWebhookSecret(n.Content.Webhook.ID)
+	if err == sql.ErrNoRows {
+		// the webhook has been deleted
+		_, err = useDB.Exec(`DELETE FROM notification_queue WHERE id = $1`, n.Id)
 		if err != nil {
-			_, err := db.FrontendWriterDB.Exec(`DELETE FROM notification_queue WHERE id = $1`, n.Id)
-			if err != nil {
-				return fmt.Errorf("error deleting from notification queue: %w", err)
-			}
-			continue
+			logger.WithError(err).Errorf("error deleting from notification queue")
 		}
+		return
+	}
+	if err != nil {
+		logger.WithError(err).Errorf("error getting secret of webhook %v", n.Content.Webhook.ID)
+		return
+	}
 
-		go func(n types.TransitWebhook) {
-			if n.Content.Webhook.Retries > 0 {
-				time.Sleep(time.Duration(n.Content.Webhook.Retries) * time.Second)
-			}
-			resp, err := client.Post(n.Content.Webhook.Url, "application/json", reqBody)
-			if err != nil {
-				logger.WithError(err).Errorf("error sending request")
-			} else {
-				metrics.NotificationsSent.WithLabelValues("webhook", resp.Status).Inc()
-			}
+	req, err := http.NewRequest(http.MethodPost, n.Content.Webhook.Url, bytes.NewReader(payload))
+	if err != nil {
+		logger.WithError(err).Errorf("error creating webhook request")
+		return
+	}
+	ts := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Timestamp", strconv.FormatInt(ts, 10))
+	req.Header.Set("X-Webhook-Signature", "sha256="+signWebhookPayload(secret, ts, payload))
 
-			_, err = useDB.Exec(`UPDATE notification_queue SET sent = now() WHERE id = $1`, n.Id)
-			if err != nil {
-				logger.WithError(err).Errorf("error updating notification_queue table")
-				return
-			}
+	attempt := n.Attempts + 1
+	delivery := &types.UserWebhookDelivery{
+		WebhookID:  n.Content.Webhook.ID,
+		EventName:  n.Content.Event.Name,
+		Payload:    string(payload),
+		Attempt:    attempt,
+		Redelivery: n.Content.Redelivery,
+	}
 
-			if resp != nil && resp.StatusCode < 400 {
-				_, err = useDB.Exec(`UPDATE users_webhooks SET retries = 0, last_sent = now() WHERE id = $1;`, n.Content.Webhook.ID)
-				if err != nil {
-					logger.WithError(err).Errorf("error updating users_webhooks table; setting retries to zero")
-					return
-				}
-			} else {
-				var errResp types.ErrorResponse
+	start := time.Now()
+	resp, err := client.Do(req)
+	delivery.LatencyMs = time.Since(start).Milliseconds()
 
-				if resp != nil {
-					b, err := io.ReadAll(resp.Body)
-					if err != nil {
-						logger.WithError(err).Error("error reading body")
-					}
+	var errResp types.ErrorResponse
+	if err != nil {
+		logger.WithError(err).Errorf("error sending request")
+		errMsg := err.Error()
+		if len(errMsg) > maxWebhookResponseSnippet {
+			errMsg = errMsg[:maxWebhookResponseSnippet]
+		}
+		delivery.Error = sql.NullString{String: errMsg, Valid: true}
+	} else {
+		metrics.NotificationsSent.WithLabelValues("webhook", resp.Status).Inc()
+		b, err := io.ReadAll(io.LimitReader(resp.Body, maxWebhookResponseSnippet))
+		resp.Body.Close()
+		if err != nil {
+			logger.WithError(err).Error("error reading body")
+		}
+		delivery.StatusCode = sql.NullInt64{Int64: int64(resp.StatusCode), Valid: true}
+		delivery.Response = sql.NullString{String: strings.ToValidUTF8(string(b), ""), Valid: true}
+		errResp.Status = resp.Status
+		errResp.Body = delivery.Response.String
+	}
 
-					errResp.Status = resp.Status
-					errResp.Body = string(b)
-				}
+	err = db.SaveWebhookDelivery(delivery)
+	if err != nil {
+		logger.WithError(err).Errorf("error saving webhook delivery")
+	}
 
-				_, err = useDB.Exec(`UPDATE users_webhooks SET retries = retries + 1, last_sent = now(), request = $2, response = $3 WHERE id = $1;`, n.Content.Webhook.ID, n.Content, errResp)
-				if err != nil {
-					logger.WithError(err).Errorf("error updating users_webhooks table; increasing retries")
-					return
-				}
-			}
-		}(n)
+	if resp != nil && resp.StatusCode < 400 {
+		_, err = useDB.Exec(`UPDATE notification_queue SET sent = now(), attempts = $2 WHERE id = $1`, n.Id, attempt)
+		if err != nil {
+			logger.WithError(err).Errorf("error updating notification_queue table")
+			return
+		}
+		_, err = useDB.Exec(`UPDATE users_webhooks SET last_sent = now() WHERE id = $1;`, n.Content.Webhook.ID)
+		if err != nil {
+			logger.WithError(err).Errorf("error updating users_webhooks table; setting last sent")
+		}
+		return
+	}
 
+	if attempt >= maxWebhookAttempts {
+		logger.Warnf("giving up delivering webhook notification %v to webhook %v after %v attempts", n.Id, n.Content.Webhook.ID, attempt)
+		_, err = useDB.Exec(`UPDATE notification_queue SET sent = now(), attempts = $2 WHERE id = $1`, n.Id, attempt)
+	} else {
+		_, err = useDB.Exec(`UPDATE notification_queue SET attempts = $2, next_attempt = now() + $3 * INTERVAL '1 second' WHERE id = $1`, n.Id, attempt, webhookRetryBackoff(attempt).Seconds())
+	}
+	if err != nil {
+		logger.WithError(err).Errorf("error updating notification_queue table")
+		return
+	}
+
+	// the attempts of generic webhooks are counted per delivery, the retries of the webhook only pause discord and chat webhooks
+	_, err = useDB.Exec(`UPDATE users_webhooks SET last_sent = now(), request = $2, response = $3 WHERE id = $1;`, n.Content.Webhook.ID, n.Content, errResp)
+	if err != nil {
+		logger.WithError(err).Errorf("error updating users_webhooks table; setting the failed request")
 	}
-	return nil
 }
 
 func sendDiscordNotifications(useDB *sqlx.DB) error {
@@ -1165,6 +1383,7 @@ func collectBlockProposalNotifications(notificationsByUserID map[uint64]map[type
 		Status        uint64 `db:"status"`
 		Slot          uint64 `db:"slot"`
 		ExecBlock     uint64 `db:"exec_block_number"`
+		MissedReason  string `db:"missed_reason"`
 		ExecRewardETH float64
 	}
 
@@ -1174,7 +1393,7 @@ func collectBlockProposalNotifications(notificationsByUserID map[uint64]map[type
 	}
 
 	events := make([]dbResult, 0)
-	err = db.WriterDb.Select(&events, "SELECT slot, proposer, status, COALESCE(exec_block_number, 0) AS exec_block_number FROM blocks WHERE epoch = $1 AND status = $2", epoch, fmt.Sprintf("%d", status))
+	err = db.WriterDb.Select(&events, "SELECT slot, proposer, status, COALESCE(exec_block_number, 0) AS exec_block_number, COALESCE(missed_reason, '') AS missed_reason FROM blocks WHERE epoch = $1 AND status = $2", epoch, fmt.Sprintf("%d", status))
 	if err != nil {
 		return fmt.Errorf("error retrieving slots for epoch %v: %w", epoch, err)
 	}
@@ -1249,15 +1468,9 @@ func collectBlockProposalNotifications(notificationsByUserID map[uint64]map[type
 				Reward:         event.ExecRewardETH,
 				EventFilter:    hex.EncodeToString(pubkey),
 				Slot:           event.Slot,
+				MissedReason:   types.MissedSlotReason(event.MissedReason),
 			}
-			if _, exists := notificationsByUserID[*sub.UserID]; !exists {
-				notificationsByUserID[*sub.UserID] = map[types.EventName][]types.Notification{}
-			}
-			if _, exists := notificationsByUserID[*sub.UserID][n.GetEventName()]; !exists {
-				notificationsByUserID[*sub.UserID][n.GetEventName()] = []types.Notification{}
-			}
-			notificationsByUserID[*sub.UserID][n.GetEventName()] = append(notificationsByUserID[*sub.UserID][n.GetEventName()], n)
-			metrics.NotificationsCollected.WithLabelValues(string(n.GetEventName())).Inc()
+			addNotification(notificationsByUserID, *sub.UserID, n)
 		}
 	}
 
@@ -1270,10 +1483,11 @@ type validatorProposalNotification struct {
 	ValidatorPublicKey string
 	Epoch              uint64
 	Slot               uint64
-	Status             uint64 // * Can be 0 = scheduled, 1 executed, 2 missed */
+	Status             uint64 // * Can be 0 = scheduled, 1 executed, 2 missed, 3 orphaned */
 	EventName          types.EventName
 	EventFilter        string
 	Reward             float64
+	MissedReason       types.MissedSlotReason
 	UnsubscribeHash    sql.NullString
 }
 
@@ -1318,8 +1532,11 @@ func (n *validatorProposalNotification) GetInfo(includeUrl bool) string {
 		generalPart = fmt.Sprintf(`New scheduled block proposal at slot %s for Validator %s.`, slot, vali)
 	case 1:
 		generalPart = fmt.Sprintf(`Validator %s proposed block at slot %s with %v %v execution reward.`, vali, slot, n.Reward, utils.Config.Frontend.ElCurrency)
-	case 2:
+	case 2, 3:
 		generalPart = fmt.Sprintf(`Validator %s missed a block proposal at slot %s.`, vali, slot)
+		if description := n.MissedReason.Description(); description != "" {
+			generalPart = fmt.Sprintf(`Validator %s missed a block proposal at slot %s, %s.`, vali, slot, description)
+		}
 	}
 	return generalPart + suffix
 }
@@ -1330,7 +1547,7 @@ func (n *validatorProposalNotification) GetTitle() string {
 		return "Block Proposal Scheduled"
 	case 1:
 		return "New Block Proposal"
-	case 2:
+	case 2, 3:
 		return "Block Proposal Missed"
 	}
 	return "-"
@@ -1347,8 +1564,11 @@ func (n *validatorProposalNotification) GetInfoMarkdown() string {
 		generalPart = fmt.Sprintf(`New scheduled block proposal at slot [%[3]v](https://%[1]v/slot/%[3]v) for Validator [%[2]v](https://%[1]v/validator/%[2]v).`, utils.Config.Frontend.SiteDomain, n.ValidatorIndex, n.Slot)
 	case 1:
 		generalPart = fmt.Sprintf(`Validator [%[2]v](https://%[1]v/validator/%[2]v) proposed a new block at slot [%[3]v](https://%[1]v/slot/%[3]v) with %[4]v %[5]v execution reward.`, utils.Config.Frontend.SiteDomain, n.ValidatorIndex, n.Slot, n.Reward, utils.Config.Frontend.ElCurrency)
-	case 2:
+	case 2, 3:
 		generalPart = fmt.Sprintf(`Validator [%[2]v](https://%[1]v/validator/%[2]v) missed a block proposal at slot [%[3]v](https://%[1]v/slot/%[3]v).`, utils.Config.Frontend.SiteDomain, n.ValidatorIndex, n.Slot)
+		if description := n.MissedReason.Description(); description != "" {
+			generalPart = fmt.Sprintf(`Validator [%[2]v](https://%[1]v/validator/%[2]v) missed a block proposal at slot [%[3]v](https://%[1]v/slot/%[3]v), %[4]v.`, utils.Config.Frontend.SiteDomain, n.ValidatorIndex, n.Slot, description)
+		}
 	}
 
 	return generalPart
@@ -1910,14 +2130,7 @@ func collectValidatorGotSlashedNotifications(notificationsByUserID map[uint64]ma
 			UnsubscribeHash: sub.UnsubscribeHash,
 		}
 
-		if _, exists := notificationsByUserID[sub.UserId]; !exists {
-			notificationsByUserID[sub.UserId] = map[types.EventName][]types.Notification{}
-		}
-		if _, exists := notificationsByUserID[sub.UserId][n.GetEventName()]; !exists {
-			notificationsByUserID[sub.UserId][n.GetEventName()] = []types.Notification{}
-		}
-		notificationsByUserID[sub.UserId][n.GetEventName()] = append(notificationsByUserID[sub.UserId][n.GetEventName()], n)
-		metrics.NotificationsCollected.WithLabelValues(string(n.GetEventName())).Inc()
+		addNotification(notificationsByUserID, sub.UserId, n)
 	}
 
 	return nil
@@ -2022,14 +2235,7 @@ func collectWithdrawalNotifications(notificationsByUserID map[uint64]map[types.E
 					EventFilter:     hex.EncodeToString(event.Pubkey),
 					UnsubscribeHash: sub.UnsubscribeHash,
 				}
-				if _, exists := notificationsByUserID[*sub.UserID]; !exists {
-					notificationsByUserID[*sub.UserID] = map[types.EventName][]types.Notification{}
-				}
-				if _, exists := notificationsByUserID[*sub.UserID][n.GetEventName()]; !exists {
-					notificationsByUserID[*sub.UserID][n.GetEventName()] = []types.Notification{}
-				}
-				notificationsByUserID[*sub.UserID][n.GetEventName()] = append(notificationsByUserID[*sub.UserID][n.GetEventName()], n)
-				metrics.NotificationsCollected.WithLabelValues(string(n.GetEventName())).Inc()
+				addNotification(notificationsByUserID, *sub.UserID, n)
 			}
 		}
 	}
@@ -2037,144 +2243,535 @@ func collectWithdrawalNotifications(notificationsByUserID map[uint64]map[types.E
 	return nil
 }
 
-type ethClientNotification struct {
+type validatorBalanceDecreasedNotification struct {
 	SubscriptionID  uint64
 	UserID          uint64
+	ValidatorIndex  uint64
 	Epoch           uint64
-	EthClient       string
+	StartBalance    uint64
+	EndBalance      uint64
+	Withdrawals     uint64
 	EventFilter     string
 	UnsubscribeHash sql.NullString
 }
 
-func (n *ethClientNotification) GetLatestState() string {
-	return ""
+// decrease returns the amount the balance decreased by, withdrawals do not count as a decrease
+func (n *validatorBalanceDecreasedNotification) decrease() uint64 {
+	return n.StartBalance - n.EndBalance - n.Withdrawals
 }
 
-func (n *ethClientNotification) GetUnsubscribeHash() string {
+func (n *validatorBalanceDecreasedNotification) GetLatestState() string {
+	return balanceDecreasedState
+}
+
+func (n *validatorBalanceDecreasedNotification) GetUnsubscribeHash() string {
 	if n.UnsubscribeHash.Valid {
 		return n.UnsubscribeHash.String
 	}
 	return ""
 }
 
-func (n *ethClientNotification) GetEmailAttachment() *types.EmailAttachment {
+func (n *validatorBalanceDecreasedNotification) GetEmailAttachment() *types.EmailAttachment {
 	return nil
 }
 
-func (n *ethClientNotification) GetSubscriptionID() uint64 {
+func (n *validatorBalanceDecreasedNotification) GetSubscriptionID() uint64 {
 	return n.SubscriptionID
 }
 
-func (n *ethClientNotification) GetEpoch() uint64 {
+func (n *validatorBalanceDecreasedNotification) GetEpoch() uint64 {
 	return n.Epoch
 }
 
-func (n *ethClientNotification) GetEventName() types.EventName {
-	return types.EthClientUpdateEventName
+func (n *validatorBalanceDecreasedNotification) GetEventName() types.EventName {
+	return types.ValidatorBalanceDecreasedEventName
 }
 
-func (n *ethClientNotification) GetInfo(includeUrl bool) string {
-	generalPart := fmt.Sprintf(`A new version for %s is available.`, n.EthClient)
+func (n *validatorBalanceDecreasedNotification) GetInfo(includeUrl bool) string {
+	generalPart := fmt.Sprintf(`The balance of validator %v decreased by %v to %v in epoch %v.`, n.ValidatorIndex, utils.FormatClCurrencyString(n.decrease(), utils.Config.Frontend.MainCurrency, 6, true, false, false), utils.FormatClCurrencyString(n.EndBalance, utils.Config.Frontend.MainCurrency, 6, true, false, false), n.Epoch)
 	if includeUrl {
-		url := ""
-		switch n.EthClient {
-		case "Geth":
-			url = "https://github.com/ethereum/go-ethereum/releases"
-		case "Nethermind":
-			url = "https://github.com/NethermindEth/nethermind/releases"
-		case "Teku":
-			url = "https://github.com/ConsenSys/teku/releases"
-		case "Prysm":
-			url = "https://github.com/prysmaticlabs/prysm/releases"
-		case "Nimbus":
-			url = "https://github.com/status-im/nimbus-eth2/releases"
-		case "Lighthouse":
-			url = "https://github.com/sigp/lighthouse/releases"
-		case "Erigon":
-			url = "https://github.com/ledgerwatch/erigon/releases"
-		case "Rocketpool":
-			url = "https://github.com/rocket-pool/smartnode-install/releases"
-		case "MEV-Boost":
-			url = "https://github.com/flashbots/mev-boost/releases"
-		case "Lodestar":
-			url = "https://github.com/chainsafe/lodestar/releases"
-		default:
-			url = "https://beaconcha.in/ethClients"
-		}
-
-		return generalPart + " " + url
+		return generalPart + getUrlPart(n.ValidatorIndex)
 	}
 	return generalPart
 }
 
-func (n *ethClientNotification) GetTitle() string {
-	return fmt.Sprintf("New %s update", n.EthClient)
+func (n *validatorBalanceDecreasedNotification) GetTitle() string {
+	return "Validator Balance Decreased"
 }
 
-func (n *ethClientNotification) GetEventFilter() string {
+func (n *validatorBalanceDecreasedNotification) GetEventFilter() string {
 	return n.EventFilter
 }
 
-func (n *ethClientNotification) GetInfoMarkdown() string {
-	url := ""
-	switch n.EthClient {
-	case "Geth":
-		url = "https://github.com/ethereum/go-ethereum/releases"
-	case "Nethermind":
-		url = "https://github.com/NethermindEth/nethermind/releases"
-	case "Teku":
-		url = "https://github.com/ConsenSys/teku/releases"
-	case "Prysm":
-		url = "https://github.com/prysmaticlabs/prysm/releases"
-	case "Nimbus":
-		url = "https://github.com/status-im/nimbus-eth2/releases"
-	case "Lighthouse":
-		url = "https://github.com/sigp/lighthouse/releases"
-	case "Erigon":
-		url = "https://github.com/ledgerwatch/erigon/releases"
-	case "Rocketpool":
-		url = "https://github.com/rocket-pool/smartnode-install/releases"
-	case "MEV-Boost":
-		url = "https://github.com/flashbots/mev-boost/releases"
-	case "Lodestar":
-		url = "https://github.com/chainsafe/lodestar/releases"
-	default:
-		url = "https://beaconcha.in/ethClients"
-	}
-
-	generalPart := fmt.Sprintf(`A new version for [%s](%s) is available.`, n.EthClient, url)
-
+func (n *validatorBalanceDecreasedNotification) GetInfoMarkdown() string {
+	generalPart := fmt.Sprintf(`The balance of validator [%[1]v](https://%[5]v/validator/%[1]v) decreased by %[2]v to %[3]v in epoch [%[4]v](https://%[5]v/epoch/%[4]v).`, n.ValidatorIndex, utils.FormatClCurrencyString(n.decrease(), utils.Config.Frontend.MainCurrency, 6, true, false, false), utils.FormatClCurrencyString(n.EndBalance, utils.Config.Frontend.MainCurrency, 6, true, false, false), n.Epoch, utils.Config.Frontend.SiteDomain)
 	return generalPart
 }
 
-func collectEthClientNotifications(notificationsByUserID map[uint64]map[types.EventName][]types.Notification, eventName types.EventName) error {
-	updatedClients := ethclients.GetUpdatedClients() //only check if there are new updates
-	for _, client := range updatedClients {
-		var dbResult []struct {
-			SubscriptionID  uint64         `db:"id"`
-			UserID          uint64         `db:"user_id"`
-			Epoch           uint64         `db:"created_epoch"`
-			EventFilter     string         `db:"event_filter"`
-			UnsubscribeHash sql.NullString `db:"unsubscribe_hash"`
+// balanceDecreasedState is stored as internal state of a subscription once a balance decrease has been notified,
+// no further notifications are sent for the validator until its balance is increasing again
+const balanceDecreasedState = "decreased"
+
+// evaluateValidatorBalanceDecreases returns the notifications of the subscriptions to validators whose balance decreased in the epoch
+// and the subscriptions that have to be reset as the balance of their validator is increasing again.
+// The withdrawals of each validator processed in the epoch are added back onto its end balance, so partial withdrawals and exits do not count as a decrease.
+func evaluateValidatorBalanceDecreases(balances map[uint64][]*types.ValidatorBalance, withdrawals map[uint64]uint64, pubkeyByIndex map[uint64]string, subMap map[string][]types.Subscription, epoch uint64) ([]*validatorBalanceDecreasedNotification, []int64, error) {
+	notifications := []*validatorBalanceDecreasedNotification{}
+	// subscriptions of validators whose balance is increasing again will be notified about the next decrease
+	resetSubs := make([]int64, 0)
+	for validator, history := range balances {
+		var start, end *types.ValidatorBalance
+		for _, balance := range history {
+			switch balance.Epoch {
+			case epoch - 1:
+				start = balance
+			case epoch:
+				end = balance
+			}
+		}
+		if start == nil || end == nil {
+			continue
+		}
+		withdrawn := withdrawals[validator]
+		endBalance := end.Balance + withdrawn
+		if start.Balance == endBalance {
+			continue
 		}
 
-		err := db.FrontendWriterDB.Select(&dbResult, `
-			SELECT us.id, us.user_id, us.created_epoch, us.event_filter, ENCODE(us.unsubscribe_hash, 'hex') AS unsubscribe_hash
-			FROM users_subscriptions AS us
-			WHERE 
-				us.event_name=$1 
-			AND 
-				us.event_filter=$2 
-			AND 
-				((us.last_sent_ts <= NOW() - INTERVAL '2 DAY' AND TO_TIMESTAMP($3) > us.last_sent_ts) OR us.last_sent_ts IS NULL)
-			`,
-			eventName, strings.ToLower(client.Name), client.Date.Unix()) // was last notification sent 2 days ago for this client
+		filter := pubkeyByIndex[validator]
+		for _, sub := range subMap[filter] {
+			if sub.UserID == nil || sub.ID == nil {
+				return nil, nil, fmt.Errorf("error expected userId and subId to be defined but got user: %v, sub: %v", sub.UserID, sub.ID)
+			}
+			notified := sub.State.Valid && sub.State.String == balanceDecreasedState
 
-		if err != nil {
-			return err
-		}
+			if endBalance > start.Balance {
+				if notified {
+					resetSubs = append(resetSubs, int64(*sub.ID))
+				}
+				continue
+			}
+			if notified || epoch < sub.CreatedEpoch || (sub.LastEpoch != nil && *sub.LastEpoch >= epoch) {
+				continue
+			}
 
-		for _, r := range dbResult {
+			notifications = append(notifications, &validatorBalanceDecreasedNotification{
+				SubscriptionID:  *sub.ID,
+				UserID:          *sub.UserID,
+				ValidatorIndex:  validator,
+				Epoch:           epoch,
+				StartBalance:    start.Balance,
+				EndBalance:      end.Balance,
+				Withdrawals:     withdrawn,
+				EventFilter:     filter,
+				UnsubscribeHash: sub.UnsubscribeHash,
+			})
+		}
+	}
+	return notifications, resetSubs, nil
+}
+
+// collectValidatorBalanceDecreasedNotifications compares the balances of all subscribed validators at the end of the epoch with the previous epoch,
+// the balances of an epoch are taken from the state of its first slot
+func collectValidatorBalanceDecreasedNotifications(notificationsByUserID map[uint64]map[types.EventName][]types.Notification, epoch uint64) error {
+	if epoch == 0 {
+		return nil
+	}
+
+	_, subMap, err := db.GetSubsForEventFilter(types.ValidatorBalanceDecreasedEventName)
+	if err != nil {
+		return fmt.Errorf("error getting subscriptions for balance decreases %w", err)
+	}
+
+	validators := make([]uint64, 0, len(subMap))
+	pubkeyByIndex := make(map[uint64]string, len(subMap))
+	for filter := range subMap {
+		pubkey, err := hex.DecodeString(filter)
+		if err != nil {
+			continue
+		}
+		index, err := GetIndexForPubkey(pubkey)
+		if err != nil {
+			logger.Warnf("error retrieving validator index for pubkey %v: %v", filter, err)
+			continue
+		}
+		validators = append(validators, index)
+		pubkeyByIndex[index] = filter
+	}
+	if len(validators) == 0 {
+		return nil
+	}
+
+	balances, err := db.BigtableClient.GetValidatorBalanceHistory(validators, epoch-1, epoch)
+	if err != nil {
+		return fmt.Errorf("error getting validator balances from bigtable: %w", err)
+	}
+
+	// the withdrawals processed between the states of the first slots of both epochs
+	slotsPerEpoch := utils.Config.Chain.ClConfig.SlotsPerEpoch
+	withdrawals, err := db.GetWithdrawalsByValidatorForSlots(validators, (epoch-1)*slotsPerEpoch+1, epoch*slotsPerEpoch)
+	if err != nil {
+		return fmt.Errorf("error getting withdrawals for balance decreases: %w", err)
+	}
+
+	notifications, resetSubs, err := evaluateValidatorBalanceDecreases(balances, withdrawals, pubkeyByIndex, subMap, epoch)
+	if err != nil {
+		return err
+	}
+	for _, n := range notifications {
+		addNotification(notificationsByUserID, n.UserID, n)
+	}
+
+	if len(resetSubs) > 0 {
+		_, err := db.FrontendWriterDB.Exec(`UPDATE users_subscriptions SET internal_state = NULL WHERE id = ANY($1)`, pq.Int64Array(resetSubs))
+		if err != nil {
+			return fmt.Errorf("error resetting internal state of balance decrease subscriptions: %w", err)
+		}
+	}
+
+	return nil
+}
+
+type validatorDidSlashNotification struct {
+	SubscriptionID  uint64
+	ValidatorIndex  uint64
+	Epoch           uint64
+	Slashed         uint64
+	Reason          string
+	EventFilter     string
+	UnsubscribeHash sql.NullString
+}
+
+func (n *validatorDidSlashNotification) GetLatestState() string {
+	return ""
+}
+
+func (n *validatorDidSlashNotification) GetUnsubscribeHash() string {
+	if n.UnsubscribeHash.Valid {
+		return n.UnsubscribeHash.String
+	}
+	return ""
+}
+
+func (n *validatorDidSlashNotification) GetEmailAttachment() *types.EmailAttachment {
+	return nil
+}
+
+func (n *validatorDidSlashNotification) GetSubscriptionID() uint64 {
+	return n.SubscriptionID
+}
+
+func (n *validatorDidSlashNotification) GetEpoch() uint64 {
+	return n.Epoch
+}
+
+func (n *validatorDidSlashNotification) GetEventName() types.EventName {
+	return types.ValidatorDidSlashEventName
+}
+
+func (n *validatorDidSlashNotification) GetInfo(includeUrl bool) string {
+	generalPart := fmt.Sprintf(`Validator %v has slashed validator %v at epoch %v for %s.`, n.ValidatorIndex, n.Slashed, n.Epoch, n.Reason)
+	if includeUrl {
+		return generalPart + getUrlPart(n.ValidatorIndex)
+	}
+	return generalPart
+}
+
+func (n *validatorDidSlashNotification) GetTitle() string {
+	return "Validator did Slash"
+}
+
+func (n *validatorDidSlashNotification) GetEventFilter() string {
+	return n.EventFilter
+}
+
+func (n *validatorDidSlashNotification) GetInfoMarkdown() string {
+	generalPart := fmt.Sprintf(`Validator [%[1]v](https://%[5]v/validator/%[1]v) has slashed validator [%[2]v](https://%[5]v/validator/%[2]v) at epoch [%[3]v](https://%[5]v/epoch/%[3]v) for %[4]s.`, n.ValidatorIndex, n.Slashed, n.Epoch, n.Reason, utils.Config.Frontend.SiteDomain)
+	return generalPart
+}
+
+// collectValidatorDidSlashNotifications notifies the subscribers of the proposers that included a slashing
+func collectValidatorDidSlashNotifications(notificationsByUserID map[uint64]map[types.EventName][]types.Notification, epoch uint64) error {
+	_, subMap, err := db.GetSubsForEventFilter(types.ValidatorDidSlashEventName)
+	if err != nil {
+		return fmt.Errorf("error getting subscriptions for slashings %w", err)
+	}
+	if len(subMap) == 0 {
+		return nil
+	}
+
+	dbResult, err := db.GetValidatorsGotSlashed(epoch)
+	if err != nil {
+		return fmt.Errorf("error getting slashed validators from database, err: %w", err)
+	}
+
+	for _, event := range dbResult {
+		filter := hex.EncodeToString([]byte(event.SlasherPubkey))
+		for _, sub := range subMap[filter] {
+			if sub.UserID == nil || sub.ID == nil {
+				return fmt.Errorf("error expected userId and subId to be defined but got user: %v, sub: %v", sub.UserID, sub.ID)
+			}
+			if event.Epoch < sub.CreatedEpoch || (sub.LastEpoch != nil && *sub.LastEpoch >= event.Epoch) {
+				continue
+			}
+
+			logger.Infof("creating %v notification for validator %v in epoch %v", types.ValidatorDidSlashEventName, event.SlasherIndex, event.Epoch)
+			n := &validatorDidSlashNotification{
+				SubscriptionID:  *sub.ID,
+				ValidatorIndex:  event.SlasherIndex,
+				Epoch:           event.Epoch,
+				Slashed:         event.SlashedValidatorIndex,
+				Reason:          event.Reason,
+				EventFilter:     filter,
+				UnsubscribeHash: sub.UnsubscribeHash,
+			}
+			addNotification(notificationsByUserID, *sub.UserID, n)
+		}
+	}
+
+	return nil
+}
+
+type validatorDepositNotification struct {
+	SubscriptionID  uint64
+	ValidatorIndex  uint64
+	Epoch           uint64
+	Slot            uint64
+	Amount          uint64
+	EventFilter     string
+	UnsubscribeHash sql.NullString
+}
+
+func (n *validatorDepositNotification) GetLatestState() string {
+	return ""
+}
+
+func (n *validatorDepositNotification) GetUnsubscribeHash() string {
+	if n.UnsubscribeHash.Valid {
+		return n.UnsubscribeHash.String
+	}
+	return ""
+}
+
+func (n *validatorDepositNotification) GetEmailAttachment() *types.EmailAttachment {
+	return nil
+}
+
+func (n *validatorDepositNotification) GetSubscriptionID() uint64 {
+	return n.SubscriptionID
+}
+
+func (n *validatorDepositNotification) GetEpoch() uint64 {
+	return n.Epoch
+}
+
+func (n *validatorDepositNotification) GetEventName() types.EventName {
+	return types.ValidatorReceivedDepositEventName
+}
+
+func (n *validatorDepositNotification) GetInfo(includeUrl bool) string {
+	generalPart := fmt.Sprintf(`A deposit of %v has been processed for validator %v.`, utils.FormatClCurrencyString(n.Amount, utils.Config.Frontend.MainCurrency, 6, true, false, false), n.ValidatorIndex)
+	if includeUrl {
+		return generalPart + getUrlPart(n.ValidatorIndex)
+	}
+	return generalPart
+}
+
+func (n *validatorDepositNotification) GetTitle() string {
+	return "Deposit Processed"
+}
+
+func (n *validatorDepositNotification) GetEventFilter() string {
+	return n.EventFilter
+}
+
+func (n *validatorDepositNotification) GetInfoMarkdown() string {
+	generalPart := fmt.Sprintf(`A deposit of %[2]v has been processed for validator [%[1]v](https://%[4]v/validator/%[1]v) during slot [%[3]v](https://%[4]v/slot/%[3]v).`, n.ValidatorIndex, utils.FormatClCurrencyString(n.Amount, utils.Config.Frontend.MainCurrency, 6, true, false, false), n.Slot, utils.Config.Frontend.SiteDomain)
+	return generalPart
+}
+
+// collectDepositNotifications collects the notifications for all deposits that have been included in the epoch
+func collectDepositNotifications(notificationsByUserID map[uint64]map[types.EventName][]types.Notification, epoch uint64) error {
+	_, subMap, err := db.GetSubsForEventFilter(types.ValidatorReceivedDepositEventName)
+	if err != nil {
+		return fmt.Errorf("error getting subscriptions for deposits %w", err)
+	}
+	if len(subMap) == 0 {
+		return nil
+	}
+
+	events, err := db.GetEpochDeposits(epoch)
+	if err != nil {
+		return fmt.Errorf("error getting deposits from database, err: %w", err)
+	}
+
+	for _, event := range events {
+		subscribers, ok := subMap[hex.EncodeToString(event.Pubkey)]
+		if !ok {
+			continue
+		}
+		for _, sub := range subscribers {
+			if sub.UserID == nil || sub.ID == nil {
+				return fmt.Errorf("error expected userId and subId to be defined but got user: %v, sub: %v", sub.UserID, sub.ID)
+			}
+			if epoch < sub.CreatedEpoch || (sub.LastEpoch != nil && *sub.LastEpoch >= epoch) {
+				continue
+			}
+
+			n := &validatorDepositNotification{
+				SubscriptionID:  *sub.ID,
+				ValidatorIndex:  event.ValidatorIndex,
+				Epoch:           epoch,
+				Slot:            event.Slot,
+				Amount:          event.Amount,
+				EventFilter:     hex.EncodeToString(event.Pubkey),
+				UnsubscribeHash: sub.UnsubscribeHash,
+			}
+			addNotification(notificationsByUserID, *sub.UserID, n)
+		}
+	}
+
+	return nil
+}
+
+type ethClientNotification struct {
+	SubscriptionID  uint64
+	UserID          uint64
+	Epoch           uint64
+	EthClient       string
+	EventFilter     string
+	UnsubscribeHash sql.NullString
+}
+
+func (n *ethClientNotification) GetLatestState() string {
+	return ""
+}
+
+func (n *ethClientNotification) GetUnsubscribeHash() string {
+	if n.UnsubscribeHash.Valid {
+		return n.UnsubscribeHash.String
+	}
+	return ""
+}
+
+func (n *ethClientNotification) GetEmailAttachment() *types.EmailAttachment {
+	return nil
+}
+
+func (n *ethClientNotification) GetSubscriptionID() uint64 {
+	return n.SubscriptionID
+}
+
+func (n *ethClientNotification) GetEpoch() uint64 {
+	return n.Epoch
+}
+
+func (n *ethClientNotification) GetEventName() types.EventName {
+	return types.EthClientUpdateEventName
+}
+
+func (n *ethClientNotification) GetInfo(includeUrl bool) string {
+	generalPart := fmt.Sprintf(`A new version for %s is available.`, n.EthClient)
+	if includeUrl {
+		url := ""
+		switch n.EthClient {
+		case "Geth":
+			url = "https://github.com/ethereum/go-ethereum/releases"
+		case "Nethermind":
+			url = "https://github.com/NethermindEth/nethermind/releases"
+		case "Teku":
+			url = "https://github.com/ConsenSys/teku/releases"
+		case "Prysm":
+			url = "https://github.com/prysmaticlabs/prysm/releases"
+		case "Nimbus":
+			url = "https://github.com/status-im/nimbus-eth2/releases"
+		case "Lighthouse":
+			url = "https://github.com/sigp/lighthouse/releases"
+		case "Erigon":
+			url = "https://github.com/ledgerwatch/erigon/releases"
+		case "Rocketpool":
+			url = "https://github.com/rocket-pool/smartnode-install/releases"
+		case "MEV-Boost":
+			url = "https://github.com/flashbots/mev-boost/releases"
+		case "Lodestar":
+			url = "https://github.com/chainsafe/lodestar/releases"
+		default:
+			url = "https://beaconcha.in/ethClients"
+		}
+
+		return generalPart + " " + url
+	}
+	return generalPart
+}
+
+func (n *ethClientNotification) GetTitle() string {
+	return fmt.Sprintf("New %s update", n.EthClient)
+}
+
+func (n *ethClientNotification) GetEventFilter() string {
+	return n.EventFilter
+}
+
+func (n *ethClientNotification) GetInfoMarkdown() string {
+	url := ""
+	switch n.EthClient {
+	case "Geth":
+		url = "https://github.com/ethereum/go-ethereum/releases"
+	case "Nethermind":
+		url = "https://github.com/NethermindEth/nethermind/releases"
+	case "Teku":
+		url = "https://github.com/ConsenSys/teku/releases"
+	case "Prysm":
+		url = "https://github.com/prysmaticlabs/prysm/releases"
+	case "Nimbus":
+		url = "https://github.com/status-im/nimbus-eth2/releases"
+	case "Lighthouse":
+		url = "https://github.com/sigp/lighthouse/releases"
+	case "Erigon":
+		url = "https://github.com/ledgerwatch/erigon/releases"
+	case "Rocketpool":
+		url = "https://github.com/rocket-pool/smartnode-install/releases"
+	case "MEV-Boost":
+		url = "https://github.com/flashbots/mev-boost/releases"
+	case "Lodestar":
+		url = "https://github.com/chainsafe/lodestar/releases"
+	default:
+		url = "https://beaconcha.in/ethClients"
+	}
+
+	generalPart := fmt.Sprintf(`A new version for [%s](%s) is available.`, n.EthClient, url)
+
+	return generalPart
+}
+
+func collectEthClientNotifications(notificationsByUserID map[uint64]map[types.EventName][]types.Notification, eventName types.EventName) error {
+	updatedClients := ethclients.GetUpdatedClients() //only check if there are new updates
+	for _, client := range updatedClients {
+		var dbResult []struct {
+			SubscriptionID  uint64         `db:"id"`
+			UserID          uint64         `db:"user_id"`
+			Epoch           uint64         `db:"created_epoch"`
+			EventFilter     string         `db:"event_filter"`
+			UnsubscribeHash sql.NullString `db:"unsubscribe_hash"`
+		}
+
+		err := db.FrontendWriterDB.Select(&dbResult, `
+			SELECT us.id, us.user_id, us.created_epoch, us.event_filter, ENCODE(us.unsubscribe_hash, 'hex') AS unsubscribe_hash
+			FROM users_subscriptions AS us
+			WHERE 
+				us.event_name=$1 
+			AND 
+				us.event_filter=$2 
+			AND 
+				((us.last_sent_ts <= NOW() - INTERVAL '2 DAY' AND TO_TIMESTAMP($3) > us.last_sent_ts) OR us.last_sent_ts IS NULL)
+			`,
+			eventName, strings.ToLower(client.Name), client.Date.Unix()) // was last notification sent 2 days ago for this client
+
+		if err != nil {
+			return err
+		}
+
+		for _, r := range dbResult {
 			n := &ethClientNotification{
 				SubscriptionID:  r.SubscriptionID,
 				UserID:          r.UserID,
@@ -2183,14 +2780,7 @@ func collectEthClientNotifications(notificationsByUserID map[uint64]map[types.Ev
 				EthClient:       client.Name,
 				UnsubscribeHash: r.UnsubscribeHash,
 			}
-			if _, exists := notificationsByUserID[r.UserID]; !exists {
-				notificationsByUserID[r.UserID] = map[types.EventName][]types.Notification{}
-			}
-			if _, exists := notificationsByUserID[r.UserID][n.GetEventName()]; !exists {
-				notificationsByUserID[r.UserID][n.GetEventName()] = []types.Notification{}
-			}
-			notificationsByUserID[r.UserID][n.GetEventName()] = append(notificationsByUserID[r.UserID][n.GetEventName()], n)
-			metrics.NotificationsCollected.WithLabelValues(string(n.GetEventName())).Inc()
+			addNotification(notificationsByUserID, r.UserID, n)
 		}
 	}
 	return nil
@@ -2383,14 +2973,7 @@ func collectMonitoringMachine(
 			UnsubscribeHash: r.UnsubscribeHash,
 		}
 		//logrus.Infof("notify %v %v", eventName, n)
-		if _, exists := notificationsByUserID[r.UserID]; !exists {
-			notificationsByUserID[r.UserID] = map[types.EventName][]types.Notification{}
-		}
-		if _, exists := notificationsByUserID[r.UserID][n.GetEventName()]; !exists {
-			notificationsByUserID[r.UserID][n.GetEventName()] = []types.Notification{}
-		}
-		notificationsByUserID[r.UserID][n.GetEventName()] = append(notificationsByUserID[r.UserID][n.GetEventName()], n)
-		metrics.NotificationsCollected.WithLabelValues(string(n.GetEventName())).Inc()
+		addNotification(notificationsByUserID, r.UserID, n)
 	}
 
 	if eventName == types.MonitoringMachineOfflineEventName {
@@ -2401,6 +2984,57 @@ func collectMonitoringMachine(
 	return nil
 }
 
+// collectMonitoringMachineFallback notifies if the beaconnode of a machine is connected to its eth1 fallback respectively its validator client to its eth2 fallback
+func collectMonitoringMachineFallback(notificationsByUserID map[uint64]map[types.EventName][]types.Notification, eventName types.EventName, process string, epoch uint64) error {
+	var allSubscribed []MachineEvents
+	err := db.FrontendWriterDB.Select(&allSubscribed,
+		`SELECT 
+			us.user_id,
+			max(us.id) AS id,
+			ENCODE((array_agg(us.unsubscribe_hash))[1], 'hex') AS unsubscribe_hash,
+			event_filter AS machine,
+			COALESCE(event_threshold, 0) AS event_threshold
+		FROM users_subscriptions us 
+		WHERE us.event_name = $1 AND us.created_epoch <= $2 
+		AND (us.last_sent_epoch < ($2 - $3) OR us.last_sent_epoch IS NULL)
+		group by us.user_id, machine, event_threshold`,
+		eventName, epoch, 120)
+	if err != nil {
+		return err
+	}
+
+	rowKeys := gcp_bigtable.RowList{}
+	for _, data := range allSubscribed {
+		rowKeys = append(rowKeys, db.BigtableClient.GetMachineRowKey(data.UserID, process, data.MachineName))
+	}
+	if len(rowKeys) == 0 {
+		return nil
+	}
+
+	fallbackInUse, err := db.BigtableClient.GetMachineFallbackInUseForNotifications(rowKeys, time.Hour)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range allSubscribed {
+		if !fallbackInUse[r.UserID][r.MachineName] {
+			continue
+		}
+
+		n := &monitorMachineNotification{
+			SubscriptionID:  r.SubscriptionID,
+			MachineName:     r.MachineName,
+			UserID:          r.UserID,
+			EventName:       eventName,
+			Epoch:           epoch,
+			UnsubscribeHash: r.UnsubscribeHash,
+		}
+		addNotification(notificationsByUserID, r.UserID, n)
+	}
+
+	return nil
+}
+
 type monitorMachineNotification struct {
 	SubscriptionID  uint64
 	MachineName     string
@@ -2608,14 +3242,7 @@ func collectTaxReportNotificationNotifications(notificationsByUserID map[uint64]
 			EventFilter:     r.EventFilter,
 			UnsubscribeHash: r.UnsubscribeHash,
 		}
-		if _, exists := notificationsByUserID[r.UserID]; !exists {
-			notificationsByUserID[r.UserID] = map[types.EventName][]types.Notification{}
-		}
-		if _, exists := notificationsByUserID[r.UserID][n.GetEventName()]; !exists {
-			notificationsByUserID[r.UserID][n.GetEventName()] = []types.Notification{}
-		}
-		notificationsByUserID[r.UserID][n.GetEventName()] = append(notificationsByUserID[r.UserID][n.GetEventName()], n)
-		metrics.NotificationsCollected.WithLabelValues(string(n.GetEventName())).Inc()
+		addNotification(notificationsByUserID, r.UserID, n)
 	}
 
 	return nil
@@ -2712,14 +3339,210 @@ func collectNetworkNotifications(notificationsByUserID map[uint64]map[types.Even
 				EventFilter:     r.EventFilter,
 				UnsubscribeHash: r.UnsubscribeHash,
 			}
-			if _, exists := notificationsByUserID[r.UserID]; !exists {
-				notificationsByUserID[r.UserID] = map[types.EventName][]types.Notification{}
-			}
-			if _, exists := notificationsByUserID[r.UserID][n.GetEventName()]; !exists {
-				notificationsByUserID[r.UserID][n.GetEventName()] = []types.Notification{}
+			addNotification(notificationsByUserID, r.UserID, n)
+		}
+	}
+
+	return nil
+}
+
+type networkValidatorQueueNotification struct {
+	SubscriptionID  uint64
+	UserID          uint64
+	Epoch           uint64
+	EventName       types.EventName
+	QueueLength     uint64
+	ChurnLimit      uint64
+	EventFilter     string
+	UnsubscribeHash sql.NullString
+	State           string
+}
+
+func (n *networkValidatorQueueNotification) GetLatestState() string {
+	return n.State
+}
+
+func (n *networkValidatorQueueNotification) GetUnsubscribeHash() string {
+	if n.UnsubscribeHash.Valid {
+		return n.UnsubscribeHash.String
+	}
+	return ""
+}
+
+func (n *networkValidatorQueueNotification) GetEmailAttachment() *types.EmailAttachment {
+	return nil
+}
+
+func (n *networkValidatorQueueNotification) GetSubscriptionID() uint64 {
+	return n.SubscriptionID
+}
+
+func (n *networkValidatorQueueNotification) GetEpoch() uint64 {
+	return n.Epoch
+}
+
+func (n *networkValidatorQueueNotification) GetEventName() types.EventName {
+	return n.EventName
+}
+
+func (n *networkValidatorQueueNotification) GetInfo(includeUrl bool) string {
+	generalPart := ""
+	switch n.EventName {
+	case types.NetworkValidatorActivationQueueFullEventName:
+		generalPart = fmt.Sprintf(`The activation queue is full, %v validators are waiting to be activated at a rate of %v validators per epoch.`, n.QueueLength, n.ChurnLimit)
+	case types.NetworkValidatorActivationQueueNotFullEventName:
+		generalPart = fmt.Sprintf(`The activation queue is empty, new validators are activated at a rate of %v validators per epoch.`, n.ChurnLimit)
+	case types.NetworkValidatorExitQueueFullEventName:
+		generalPart = fmt.Sprintf(`The exit queue is full, %v validators are waiting to exit at a rate of %v validators per epoch.`, n.QueueLength, n.ChurnLimit)
+	case types.NetworkValidatorExitQueueNotFullEventName:
+		generalPart = fmt.Sprintf(`The exit queue is empty, validators exit at a rate of %v validators per epoch.`, n.ChurnLimit)
+	}
+	if includeUrl && generalPart != "" {
+		return generalPart + fmt.Sprintf(` Learn more at https://%v/validators`, utils.Config.Frontend.SiteDomain)
+	}
+	return generalPart
+}
+
+func (n *networkValidatorQueueNotification) GetTitle() string {
+	switch n.EventName {
+	case types.NetworkValidatorActivationQueueFullEventName:
+		return "Activation Queue Full"
+	case types.NetworkValidatorActivationQueueNotFullEventName:
+		return "Activation Queue Empty"
+	case types.NetworkValidatorExitQueueFullEventName:
+		return "Exit Queue Full"
+	case types.NetworkValidatorExitQueueNotFullEventName:
+		return "Exit Queue Empty"
+	}
+	return ""
+}
+
+func (n *networkValidatorQueueNotification) GetEventFilter() string {
+	return n.EventFilter
+}
+
+func (n *networkValidatorQueueNotification) GetInfoMarkdown() string {
+	generalPart := n.GetInfo(false)
+	if generalPart == "" {
+		return ""
+	}
+	return generalPart + fmt.Sprintf(` ([view queue](https://%v/validators))`, utils.Config.Frontend.SiteDomain)
+}
+
+const (
+	validatorQueueStateFull  = "full"
+	validatorQueueStateEmpty = "empty"
+)
+
+// validatorQueueSubscription is a subscription to one of the validator queue events
+type validatorQueueSubscription struct {
+	SubscriptionID  uint64         `db:"id"`
+	UserID          uint64         `db:"user_id"`
+	EventName       string         `db:"event_name"`
+	EventFilter     string         `db:"event_filter"`
+	UnsubscribeHash sql.NullString `db:"unsubscribe_hash"`
+	State           sql.NullString `db:"internal_state"`
+}
+
+// validatorQueueEvent holds the state of one validator queue and the events notifying about it
+type validatorQueueEvent struct {
+	FullEventName  types.EventName
+	EmptyEventName types.EventName
+	QueueLength    uint64
+	ChurnLimit     uint64
+}
+
+// evaluateValidatorQueueSubscriptions returns the notifications of the subscriptions to the event matching the current state of each queue.
+// A queue is full as long as validators are waiting in it and empty otherwise. The notified state is stored in the internal state of a subscription,
+// so a subscription is notified once when the queue enters its state. The subscriptions to the opposite state are returned to be reset,
+// so that they are notified again once the queue enters their state.
+func evaluateValidatorQueueSubscriptions(queues []validatorQueueEvent, subs []validatorQueueSubscription, epoch uint64) ([]*networkValidatorQueueNotification, []int64) {
+	notifications := []*networkValidatorQueueNotification{}
+	resetSubs := []int64{}
+	for _, queue := range queues {
+		state := validatorQueueStateEmpty
+		eventName, otherEventName := queue.EmptyEventName, queue.FullEventName
+		if queue.QueueLength > 0 {
+			state = validatorQueueStateFull
+			eventName, otherEventName = queue.FullEventName, queue.EmptyEventName
+		}
+
+		for _, sub := range subs {
+			switch sub.EventName {
+			case utils.GetNetwork() + ":" + string(otherEventName):
+				if sub.State.Valid {
+					resetSubs = append(resetSubs, int64(sub.SubscriptionID))
+				}
+			case utils.GetNetwork() + ":" + string(eventName):
+				if sub.State.Valid && sub.State.String == state {
+					continue
+				}
+				notifications = append(notifications, &networkValidatorQueueNotification{
+					SubscriptionID:  sub.SubscriptionID,
+					UserID:          sub.UserID,
+					Epoch:           epoch,
+					EventName:       eventName,
+					QueueLength:     queue.QueueLength,
+					ChurnLimit:      queue.ChurnLimit,
+					EventFilter:     sub.EventFilter,
+					UnsubscribeHash: sub.UnsubscribeHash,
+					State:           state,
+				})
 			}
-			notificationsByUserID[r.UserID][n.GetEventName()] = append(notificationsByUserID[r.UserID][n.GetEventName()], n)
-			metrics.NotificationsCollected.WithLabelValues(string(n.GetEventName())).Inc()
+		}
+	}
+	return notifications, resetSubs
+}
+
+func collectNetworkValidatorQueueNotifications(notificationsByUserID map[uint64]map[types.EventName][]types.Notification, epoch uint64) error {
+	queue, err := db.GetLatestValidatorQueue()
+	if err != nil {
+		return err
+	}
+	if queue == nil {
+		return nil
+	}
+
+	activeValidatorCount, err := db.GetActiveValidatorCount()
+	if err != nil {
+		return fmt.Errorf("error getting active validator count: %w", err)
+	}
+	activationChurnLimit, err := getValidatorActivationChurnLimit(activeValidatorCount, epoch)
+	if err != nil {
+		return fmt.Errorf("error getting validator activation churn limit: %w", err)
+	}
+	exitChurnLimit, err := getValidatorChurnLimit(activeValidatorCount)
+	if err != nil {
+		return fmt.Errorf("error getting validator churn limit: %w", err)
+	}
+
+	eventNames := []string{}
+	for _, eventName := range []types.EventName{types.NetworkValidatorActivationQueueFullEventName, types.NetworkValidatorActivationQueueNotFullEventName, types.NetworkValidatorExitQueueFullEventName, types.NetworkValidatorExitQueueNotFullEventName} {
+		eventNames = append(eventNames, utils.GetNetwork()+":"+string(eventName))
+	}
+
+	var subs []validatorQueueSubscription
+	err = db.FrontendWriterDB.Select(&subs, `
+		SELECT us.id, us.user_id, us.event_name, us.event_filter, ENCODE(us.unsubscribe_hash, 'hex') AS unsubscribe_hash, us.internal_state
+		FROM users_subscriptions AS us
+		WHERE us.event_name = ANY($1) AND us.created_epoch <= $2`,
+		pq.StringArray(eventNames), epoch)
+	if err != nil {
+		return err
+	}
+
+	notifications, resetSubs := evaluateValidatorQueueSubscriptions([]validatorQueueEvent{
+		{types.NetworkValidatorActivationQueueFullEventName, types.NetworkValidatorActivationQueueNotFullEventName, queue.Activating, activationChurnLimit},
+		{types.NetworkValidatorExitQueueFullEventName, types.NetworkValidatorExitQueueNotFullEventName, queue.Exiting, exitChurnLimit},
+	}, subs, epoch)
+	for _, n := range notifications {
+		addNotification(notificationsByUserID, n.UserID, n)
+	}
+
+	if len(resetSubs) > 0 {
+		_, err := db.FrontendWriterDB.Exec(`UPDATE users_subscriptions SET internal_state = NULL WHERE id = ANY($1)`, pq.Int64Array(resetSubs))
+		if err != nil {
+			return fmt.Errorf("error resetting the state of validator queue subscriptions: %w", err)
 		}
 	}
 
@@ -2845,14 +3668,7 @@ func collectRocketpoolComissionNotifications(notificationsByUserID map[uint64]ma
 				ExtraData:       strconv.FormatInt(int64(fee*100), 10) + "%",
 				UnsubscribeHash: r.UnsubscribeHash,
 			}
-			if _, exists := notificationsByUserID[r.UserID]; !exists {
-				notificationsByUserID[r.UserID] = map[types.EventName][]types.Notification{}
-			}
-			if _, exists := notificationsByUserID[r.UserID][n.GetEventName()]; !exists {
-				notificationsByUserID[r.UserID][n.GetEventName()] = []types.Notification{}
-			}
-			notificationsByUserID[r.UserID][n.GetEventName()] = append(notificationsByUserID[r.UserID][n.GetEventName()], n)
-			metrics.NotificationsCollected.WithLabelValues(string(n.GetEventName())).Inc()
+			addNotification(notificationsByUserID, r.UserID, n)
 		}
 	}
 
@@ -2899,14 +3715,7 @@ func collectRocketpoolRewardClaimRoundNotifications(notificationsByUserID map[ui
 				EventName:       eventName,
 				UnsubscribeHash: r.UnsubscribeHash,
 			}
-			if _, exists := notificationsByUserID[r.UserID]; !exists {
-				notificationsByUserID[r.UserID] = map[types.EventName][]types.Notification{}
-			}
-			if _, exists := notificationsByUserID[r.UserID][n.GetEventName()]; !exists {
-				notificationsByUserID[r.UserID][n.GetEventName()] = []types.Notification{}
-			}
-			notificationsByUserID[r.UserID][n.GetEventName()] = append(notificationsByUserID[r.UserID][n.GetEventName()], n)
-			metrics.NotificationsCollected.WithLabelValues(string(n.GetEventName())).Inc()
+			addNotification(notificationsByUserID, r.UserID, n)
 		}
 	}
 
@@ -3024,14 +3833,7 @@ func collectRocketpoolRPLCollateralNotifications(notificationsByUserID map[uint6
 			ExtraData:       strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.2f", threshold*100), "0"), "."),
 			UnsubscribeHash: sub.UnsubscribeHash,
 		}
-		if _, exists := notificationsByUserID[*sub.UserID]; !exists {
-			notificationsByUserID[*sub.UserID] = map[types.EventName][]types.Notification{}
-		}
-		if _, exists := notificationsByUserID[*sub.UserID][n.GetEventName()]; !exists {
-			notificationsByUserID[*sub.UserID][n.GetEventName()] = []types.Notification{}
-		}
-		notificationsByUserID[*sub.UserID][n.GetEventName()] = append(notificationsByUserID[*sub.UserID][n.GetEventName()], n)
-		metrics.NotificationsCollected.WithLabelValues(string(n.GetEventName())).Inc()
+		addNotification(notificationsByUserID, *sub.UserID, n)
 	}
 
 	return nil
@@ -3134,14 +3936,7 @@ func collectSyncCommittee(notificationsByUserID map[uint64]map[types.EventName][
 			ExtraData:       fmt.Sprintf("%v|%v|%v", mapping[r.EventFilter], nextPeriod*utils.Config.Chain.ClConfig.EpochsPerSyncCommitteePeriod, (nextPeriod+1)*utils.Config.Chain.ClConfig.EpochsPerSyncCommitteePeriod),
 			UnsubscribeHash: r.UnsubscribeHash,
 		}
-		if _, exists := notificationsByUserID[r.UserID]; !exists {
-			notificationsByUserID[r.UserID] = map[types.EventName][]types.Notification{}
-		}
-		if _, exists := notificationsByUserID[r.UserID][n.GetEventName()]; !exists {
-			notificationsByUserID[r.UserID][n.GetEventName()] = []types.Notification{}
-		}
-		notificationsByUserID[r.UserID][n.GetEventName()] = append(notificationsByUserID[r.UserID][n.GetEventName()], n)
-		metrics.NotificationsCollected.WithLabelValues(string(n.GetEventName())).Inc()
+		addNotification(notificationsByUserID, r.UserID, n)
 	}
 
 	return nil
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"database/sql"
+	"eth2-exporter/types"
+	"eth2-exporter/utils"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestSignWebhookPayload(t *testing.T) {
+	signature := signWebhookPayload("secret", 1700000000, []byte(`{"event":"x"}`))
+	expected := "ca458d767c4041a70394d25abfdb6d6bc3777d2044b7dcf28fcc31911996fde4"
+	if signature != expected {
+		t.Errorf("expected signature %v, got %v", expected, signature)
+	}
+}
+
+func TestWebhookRetryBackoff(t *testing.T) {
+	total := time.Duration(0)
+	for attempt := 1; attempt < maxWebhookAttempts; attempt++ {
+		backoff := webhookRetryBackoff(attempt)
+		min := webhookBackoffBase << (attempt - 1)
+		if backoff < min || backoff > min+min/2 {
+			t.Errorf("backoff %v of attempt %v is not within [%v, %v]", backoff, attempt, min, min+min/2)
+		}
+		total += backoff
+	}
+	// queue entries are garbage collected after one hour
+	if total >= time.Hour {
+		t.Errorf("the last attempt is scheduled after the queue entry is garbage collected (%v)", total)
+	}
+}
+
+func TestEvaluateValidatorQueueSubscriptions(t *testing.T) {
+	utils.Config = &types.Config{}
+	utils.Config.Chain.ClConfig.ConfigName = "mainnet"
+
+	subs := []validatorQueueSubscription{
+		{SubscriptionID: 1, UserID: 10, EventName: "mainnet:" + string(types.NetworkValidatorActivationQueueFullEventName)},
+		{SubscriptionID: 2, UserID: 10, EventName: "mainnet:" + string(types.NetworkValidatorActivationQueueNotFullEventName), State: sql.NullString{String: validatorQueueStateEmpty, Valid: true}},
+		{SubscriptionID: 3, UserID: 20, EventName: "mainnet:" + string(types.NetworkValidatorExitQueueFullEventName), State: sql.NullString{String: validatorQueueStateFull, Valid: true}},
+		{SubscriptionID: 4, UserID: 20, EventName: "mainnet:" + string(types.NetworkValidatorExitQueueNotFullEventName)},
+		{SubscriptionID: 5, UserID: 30, EventName: "gnosis:" + string(types.NetworkValidatorActivationQueueFullEventName)},
+	}
+	queues := func(activating, exiting uint64) []validatorQueueEvent {
+		return []validatorQueueEvent{
+			{types.NetworkValidatorActivationQueueFullEventName, types.NetworkValidatorActivationQueueNotFullEventName, activating, 8},
+			{types.NetworkValidatorExitQueueFullEventName, types.NetworkValidatorExitQueueNotFullEventName, exiting, 8},
+		}
+	}
+
+	tests := []struct {
+		Name       string
+		Activating uint64
+		Exiting    uint64
+		Expected   string
+		Reset      string
+	}{
+		{"activation queue filled, exit queue still full", 100, 5, "[1:full]", "[2]"},
+		{"both queues empty", 0, 0, "[4:empty]", "[3]"},
+		{"both queues full", 1, 1, "[1:full]", "[2]"},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			notifications, resetSubs := evaluateValidatorQueueSubscriptions(queues(test.Activating, test.Exiting), subs, 100)
+			actual := []string{}
+			for _, n := range notifications {
+				actual = append(actual, fmt.Sprintf("%v:%v", n.SubscriptionID, n.GetLatestState()))
+			}
+			if fmt.Sprint(actual) != test.Expected {
+				t.Errorf("expected notifications %v, got %v", test.Expected, actual)
+			}
+			if fmt.Sprint(resetSubs) != test.Reset {
+				t.Errorf("expected reset subscriptions %v, got %v", test.Reset, resetSubs)
+			}
+		})
+	}
+}
+
+func TestEvaluateValidatorBalanceDecreases(t *testing.T) {
+	sub := func(id, userID uint64, state string) types.Subscription {
+		s := types.Subscription{ID: &id, UserID: &userID}
+		if state != "" {
+			s.State = sql.NullString{String: state, Valid: true}
+		}
+		return s
+	}
+	subMap := map[string][]types.Subscription{
+		"aa": {sub(1, 10, ""), sub(2, 20, balanceDecreasedState)},
+		"bb": {sub(3, 10, balanceDecreasedState)},
+		"cc": {sub(4, 30, "")},
+		"dd": {sub(5, 40, "")},
+		"ee": {sub(6, 50, "")},
+	}
+	pubkeyByIndex := map[uint64]string{1: "aa", 2: "bb", 3: "cc", 4: "dd", 5: "ee"}
+	balances := map[uint64][]*types.ValidatorBalance{
+		// decreased, only the subscription that has not been notified yet is notified
+		1: {{Epoch: 9, Balance: 32000000000}, {Epoch: 10, Balance: 31999990000}},
+		// increasing again, the notified subscription is reset
+		2: {{Epoch: 9, Balance: 32000000000}, {Epoch: 10, Balance: 32000010000}},
+		// no balance of the previous epoch
+		3: {{Epoch: 10, Balance: 31000000000}},
+		// the only drop comes from a partial withdrawal
+		4: {{Epoch: 9, Balance: 32012000000}, {Epoch: 10, Balance: 32000010000}},
+		// decreased in addition to a withdrawal
+		5: {{Epoch: 9, Balance: 32012000000}, {Epoch: 10, Balance: 31999990000}},
+	}
+	withdrawals := map[uint64]uint64{4: 12000000, 5: 12000000}
+
+	notifications, resetSubs, err := evaluateValidatorBalanceDecreases(balances, withdrawals, pubkeyByIndex, subMap, 10)
+	if err != nil {
+		t.Fatalf("error evaluating balance decreases: %v", err)
+	}
+	sort.Slice(notifications, func(i, j int) bool {
+		return notifications[i].SubscriptionID < notifications[j].SubscriptionID
+	})
+	if len(notifications) != 2 || notifications[0].SubscriptionID != 1 || notifications[0].UserID != 10 || notifications[0].ValidatorIndex != 1 {
+		t.Fatalf("expected notifications of subscription 1 for validator 1 and subscription 6 for validator 5, got %+v", notifications)
+	}
+	if notifications[0].GetLatestState() != balanceDecreasedState {
+		t.Errorf("expected the notification to store the decreased state, got %v", notifications[0].GetLatestState())
+	}
+	if notifications[1].SubscriptionID != 6 || notifications[1].ValidatorIndex != 5 || notifications[1].decrease() != 10000 {
+		t.Errorf("expected a decrease of 10000 of validator 5 without its withdrawal, got %+v", notifications[1])
+	}
+	if fmt.Sprint(resetSubs) != "[3]" {
+		t.Errorf("expected subscription 3 to be reset, got %v", resetSubs)
+	}
+}
@@ -227,6 +227,65 @@ type ApiEth1AddressResponse struct {
 	Tokens  []ApiEth1AddressERC20TokenResponse `json:"tokens"`
 }
 
+type ApiEth1LogResponse struct {
+	Address     string            `json:"address"`
+	BlockNumber uint64            `json:"block_number"`
+	Timestamp   int64             `json:"timestamp"`
+	TxHash      string            `json:"tx_hash"`
+	TxIndex     uint64            `json:"tx_index"`
+	LogIndex    uint64            `json:"log_index"`
+	Topics      []string          `json:"topics"`
+	Data        string            `json:"data"`
+	Event       string            `json:"event"`                  // decoded event signature of topic0, empty if unknown
+	DecodedData map[string]string `json:"decoded_data,omitempty"` // event arguments, only set if the abi of the emitting contract is known
+}
+
+type ApiEth1LogsResponse struct {
+	Logs      []ApiEth1LogResponse `json:"logs"`
+	PageToken string               `json:"page_token"` // empty if there are no more logs
+}
+
+type ApiEth1BalanceHistoryEntry struct {
+	Timestamp int64  `json:"timestamp"`
+	Block     uint64 `json:"block,omitempty"` // only set if the balance at a block has been requested
+	Balance   string `json:"balance"`
+}
+
+type ApiEth1BalanceHistoryResponse struct {
+	Address  string                       `json:"address"`
+	Token    string                       `json:"token"` // 0x00 for the native balance
+	Balances []ApiEth1BalanceHistoryEntry `json:"balances"`
+}
+
+type ApiEth1TokenHolderResponse struct {
+	Address string  `json:"address"`
+	Balance string  `json:"balance"`
+	Share   float64 `json:"share"` // share of the total supply, ranging from 0 to 1
+}
+
+type ApiEth1TokenHoldersResponse struct {
+	Token       string                       `json:"token"`
+	HolderCount uint64                       `json:"holder_count"`
+	Holders     []ApiEth1TokenHolderResponse `json:"holders"`
+	PageToken   string                       `json:"page_token"` // empty if there are no more holders
+}
+
+type ApiEth1ContractDeploymentResponse struct {
+	Address        string `json:"address"`
+	Deployer       string `json:"deployer"`
+	Factory        string `json:"factory,omitempty"` // set if the contract was created by another contract
+	TxHash         string `json:"tx_hash"`
+	BlockNumber    uint64 `json:"block_number"`
+	Timestamp      int64  `json:"timestamp"`
+	CodeHash       string `json:"code_hash"`
+	CreationMethod string `json:"creation_method"`
+}
+
+type ApiEth1ContractDeploymentsResponse struct {
+	Contracts []ApiEth1ContractDeploymentResponse `json:"contracts"`
+	PageToken string                              `json:"page_token"` // empty if there are no more contracts
+}
+
 type Eth1TransactionParsed struct {
 	Hash               string    `json:"hash,omitempty"`
 	BlockNumber        uint64    `json:"block,omitempty"`
@@ -450,6 +509,27 @@ type APISyncCommitteeResponse struct {
 	Validators []uint64 `json:"validators"`
 }
 
+type APISyncCommitteeParticipationResponse struct {
+	Period        uint64                                 `json:"period"`
+	StartEpoch    uint64                                 `json:"start_epoch"`
+	EndEpoch      uint64                                 `json:"end_epoch"`
+	TrackedSlots  uint64                                 `json:"tracked_slots"`
+	Participation float64                                `json:"participation"`
+	Members       []*APISyncCommitteeMemberParticipation `json:"members"`
+}
+
+type APISyncCommitteeMemberParticipation struct {
+	CommitteeIndex    uint64   `json:"committeeindex"`
+	ValidatorIndex    uint64   `json:"validatorindex"`
+	Participated      uint64   `json:"participated"`
+	Missed            uint64   `json:"missed"`
+	ParticipationRate float64  `json:"participation_rate"`
+	MissedSlots       []uint64 `json:"missed_slots"`
+	Rewards           uint64   `json:"rewards"`
+	Penalties         uint64   `json:"penalties"`
+	Income            int64    `json:"income"`
+}
+
 type APIRocketpoolStatsResponse struct {
 	ClaimIntervalTime      string  `json:"claim_interval_time"`
 	ClaimIntervalTimeStart int64   `json:"claim_interval_time_start"`
@@ -604,6 +684,42 @@ type ApiValidatorBlsChangeResponse struct {
 	WithdrawalCredentialsNew string `db:"withdrawalcredentials_0x01" json:"withdrawalcredentials_0x01,omitempty"`
 }
 
+type ApiValidatorBlockValueResponse struct {
+	Epoch           uint64   `json:"epoch"`
+	Slot            uint64   `json:"slot"`
+	ValidatorIndex  uint64   `json:"validatorindex"`
+	ExecBlockNumber uint64   `json:"exec_block_number"`
+	ExecBlockHash   string   `json:"exec_block_hash"`
+	Relay           string   `json:"relay"` // empty if the block was built locally
+	CapturedValue   *big.Int `json:"captured_value"`
+	BestBidRelay    string   `json:"best_bid_relay"`
+	BestBidValue    *big.Int `json:"best_bid_value"`
+	LeftOnTable     *big.Int `json:"left_on_table"`
+}
+
+type ApiValidatorWithdrawalRequestResponse struct {
+	Epoch           uint64  `json:"epoch"`
+	Slot            uint64  `json:"slot"`
+	BlockRoot       string  `json:"blockroot"`
+	RequestIndex    uint64  `json:"request_index"`
+	SourceAddress   string  `json:"source_address"`
+	ValidatorPubkey string  `json:"validator_pubkey"`
+	ValidatorIndex  *uint64 `json:"validatorindex"`
+	Amount          uint64  `json:"amount"`
+}
+
+type ApiValidatorConsolidationResponse struct {
+	Epoch         uint64  `json:"epoch"`
+	Slot          uint64  `json:"slot"`
+	BlockRoot     string  `json:"blockroot"`
+	RequestIndex  uint64  `json:"request_index"`
+	SourceAddress string  `json:"source_address"`
+	SourcePubkey  string  `json:"source_pubkey"`
+	SourceIndex   *uint64 `json:"source_validatorindex"`
+	TargetPubkey  string  `json:"target_pubkey"`
+	TargetIndex   *uint64 `json:"target_validatorindex"`
+}
+
 type ApiValidatorPerformanceResponse struct {
 	Balance         uint64 `json:"balance"`
 	Performance1d   uint64 `json:"performance1d"`
@@ -647,6 +763,14 @@ type ApiValidatorAttestationsResponse struct {
 	Week           uint64    `json:"week"`
 	WeekStart      time.Time `json:"week_start"`
 	WeekEnd        time.Time `json:"week_end"`
+	// the vote flags are null for missed attestations and attestations exported before the flags were tracked
+	CorrectHead   *bool    `json:"correct_head"`
+	CorrectTarget *bool    `json:"correct_target"`
+	CorrectSource *bool    `json:"correct_source"`
+	TimelyHead    *bool    `json:"timely_head"`
+	TimelyTarget  *bool    `json:"timely_target"`
+	TimelySource  *bool    `json:"timely_source"`
+	MissedRewards []string `json:"missed_rewards,omitempty"`
 }
 
 // convert this json object to a golang struct called ApiValidatorProposalsResponse
@@ -60,6 +60,8 @@ type GasNowHistory struct {
 type BulkMutations struct {
 	Keys []string
 	Muts []*gcp_bigtable.Mutation
+	// Journal holds the undo operations of the mutations written by the eth1 transformers
+	Journal []*Eth1BlockJournalEntry
 }
 
 func NewBulkMutations(length int) *BulkMutations {
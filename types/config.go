@@ -34,6 +34,8 @@ type Config struct {
 		EmulatorPort        int    `yaml:"emulatorPort" envconfig:"BIGTABLE_EMULATOR_PORT"`
 		EmulatorHost        string `yaml:"emulatorHost" envconfig:"BIGTABLE_EMULATOR_HOST"`
 		V2SchemaCutOffEpoch uint64 `yaml:"v2SchemaCutOffEpoch" envconfig:"BIGTABLE_V2_SCHEMA_CUTT_OFF_EPOCH"`
+		Embedded            bool   `yaml:"embedded" envconfig:"BIGTABLE_EMBEDDED"`
+		EmbeddedPath        string `yaml:"embeddedPath" envconfig:"BIGTABLE_EMBEDDED_PATH"`
 	} `yaml:"bigtable"`
 	BlobIndexer struct {
 		S3 struct {
@@ -56,6 +58,7 @@ type Config struct {
 		ElConfig                   *params.ChainConfig
 	} `yaml:"chain"`
 	Eth1ErigonEndpoint        string `yaml:"eth1ErigonEndpoint" envconfig:"ETH1_ERIGON_ENDPOINT"`
+	Eth1ErigonWsEndpoint      string `yaml:"eth1ErigonWsEndpoint" envconfig:"ETH1_ERIGON_WS_ENDPOINT"`
 	Eth1GethEndpoint          string `yaml:"eth1GethEndpoint" envconfig:"ETH1_GETH_ENDPOINT"`
 	EtherscanAPIKey           string `yaml:"etherscanApiKey" envconfig:"ETHERSCAN_API_KEY"`
 	EtherscanAPIBaseURL       string `yaml:"etherscanApiBaseUrl" envconfig:"ETHERSCAN_API_BASEURL"`
@@ -71,13 +74,28 @@ type Config struct {
 			Type     string `yaml:"type" envconfig:"INDEXER_NODE_TYPE"`
 			PageSize int32  `yaml:"pageSize" envconfig:"INDEXER_NODE_PAGE_SIZE"`
 		} `yaml:"node"`
+		// additional beacon nodes, if set the indexer fails over between all configured nodes
+		Nodes []struct {
+			Port string `yaml:"port"`
+			Host string `yaml:"host"`
+			Type string `yaml:"type"`
+		} `yaml:"nodes"`
+		NodeCrossCheck                bool   `yaml:"nodeCrossCheck" envconfig:"INDEXER_NODE_CROSS_CHECK"`
+		RecordFixturesDir             string `yaml:"recordFixturesDir" envconfig:"INDEXER_RECORD_FIXTURES_DIR"`
 		Eth1DepositContractFirstBlock uint64 `yaml:"eth1DepositContractFirstBlock" envconfig:"INDEXER_ETH1_DEPOSIT_CONTRACT_FIRST_BLOCK"`
 		PubKeyTagsExporter            struct {
 			Enabled bool `yaml:"enabled" envconfig:"PUBKEY_TAGS_EXPORTER_ENABLED"`
 		} `yaml:"pubkeyTagsExporter"`
+		LightClientExporter struct {
+			Enabled bool `yaml:"enabled" envconfig:"LIGHT_CLIENT_EXPORTER_ENABLED"`
+		} `yaml:"lightClientExporter"`
 		EnsTransformer struct {
 			ValidRegistrarContracts []string `yaml:"validRegistrarContracts" envconfig:"ENS_VALID_REGISTRAR_CONTRACTS"`
 		} `yaml:"ensTransformer"`
+		NftMetadata struct {
+			IpfsGateway    string `yaml:"ipfsGateway" envconfig:"NFT_METADATA_IPFS_GATEWAY"`
+			ArweaveGateway string `yaml:"arweaveGateway" envconfig:"NFT_METADATA_ARWEAVE_GATEWAY"`
+		} `yaml:"nftMetadata"`
 	} `yaml:"indexer"`
 	Frontend struct {
 		Debug                          bool   `yaml:"debug" envconfig:"FRONTEND_DEBUG"`
@@ -206,6 +224,8 @@ type Config struct {
 		MachineEventThreshold                         uint64  `yaml:"machineEventThreshold" envconfig:"MACHINE_EVENT_THRESHOLD"`
 		MachineEventFirstRatioThreshold               float64 `yaml:"machineEventFirstRatioThreshold" envconfig:"MACHINE_EVENT_FIRST_RATIO_THRESHOLD"`
 		MachineEventSecondRatioThreshold              float64 `yaml:"machineEventSecondRatioThreshold" envconfig:"MACHINE_EVENT_SECOND_RATIO_THRESHOLD"`
+		TelegramBotToken                              string  `yaml:"telegramBotToken" envconfig:"NOTIFICATIONS_TELEGRAM_BOT_TOKEN"`
+		TelegramBotName                               string  `yaml:"telegramBotName" envconfig:"NOTIFICATIONS_TELEGRAM_BOT_NAME"`
 	} `yaml:"notifications"`
 	SSVExporter struct {
 		Enabled bool   `yaml:"enabled" envconfig:"SSV_EXPORTER_ENABLED"`
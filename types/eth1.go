@@ -1,9 +1,33 @@
 package types
 
-import "time"
+import (
+	"bytes"
+	"time"
+)
 
 type GetBlockTimings struct {
 	Headers  time.Duration
 	Receipts time.Duration
 	Traces   time.Duration
 }
+
+// Eth1LogFilter selects logs by their emitting address, their topics and a block range, empty fields match all logs
+type Eth1LogFilter struct {
+	Address   []byte
+	Topics    [4][]byte
+	FromBlock uint64
+	ToBlock   uint64
+}
+
+// MatchesTopics returns whether the given topics match all topics of the filter
+func (f *Eth1LogFilter) MatchesTopics(topics [][]byte) bool {
+	for i, topic := range f.Topics {
+		if len(topic) == 0 {
+			continue
+		}
+		if i >= len(topics) || !bytes.Equal(topics[i], topic) {
+			return false
+		}
+	}
+	return true
+}
@@ -126,6 +126,9 @@ type Block struct {
 	SyncAggregate              *SyncAggregate    // warning: sync aggregate may be nil, for phase0 blocks
 	ExecutionPayload           *ExecutionPayload // warning: payload may be nil, for phase0/altair blocks
 	SignedBLSToExecutionChange []*SignedBLSToExecutionChange
+	DepositRequests            []*DepositRequest       // present only after electra
+	WithdrawalRequests         []*WithdrawalRequest    // present only after electra
+	ConsolidationRequests      []*ConsolidationRequest // present only after electra
 	BlobGasUsed                uint64
 	ExcessBlobGas              uint64
 	BlobKZGCommitments         [][]byte
@@ -148,6 +151,29 @@ type BLSToExecutionChange struct {
 	Address        []byte
 }
 
+// DepositRequest is a deposit processed in-protocol from the deposit contract logs of the execution layer (EIP-6110)
+type DepositRequest struct {
+	Pubkey                []byte
+	WithdrawalCredentials []byte
+	Amount                uint64
+	Signature             []byte
+	Index                 uint64
+}
+
+// WithdrawalRequest is a full exit or partial withdrawal triggered by the withdrawal address of a validator (EIP-7002)
+type WithdrawalRequest struct {
+	SourceAddress   []byte
+	ValidatorPubkey []byte
+	Amount          uint64
+}
+
+// ConsolidationRequest moves the balance of the source validator to the target validator (EIP-7251)
+type ConsolidationRequest struct {
+	SourceAddress []byte
+	SourcePubkey  []byte
+	TargetPubkey  []byte
+}
+
 type Transaction struct {
 	Raw []byte
 	// Note: below values may be nil/0 if Raw fails to decode into a valid transaction
@@ -213,6 +239,13 @@ type WithdrawalsNotification struct {
 	Pubkey         []byte `json:"pubkey"`
 }
 
+type DepositsNotification struct {
+	Slot           uint64 `db:"slot"`
+	ValidatorIndex uint64 `db:"validatorindex"`
+	Amount         uint64 `db:"amount"`
+	Pubkey         []byte `db:"pubkey"`
+}
+
 // Eth1Data is a struct to hold the ETH1 data
 type Eth1Data struct {
 	DepositRoot  []byte
@@ -263,6 +296,52 @@ type Checkpoint struct {
 	Root  []byte
 }
 
+// AttestationFlags holds the correctness and timeliness of the votes of an included attestation, the timely flags are
+// the altair participation flags that decide the attestation rewards
+type AttestationFlags uint8
+
+const (
+	AttestationFlagCorrectSource AttestationFlags = 1 << iota
+	AttestationFlagCorrectTarget
+	AttestationFlagCorrectHead
+	AttestationFlagTimelySource
+	AttestationFlagTimelyTarget
+	AttestationFlagTimelyHead
+)
+
+// Has returns true if all given flags are set
+func (f AttestationFlags) Has(flags AttestationFlags) bool {
+	return f&flags == flags
+}
+
+// Known returns false for attestations that were missed or exported before the flags were tracked, as the source of an
+// included attestation is always correct
+func (f AttestationFlags) Known() bool {
+	return f.Has(AttestationFlagCorrectSource)
+}
+
+// MissedRewards explains which rewards of an included attestation have been missed and why
+func (f AttestationFlags) MissedRewards() []string {
+	missed := []string{}
+	if !f.Known() {
+		return missed
+	}
+	if !f.Has(AttestationFlagTimelySource) {
+		missed = append(missed, "source reward missed: the attestation was included too late")
+	}
+	if !f.Has(AttestationFlagCorrectTarget) {
+		missed = append(missed, "target reward missed: the attestation voted for a target checkpoint that is not canonical")
+	} else if !f.Has(AttestationFlagTimelyTarget) {
+		missed = append(missed, "target reward missed: the attestation was included too late")
+	}
+	if !f.Has(AttestationFlagCorrectHead) {
+		missed = append(missed, "head reward missed: the attestation voted for a head block that is not canonical, usually because the block of the slot was late or missed")
+	} else if !f.Has(AttestationFlagTimelyHead) {
+		missed = append(missed, "head reward missed: the attestation was not included in the next slot")
+	}
+	return missed
+}
+
 // Deposit is a struct to hold deposit data
 type Deposit struct {
 	Proof                 [][]byte
@@ -295,6 +374,69 @@ type CanonBlock struct {
 	Canonical bool   `db:"-"`
 }
 
+// ChainReorg is a struct to hold the data of a chain reorg announced by the beacon node
+type ChainReorg struct {
+	Slot         uint64    `db:"slot"`
+	Depth        uint64    `db:"depth"`
+	OldHeadBlock []byte    `db:"old_head_block"`
+	NewHeadBlock []byte    `db:"new_head_block"`
+	OldHeadState []byte    `db:"old_head_state"`
+	NewHeadState []byte    `db:"new_head_state"`
+	Epoch        uint64    `db:"epoch"`
+	Ts           time.Time `db:"ts"`
+}
+
+// Eth1ChainReorg is a reorg of the execution chain handled by the eth1 indexer
+type Eth1ChainReorg struct {
+	BlockNumber uint64    `db:"block_number"`
+	Depth       uint64    `db:"depth"`
+	OldHeadHash []byte    `db:"old_head_hash"`
+	NewHeadHash []byte    `db:"new_head_hash"`
+	Ts          time.Time `db:"ts"`
+}
+
+// MissedSlotReason is the root cause of a slot that ended up without a canonical block
+type MissedSlotReason string
+
+const (
+	// MissedSlotReasonNotSeen is used if the beacon node never received a block for the slot, usually because the proposer was offline
+	MissedSlotReasonNotSeen MissedSlotReason = "not_seen"
+	// MissedSlotReasonLate is used if the block arrived after the attestation deadline of the slot and did not become canonical
+	MissedSlotReasonLate MissedSlotReason = "late"
+	// MissedSlotReasonOrphaned is used if the block arrived in time but was reorged out of the canonical chain
+	MissedSlotReasonOrphaned MissedSlotReason = "orphaned"
+	// MissedSlotReasonInvalidExecution is used if the block passed the gossip validation but was never imported by the beacon node as its execution payload was invalid
+	MissedSlotReasonInvalidExecution MissedSlotReason = "invalid_execution"
+)
+
+// Description returns a human readable explanation of the reason
+func (r MissedSlotReason) Description() string {
+	switch r {
+	case MissedSlotReasonNotSeen:
+		return "no block was seen for the slot, the proposer was likely offline"
+	case MissedSlotReasonLate:
+		return "the block was seen too late to become canonical"
+	case MissedSlotReasonOrphaned:
+		return "the block was orphaned by a reorg"
+	case MissedSlotReasonInvalidExecution:
+		return "the block was rejected due to an invalid execution payload"
+	}
+	return ""
+}
+
+// LightClientSyncAggregate is the sync aggregate of a slot as served to light clients by the light client updates of the beacon node
+type LightClientSyncAggregate struct {
+	Slot                   uint64        `db:"slot"`
+	Period                 uint64        `db:"period"`
+	AttestedSlot           uint64        `db:"attested_slot"`
+	AttestedBlockRoot      []byte        `db:"attested_block_root"`
+	FinalizedSlot          sql.NullInt64 `db:"finalized_slot"`
+	SyncCommitteeBits      []byte        `db:"sync_committee_bits"`
+	SyncCommitteeSignature []byte        `db:"sync_committee_signature"`
+	Participation          float64       `db:"participation"`
+	Ts                     time.Time     `db:"ts"`
+}
+
 // EpochAssignments is a struct to hold epoch assignment data
 type EpochAssignments struct {
 	ProposerAssignments map[uint64]uint64
@@ -536,6 +678,16 @@ type RelayBlock struct {
 	ProposerFeeRecipient string `db:"proposer_fee_recipient" json:"proposer_fee_recipient"`
 }
 
+// RelayBid is the highest bid a relay received for a slot
+type RelayBid struct {
+	BlockSlot     uint64    `db:"block_slot"`
+	TagID         string    `db:"tag_id"`
+	ExecBlockHash []byte    `db:"exec_block_hash"`
+	BuilderPubkey []byte    `db:"builder_pubkey"`
+	Value         WeiString `db:"value"`
+	BidsCount     uint64    `db:"bids_count"`
+}
+
 type BlockTag struct {
 	ID        string `db:"tag_id"`
 	BlockSlot uint64 `db:"slot"`
@@ -49,6 +49,7 @@ const (
 	RocketpoolCollateralMinReached                   EventName = "rocketpool_colleteral_min"
 	RocketpoolCollateralMaxReached                   EventName = "rocketpool_colleteral_max"
 	SyncCommitteeSoon                                EventName = "validator_synccommittee_soon"
+	ValidatorAlertRuleEventName                      EventName = "validator_alert_rule"
 )
 
 var MachineEvents = []EventName{
@@ -102,6 +103,7 @@ var EventLabel map[EventName]string = map[EventName]string{
 	RocketpoolCollateralMinReached:                   "You reached the Rocket Pool min RPL collateral",
 	RocketpoolCollateralMaxReached:                   "You reached the Rocket Pool max RPL collateral",
 	SyncCommitteeSoon:                                "Your validator(s) will soon be part of the sync committee",
+	ValidatorAlertRuleEventName:                      "Your alert rule(s) have been triggered",
 }
 
 func IsUserIndexed(event EventName) bool {
@@ -151,6 +153,7 @@ var EventNames = []EventName{
 	RocketpoolCollateralMinReached,
 	RocketpoolCollateralMaxReached,
 	SyncCommitteeSoon,
+	ValidatorAlertRuleEventName,
 }
 
 type EventNameDesc struct {
@@ -359,13 +362,15 @@ type TransitWebhook struct {
 	Created sql.NullTime `db:"created"`
 	Sent    sql.NullTime `db:"sent"`
 	// Delivered sql.NullTime          `db:"delivered"`
-	Channel string                `db:"channel"`
-	Content TransitWebhookContent `db:"content"`
+	Channel  string                `db:"channel"`
+	Content  TransitWebhookContent `db:"content"`
+	Attempts int                   `db:"attempts"`
 }
 
 type TransitWebhookContent struct {
-	Webhook UserWebhook
-	Event   WebhookEvent `json:"event"`
+	Webhook    UserWebhook
+	Event      WebhookEvent `json:"event"`
+	Redelivery bool         `json:"redelivery,omitempty"`
 }
 
 type WebhookEvent struct {
@@ -417,6 +422,42 @@ func (a TransitDiscordContent) Value() (driver.Value, error) {
 	return json.Marshal(a)
 }
 
+// TransitChat is a queued message of a chat channel (telegram, slack, matrix or ntfy).
+// The messages are stored as markdown and rendered for the channel when they are sent.
+type TransitChat struct {
+	Id      uint64       `db:"id,omitempty"`
+	Created sql.NullTime `db:"created"`
+	Sent    sql.NullTime `db:"sent"`
+	// Delivered sql.NullTime          `db:"delivered"`
+	Channel string             `db:"channel"`
+	Content TransitChatContent `db:"content"`
+}
+
+type TransitChatContent struct {
+	Webhook  UserWebhook
+	ChatID   int64         `json:"chatId,omitempty"`
+	UserID   uint64        `json:"userId,omitempty"`
+	Messages []ChatMessage `json:"messages"`
+}
+
+type ChatMessage struct {
+	Title    string `json:"title"`
+	Markdown string `json:"markdown"`
+}
+
+func (e *TransitChatContent) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(b, &e)
+}
+
+func (a TransitChatContent) Value() (driver.Value, error) {
+	return json.Marshal(a)
+}
+
 type TransitPush struct {
 	Id      uint64       `db:"id,omitempty"`
 	Created sql.NullTime `db:"created"`
@@ -465,6 +506,97 @@ type UserWebhook struct {
 	Request     sql.NullString `db:"request" json:"request"`
 	Destination sql.NullString `db:"destination" json:"destination"`
 	EventNames  pq.StringArray `db:"event_names" json:"-"`
+	Secret      sql.NullString `db:"secret" json:"-"`
+}
+
+// UserWebhookDelivery is a single attempt to deliver a webhook payload
+type UserWebhookDelivery struct {
+	ID         uint64         `db:"id"`
+	WebhookID  uint64         `db:"webhook_id"`
+	UserID     uint64         `db:"user_id"`
+	EventName  string         `db:"event_name"`
+	Payload    string         `db:"payload"`
+	Attempt    int            `db:"attempt"`
+	Redelivery bool           `db:"redelivery"`
+	StatusCode sql.NullInt64  `db:"status_code"`
+	LatencyMs  int64          `db:"latency_ms"`
+	Response   sql.NullString `db:"response"`
+	Error      sql.NullString `db:"error"`
+	Ts         time.Time      `db:"ts"`
+}
+
+type UserTelegram struct {
+	UserID   uint64         `db:"user_id"`
+	ChatID   sql.NullInt64  `db:"chat_id"`
+	Username sql.NullString `db:"username"`
+	LinkedTs sql.NullTime   `db:"linked_ts"`
+}
+
+type AlertRuleMetric string
+
+const (
+	// average attestation effectiveness of the validators in % over the window
+	AlertRuleMetricEffectiveness AlertRuleMetric = "effectiveness"
+	// share of the sync committee duties of the validators in % that were participated in over the window
+	AlertRuleMetricSyncParticipation AlertRuleMetric = "sync_participation"
+	// balance of the validator with the lowest (below) or highest (above) balance in ETH
+	AlertRuleMetricBalance AlertRuleMetric = "balance"
+	// days since the last proposal of the validator that has not proposed for the longest (above) or shortest (below) time
+	AlertRuleMetricDaysSinceProposal AlertRuleMetric = "days_since_proposal"
+)
+
+var AlertRuleMetrics = []AlertRuleMetric{
+	AlertRuleMetricEffectiveness,
+	AlertRuleMetricSyncParticipation,
+	AlertRuleMetricBalance,
+	AlertRuleMetricDaysSinceProposal,
+}
+
+var AlertRuleMetricLabels map[AlertRuleMetric]string = map[AlertRuleMetric]string{
+	AlertRuleMetricEffectiveness:     "Attestation effectiveness",
+	AlertRuleMetricSyncParticipation: "Sync participation",
+	AlertRuleMetricBalance:           "Balance",
+	AlertRuleMetricDaysSinceProposal: "Days since last proposal",
+}
+
+const (
+	AlertRuleOperatorBelow = "below"
+	AlertRuleOperatorAbove = "above"
+)
+
+// AlertRule is a user defined threshold on a metric of a single validator or a set of validators (e.g. a dashboard),
+// the rule is active as long as the user is subscribed to its ValidatorAlertRuleEventName subscription.
+// Dashboards only exist as the validator list of their url, the rule stores a copy of that list and is not updated with the dashboard
+type AlertRule struct {
+	ID           uint64          `db:"id" json:"id"`
+	UserID       uint64          `db:"user_id" json:"-"`
+	Name         string          `db:"name" json:"name"`
+	Validators   pq.Int64Array   `db:"validators" json:"validators" swaggertype:"array,integer"`
+	Metric       AlertRuleMetric `db:"metric" json:"metric"`
+	Operator     string          `db:"operator" json:"operator"`
+	Threshold    float64         `db:"threshold" json:"threshold"`
+	WindowEpochs uint64          `db:"window_epochs" json:"window_epochs"`
+	Active       bool            `db:"active" json:"active"`
+	CreatedTs    time.Time       `db:"created_ts" json:"created_ts"`
+	UpdatedTs    time.Time       `db:"updated_ts" json:"updated_ts"`
+}
+
+// IsTriggeredBy returns true if the value of the metric violates the threshold of the rule
+func (r *AlertRule) IsTriggeredBy(value float64) bool {
+	if r.Operator == AlertRuleOperatorAbove {
+		return value > r.Threshold
+	}
+	return value < r.Threshold
+}
+
+type AlertRuleRequest struct {
+	Name         string          `json:"name"`
+	Validators   []uint64        `json:"validators"`
+	Metric       AlertRuleMetric `json:"metric"`
+	Operator     string          `json:"operator"`
+	Threshold    float64         `json:"threshold"`
+	WindowEpochs uint64          `json:"window_epochs"`
+	Active       *bool           `json:"active,omitempty"`
 }
 
 type UserWebhookSubscriptions struct {
@@ -481,6 +613,10 @@ var NotificationChannelLabels map[NotificationChannel]template.HTML = map[Notifi
 	PushNotificationChannel:           "Push Notification",
 	WebhookNotificationChannel:        `Webhook Notification (<a href="/user/webhooks">configure</a>)`,
 	WebhookDiscordNotificationChannel: "Discord Notification",
+	TelegramNotificationChannel:       `Telegram Notification (<a href="/user/settings">link account</a>)`,
+	WebhookSlackNotificationChannel:   "Slack Notification",
+	WebhookMatrixNotificationChannel:  "Matrix Notification",
+	WebhookNtfyNotificationChannel:    "ntfy Notification",
 }
 
 const (
@@ -488,6 +624,10 @@ const (
 	PushNotificationChannel           NotificationChannel = "push"
 	WebhookNotificationChannel        NotificationChannel = "webhook"
 	WebhookDiscordNotificationChannel NotificationChannel = "webhook_discord"
+	TelegramNotificationChannel       NotificationChannel = "telegram"
+	WebhookSlackNotificationChannel   NotificationChannel = "webhook_slack"
+	WebhookMatrixNotificationChannel  NotificationChannel = "webhook_matrix"
+	WebhookNtfyNotificationChannel    NotificationChannel = "webhook_ntfy"
 )
 
 var NotificationChannels = []NotificationChannel{
@@ -495,6 +635,72 @@ var NotificationChannels = []NotificationChannel{
 	PushNotificationChannel,
 	WebhookNotificationChannel,
 	WebhookDiscordNotificationChannel,
+	TelegramNotificationChannel,
+	WebhookSlackNotificationChannel,
+	WebhookMatrixNotificationChannel,
+	WebhookNtfyNotificationChannel,
+}
+
+// WebhookChatNotificationChannels are the webhook destinations whose requests are rendered for a chat service
+var WebhookChatNotificationChannels = []NotificationChannel{
+	WebhookSlackNotificationChannel,
+	WebhookMatrixNotificationChannel,
+	WebhookNtfyNotificationChannel,
+}
+
+// DigestNotificationChannels are the channels whose notifications can be delivered in a digest instead of immediately
+var DigestNotificationChannels = []NotificationChannel{
+	EmailNotificationChannel,
+	PushNotificationChannel,
+	TelegramNotificationChannel,
+	WebhookNotificationChannel,
+}
+
+type NotificationDeliveryMode string
+
+const (
+	NotificationDeliveryImmediate NotificationDeliveryMode = "immediate"
+	NotificationDeliveryHourly    NotificationDeliveryMode = "hourly"
+	NotificationDeliveryDaily     NotificationDeliveryMode = "daily"
+	NotificationDeliveryWeekly    NotificationDeliveryMode = "weekly"
+)
+
+var NotificationDeliveryModes = []NotificationDeliveryMode{
+	NotificationDeliveryImmediate,
+	NotificationDeliveryHourly,
+	NotificationDeliveryDaily,
+	NotificationDeliveryWeekly,
+}
+
+var NotificationDeliveryModeLabels map[NotificationDeliveryMode]string = map[NotificationDeliveryMode]string{
+	NotificationDeliveryImmediate: "Immediately",
+	NotificationDeliveryHourly:    "Hourly digest",
+	NotificationDeliveryDaily:     "Daily digest",
+	NotificationDeliveryWeekly:    "Weekly digest",
+}
+
+// UserNotificationDeliveryMode defines how the notifications of an event are delivered over a channel,
+// an empty event name sets the default of the channel for all events without a mode of their own
+type UserNotificationDeliveryMode struct {
+	UserID    uint64                   `db:"user_id" json:"-"`
+	Channel   NotificationChannel      `db:"channel" json:"channel"`
+	EventName string                   `db:"event_name" json:"event_name"`
+	Mode      NotificationDeliveryMode `db:"mode" json:"mode"`
+}
+
+// NotificationDigestEntry is a notification that has been collected for the next digest of the user
+type NotificationDigestEntry struct {
+	ID             uint64                   `db:"id"`
+	UserID         uint64                   `db:"user_id"`
+	Channel        NotificationChannel      `db:"channel"`
+	Mode           NotificationDeliveryMode `db:"mode"`
+	EventName      EventName                `db:"event_name"`
+	Epoch          uint64                   `db:"epoch"`
+	EventFilter    string                   `db:"event_filter"`
+	ValidatorIndex sql.NullInt64            `db:"validator_index"`
+	Title          string                   `db:"title"`
+	Info           string                   `db:"info"`
+	Created        time.Time                `db:"created"`
 }
 
 func GetNotificationChannel(channel string) (NotificationChannel, error) {
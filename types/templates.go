@@ -255,27 +255,28 @@ type IndexPageEpochHistory struct {
 
 // IndexPageDataBlocks is a struct to hold detail data for the main web page
 type BlocksPageDataBlocks struct {
-	TotalCount           uint64        `db:"total_count"`
-	Epoch                uint64        `json:"epoch"`
-	Slot                 uint64        `json:"slot"`
-	Ts                   time.Time     `json:"ts"`
-	Proposer             uint64        `db:"proposer" json:"proposer"`
-	ProposerFormatted    template.HTML `json:"proposer_formatted"`
-	BlockRoot            []byte        `db:"blockroot" json:"block_root"`
-	BlockRootFormatted   string        `json:"block_root_formatted"`
-	ParentRoot           []byte        `db:"parentroot" json:"parent_root"`
-	Attestations         uint64        `db:"attestationscount" json:"attestations"`
-	Deposits             uint64        `db:"depositscount" json:"deposits"`
-	Withdrawals          uint64        `db:"withdrawalcount" json:"withdrawals"`
-	Exits                uint64        `db:"voluntaryexitscount" json:"exits"`
-	Proposerslashings    uint64        `db:"proposerslashingscount" json:"proposerslashings"`
-	Attesterslashings    uint64        `db:"attesterslashingscount" json:"attesterslashings"`
-	SyncAggParticipation float64       `db:"syncaggregate_participation" json:"sync_aggregate_participation"`
-	Status               uint64        `db:"status" json:"status"`
-	StatusFormatted      template.HTML `json:"status_formatted"`
-	Votes                uint64        `db:"votes" json:"votes"`
-	Graffiti             []byte        `db:"graffiti"`
-	ProposerName         string        `db:"name"`
+	TotalCount           uint64         `db:"total_count"`
+	Epoch                uint64         `json:"epoch"`
+	Slot                 uint64         `json:"slot"`
+	Ts                   time.Time      `json:"ts"`
+	Proposer             uint64         `db:"proposer" json:"proposer"`
+	ProposerFormatted    template.HTML  `json:"proposer_formatted"`
+	BlockRoot            []byte         `db:"blockroot" json:"block_root"`
+	BlockRootFormatted   string         `json:"block_root_formatted"`
+	ParentRoot           []byte         `db:"parentroot" json:"parent_root"`
+	Attestations         uint64         `db:"attestationscount" json:"attestations"`
+	Deposits             uint64         `db:"depositscount" json:"deposits"`
+	Withdrawals          uint64         `db:"withdrawalcount" json:"withdrawals"`
+	Exits                uint64         `db:"voluntaryexitscount" json:"exits"`
+	Proposerslashings    uint64         `db:"proposerslashingscount" json:"proposerslashings"`
+	Attesterslashings    uint64         `db:"attesterslashingscount" json:"attesterslashings"`
+	SyncAggParticipation float64        `db:"syncaggregate_participation" json:"sync_aggregate_participation"`
+	Status               uint64         `db:"status" json:"status"`
+	StatusFormatted      template.HTML  `json:"status_formatted"`
+	Votes                uint64         `db:"votes" json:"votes"`
+	Graffiti             []byte         `db:"graffiti"`
+	ProposerName         string         `db:"name"`
+	MissedReason         sql.NullString `db:"missed_reason" json:"missed_reason"`
 }
 
 // ValidatorsPageData is a struct to hold data about the validators page
@@ -393,6 +394,8 @@ type ValidatorPageData struct {
 	SubscriptionFlash                        []interface{}
 	User                                     *User
 	AttestationInclusionEffectiveness        float64
+	AttestationCorrectness                   *ValidatorAttestationCorrectness
+	BlockValues                              []*ProposerBlockValue
 	CsrfField                                template.HTML
 	NetworkStats                             *IndexPageData
 	ChurnRate                                uint64
@@ -407,6 +410,8 @@ type ValidatorPageData struct {
 	ShowMultipleWithdrawalCredentialsWarning bool
 	CappellaHasHappened                      bool
 	BLSChange                                *BLSChange
+	WithdrawalRequests                       []*BlockPageWithdrawalRequest
+	ConsolidationRequests                    []*BlockPageConsolidationRequest
 	IsWithdrawableAddress                    bool
 	EstimatedNextWithdrawal                  template.HTML
 	AddValidatorWatchlistModal               *AddValidatorWatchlistModal
@@ -549,9 +554,21 @@ type ValidatorAttestation struct {
 	Status         uint64 `db:"status"`
 	InclusionSlot  uint64 `db:"inclusionslot"`
 	Delay          int64  `db:"delay"`
+	Flags          AttestationFlags
 	// EarliestInclusionSlot uint64 `db:"earliestinclusionslot"`
 }
 
+// ValidatorAttestationCorrectness holds the share of the included attestations of a validator that voted correctly and in time
+type ValidatorAttestationCorrectness struct {
+	StartEpoch        uint64
+	EndEpoch          uint64
+	Attestations      uint64
+	CorrectHeadRate   float64
+	CorrectTargetRate float64
+	TimelySourceRate  float64
+	MissedRewards     map[string]uint64
+}
+
 // ValidatorSyncParticipation hold information about sync-participation of a validator
 type ValidatorSyncParticipation struct {
 	Period uint64 `db:"period"`
@@ -670,6 +687,14 @@ type BlockPageData struct {
 	ProposerSlashings []*BlockPageProposerSlashing
 	SyncCommittee     []uint64 // TODO: Setting it to contain the validator index
 	BlobSidecars      []*BlockPageBlobSidecar
+	Reorgs            []*ChainReorg // Chain reorgs that affected this slot
+
+	DepositRequests       []*BlockPageDepositRequest
+	WithdrawalRequests    []*BlockPageWithdrawalRequest
+	ConsolidationRequests []*BlockPageConsolidationRequest
+
+	BlockValue *ProposerBlockValue
+	RelayBids  []*RelayBid
 
 	Tags       TagMetadataSlice `db:"tags"`
 	IsValidMev bool             `db:"is_valid_mev"`
@@ -689,6 +714,22 @@ func (u *BlockPageData) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// ProposerBlockValue compares the value a proposer captured with a block to the best bid the relays received for the slot
+type ProposerBlockValue struct {
+	Slot            uint64         `db:"slot"`
+	Proposer        uint64         `db:"proposer"`
+	ExecBlockNumber uint64         `db:"exec_block_number"`
+	ExecBlockHash   []byte         `db:"exec_block_hash"`
+	RelayTag        sql.NullString `db:"relay_tag"` // empty if the block was built locally
+	DeliveredValue  WeiString      `db:"delivered_value"`
+	BestBidRelayTag sql.NullString `db:"best_bid_relay_tag"`
+	BestBidValue    WeiString      `db:"best_bid_value"`
+	// CapturedValue is the value reported by the relay or the tx fees of a locally built block
+	CapturedValue *big.Int
+	// LeftOnTable is the difference between the best bid and the captured value, nil if no bids are known for the slot
+	LeftOnTable *big.Int
+}
+
 // BlockVote stores a vote for a given block
 type BlockVote struct {
 	Validator      uint64 `db:"validator"`
@@ -1228,6 +1269,8 @@ type UserSettingsPageData struct {
 	Diamond             *string
 	ShareMonitoringData bool
 	ApiStatistics       *ApiStatistics
+	Telegram            *UserTelegram
+	TelegramBotName     string
 }
 
 type PairedDevice struct {
@@ -1272,11 +1315,15 @@ type UserNotificationsCenterPageData struct {
 type NotificationChannelsModal struct {
 	CsrfField            template.HTML
 	NotificationChannels []UserNotificationChannels
+	DeliveryModes        []NotificationDeliveryMode
+	DeliveryModeLabels   map[NotificationDeliveryMode]string
 }
 
 type UserNotificationChannels struct {
-	Channel NotificationChannel `db:"channel"`
-	Active  bool                `db:"active"`
+	Channel        NotificationChannel `db:"channel"`
+	Active         bool                `db:"active"`
+	SupportsDigest bool
+	DeliveryMode   NotificationDeliveryMode
 }
 
 type UserValidatorNotificationTableData struct {
@@ -1461,6 +1508,8 @@ type UserWebhookRow struct {
 	Events       []EventNameCheckbox     `db:"event_names" json:"-"`
 	Discord      bool
 	CsrfField    template.HTML
+	Secret       string
+	Deliveries   []UserWebhookDelivery
 }
 
 type AdConfigurationPageData struct {
@@ -1584,6 +1633,7 @@ type Eth1AddressPageData struct {
 	Address            string `json:"address"`
 	EnsName            string `json:"ensName"`
 	IsContract         bool
+	Deployment         *Eth1ContractDeploymentIndexed
 	QRCode             string `json:"qr_code_base64"`
 	QRCodeInverse      string
 	Metadata           *Eth1AddressMetadata
@@ -1597,6 +1647,9 @@ type Eth1AddressPageData struct {
 	Erc721Table        *DataTableResponse
 	Erc1155Table       *DataTableResponse
 	WithdrawalsTable   *DataTableResponse
+	UserOpsTable       *DataTableResponse
+	SponsoredOpsTable  *DataTableResponse
+	BundledOpsTable    *DataTableResponse
 	EtherValue         template.HTML
 	Tabs               []Eth1AddressPageTabs
 }
@@ -1628,10 +1681,11 @@ type Eth1AddressMetadata struct {
 }
 
 type Eth1AddressBalance struct {
-	Address  []byte
-	Token    []byte
-	Balance  []byte
-	Metadata *ERC20Metadata
+	Address     []byte
+	Token       []byte
+	Balance     []byte
+	BlockNumber uint64 // block whose state the balance has been retrieved from
+	Metadata    *ERC20Metadata
 }
 
 type ERC20TokenPrice struct {
@@ -1660,31 +1714,119 @@ func (metadata ERC20Metadata) UnmarshalBinary(data []byte) error {
 	return json.Unmarshal(data, &metadata)
 }
 
+// NftMetadata is the resolved metadata of a single ERC721 or ERC1155 token id
+type NftMetadata struct {
+	Token       []byte
+	TokenId     *big.Int
+	Standard    string // erc721 or erc1155
+	Uri         string
+	Name        string
+	Description string
+	ImageUri    string
+	Attributes  []NftAttribute
+	Image       []byte // thumbnail of the image
+	ImageFormat string // content type of the thumbnail
+	Raw         []byte // metadata json as returned by the token uri
+}
+
+type NftAttribute struct {
+	TraitType   string      `json:"trait_type"`
+	Value       interface{} `json:"value"`
+	DisplayType string      `json:"display_type,omitempty"`
+}
+
+type Eth1NftPageData struct {
+	Token    string
+	TokenId  string
+	Metadata *NftMetadata
+}
+
+type Eth1UserOperationPageData struct {
+	Hash            []byte
+	Success         bool
+	TxHash          []byte
+	BlockNumber     uint64
+	Timestamp       time.Time
+	EntryPoint      common.Address
+	Sender          common.Address
+	SenderName      string
+	Paymaster       *common.Address
+	PaymasterName   string
+	Bundler         common.Address
+	BundlerName     string
+	Beneficiary     *common.Address
+	Nonce           string
+	GasCost         template.HTML
+	GasUsed         template.HTML
+	CallData        string
+	DecodedCallData *Eth1DecodedCallData
+	RevertReason    string
+}
+
 type ContractMetadata struct {
 	Name    string
 	ABI     *abi.ABI `msgpack:"-" json:"-"`
 	ABIJson []byte
+	Source  string // origin of the abi, see the ContractMetadataSource constants
+}
+
+const (
+	ContractMetadataSourceEtherscan = "etherscan"
+	ContractMetadataSourceVerified  = "verified"
+)
+
+type ContractAbiPageData struct {
+	CsrfField   template.HTML
+	Address     string
+	Metadata    *ContractMetadata
+	IsAdmin     bool
+	Submissions []ContractAbiSubmission
+}
+
+// ContractAbiSubmission is an abi uploaded by a user, it is used to decode the contract once an admin approved it
+type ContractAbiSubmission struct {
+	ID         uint64       `db:"id"`
+	UserID     uint64       `db:"user_id"`
+	Address    []byte       `db:"address"`
+	Name       string       `db:"name"`
+	Abi        string       `db:"abi"`
+	Status     string       `db:"status"`
+	CreatedTs  time.Time    `db:"created_ts"`
+	ReviewedTs sql.NullTime `db:"reviewed_ts"`
 }
 
+const (
+	ContractAbiSubmissionPending  = "pending"
+	ContractAbiSubmissionApproved = "approved"
+	ContractAbiSubmissionRejected = "rejected"
+)
+
 type Eth1TokenPageData struct {
-	Token            string `json:"token"`
-	Address          string `json:"address"`
-	QRCode           string `json:"qr_code_base64"`
-	QRCodeInverse    string
-	Metadata         *ERC20Metadata
-	Balance          *Eth1AddressBalance
-	Holders          template.HTML `json:"holders"`
-	Transfers        template.HTML `json:"transfers"`
-	Price            template.HTML `json:"price"`
-	Supply           template.HTML `json:"supply"`
-	MarketCap        template.HTML `json:"marketCap"`
-	DilutedMarketCap template.HTML `json:"dilutedMarketCap"`
-	Decimals         template.HTML `json:"decimals"`
-	Contract         template.HTML `json:"contract"`
-	WebSite          template.HTML `json:"website"`
-	SocialProfiles   template.HTML `json:"socialProfiles"`
-	TransfersTable   *DataTableResponse
-	HoldersTable     *DataTableResponse
+	Token              string `json:"token"`
+	Address            string `json:"address"`
+	QRCode             string `json:"qr_code_base64"`
+	QRCodeInverse      string
+	Metadata           *ERC20Metadata
+	Balance            *Eth1AddressBalance
+	Holders            template.HTML `json:"holders"`
+	Transfers          template.HTML `json:"transfers"`
+	Price              template.HTML `json:"price"`
+	Supply             template.HTML `json:"supply"`
+	MarketCap          template.HTML `json:"marketCap"`
+	DilutedMarketCap   template.HTML `json:"dilutedMarketCap"`
+	Decimals           template.HTML `json:"decimals"`
+	Contract           template.HTML `json:"contract"`
+	WebSite            template.HTML `json:"website"`
+	SocialProfiles     template.HTML `json:"socialProfiles"`
+	TransfersTable     *DataTableResponse
+	HoldersTable       *DataTableResponse
+	HolderDistribution []Eth1TokenHolderShare
+}
+
+// Eth1TokenHolderShare is a slice of the holder distribution chart of a token
+type Eth1TokenHolderShare struct {
+	Name  string  `json:"name"`
+	Share float64 `json:"y"`
 }
 
 type ITransaction struct {
@@ -1752,6 +1894,7 @@ type Eth1TxData struct {
 	TargetIsContract            bool
 	IsContractCreation          bool
 	CallData                    string
+	DecodedCallData             *Eth1DecodedCallData
 	Method                      string
 	Events                      []*Eth1EventData
 	Transfers                   []*Transfer
@@ -1776,51 +1919,30 @@ type Eth1DecodedEventData struct {
 	Address common.Address
 }
 
+// Eth1DecodedCallData contains the decoded input parameters of a contract call, Verified is false if only the signature database was used
+type Eth1DecodedCallData struct {
+	Method   string
+	Verified bool
+	Inputs   []Eth1DecodedArgument
+}
+
+type Eth1DecodedArgument struct {
+	Name string
+	Eth1DecodedEventData
+}
+
+// SourcifyContractMetadata contains the parts of the solidity metadata json (as published by sourcify) that are used to import contract abis
 type SourcifyContractMetadata struct {
 	Compiler struct {
 		Version string `json:"version"`
 	} `json:"compiler"`
 	Language string `json:"language"`
 	Output   struct {
-		Abi []struct {
-			Anonymous bool `json:"anonymous"`
-			Inputs    []struct {
-				Indexed      bool   `json:"indexed"`
-				InternalType string `json:"internalType"`
-				Name         string `json:"name"`
-				Type         string `json:"type"`
-			} `json:"inputs"`
-			Name    string `json:"name"`
-			Outputs []struct {
-				InternalType string `json:"internalType"`
-				Name         string `json:"name"`
-				Type         string `json:"type"`
-			} `json:"outputs"`
-			StateMutability string `json:"stateMutability"`
-			Type            string `json:"type"`
-		} `json:"abi"`
+		Abi json.RawMessage `json:"abi"`
 	} `json:"output"`
 	Settings struct {
-		CompilationTarget struct {
-			Browser_Stakehavens_sol string `json:"browser/Stakehavens.sol"`
-		} `json:"compilationTarget"`
-		EvmVersion string   `json:"evmVersion"`
-		Libraries  struct{} `json:"libraries"`
-		Metadata   struct {
-			BytecodeHash string `json:"bytecodeHash"`
-		} `json:"metadata"`
-		Optimizer struct {
-			Enabled bool  `json:"enabled"`
-			Runs    int64 `json:"runs"`
-		} `json:"optimizer"`
-		Remappings []interface{} `json:"remappings"`
+		CompilationTarget map[string]string `json:"compilationTarget"`
 	} `json:"settings"`
-	Sources struct {
-		Browser_Stakehavens_sol struct {
-			Keccak256 string   `json:"keccak256"`
-			Urls      []string `json:"urls"`
-		} `json:"browser/Stakehavens.sol"`
-	} `json:"sources"`
 	Version int64 `json:"version"`
 }
 
@@ -2026,6 +2148,42 @@ type ValidatorsBLSChange struct {
 	WithdrawalCredentialsOld []byte `db:"withdrawalcredentials" json:"withdrawalcredentials,omitempty"`
 }
 
+// BlockPageDepositRequest is a deposit request of the execution layer included in a block, the validator index is only known once the deposit got processed
+type BlockPageDepositRequest struct {
+	BlockSlot             uint64  `db:"block_slot"`
+	BlockRoot             []byte  `db:"block_root"`
+	RequestIndex          uint64  `db:"request_index"`
+	Pubkey                []byte  `db:"pubkey"`
+	ValidatorIndex        *uint64 `db:"validatorindex"`
+	WithdrawalCredentials []byte  `db:"withdrawal_credentials"`
+	Amount                uint64  `db:"amount"`
+	Signature             []byte  `db:"signature"`
+	DepositIndex          uint64  `db:"deposit_index"`
+}
+
+// BlockPageWithdrawalRequest is a withdrawal request of the execution layer included in a block, an amount of 0 requests a full exit
+type BlockPageWithdrawalRequest struct {
+	BlockSlot       uint64  `db:"block_slot"`
+	BlockRoot       []byte  `db:"block_root"`
+	RequestIndex    uint64  `db:"request_index"`
+	SourceAddress   []byte  `db:"source_address"`
+	ValidatorPubkey []byte  `db:"validator_pubkey"`
+	ValidatorIndex  *uint64 `db:"validatorindex"`
+	Amount          uint64  `db:"amount"`
+}
+
+// BlockPageConsolidationRequest is a consolidation request of the execution layer included in a block
+type BlockPageConsolidationRequest struct {
+	BlockSlot     uint64  `db:"block_slot"`
+	BlockRoot     []byte  `db:"block_root"`
+	RequestIndex  uint64  `db:"request_index"`
+	SourceAddress []byte  `db:"source_address"`
+	SourcePubkey  []byte  `db:"source_pubkey"`
+	SourceIndex   *uint64 `db:"source_index"`
+	TargetPubkey  []byte  `db:"target_pubkey"`
+	TargetIndex   *uint64 `db:"target_index"`
+}
+
 // AdConfig is a struct to hold the configuration for one specific ad banner placement
 type AdConfig struct {
 	Id              string `db:"id"`
@@ -3,6 +3,7 @@ package utils
 import (
 	"crypto/sha256"
 	"fmt"
+	"math/big"
 
 	"github.com/attestantio/go-eth2-client/spec/capella"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
@@ -141,3 +142,21 @@ func VersionedBlobHash(commitment []byte) common.Hash {
 	vhash[0] = 0x01
 	return vhash
 }
+
+// SyncCommitteeParticipantReward returns the reward in gwei a sync committee member receives for participating in a single slot,
+// a member that misses the slot is penalized by the same amount.
+// see: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/beacon-chain.md#sync-aggregate-processing
+func SyncCommitteeParticipantReward(totalActiveBalance uint64) uint64 {
+	const syncRewardWeight = 2
+	const weightDenominator = 64
+
+	if totalActiveBalance == 0 || Config.Chain.ClConfig.SyncCommitteeSize == 0 {
+		return 0
+	}
+
+	sqrt := new(big.Int).Sqrt(new(big.Int).SetUint64(totalActiveBalance)).Uint64()
+	baseRewardPerIncrement := Config.Chain.ClConfig.EffectiveBalanceIncrement * Config.Chain.ClConfig.BaseRewardFactor / sqrt
+	totalBaseRewards := baseRewardPerIncrement * (totalActiveBalance / Config.Chain.ClConfig.EffectiveBalanceIncrement)
+	maxParticipantRewards := totalBaseRewards * syncRewardWeight / weightDenominator / Config.Chain.ClConfig.SlotsPerEpoch
+	return maxParticipantRewards / Config.Chain.ClConfig.SyncCommitteeSize
+}
@@ -97,6 +97,17 @@ func FormatTransactionHash(hash []byte, successful bool) template.HTML {
 	return template.HTML(fmt.Sprintf(`<a class="text-monospace" href="/tx/0x%x">0x%x…%x</a>%s`, hash, hash[:3], hash[len(hash)-3:], failedStr))
 }
 
+func FormatUserOperationHash(hash []byte, successful bool) template.HTML {
+	if len(hash) < 20 {
+		return template.HTML("N/A")
+	}
+	failedStr := ""
+	if !successful {
+		failedStr = `<span data-toggle="tooltip" title="User operation failed">❗</span>`
+	}
+	return template.HTML(fmt.Sprintf(`<a class="text-monospace" href="/userop/0x%x">0x%x…%x</a>%s`, hash, hash[:3], hash[len(hash)-3:], failedStr))
+}
+
 func FormatInOutSelf(address, from, to []byte) template.HTML {
 	if address == nil && len(address) == 0 {
 		return ""
@@ -93,3 +93,22 @@ func TestVerifyVoluntaryExitSignature(t *testing.T) {
 		}
 	}
 }
+
+func TestSyncCommitteeParticipantReward(t *testing.T) {
+	Config = &types.Config{}
+	ReadConfig(Config, "")
+
+	tests := []struct {
+		totalActiveBalance uint64
+		reward             uint64
+	}{
+		{0, 0},
+		{32_000_000_000_000_000, 21789},
+	}
+	for _, tt := range tests {
+		reward := SyncCommitteeParticipantReward(tt.totalActiveBalance)
+		if reward != tt.reward {
+			t.Errorf("wrong sync committee participant reward for total active balance %v: got %v, want %v", tt.totalActiveBalance, reward, tt.reward)
+		}
+	}
+}
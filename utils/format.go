@@ -479,6 +479,38 @@ func FormatInclusionDelay(inclusionSlot uint64, delay int64) template.HTML {
 	}
 }
 
+// FormatAttestationFlags will return badges for the head, target and source votes of an included attestation
+func FormatAttestationFlags(status uint64, flags types.AttestationFlags) template.HTML {
+	if status != 1 || !flags.Known() {
+		return template.HTML("-")
+	}
+
+	badge := func(label, title string, correct, timely bool) string {
+		class := "bg-success"
+		if !correct {
+			class = "bg-danger"
+			title += " incorrect"
+		} else if !timely {
+			class = "bg-warning"
+			title += " correct but included too late"
+		} else {
+			title += " correct and timely"
+		}
+		return fmt.Sprintf(`<span title="%s" data-toggle="tooltip" class="mx-1 badge badge-pill %s text-white" style="font-size: 12px; font-weight: 500;">%s</span>`, title, class, label)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(badge("H", "Head vote", flags.Has(types.AttestationFlagCorrectHead), flags.Has(types.AttestationFlagTimelyHead)))
+	sb.WriteString(badge("T", "Target vote", flags.Has(types.AttestationFlagCorrectTarget), flags.Has(types.AttestationFlagTimelyTarget)))
+	sb.WriteString(badge("S", "Source vote", flags.Has(types.AttestationFlagCorrectSource), flags.Has(types.AttestationFlagTimelySource)))
+
+	missed := flags.MissedRewards()
+	if len(missed) > 0 {
+		sb.WriteString(fmt.Sprintf(`<i class="fas fa-info-circle text-muted" data-toggle="tooltip" data-html="true" title="%s"></i>`, html.EscapeString(strings.Join(missed, "<br>"))))
+	}
+	return template.HTML(sb.String())
+}
+
 // FormatSlotToTimestamp will return the time elapsed since blockSlot
 func FormatSlotToTimestamp(blockSlot uint64) template.HTML {
 	time := SlotToTime(blockSlot)
@@ -504,6 +536,15 @@ func FormatBlockStatus(status, slot uint64) template.HTML {
 	}
 }
 
+// FormatMissedSlotReason will return an html icon explaining why a slot has been missed, empty if the reason is unknown
+func FormatMissedSlotReason(reason types.MissedSlotReason) template.HTML {
+	description := reason.Description()
+	if description == "" {
+		return ""
+	}
+	return template.HTML(fmt.Sprintf(`<i class="fas fa-info-circle ml-1 text-muted" data-toggle="tooltip" title="%s"></i>`, html.EscapeString(strings.ToUpper(description[:1])+description[1:])))
+}
+
 // FormatBlockStatusShort will return an html status for a block.
 func FormatBlockStatusShort(status, slot uint64) template.HTML {
 	// genesis <span class="badge text-dark" style="background: rgba(179, 159, 70, 0.8) none repeat scroll 0% 0%;">Genesis</span>
@@ -1336,6 +1377,28 @@ func FormatTokenName(balance *types.Eth1AddressBalance) template.HTML {
 	return template.HTML(fmt.Sprintf(`<a href='/token/0x%x?a=0x%x' title="%s">%s %s</a>`, balance.Token, balance.Address, symbolTitle, logo, symbol))
 }
 
+// FormatNftToken returns the thumbnail and the name of an nft linking to its detail page, the attributes are shown as tooltip
+func FormatNftToken(token []byte, tokenId *big.Int, metadata *types.NftMetadata) template.HTML {
+	link := fmt.Sprintf("/token/0x%x/%s", token, tokenId.String())
+	if metadata == nil {
+		return template.HTML(fmt.Sprintf(`<a href="%s">%s</a>`, link, tokenId.String()))
+	}
+
+	image := ""
+	if metadata.ImageFormat != "" {
+		image = fmt.Sprintf(`<img class="mr-1" style="height: 20px;" loading="lazy" src="%s/image">`, link)
+	}
+	name := tokenId.String()
+	if metadata.Name != "" {
+		name = metadata.Name
+	}
+	attributes := make([]string, 0, len(metadata.Attributes))
+	for _, a := range metadata.Attributes {
+		attributes = append(attributes, fmt.Sprintf("%v: %v", a.TraitType, a.Value))
+	}
+	return template.HTML(fmt.Sprintf(`<a href="%s" data-toggle="tooltip" title="%s">%s%s</a>`, link, html.EscapeString(strings.Join(attributes, ", ")), image, html.EscapeString(name)))
+}
+
 func ToBase64(input []byte) string {
 	return base64.StdEncoding.EncodeToString(input)
 }
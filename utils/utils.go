@@ -744,6 +744,14 @@ func ReadConfig(cfg *types.Config, path string) error {
 		cfg.Frontend.Keywords = "open source ethereum block explorer, ethereum block explorer, beacon chain explorer, ethereum blockchain explorer"
 	}
 
+	if cfg.Indexer.NftMetadata.IpfsGateway == "" {
+		cfg.Indexer.NftMetadata.IpfsGateway = "https://ipfs.io/ipfs/"
+	}
+
+	if cfg.Indexer.NftMetadata.ArweaveGateway == "" {
+		cfg.Indexer.NftMetadata.ArweaveGateway = "https://arweave.net/"
+	}
+
 	if cfg.Chain.Id != 0 {
 		switch cfg.Chain.Name {
 		case "mainnet", "ethereum":
@@ -1284,6 +1292,36 @@ func getABIFromEtherscan(address []byte) (*types.ContractMetadata, error) {
 	meta.ABIJson = []byte(data.Result[0].Abi)
 	meta.ABI = &contractAbi
 	meta.Name = data.Result[0].ContractName
+	meta.Source = types.ContractMetadataSourceEtherscan
+	return meta, nil
+}
+
+// ParseContractMetadata parses a verified contract abi which is either given as plain abi json array or as solidity metadata json (as published by sourcify)
+func ParseContractMetadata(data []byte) (*types.ContractMetadata, error) {
+	data = bytes.TrimSpace(data)
+	meta := &types.ContractMetadata{Source: types.ContractMetadataSourceVerified}
+
+	if len(data) > 0 && data[0] == '{' {
+		sourcify := &types.SourcifyContractMetadata{}
+		err := json.Unmarshal(data, sourcify)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing contract metadata json: %w", err)
+		}
+		if len(sourcify.Output.Abi) == 0 {
+			return nil, fmt.Errorf("error parsing contract metadata json: no abi found")
+		}
+		data = sourcify.Output.Abi
+		for _, name := range sourcify.Settings.CompilationTarget {
+			meta.Name = name
+		}
+	}
+
+	contractAbi, err := abi.JSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing contract abi: %w", err)
+	}
+	meta.ABIJson = data
+	meta.ABI = &contractAbi
 	return meta, nil
 }
 